@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 开启了变化提醒的连接文件名（位于数据目录中），保存连接标识列表
+const alertsFile = "alerts.json"
+
+// 提醒提示框默认显示时长
+const defaultToastDuration = 10 * time.Second
+
+// 同时显示的提醒提示框最多个数
+const maxToasts = 3
+
+// 界面是否挂起（SSH 会话等外部程序占用整个终端），此时提醒只能直接向终端输出响铃
+var uiSuspended atomic.Bool
+
+// 右上角的提醒提示框
+type toast struct {
+	Text    string      // 提示文字（可含颜色标签）
+	Color   tcell.Color // 边框颜色
+	Expires time.Time   // 消失时间
+}
+
+// 读取开启了变化提醒的连接标识
+func loadAlerts() []string {
+	var alerts []string
+	_ = readJSONFile(alertsFile, &alerts)
+	return alerts
+}
+
+// 提醒提示框显示时长（配置项 watch.toast_duration）
+func toastDuration() time.Duration {
+	if duration := configDuration("watch.toast_duration"); duration > 0 {
+		return duration
+	}
+	return defaultToastDuration
+}
+
+// 挂起界面运行 f，期间记录挂起状态；恢复后刷新挂起期间的监视结果
+func (a *App) suspend(f func()) {
+	a.app.Suspend(func() {
+		uiSuspended.Store(true)
+		defer uiSuspended.Store(false)
+		f()
+	})
+	if watchDirty.Load() {
+		a.flushWatch()
+	}
+}
+
+// 界面挂起时事件循环停止，直接向终端输出响铃；返回是否已响铃
+func ringSuspendedBell() bool {
+	if !uiSuspended.Load() {
+		return false
+	}
+	os.Stdout.WriteString("\a")
+	return true
+}
+
+// 开启或关闭当前连接的变化提醒
+func (a *App) toggleAlert() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	id := target.ID()
+	alerts := loadAlerts()
+	watched := slices.Contains(alerts, id)
+	if watched {
+		alerts = slices.DeleteFunc(alerts, func(other string) bool { return other == id })
+	} else {
+		alerts = append(alerts, id)
+	}
+	if err := writeJSONFile(alertsFile, alerts); err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("alert.save_failed", err))))
+		return
+	}
+	if watched {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("alert.off", target.Conn.Name))))
+	} else {
+		a.statusBar.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(tr("alert.on", target.Conn.Name))))
+	}
+	a.updateMainPanel()
+	select {
+	case watchRefresh <- struct{}{}:
+	default:
+	}
+}
+
+// 开启了变化提醒的连接可达状态翻转时弹出提示框，rang 为 false 时在下次绘制时响铃（在界面线程中调用）
+func (a *App) raiseAlert(target connTarget, result healthResult, rang bool) {
+	text := tr("alert.offline", tview.Escape(target.Conn.Name), tview.Escape(target.ID()))
+	color := tcell.ColorRed
+	if result.OK {
+		text = tr("alert.online", tview.Escape(target.Conn.Name), tview.Escape(target.ID()))
+		color = tcell.ColorGreen
+	}
+	a.toasts = append(a.toasts, toast{Text: text, Color: color, Expires: time.Now().Add(toastDuration())})
+	if len(a.toasts) > maxToasts {
+		a.toasts = a.toasts[len(a.toasts)-maxToasts:]
+	}
+	a.ringBell = a.ringBell || !rang
+	// 到期后重绘一次以移除提示框；界面挂起时跳过，恢复后的绘制会一并移除
+	time.AfterFunc(toastDuration(), func() {
+		if !uiSuspended.Load() {
+			a.app.QueueUpdateDraw(func() {})
+		}
+	})
+}
+
+// 在界面最上层（包括覆盖层和内嵌终端之上）的右上角绘制提醒提示框，并响铃
+func (a *App) drawToasts(screen tcell.Screen) {
+	if a.ringBell {
+		a.ringBell = false
+		screen.Beep()
+	}
+	now := time.Now()
+	a.toasts = slices.DeleteFunc(a.toasts, func(t toast) bool { return now.After(t.Expires) })
+	width, _ := screen.Size()
+	y := 1
+	for i := len(a.toasts) - 1; i >= 0; i-- {
+		t := a.toasts[i]
+		box := tview.NewTextView().
+			SetDynamicColors(true).
+			SetText(t.Text)
+		box.SetBorder(true).
+			SetBorderColor(t.Color).
+			SetTitle(tr("alert.title")).
+			SetTitleAlign(tview.AlignLeft)
+		w := min(tview.TaggedStringWidth(t.Text)+4, width-2)
+		box.SetRect(width-w-1, y, w, 3)
+		box.Draw(screen)
+		y += 3
+	}
+}
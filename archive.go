@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 提取归档成员的超时时间
+const archiveExtractTimeout = 30 * time.Minute
+
+// 可在远程浏览的归档格式后缀
+var tarSuffixes = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tbz2", ".tar.xz", ".txz", ".tar.zst"}
+
+// 归档中的成员
+type archiveMember struct {
+	Name  string // 归档内路径
+	IsDir bool   // 是否为目录
+	Size  int64  // 文件大小
+	Time  string // 修改时间（按归档工具输出原样显示）
+}
+
+// 判断文件名是否为 zip 归档
+func isZipArchive(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".zip")
+}
+
+// 判断文件名是否为支持浏览的归档
+func isArchive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range tarSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return isZipArchive(name)
+}
+
+// 去掉前 n 个空白分隔的字段，保留剩余部分（文件名中可能包含空格）
+func restAfterFields(line string, n int) string {
+	line = strings.TrimLeft(line, " \t")
+	for i := 0; i < n; i++ {
+		end := strings.IndexAny(line, " \t")
+		if end < 0 {
+			return ""
+		}
+		line = strings.TrimLeft(line[end:], " \t")
+	}
+	return line
+}
+
+// 解析 tar -tv 的输出，兼容 GNU tar 与 bsdtar 的格式
+func parseTarListing(output string) []archiveMember {
+	var members []archiveMember
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			continue
+		}
+		var member archiveMember
+		if strings.Contains(fields[1], "/") {
+			// GNU tar: 权限 用户/组 大小 日期 时间 名称
+			member.Size, _ = strconv.ParseInt(fields[2], 10, 64)
+			member.Time = fields[3] + " " + fields[4]
+			member.Name = restAfterFields(line, 5)
+		} else if len(fields) >= 9 {
+			// bsdtar: 权限 链接数 用户 组 大小 月 日 时间/年 名称
+			member.Size, _ = strconv.ParseInt(fields[4], 10, 64)
+			member.Time = strings.Join(fields[5:8], " ")
+			member.Name = restAfterFields(line, 8)
+		} else {
+			continue
+		}
+		member.Name, _, _ = strings.Cut(member.Name, " -> ")
+		member.IsDir = strings.HasPrefix(fields[0], "d")
+		members = append(members, member)
+	}
+	return members
+}
+
+// 解析 unzip -l 的输出
+func parseZipListing(output string) []archiveMember {
+	var members []archiveMember
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		name := restAfterFields(line, 3)
+		members = append(members, archiveMember{
+			Name:  name,
+			IsDir: strings.HasSuffix(name, "/"),
+			Size:  size,
+			Time:  fields[1] + " " + fields[2],
+		})
+	}
+	return members
+}
+
+// 在远程主机上列出归档内容（tar 读取时自动识别压缩格式，zip 只读取中央目录）
+func listRemoteArchive(ctx context.Context, target connTarget, archive string) ([]archiveMember, error) {
+	if isZipArchive(archive) {
+		output, err := runRemote(ctx, target, "unzip -l "+shellQuote(archive))
+		if err != nil {
+			return nil, err
+		}
+		return parseZipListing(output), nil
+	}
+	output, err := runRemote(ctx, target, "tar -tvf "+shellQuote(archive))
+	if err != nil {
+		return nil, err
+	}
+	return parseTarListing(output), nil
+}
+
+// 在远程主机上将单个归档成员输出到标准输出的命令
+func archiveExtractCommand(archive, member string) string {
+	if isZipArchive(archive) {
+		// unzip 将成员名视为通配模式，需要转义通配字符
+		escaped := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "?", `\?`).Replace(member)
+		return fmt.Sprintf("unzip -p %s %s", shellQuote(archive), shellQuote(escaped))
+	}
+	return fmt.Sprintf("tar -xOf %s %s", shellQuote(archive), shellQuote(member))
+}
+
+// 提取单个归档成员到本地文件，只传输该成员的内容
+func extractRemoteMember(ctx context.Context, target connTarget, archive, member, local string) error {
+	local = expandHome(local)
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(local)
+	if err != nil {
+		return err
+	}
+	args := sshExecCommand(target, archiveExtractCommand(archive, member))
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = file
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	closeErr := file.Close()
+	if runErr != nil {
+		os.Remove(local)
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", runErr, message)
+		}
+		return runErr
+	}
+	return closeErr
+}
+
+// 显示远程归档内容：Enter/X 提取选中的文件到本地
+func (a *App) showRemoteArchive(target connTarget, archive string) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	status := tview.NewTextView().SetDynamicColors(true)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(table, 0, 1, true).
+		AddItem(status, 1, 0, false)
+	layout.SetBorder(true).
+		SetTitle(tr("archive.title", target.Conn.Name, archive)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	hint := tr("archive.hint")
+	var members []archiveMember
+	status.SetText(tr("archive.loading"))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+		defer cancel()
+		listed, err := listRemoteArchive(ctx, target, archive)
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				status.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+			members = listed
+			rows := [][]string{{tr("common.col_name"), tr("common.col_size"), tr("common.col_modified")}}
+			for _, member := range members {
+				size := formatBytes(member.Size)
+				if member.IsDir {
+					size = "-"
+				}
+				rows = append(rows, []string{member.Name, size, member.Time})
+			}
+			fillTable(table, rows)
+			table.Select(1, 0)
+			status.SetText(tr("archive.count", hint, len(members)))
+		})
+	}()
+
+	extract := func() {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(members) || members[row-1].IsDir {
+			return
+		}
+		member := members[row-1].Name
+		a.prompt(tr("archive.extract"), tr("archive.local_path"), path.Base(member), func(local string) {
+			if local == "" {
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), archiveExtractTimeout)
+			progress := a.startProgress(tr("archive.extracting")+member, cancel)
+			go func() {
+				defer cancel()
+				err := extractRemoteMember(ctx, target, archive, member, local)
+				detail := archive + " -> " + member
+				if err != nil {
+					detail += ": " + err.Error()
+				}
+				recordAudit(auditEvent{Action: "archive_extract", Target: target.ID(), Detail: detail})
+				progress.finish(func() {
+					if err != nil {
+						status.SetText(tr("archive.failed", tview.Escape(err.Error())))
+						return
+					}
+					status.SetText(tr("archive.done", tview.Escape(local)))
+				})
+			}()
+		})
+	}
+
+	a.pushOverlay(layout, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case event.Key() == tcell.KeyEnter, event.Key() == tcell.KeyRune && (event.Rune() == 'x' || event.Rune() == 'X'):
+			extract()
+			return nil
+		}
+		return event
+	})
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"os/user"
+	"time"
+)
+
+// 审计日志文件名（位于数据目录中）
+const auditFile = "audit.jsonl"
+
+// 审计事件
+type auditEvent struct {
+	Time          time.Time     `json:"time"`                     // 事件时间
+	User          string        `json:"user"`                     // 本地用户名
+	Action        string        `json:"action"`                   // 操作类型（如 session、transfer）
+	Target        string        `json:"target"`                   // 目标连接标识
+	Detail        string        `json:"detail,omitempty"`         // 附加说明
+	Duration      time.Duration `json:"duration,omitempty"`       // 持续时间
+	BytesSent     int64         `json:"bytes_sent,omitempty"`     // 发送字节数
+	BytesReceived int64         `json:"bytes_received,omitempty"` // 接收字节数
+	Path          []string      `json:"path,omitempty"`           // 实际连接路径（本机 → 跳板 → 目标）
+}
+
+// 记录一条审计事件，自动填充时间和用户
+func recordAudit(event auditEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.User == "" {
+		event.User = currentUsername()
+	}
+	_ = appendJSONLine(auditFile, event) // 审计写入失败不影响主流程
+}
+
+// 本地用户名，获取失败时为空
+func currentUsername() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return ""
+}
+
+// 读取全部审计事件
+func loadAuditEvents() []auditEvent {
+	events, _ := readJSONLines[auditEvent](auditFile)
+	return events
+}
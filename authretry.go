@@ -0,0 +1,171 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 认证失败时客户端输出的提示，按模块类型区分
+var authFailureMessages = map[string][]string{
+	"SSH":        {"Permission denied", "Too many authentication failures"},
+	"MySQL":      {"Access denied for user"},
+	"PostgreSQL": {"password authentication failed", "no password supplied", "authentication failed for user"},
+	"Redis":      {"WRONGPASS", "NOAUTH", "invalid password", "invalid username-password pair"},
+}
+
+// 重试认证时输入的密码（连接标识 -> 密码），只在本次运行中优先于保存的密码使用
+var (
+	retryPasswordsMu sync.Mutex
+	retryPasswords   = make(map[string]string)
+)
+
+// 读取重试时输入的密码
+func retryPassword(target connTarget) (string, bool) {
+	retryPasswordsMu.Lock()
+	defer retryPasswordsMu.Unlock()
+	password, ok := retryPasswords[target.ID()]
+	return password, ok
+}
+
+// 根据客户端输出判断是否因认证失败而结束
+func authFailed(module string, outputs ...string) bool {
+	for _, output := range outputs {
+		for _, message := range authFailureMessages[moduleType(module)] {
+			if strings.Contains(output, message) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 读取文件末尾的内容（会话日志或回滚捕获），用于判断认证失败
+func fileTail(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	const size = 4096
+	if len(data) > size {
+		data = data[len(data)-size:]
+	}
+	return string(data)
+}
+
+// 认证失败后的重试选项
+type authRetryOption struct {
+	Label string
+	Apply func(conn *Connection)
+}
+
+// SSH 连接可选的其他认证方式：ssh-agent、保存的密码和 ~/.ssh 下的其他私钥
+func sshRetryOptions(target connTarget) []authRetryOption {
+	var options []authRetryOption
+	if target.Conn.Auth != "agent" {
+		options = append(options, authRetryOption{tr("authretry.agent"), func(conn *Connection) {
+			conn.Auth, conn.IdentityFile = "agent", ""
+		}})
+	}
+	if target.Conn.Auth != "password" && connectionPassword(target) != "" {
+		options = append(options, authRetryOption{tr("authretry.saved_password"), func(conn *Connection) {
+			conn.Auth = "password"
+		}})
+	}
+	current, _ := filepath.Abs(expandHome(target.Conn.IdentityFile))
+	for _, key := range sshPrivateKeys(expandHome("~/.ssh")) {
+		if key == current && target.Conn.Auth != "agent" {
+			continue
+		}
+		options = append(options, authRetryOption{tr("authretry.key") + key, func(conn *Connection) {
+			conn.Auth, conn.IdentityFile = "key", key
+		}})
+	}
+	return options
+}
+
+// 认证失败时显示重试窗口：选择其他认证方式或输入密码后用 reopen 重新连接，修改只在本次重试中生效
+func (a *App) offerAuthRetry(target connTarget, reopen func(connTarget)) {
+	var options []authRetryOption
+	if moduleType(target.Module) == "SSH" {
+		options = sshRetryOptions(target)
+	}
+
+	list := tview.NewList().ShowSecondaryText(false)
+	width := 0
+	for _, option := range options {
+		width = max(width, tview.TaggedStringWidth(option.Label))
+		list.AddItem(tview.Escape(option.Label), "", 0, func() {
+			a.popOverlay()
+			retry := target
+			option.Apply(&retry.Conn)
+			reopen(retry)
+		})
+	}
+	list.AddItem(tr("authretry.enter_password"), "", 'p', func() {
+		a.popOverlay()
+		a.promptRetryPassword(target, reopen)
+	})
+	list.AddItem(tr("authretry.details"), "", '!', func() {
+		a.popOverlay()
+		a.showLastFailure()
+	})
+	list.SetBorder(true).
+		SetTitle(tr("authretry.title", tview.Escape(target.Conn.Name))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorRed)
+
+	a.pushOverlay(centered(list, max(width+10, 56), len(options)+4), list, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
+
+// 输入重试用的密码，可选保存到连接对应的密钥后端
+func (a *App) promptRetryPassword(target connTarget, reopen func(connTarget)) {
+	password, save := "", false
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addSecretField(fieldLabel(passwordField), 40, func(text string) {
+		password = text
+	}, fixedRules(ruleRequired))
+	form.AddCheckbox(tr("authretry.save_password"), false, func(checked bool) {
+		save = checked
+	})
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		a.popOverlay()
+		retryPasswordsMu.Lock()
+		retryPasswords[target.ID()] = password
+		retryPasswordsMu.Unlock()
+		retry := target
+		if moduleType(target.Module) == "SSH" {
+			retry.Conn.Auth = "password"
+		}
+		if save {
+			// 凭据库未解锁时先输入主密码，保存后再连接
+			a.saveConnectionPassword(target, password, func() { reopen(retry) })
+			return
+		}
+		reopen(retry)
+	}
+	form.AddButton(tr("authretry.connect"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("authretry.password_title", tview.Escape(target.Conn.Name))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, centered(validator.root(), 56, 11), submit)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 自动化规则触发事件
+const (
+	ruleEventDown    = "down"    // 连接持续不可达
+	ruleEventConnect = "connect" // 打开交互式会话
+)
+
+// 钩子命令的执行超时时间
+const hookTimeout = 5 * time.Minute
+
+// 自动化规则：满足条件时执行钩子命令或开启会话录制
+type automationRule struct {
+	Name   string        `mapstructure:"name"`   // 规则名称
+	Event  string        `mapstructure:"event"`  // 触发事件：down 或 connect
+	Match  string        `mapstructure:"match"`  // 目标匹配模式（模块/项目/环境/连接，支持通配符）
+	For    time.Duration `mapstructure:"for"`    // down 事件需要持续的时间
+	Run    string        `mapstructure:"run"`    // 触发时执行的钩子命令（sh -c）
+	Record bool          `mapstructure:"record"` // connect 事件是否录制会话
+}
+
+// 读取配置中的自动化规则（配置项 automation.rules）
+func automationRules() []automationRule {
+	var rules []automationRule
+	if err := unmarshalConfig("automation.rules", &rules); err != nil {
+		return nil
+	}
+	return rules
+}
+
+// 获取匹配指定事件和目标的规则
+func matchingRules(rules []automationRule, event string, target connTarget) []automationRule {
+	var matched []automationRule
+	for _, rule := range rules {
+		if strings.EqualFold(rule.Event, event) && matchTargetPattern(rule.Match, target) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// 执行规则的钩子命令，通过环境变量传递目标信息，并记录到审计日志
+func runHook(rule automationRule, event string, target connTarget, extra map[string]string) error {
+	if rule.Run == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", rule.Run)
+	cmd.Env = append(os.Environ(),
+		"CM_RULE="+rule.Name,
+		"CM_EVENT="+event,
+		"CM_TARGET="+target.ID(),
+		"CM_MODULE="+target.Module,
+		"CM_PROJECT="+target.Project,
+		"CM_ENV="+target.Env,
+		"CM_NAME="+target.Conn.Name,
+		"CM_HOST="+target.Conn.Host,
+		"CM_PORT="+strconv.Itoa(target.Conn.Port),
+	)
+	for key, value := range extra {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	output, err := cmd.CombinedOutput()
+
+	detail := fmt.Sprintf("%s (%s): %s", rule.Name, event, rule.Run)
+	if err != nil {
+		detail += ": " + err.Error()
+		if message := strings.TrimSpace(string(output)); message != "" {
+			err = fmt.Errorf("%w: %s", err, message)
+		}
+	}
+	recordAudit(auditEvent{Action: "automation", Target: target.ID(), Detail: detail})
+	return err
+}
+
+// 连接状态规则的求值器，记录各连接的不可达起始时间与已触发的规则
+type ruleEvaluator struct {
+	rules     []automationRule
+	downSince map[string]time.Time // 连接标识 -> 首次检测到不可达的时间
+	fired     map[string]bool      // 规则名/连接标识 -> 本次故障中是否已触发
+}
+
+// 创建规则求值器
+func newRuleEvaluator(rules []automationRule) *ruleEvaluator {
+	return &ruleEvaluator{
+		rules:     rules,
+		downSince: make(map[string]time.Time),
+		fired:     make(map[string]bool),
+	}
+}
+
+// 待执行的规则触发
+type ruleFiring struct {
+	Rule      automationRule
+	Target    connTarget
+	DownSince time.Time
+}
+
+// 根据一轮健康检查结果求值，返回本轮需要触发的规则；每次故障每条规则只触发一次
+func (e *ruleEvaluator) evaluate(target connTarget, result healthResult, now time.Time) []ruleFiring {
+	id := target.ID()
+	rules := matchingRules(e.rules, ruleEventDown, target)
+	if result.OK {
+		delete(e.downSince, id)
+		for _, rule := range rules {
+			delete(e.fired, rule.Name+"/"+id)
+		}
+		return nil
+	}
+
+	since, ok := e.downSince[id]
+	if !ok {
+		since = now
+		e.downSince[id] = now
+	}
+	// 维护窗口内不触发故障规则
+	if _, maintenance := inMaintenance(target, now); maintenance {
+		return nil
+	}
+	var firings []ruleFiring
+	for _, rule := range rules {
+		key := rule.Name + "/" + id
+		if !e.fired[key] && now.Sub(since) >= rule.For {
+			e.fired[key] = true
+			firings = append(firings, ruleFiring{Rule: rule, Target: target, DownSince: since})
+		}
+	}
+	return firings
+}
+
+// 获取会话录制文件路径（数据目录下的 recordings 目录）
+func recordingPath(target connTarget, now time.Time) (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "recordings")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(target.ID())
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.log", name, now.Format("20060102-150405"))), nil
+}
+
+// 录制文件对应的计时文件（script 的 advanced 计时格式：终端大小与每段输出的时间间隔）
+func recordingTimingPath(file string) string {
+	return strings.TrimSuffix(file, ".log") + ".timing"
+}
+
+// script 是否支持 --log-timing 与 advanced 计时格式（util-linux 2.35 起），只检测一次；不支持时录制不带计时
+var scriptLogsTiming = sync.OnceValue(func() bool {
+	output, _ := exec.Command("script", "--help").CombinedOutput()
+	return strings.Contains(string(output), "--log-timing") && strings.Contains(string(output), "--logging-format")
+})
+
+// 使用 script 包装命令以录制终端会话，flags 为附加的 script 参数
+func recordedCommand(args []string, file string, flags ...string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	command := append([]string{"script", "-q", "-f"}, flags...)
+	return append(command, "-c", strings.Join(quoted, " "), file)
+}
+
+// 应用 connect 事件规则：后台执行钩子，需要录制时返回包装后的命令和录制文件
+func applyConnectRules(target connTarget, args []string) ([]string, string) {
+	recording := ""
+	for _, rule := range matchingRules(automationRules(), ruleEventConnect, target) {
+		go runHook(rule, ruleEventConnect, target, nil)
+		if rule.Record && recording == "" {
+			if file, err := recordingPath(target, time.Now()); err == nil {
+				recording = file
+				if scriptLogsTiming() {
+					args = recordedCommand(args, file, "-m", "advanced", "--log-timing", recordingTimingPath(file))
+				} else {
+					args = recordedCommand(args, file)
+				}
+			}
+		}
+	}
+	return args, recording
+}
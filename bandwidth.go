@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 带宽单位换算为 KiB/s 的系数（字节单位按 1024，比特单位按 1000）
+var bandwidthUnits = map[string]float64{
+	"":     1,
+	"k":    1,
+	"kb":   1,
+	"kib":  1,
+	"m":    1024,
+	"mb":   1024,
+	"mib":  1024,
+	"g":    1024 * 1024,
+	"gb":   1024 * 1024,
+	"gib":  1024 * 1024,
+	"kbit": 1000.0 / 8 / 1024,
+	"kbps": 1000.0 / 8 / 1024,
+	"mbit": 1000 * 1000.0 / 8 / 1024,
+	"mbps": 1000 * 1000.0 / 8 / 1024,
+	"gbit": 1000 * 1000 * 1000.0 / 8 / 1024,
+	"gbps": 1000 * 1000 * 1000.0 / 8 / 1024,
+}
+
+// 解析带宽上限（如 500K、5MB/s、20Mbit），返回 KiB/s；不带单位时按 KiB/s，空字符串表示不限速
+func parseBandwidth(value string) (int, error) {
+	value = strings.TrimSuffix(strings.ToLower(strings.ReplaceAll(value, " ", "")), "/s")
+	if value == "" {
+		return 0, nil
+	}
+	i := strings.IndexFunc(value, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(value)
+	}
+	number, err := strconv.ParseFloat(value[:i], 64)
+	factor, ok := bandwidthUnits[value[i:]]
+	if err != nil || !ok || number <= 0 {
+		return 0, errors.New(tr("bandwidth.invalid", value))
+	}
+	if kib := int(number * factor); kib > 0 {
+		return kib, nil
+	}
+	return 1, nil
+}
+
+// 单个传输的带宽上限（KiB/s）：配方的 bandwidth 优先，否则使用 transfer.bandwidth
+func recipeBandwidth(recipe transferRecipe) (int, error) {
+	if recipe.Bandwidth != "" {
+		return parseBandwidth(recipe.Bandwidth)
+	}
+	return parseBandwidth(configString("transfer.bandwidth"))
+}
+
+// 正在进行的传输数量，用于分摊全局带宽上限
+var (
+	activeMu        sync.Mutex
+	activeTransfers int
+)
+
+// 登记一个开始的传输，按全局上限 transfer.bandwidth_total 计算本次可用的带宽（KiB/s，0 表示不限）
+func beginTransfer() int {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	activeTransfers++
+	total, err := parseBandwidth(configString("transfer.bandwidth_total"))
+	if err != nil || total == 0 {
+		return 0
+	}
+	if share := total / activeTransfers; share > 0 {
+		return share
+	}
+	return 1
+}
+
+// 登记一个结束的传输
+func endTransfer() {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	activeTransfers--
+}
+
+// 传输命令当前的带宽上限（KiB/s），未限速时为 0
+func bandwidthLimit(args []string) int {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, "--bwlimit="); ok {
+			limit, _ := strconv.Atoi(value)
+			return limit
+		}
+		if arg == "-l" && i+1 < len(args) && i > 0 && args[0] == "scp" {
+			kbit, _ := strconv.Atoi(args[i+1])
+			return kbit * 1000 / 8 / 1024
+		}
+	}
+	return 0
+}
+
+// 为传输命令设置带宽上限（rsync 的 --bwlimit 单位为 KiB/s，scp 的 -l 单位为 Kbit/s），替换已有的上限
+func withBandwidthLimit(args []string, kib int) []string {
+	if kib <= 0 || len(args) == 0 {
+		return args
+	}
+	var result []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case strings.HasPrefix(args[i], "--bwlimit="):
+			continue
+		case args[0] == "scp" && args[i] == "-l" && i+1 < len(args):
+			i++
+			continue
+		}
+		result = append(result, args[i])
+	}
+	switch result[0] {
+	case "rsync":
+		return append([]string{result[0], fmt.Sprintf("--bwlimit=%d", kib)}, result[1:]...)
+	case "scp":
+		return append([]string{result[0], "-l", strconv.Itoa(kib * 1024 * 8 / 1000)}, result[1:]...)
+	}
+	return args
+}
+
+// 在单个传输上限与全局分摊额度中取较小者
+func applySharedBandwidth(args []string, share int) []string {
+	if limit := bandwidthLimit(args); share > 0 && (limit == 0 || share < limit) {
+		return withBandwidthLimit(args, share)
+	}
+	return args
+}
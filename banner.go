@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 横幅记录文件名（位于数据目录中）
+const bannerFile = "banners.jsonl"
+
+// 读取 MOTD 的超时时间
+const motdTimeout = 10 * time.Second
+
+// 连接时捕获的服务器横幅与 MOTD
+type bannerRecord struct {
+	Time   time.Time `json:"time"`             // 捕获时间
+	Target string    `json:"target"`           // 目标连接标识
+	Banner string    `json:"banner,omitempty"` // 认证前横幅（sshd Banner）
+	MOTD   string    `json:"motd,omitempty"`   // 登录提示信息
+}
+
+// ssh 自身输出的非横幅信息
+var sshNoisePattern = regexp.MustCompile(`^(Connection to .* closed\.|Shared connection to .* closed\.|Warning: Permanently added .*)$`)
+
+// 在后台读取远程主机的 MOTD
+func fetchMOTD(target connTarget) <-chan string {
+	result := make(chan string, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), motdTimeout)
+		defer cancel()
+		output, _ := runRemote(ctx, target, "cat /run/motd.dynamic /etc/motd 2>/dev/null")
+		result <- strings.TrimSpace(output)
+	}()
+	return result
+}
+
+// 从 ssh 标准错误输出中提取横幅
+func extractBanner(stderr string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(stderr, "\r\n", "\n"), "\n") {
+		if !sshNoisePattern.MatchString(strings.TrimSpace(line)) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// 保存会话捕获的横幅与 MOTD，ssh 自身出错（退出码 255）时标准错误不是横幅
+func captureBanner(target connTarget, stderr, motd string, runErr error) {
+	if sshConnectionFailed(runErr) {
+		stderr = ""
+	}
+	record := bannerRecord{Time: time.Now(), Target: target.ID(), Banner: extractBanner(stderr), MOTD: motd}
+	if record.Banner == "" && record.MOTD == "" {
+		return
+	}
+	_ = appendJSONLine(bannerFile, record)
+	recordAudit(auditEvent{Action: "banner", Target: target.ID(), Detail: strings.TrimSpace(record.Banner + "\n" + record.MOTD)})
+}
+
+// 获取目标最近一次捕获的横幅
+func latestBanner(target connTarget) (bannerRecord, bool) {
+	records, _ := readJSONLines[bannerRecord](bannerFile)
+	id := target.ID()
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Target == id {
+			return records[i], true
+		}
+	}
+	return bannerRecord{}, false
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// 提权配置，语义参照 Ansible 的 become
+type becomeConfig struct {
+	Method   string `mapstructure:"method"`   // 提权方式：sudo、su 或 none（关闭）
+	User     string `mapstructure:"user"`     // 目标用户，默认 root
+	Password string `mapstructure:"password"` // 密码来源：env:变量名、file:路径、cmd:命令、secret:密钥名，为空表示免密
+	OnLogin  bool   `mapstructure:"on_login"` // 交互式登录后是否自动切换用户
+}
+
+// 用非空字段覆盖配置
+func (c becomeConfig) merge(override becomeConfig) becomeConfig {
+	if override.Method != "" {
+		c.Method = override.Method
+	}
+	if override.User != "" {
+		c.User = override.User
+	}
+	if override.Password != "" {
+		c.Password = override.Password
+	}
+	if override.OnLogin {
+		c.OnLogin = true
+	}
+	return c
+}
+
+// 是否启用了提权
+func (c becomeConfig) enabled() bool {
+	return c.Method != "" && !strings.EqualFold(c.Method, "none")
+}
+
+// 提权目标用户
+func (c becomeConfig) user() string {
+	if c.User == "" {
+		return "root"
+	}
+	return c.User
+}
+
+// 解析目标的提权配置，优先级：连接 > 环境 > 全局默认（配置项 become）
+func resolveBecome(target connTarget) becomeConfig {
+	var config becomeConfig
+	var global becomeConfig
+	if unmarshalConfig("become.default", &global) == nil {
+		config = config.merge(global)
+	}
+	var byEnv map[string]becomeConfig
+	if unmarshalConfig("become.environments", &byEnv) == nil {
+		config = config.merge(lookupFold(byEnv, target.Env))
+	}
+	var byConn map[string]becomeConfig
+	if unmarshalConfig("become.connections", &byConn) == nil {
+		config = config.merge(lookupFold(byConn, target.ID()))
+	}
+	return config
+}
+
+// 从密码来源读取提权密码，secret: 来源通过目标对应的密钥后端读取
+func becomePassword(target connTarget, source string) (string, error) {
+	kind, value, _ := strings.Cut(source, ":")
+	switch kind {
+	case "":
+		return "", nil
+	case "secret":
+		return lookupSecret(target, value)
+	case "env":
+		password, ok := os.LookupEnv(value)
+		if !ok {
+			return "", errors.New(tr("become.env_unset", value))
+		}
+		return password, nil
+	case "file":
+		data, err := os.ReadFile(value)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case "cmd":
+		output, err := exec.Command("sh", "-c", value).Output()
+		if err != nil {
+			return "", fmt.Errorf(tr("become.password_failed"), err)
+		}
+		return strings.TrimRight(string(output), "\r\n"), nil
+	}
+	return "", errors.New(tr("become.unsupported_source", source))
+}
+
+// 构建以提权方式执行命令的远程命令，返回是否需要伪终端（su 只从终端读取密码）
+func (c becomeConfig) wrap(command string, hasPassword bool) (string, bool, error) {
+	user := shellQuote(c.user())
+	switch strings.ToLower(c.Method) {
+	case "", "sudo":
+		if hasPassword {
+			// 密码在标准输入的第一行：先读出来，sudo 免密（NOPASSWD 或凭据仍在缓存中）时丢弃，
+			// 只有确实需要密码时才放在命令输入前交给 sudo，避免密码成为被执行命令的输入
+			script := fmt.Sprintf(`IFS= read -r p; if sudo -n -u %[1]s true 2>/dev/null; then unset p; exec sudo -n -u %[1]s -- sh -c %[2]s; fi; { printf '%%s\n' "$p"; unset p; exec cat; } | sudo -S -p '' -u %[1]s -- sh -c %[2]s`, user, shellQuote(command))
+			return "sh -c " + shellQuote(script), false, nil
+		}
+		return fmt.Sprintf("sudo -n -u %s -- sh -c %s", user, shellQuote(command)), false, nil
+	case "su":
+		return fmt.Sprintf("su - %s -c %s", user, shellQuote(command)), hasPassword, nil
+	}
+	return "", false, errors.New(tr("become.unsupported_method", c.Method))
+}
+
+// 构建交互式登录后切换用户的命令，command 非空时以目标用户身份执行
+func (c becomeConfig) loginCommand(command string) string {
+	user := shellQuote(c.user())
+	if strings.EqualFold(c.Method, "su") {
+		if command == "" {
+			return "su - " + user
+		}
+		return fmt.Sprintf("su - %s -c %s", user, shellQuote(command))
+	}
+	if command == "" {
+		return "sudo -i -u " + user
+	}
+	return fmt.Sprintf("sudo -i -u %s -- sh -c %s", user, shellQuote(command))
+}
+
+// 以提权方式在远程主机上执行命令，密码通过标准输入传递，input 紧随其后
+func runRemoteBecome(ctx context.Context, target connTarget, config becomeConfig, command string, input io.Reader) (string, error) {
+	password, err := becomePassword(target, config.Password)
+	if err != nil {
+		return "", err
+	}
+	remote, tty, err := config.wrap(command, password != "")
+	if err != nil {
+		return "", err
+	}
+	if password != "" {
+		prefix := strings.NewReader(password + "\n")
+		if input == nil {
+			input = prefix
+		} else {
+			input = io.MultiReader(prefix, input)
+		}
+	}
+	if !tty {
+		return runRemoteInput(ctx, target, remote, input)
+	}
+
+	// su 需要伪终端读取密码，输出中会带有密码提示和回车符
+	if err := proxyCommandError(target); err != nil {
+		return "", err
+	}
+	args := sshExecCommand(target, remote)
+	args = append(args[:1], append([]string{"-tt"}, args[1:]...)...)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = input
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stdout
+	err = cmd.Run()
+	output := strings.ReplaceAll(stdout.String(), "\r\n", "\n")
+	if first, rest, found := strings.Cut(output, "\n"); found && strings.HasSuffix(strings.TrimSpace(first), ":") {
+		output = rest // 去掉 su 的密码提示行
+	}
+	return output, err
+}
+
+// 执行远程命令，按提权配置自动应用 sudo/su
+func runExecAction(ctx context.Context, target connTarget, command string) (string, error) {
+	if config := resolveBecome(target); config.enabled() {
+		return runRemoteBecome(ctx, target, config, command, nil)
+	}
+	return runRemote(ctx, target, command)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 书签文件名（位于数据目录中，按工作区保存）：标记字母 -> 节点路径
+const bookmarksFile = "bookmarks.json"
+
+// 读取全部书签
+func loadBookmarks() map[string]string {
+	bookmarks := make(map[string]string)
+	_ = readJSONFile(bookmarksFile, &bookmarks)
+	return bookmarks
+}
+
+// 开始设置书签（m）或跳到书签（'），等待下一个按键给出标记字母
+func (a *App) startMark(kind rune) {
+	a.pendingMark = kind
+	if kind == 'm' {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tr("mark.set_prompt")))
+		return
+	}
+	bookmarks := loadBookmarks()
+	names := make([]string, 0, len(bookmarks))
+	for name := range bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("[yellow]%s[-] %s", name, tview.Escape(bookmarks[name])))
+	}
+	list := strings.Join(parts, "  ")
+	if list == "" {
+		list = tr("mark.none")
+	}
+	a.statusBar.SetText(tr("mark.jump_prompt", list))
+}
+
+// 处理 m 或 ' 之后的标记字母，正在等待标记时返回 true；连按两次 ' 回到跳转前的位置
+func (a *App) handleMarkKey(event *tcell.EventKey) bool {
+	kind := a.pendingMark
+	if kind == 0 {
+		return false
+	}
+	a.pendingMark = 0
+	if event.Key() != tcell.KeyRune {
+		a.updateStatusBar()
+		return true
+	}
+	r := event.Rune()
+	switch {
+	case kind == '\'' && r == '\'':
+		a.jumpOlder()
+	case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("mark.invalid", string(r)))))
+	case kind == 'm':
+		a.setBookmark(string(r))
+	default:
+		a.jumpToBookmark(string(r))
+	}
+	return true
+}
+
+// 当前选中节点的路径（模块/项目/环境/连接）
+func (a *App) currentNodePath() (string, bool) {
+	path := a.selected.ID()
+	return path, a.inTreeView && path != ""
+}
+
+// 把当前选中的节点记为书签
+func (a *App) setBookmark(name string) {
+	path, ok := a.currentNodePath()
+	if !ok {
+		return
+	}
+	bookmarks := loadBookmarks()
+	bookmarks[name] = path
+	if err := writeJSONFile(bookmarksFile, bookmarks); err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("mark.save_failed", err))))
+		return
+	}
+	a.statusBar.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(tr("mark.saved", name, path))))
+}
+
+// 跳到书签指向的节点
+func (a *App) jumpToBookmark(name string) {
+	path, ok := loadBookmarks()[name]
+	if !ok {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("mark.unset", name))))
+		return
+	}
+	for _, entry := range a.paletteEntries() {
+		if entry.Path == path {
+			a.focusNode(entry)
+			return
+		}
+	}
+	a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("mark.missing", name, path))))
+}
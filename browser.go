@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 远程文件浏览器操作超时时间
+const browserTimeout = 30 * time.Second
+
+// 远程目录项
+type remoteEntry struct {
+	Name    string    // 文件名
+	IsDir   bool      // 是否为目录
+	Size    int64     // 文件大小
+	ModTime time.Time // 修改时间
+}
+
+// 远程文件浏览器
+type fileBrowser struct {
+	target  connTarget      // 浏览的SSH连接
+	dir     string          // 当前目录
+	entries []remoteEntry   // 当前目录内容
+	table   *tview.Table    // 目录列表
+	status  *tview.TextView // 状态行
+	layout  *tview.Flex     // 整体布局
+	trashed *trashedFile    // 最近一次移入回收站的文件（用于撤销）
+}
+
+// 列出远程目录内容（依赖 GNU find 的 -printf）
+func listRemoteDir(ctx context.Context, target connTarget, dir string) ([]remoteEntry, error) {
+	output, err := runRemote(ctx, target, fmt.Sprintf(`find %s -mindepth 1 -maxdepth 1 -printf '%%y\t%%s\t%%T@\t%%f\n'`, shellQuote(dir)))
+	if err != nil {
+		return nil, err
+	}
+	var entries []remoteEntry
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		seconds, _ := strconv.ParseFloat(fields[2], 64)
+		entries = append(entries, remoteEntry{
+			Name:    fields[3],
+			IsDir:   fields[0] == "d",
+			Size:    size,
+			ModTime: time.Unix(int64(seconds), 0),
+		})
+	}
+	// 目录在前，同类按名称排序
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].IsDir != entries[j].IsDir {
+			return entries[i].IsDir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// 打开当前SSH连接的远程文件浏览器
+func (a *App) showFileBrowser() {
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		a.statusBar.SetText(tr("browser.ssh_only"))
+		return
+	}
+
+	browser := &fileBrowser{
+		target: target,
+		dir:    ".",
+		table: tview.NewTable().
+			SetBorders(false).
+			SetFixed(1, 0).
+			SetSelectable(true, false),
+		status: tview.NewTextView().
+			SetDynamicColors(true),
+	}
+	browser.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(browser.table, 0, 1, true).
+		AddItem(browser.status, 1, 0, false)
+	browser.layout.SetBorder(true).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.browser = browser
+	a.pushOverlay(browser.layout, browser.table, a.handleBrowserKey)
+	a.loadBrowserDir(".")
+}
+
+// 浏览器默认提示信息
+func (b *fileBrowser) hint() string {
+	if b.trashed.undoable() {
+		return tr("browser.trashed_hint",
+			tview.Escape(path.Base(b.trashed.original)), formatShortTime(b.trashed.at.Add(undoWindow())))
+	}
+	return tr("browser.hint")
+}
+
+// 在后台加载远程目录并刷新列表
+func (a *App) loadBrowserDir(dir string) {
+	browser := a.browser
+	browser.status.SetText(tr("common.loading_status"))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+		defer cancel()
+		// 先解析为绝对路径，便于显示和返回上级
+		resolved, err := runRemote(ctx, browser.target, fmt.Sprintf("cd %s && pwd", shellQuote(dir)))
+		var entries []remoteEntry
+		if err == nil {
+			dir = strings.TrimSpace(resolved)
+			entries, err = listRemoteDir(ctx, browser.target, dir)
+		}
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				browser.status.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+			browser.dir = dir
+			browser.entries = entries
+			a.renderBrowser()
+		})
+	}()
+}
+
+// 渲染目录列表
+func (a *App) renderBrowser() {
+	browser := a.browser
+	browser.layout.SetTitle(tr("browser.title", browser.target.Conn.Name, browser.dir))
+	rows := [][]string{{tr("common.col_name"), tr("common.col_size"), tr("common.col_modified")}}
+	for _, entry := range browser.entries {
+		name, size := entry.Name, formatBytes(entry.Size)
+		if entry.IsDir {
+			name, size = entry.Name+"/", "-"
+		}
+		rows = append(rows, []string{name, size, formatTime(entry.ModTime)})
+	}
+	fillTable(browser.table, rows)
+	if len(browser.entries) > 0 {
+		browser.table.Select(1, 0)
+	}
+	browser.status.SetText(browser.hint())
+}
+
+// 获取当前选中的目录项
+func (b *fileBrowser) selected() (remoteEntry, bool) {
+	row, _ := b.table.GetSelection()
+	if row < 1 || row > len(b.entries) {
+		return remoteEntry{}, false
+	}
+	return b.entries[row-1], true
+}
+
+// 处理文件浏览器中的键盘事件
+func (a *App) handleBrowserKey(event *tcell.EventKey) *tcell.EventKey {
+	browser := a.browser
+	switch event.Key() {
+	case tcell.KeyEsc:
+		a.browser = nil
+		a.popOverlay()
+		return nil
+	case tcell.KeyEnter:
+		if entry, ok := browser.selected(); ok && entry.IsDir {
+			a.loadBrowserDir(path.Join(browser.dir, entry.Name))
+		} else if ok && isArchive(entry.Name) {
+			a.showRemoteArchive(browser.target, path.Join(browser.dir, entry.Name))
+		}
+		return nil
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		a.loadBrowserDir(path.Dir(browser.dir))
+		return nil
+	case tcell.KeyCtrlE:
+		if entry, ok := browser.selected(); ok && !entry.IsDir {
+			a.editRemoteFile(browser.target, path.Join(browser.dir, entry.Name), true)
+		}
+		return nil
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'e', 'E':
+			if entry, ok := browser.selected(); ok && !entry.IsDir {
+				a.editRemoteFile(browser.target, path.Join(browser.dir, entry.Name), false)
+			}
+			return nil
+		case 'r', 'R':
+			a.loadBrowserDir(browser.dir)
+			return nil
+		case 'd', 'D':
+			if entry, ok := browser.selected(); ok {
+				a.deleteRemoteEntry(path.Join(browser.dir, entry.Name))
+			}
+			return nil
+		case 'u', 'U':
+			a.undoRemoteDelete()
+			return nil
+		}
+	}
+	return event
+}
+
+// 确认后删除远程文件或目录，配置了回收站时移入回收站并可在时限内撤销
+func (a *App) deleteRemoteEntry(remotePath string) {
+	browser := a.browser
+	trashDir := resolveTrashDir(browser.target)
+	message := tr("browser.confirm_trash", tview.Escape(remotePath), tview.Escape(trashDir), undoWindow())
+	if trashDir == "" {
+		message = tr("browser.confirm_delete", tview.Escape(remotePath))
+	}
+	if isProtectedEnv(browser.target.Env) {
+		message += tr("common.protected_target")
+	}
+	a.confirmEnvs(tr("common.delete"), message, []string{browser.target.Env}, func() {
+		browser.status.SetText(tr("browser.deleting"))
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+			defer cancel()
+			trashed, err := removeRemote(ctx, browser.target, remotePath)
+			event := auditEvent{Action: "remote_delete", Target: browser.target.ID(), Detail: remotePath}
+			if trashed != nil {
+				event.Detail += " -> " + trashed.trashed
+			}
+			if err != nil {
+				event.Detail += ": " + err.Error()
+			}
+			recordAudit(event)
+			a.app.QueueUpdateDraw(func() {
+				if err != nil {
+					browser.status.SetText(tr("edit.delete_failed", tview.Escape(err.Error())))
+					return
+				}
+				browser.trashed = trashed
+				a.loadBrowserDir(browser.dir)
+			})
+		}()
+	})
+}
+
+// 撤销最近一次移入回收站的删除
+func (a *App) undoRemoteDelete() {
+	browser := a.browser
+	trashed := browser.trashed
+	if !trashed.undoable() {
+		browser.status.SetText(tr("browser.nothing_to_undo"))
+		return
+	}
+	browser.status.SetText(tr("browser.restoring"))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+		defer cancel()
+		err := trashed.restore(ctx)
+		event := auditEvent{Action: "remote_restore", Target: trashed.target.ID(), Detail: trashed.trashed + " -> " + trashed.original}
+		if err != nil {
+			event.Detail += ": " + err.Error()
+		}
+		recordAudit(event)
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				browser.status.SetText(tr("browser.restore_failed", tview.Escape(err.Error())))
+				return
+			}
+			browser.trashed = nil
+			a.loadBrowserDir(browser.dir)
+		})
+	}()
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 批量编辑操作（消息标识）
+var bulkOperations = []string{"bulk.set_field", "bulk.add_tag", "bulk.remove_tag"}
+
+// 单个连接上的一处字段修改
+type fieldChange struct {
+	Target connTarget // 目标连接
+	Field  string     // 字段
+	Old    string     // 原值
+	New    string     // 新值
+}
+
+// 计算批量编辑对每个连接产生的修改，值未变化的连接不计入
+func planBulkEdit(targets []connTarget, field string, operation int, value string) ([]fieldChange, error) {
+	if operation != 0 {
+		field = "tags"
+	}
+	var changes []fieldChange
+	for _, target := range targets {
+		old := connectionField(target.Conn, field)
+		updated := value
+		switch operation {
+		case 1:
+			updated = strings.Join(splitTags(old+","+value), ",")
+		case 2:
+			remove := make(map[string]bool)
+			for _, tag := range splitTags(value) {
+				remove[tag] = true
+			}
+			var kept []string
+			for _, tag := range target.Conn.Tags {
+				if !remove[tag] {
+					kept = append(kept, tag)
+				}
+			}
+			updated = strings.Join(kept, ",")
+		}
+		// 通过设置到副本上校验并规范化新值
+		conn := target.Conn
+		if err := setConnectionField(&conn, field, updated); err != nil {
+			return nil, err
+		}
+		if updated = connectionField(conn, field); updated != old {
+			changes = append(changes, fieldChange{Target: target, Field: field, Old: old, New: updated})
+		}
+	}
+	return changes, nil
+}
+
+// 应用字段修改并写入审计日志；启用审阅模式时只暂存修改
+func applyFieldChanges(changes []fieldChange, action string) error {
+	if reviewMode() {
+		return stageFieldChanges(changes, action)
+	}
+	updates := make(map[string]map[string]string)
+	for _, change := range changes {
+		id := change.Target.ID()
+		if updates[id] == nil {
+			updates[id] = make(map[string]string)
+		}
+		updates[id][change.Field] = change.New
+	}
+	if err := saveConnectionChanges(updates); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		recordAudit(auditEvent{Action: action, Target: change.Target.ID(), Detail: fmt.Sprintf("%s: %s -> %s", change.Field, change.Old, change.New)})
+	}
+	return nil
+}
+
+// 打开批量编辑表单，对标记的连接（或当前连接）修改字段
+func (a *App) showBulkEditForm() {
+	targets := a.selectedTargets()
+	if len(targets) == 0 {
+		a.statusBar.SetText(tr("bulk.no_targets"))
+		return
+	}
+
+	field, operation, value := 0, 0, ""
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	form.AddDropDown(tr("common.field"), fieldLabels(connectionFields), 0, func(option string, index int) {
+		field = index
+	}).
+		AddDropDown(tr("common.operation"), trAll(bulkOperations), 0, func(option string, index int) {
+			operation = index
+		})
+	// 设置字段时按所选字段校验，增删标签不校验
+	validator.addInputField(tr("common.value"), "", 40, func(text string) {
+		value = text
+	}, func() []fieldRule {
+		if operation != 0 {
+			return nil
+		}
+		return connectionFieldRules(targets[0].Module, connectionFields[field])
+	})
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		changes, err := planBulkEdit(targets, connectionFields[field], operation, value)
+		if err != nil {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		a.popOverlay()
+		a.showFieldChangePreview(tr("bulk.action"), changes, "bulk_edit")
+	}
+	form.AddButton(tr("common.preview"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("bulk.title", len(targets))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, centered(validator.root(), 64, 12), submit)
+}
+
+// 预览字段修改，确认后应用
+func (a *App) showFieldChangePreview(title string, changes []fieldChange, action string) {
+	preview := changePreview{Title: title, Header: []string{tr("common.col_connection"), tr("common.field"), tr("common.col_old"), tr("common.col_new")}, Empty: tr("bulk.nothing")}
+	for _, change := range changes {
+		preview.Rows = append(preview.Rows, []string{change.Target.ID(), fieldLabel(change.Field), change.Old, change.New})
+	}
+	a.showPreview(preview, func() {
+		if err := applyFieldChanges(changes, action); err != nil {
+			a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+			return
+		}
+		a.statusBar.SetText(changeSavedMessage(len(changes)))
+		a.updateMainPanel()
+	})
+}
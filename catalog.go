@@ -0,0 +1,157 @@
+package main
+
+import (
+	"slices"
+	"sync"
+)
+
+// 配置清单中的连接
+type configConnection struct {
+	Name         string   `mapstructure:"name"`          // 连接名称
+	Host         string   `mapstructure:"host"`          // 主机地址
+	Port         int      `mapstructure:"port"`          // 端口，默认使用模块默认端口
+	User         string   `mapstructure:"user"`          // 用户名，默认使用模块默认用户
+	Database     string   `mapstructure:"database"`      // 数据库名或 Redis 库编号
+	IdentityFile string   `mapstructure:"identity_file"` // SSH 私钥文件
+	ProxyCommand string   `mapstructure:"proxy_command"` // SSH ProxyCommand 模板
+	JumpHost     string   `mapstructure:"jump_host"`     // SSH 跳板连接标识
+	Addresses    []string `mapstructure:"addresses"`     // 备用地址
+	Tags         []string `mapstructure:"tags"`          // 标签
+	Auth         string   `mapstructure:"auth"`          // SSH 认证方式
+	Certificate  string   `mapstructure:"certificate"`   // SSH 证书文件
+	TLS          bool     `mapstructure:"tls"`           // 数据库连接启用 TLS
+	TLSCA        string   `mapstructure:"tls_ca"`        // TLS CA 证书
+	TLSCert      string   `mapstructure:"tls_cert"`      // TLS 客户端证书
+	TLSKey       string   `mapstructure:"tls_key"`       // TLS 客户端密钥
+	SSHTunnel    string   `mapstructure:"ssh_tunnel"`    // 经由的 SSH 连接标识
+}
+
+// 配置清单中的环境
+type inventoryEnv struct {
+	Name        string             `mapstructure:"name"`        // 环境名称
+	Connections []configConnection `mapstructure:"connections"` // 环境中的连接
+}
+
+// 配置清单中的项目
+type inventoryProject struct {
+	Name         string         `mapstructure:"name"`         // 项目名称
+	Environments []inventoryEnv `mapstructure:"environments"` // 项目中的环境
+}
+
+// 模块的连接清单（配置项 inventory.<模块>），编辑模式下写回配置文件
+type moduleInventory struct {
+	Projects []inventoryProject `mapstructure:"projects"` // 项目、环境与连接
+	Removed  []string           `mapstructure:"removed"`  // 已删除的示例节点：项目、项目/环境 或 项目/环境/连接
+}
+
+// 已解析的清单配置，首次使用时从配置中读取；写回清单或重新加载配置后丢弃
+var (
+	inventoryMu    sync.Mutex
+	inventoryCache map[string]moduleInventory
+)
+
+// 全部模块的清单配置（只读，修改请使用 moduleInventoryFor 返回的副本）
+func inventoryConfig() map[string]moduleInventory {
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	if inventoryCache == nil {
+		inventoryCache = make(map[string]moduleInventory)
+		_ = unmarshalConfig("inventory", &inventoryCache)
+	}
+	return inventoryCache
+}
+
+// 丢弃已解析的清单配置，下次使用时重新读取（写回清单、切换工作区或重新加载配置时调用）
+func resetInventory() {
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	inventoryCache = nil
+	invalidateStore()
+}
+
+// 模块的清单配置副本（模块名不区分大小写），修改后通过 saveModuleInventory 写回
+func moduleInventoryFor(module string) moduleInventory {
+	return lookupFold(inventoryConfig(), module).clone()
+}
+
+// 深拷贝清单，修改副本不影响已解析的配置
+func (m moduleInventory) clone() moduleInventory {
+	c := moduleInventory{Removed: slices.Clone(m.Removed)}
+	for _, p := range m.Projects {
+		project := inventoryProject{Name: p.Name}
+		for _, env := range p.Environments {
+			project.Environments = append(project.Environments, inventoryEnv{Name: env.Name, Connections: slices.Clone(env.Connections)})
+		}
+		c.Projects = append(c.Projects, project)
+	}
+	return c
+}
+
+// 项目中配置的环境
+func (m moduleInventory) environments(project string) []Environment {
+	var envs []Environment
+	for _, p := range m.Projects {
+		if p.Name != project {
+			continue
+		}
+		for _, env := range p.Environments {
+			envs = append(envs, Environment{Name: env.Name})
+		}
+	}
+	return envs
+}
+
+// 环境中配置的连接，未填写的端口和用户名使用模块默认值
+func (m moduleInventory) connections(module, project, env string) []Connection {
+	var conns []Connection
+	for _, p := range m.Projects {
+		if p.Name != project {
+			continue
+		}
+		for _, e := range p.Environments {
+			if e.Name != env {
+				continue
+			}
+			for _, c := range e.Connections {
+				if c.Name != "" {
+					conns = append(conns, c.connection(module))
+				}
+			}
+		}
+	}
+	return conns
+}
+
+// 转换为连接，主机地址默认与名称相同
+func (c configConnection) connection(module string) Connection {
+	conn := Connection{
+		Name:         c.Name,
+		Status:       "disconnected",
+		Host:         c.Host,
+		Port:         c.Port,
+		User:         c.User,
+		Database:     c.Database,
+		IdentityFile: c.IdentityFile,
+		ProxyCommand: c.ProxyCommand,
+		JumpHost:     c.JumpHost,
+		Addresses:    c.Addresses,
+		Tags:         c.Tags,
+		Auth:         c.Auth,
+		Certificate:  c.Certificate,
+		TLS:          c.TLS,
+		TLSCA:        c.TLSCA,
+		TLSCert:      c.TLSCert,
+		TLSKey:       c.TLSKey,
+		SSHTunnel:    c.SSHTunnel,
+	}
+	if conn.Host == "" {
+		conn.Host = conn.Name
+	}
+	if conn.Port == 0 {
+		conn.Port = defaultPort(module)
+	}
+	if conn.User == "" {
+		conn.User = defaultUser(module)
+	}
+	return conn
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 默认的 leader 键（配置项 keys.leader）
+const defaultLeaderKey = `\`
+
+// 组合键序列中代表 leader 键的写法
+const leaderToken = "<leader>"
+
+// 组合键可执行的操作：消息标识（显示名称）与执行函数
+type chordAction struct {
+	Label string
+	Run   func(a *App)
+}
+
+// 可绑定到组合键的操作，按名称引用
+var chordActions = map[string]chordAction{
+	"top":          {"chord.top", (*App).moveTreeTop},
+	"bottom":       {"chord.bottom", (*App).moveTreeBottom},
+	"expand_env":   {"chord.expand_env", (*App).expandProjectEnvs},
+	"collapse_all": {"chord.collapse_all", (*App).collapseTree},
+	"test":         {"chord.test", (*App).testCurrentConnection},
+	"palette":      {"chord.palette", func(a *App) { a.showPalette("") }},
+	"actions":      {"chord.actions", (*App).showActionMenu},
+	"dependencies": {"menu.dependencies", (*App).showDependencyGraph},
+	"diagnostics":  {"menu.diagnostics", (*App).showDiagnostics},
+	"notes":        {"menu.notes", (*App).showNotes},
+	"tunnels":      {"menu.tunnels", (*App).showTunnels},
+	"last_failure": {"menu.last_failure", (*App).showLastFailure},
+	"alert":        {"menu.alert", (*App).toggleAlert},
+	"recordings":   {"menu.recordings", (*App).showRecordings},
+}
+
+// 内置的组合键：按键序列（空格分隔）-> 操作名称
+var defaultChords = map[string]string{
+	"g g":          "top",
+	"g b":          "bottom",
+	"g e":          "expand_env",
+	"g c":          "collapse_all",
+	"g d":          "dependencies",
+	"<leader> t":   "test",
+	"<leader> p":   "palette",
+	"<leader> a":   "actions",
+	"<leader> i":   "diagnostics",
+	"<leader> n":   "notes",
+	"<leader> u":   "tunnels",
+	"<leader> !":   "last_failure",
+	"<leader> w":   "alert",
+	"<leader> r":   "recordings",
+	"<leader> g g": "top",
+}
+
+// leader 键（配置项 keys.leader，取第一个字符）
+func leaderKey() string {
+	if leader := configString("keys.leader"); leader != "" {
+		return string([]rune(leader)[:1])
+	}
+	return defaultLeaderKey
+}
+
+// 生效的组合键：内置组合键与配置项 keys.chords 合并（操作名为 none 时取消该序列），<leader> 替换为实际按键
+func chordBindings() map[string]string {
+	merged := make(map[string]string, len(defaultChords))
+	for sequence, action := range defaultChords {
+		merged[sequence] = action
+	}
+	for sequence, action := range configStringMapString("keys.chords") {
+		sequence = strings.Join(strings.Fields(sequence), " ")
+		if strings.EqualFold(action, "none") {
+			delete(merged, sequence)
+			continue
+		}
+		merged[sequence] = action
+	}
+
+	leader := leaderKey()
+	bindings := make(map[string]string, len(merged))
+	for sequence, action := range merged {
+		bindings[strings.ReplaceAll(sequence, leaderToken, leader)] = action
+	}
+	return bindings
+}
+
+// 按键在组合键序列中的写法，不能用于组合键的按键返回空字符串
+func chordKeyName(event *tcell.EventKey) string {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 {
+		return ""
+	}
+	return string(event.Rune())
+}
+
+// 推进组合键序列：完整匹配时执行操作，是某个组合键的前缀时弹出后续按键提示；都不是时返回 false
+func (a *App) advanceChord(sequence []string) bool {
+	bindings := chordBindings()
+	joined := strings.Join(sequence, " ")
+	if name, ok := bindings[joined]; ok {
+		if action, ok := chordActions[name]; ok {
+			action.Run(a)
+		} else {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("chord.unknown_action", name))))
+		}
+		return true
+	}
+	for binding := range bindings {
+		if strings.HasPrefix(binding, joined+" ") {
+			a.showChordHints(sequence, bindings)
+			return true
+		}
+	}
+	return false
+}
+
+// 显示前缀之后可按的键（which-key 提示），继续按键推进序列，ESC 取消
+func (a *App) showChordHints(prefix []string, bindings map[string]string) {
+	joined := strings.Join(prefix, " ") + " "
+	next := make(map[string]string)
+	for binding, name := range bindings {
+		rest, ok := strings.CutPrefix(binding, joined)
+		if !ok {
+			continue
+		}
+		key, more, _ := strings.Cut(rest, " ")
+		switch {
+		case more != "":
+			next[key] = tr("chord.more")
+		case chordActions[name].Label != "":
+			next[key] = tr(chordActions[name].Label)
+		default:
+			next[key] = name
+		}
+	}
+	keys := make([]string, 0, len(next))
+	for key := range next {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	width := 0
+	for _, key := range keys {
+		line := fmt.Sprintf(" [yellow]%s[-]  %s", tview.Escape(key), tview.Escape(next[key]))
+		lines = append(lines, line)
+		width = max(width, tview.TaggedStringWidth(line))
+	}
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(strings.Join(lines, "\n"))
+	title := tr("chord.title", strings.Join(prefix, " "))
+	view.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	width = max(width, tview.TaggedStringWidth(title)+2)
+	a.pushOverlay(centered(view, width+4, len(lines)+2), view, func(event *tcell.EventKey) *tcell.EventKey {
+		a.popOverlay()
+		if event.Key() == tcell.KeyEsc {
+			return nil
+		}
+		sequence := append(append([]string{}, prefix...), chordKeyName(event))
+		if !a.advanceChord(sequence) {
+			a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("chord.unbound", strings.Join(sequence, " ")))))
+		}
+		return nil
+	})
+}
+
+// 选中第一个项目
+func (a *App) moveTreeTop() {
+	a.recordJump()
+	a.setCurrentNode(a.firstNode())
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 选中树中最后一个可见节点
+func (a *App) moveTreeBottom() {
+	module := a.modules[a.currentModule]
+	projects := a.getProjectList()
+	if len(projects) == 0 {
+		return
+	}
+	a.recordJump()
+	node := TreeNode{Level: 0, Module: module, Project: projects[len(projects)-1].Name}
+	if envs := a.getEnvironmentList(node.Project); len(envs) > 0 && a.expandedNodes[node.ID()] {
+		node.Level, node.Env = 1, envs[len(envs)-1].Name
+		if conns := a.getConnectionList(node.Project, node.Env); len(conns) > 0 && a.expandedNodes[node.ID()] {
+			node.Level, node.Conn = 2, conns[len(conns)-1].Name
+		}
+	}
+	a.setCurrentNode(node)
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 展开当前项目及其全部环境
+func (a *App) expandProjectEnvs() {
+	projectID := a.selected.projectNode().ID()
+	if projectID == "" {
+		return
+	}
+	a.expandedNodes[projectID] = true
+	for _, env := range a.getEnvironmentList(a.selected.Project) {
+		a.expandedNodes[projectID+"/"+env.Name] = true
+	}
+	a.updateMainPanel()
+}
+
+// 收起当前模块的全部节点，选中当前项目
+func (a *App) collapseTree() {
+	prefix := a.modules[a.currentModule] + "/"
+	for key := range a.expandedNodes {
+		if strings.HasPrefix(key, prefix) {
+			delete(a.expandedNodes, key)
+		}
+	}
+	a.setCurrentNode(a.selected.projectNode())
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 测试当前连接的可达性（TCP 连接与协议级探测），结果显示在状态栏
+func (a *App) testCurrentConnection() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	if !healthCheckable(target) {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("chord.test_remote", target.Conn.Name))))
+		return
+	}
+	a.rememberAction(tr("repeat.test"), (*App).testCurrentConnection)
+	a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("chord.testing", target.Conn.Name))))
+	go func() {
+		result := checkProtocol(target, defaultHealthTimeout)
+		a.app.QueueUpdateDraw(func() {
+			if !result.OK {
+				a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("chord.test_failed", target.Conn.Name, result.Error))))
+				return
+			}
+			a.statusBar.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(tr("chord.test_ok", target.Conn.Name, formatDuration(result.Latency)))))
+		})
+	}()
+}
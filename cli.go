@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// 命令行退出码
+const (
+	exitOK      = 0 // 全部成功
+	exitFailure = 1 // 存在失败的检查
+	exitUsage   = 2 // 参数错误
+)
+
+// 环境名称的英文别名，便于在命令行中使用
+var envAliases = map[string]string{
+	"prod":    "生产环境",
+	"test":    "测试环境",
+	"staging": "测试环境",
+	"dev":     "开发环境",
+}
+
+// 执行命令行子命令，返回退出码；不是子命令时返回 -1
+func runSubcommand(args []string) int {
+	if len(args) == 0 {
+		return -1
+	}
+	if len(discoverySources()) > 0 {
+		refreshDiscovery()
+	}
+	if meshEnabled() {
+		_ = refreshMesh()
+	}
+	switch args[0] {
+	case "check":
+		return runCheckCommand(args[1:], os.Stdout)
+	case "watch":
+		return runWatchCommand(args[1:], os.Stdout)
+	case "credentials":
+		return runCredentialsCommand(os.Stdout)
+	case "report":
+		return runReportCommand(args[1:], os.Stdout)
+	case "import":
+		return runImportCommand(args[1:], os.Stdout)
+	case "version":
+		fmt.Println(version)
+		return exitOK
+	case "self-update":
+		return runSelfUpdateCommand(args[1:], os.Stdout)
+	case "register-handlers":
+		return runRegisterHandlersCommand(args[1:], os.Stdout)
+	case "daemon":
+		return runDaemonCommand(args[1:], os.Stdout)
+	}
+	return -1
+}
+
+// 判断环境名是否匹配过滤条件（支持英文别名和不区分大小写的子串匹配）
+func matchEnv(env, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if alias, ok := envAliases[strings.ToLower(filter)]; ok {
+		filter = alias
+	}
+	return strings.Contains(strings.ToLower(env), strings.ToLower(filter))
+}
+
+// 判断模块名或模块类型是否匹配过滤条件（不区分大小写）
+func matchModule(module, filter string) bool {
+	return filter == "" || strings.EqualFold(module, filter) || strings.EqualFold(moduleType(module), filter)
+}
+
+// 健康检查报告中的单条记录
+type checkRecord struct {
+	Module      string `json:"module"`
+	Project     string `json:"project"`
+	Env         string `json:"env"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	OK          bool   `json:"ok"`
+	Maintenance bool   `json:"maintenance"`
+	LatencyMS   int64  `json:"latency_ms"`
+	Error       string `json:"error,omitempty"`
+	Cause       string `json:"cause,omitempty"` // 导致失败的上游依赖
+}
+
+// check 子命令：对匹配的连接执行健康检查，任一失败时以非零码退出
+func runCheckCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	env := flags.String("env", "", tr("cli.flag_env"))
+	module := flags.String("module", "", tr("cli.flag_module"))
+	format := flags.String("format", "table", tr("cli.flag_format_check"))
+	timeout := flags.Duration("timeout", defaultHealthTimeout, tr("cli.flag_timeout"))
+	concurrency := flags.Int("concurrency", 16, tr("cli.flag_concurrency"))
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintln(os.Stderr, tr("cli.unsupported_format", *format))
+		return exitUsage
+	}
+	if *concurrency < 1 {
+		*concurrency = 1
+	}
+
+	var targets []connTarget
+	for _, target := range inventoryTargets(configuredModules()) {
+		if matchModule(target.Module, *module) && matchEnv(target.Env, *env) {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(os.Stderr, tr("cli.no_match"))
+		return exitUsage
+	}
+
+	records := make([]checkRecord, len(targets))
+	semaphore := make(chan struct{}, *concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			result := checkTCP(target.Conn, *timeout)
+			recordHealth(target, result)
+			_, maintenance := inMaintenance(target, time.Now())
+			records[i] = checkRecord{
+				Maintenance: maintenance,
+				Module:      target.Module,
+				Project:     target.Project,
+				Env:         target.Env,
+				Name:        target.Conn.Name,
+				Address:     fmt.Sprintf("%s:%d", target.Conn.Host, target.Conn.Port),
+				OK:          result.OK,
+				LatencyMS:   result.Latency.Milliseconds(),
+				Error:       result.Error,
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 根据依赖关系标注级联故障的上游根因
+	deps := connectionDependencies()
+	failedIDs := make(map[string]bool)
+	for i, record := range records {
+		if !record.OK {
+			failedIDs[targets[i].ID()] = true
+		}
+	}
+	for i := range records {
+		if !records[i].OK {
+			records[i].Cause = strings.Join(failureCause(deps, targets[i].ID(), failedIDs), ", ")
+		}
+	}
+
+	// 维护窗口内的失败不计入失败数
+	failed := 0
+	for _, record := range records {
+		if !record.OK && !record.Maintenance {
+			failed++
+		}
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(records)
+	} else {
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "MODULE\tPROJECT\tENV\tNAME\tADDRESS\tSTATUS\tLATENCY\tERROR\tCAUSE")
+		for _, record := range records {
+			status := "OK"
+			switch {
+			case !record.OK && record.Maintenance:
+				status = "MAINT"
+			case !record.OK:
+				status = "FAIL"
+			}
+			latency := (time.Duration(record.LatencyMS) * time.Millisecond).String()
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				record.Module, record.Project, record.Env, record.Name, record.Address, status, latency, record.Error, record.Cause)
+		}
+		writer.Flush()
+		fmt.Fprintf(out, tr("cli.summary"), len(records), failed)
+	}
+
+	if failed > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// watch 子命令：常驻运行，周期性检查连接并对 down 规则执行钩子
+func runWatchCommand(args []string, out io.Writer) int {
+	interval := configDuration("automation.interval")
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	flags.DurationVar(&interval, "interval", interval, tr("cli.flag_interval"))
+	timeout := flags.Duration("timeout", defaultHealthTimeout, tr("cli.flag_timeout"))
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	rules := automationRules()
+	targets := watchTargets(rules)
+	if len(targets) == 0 && len(discoverySources()) == 0 {
+		fmt.Fprintln(os.Stderr, tr("cli.no_down_rules"))
+		return exitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	fmt.Fprintf(out, tr("cli.watching"), len(targets), interval)
+
+	evaluator := newRuleEvaluator(rules)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		results := make([]healthResult, len(targets))
+		var wg sync.WaitGroup
+		for i, target := range targets {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = checkTCP(target.Conn, *timeout)
+			}()
+		}
+		wg.Wait()
+
+		now := time.Now()
+		for i, target := range targets {
+			recordHealth(target, results[i])
+			for _, firing := range evaluator.evaluate(target, results[i], now) {
+				fmt.Fprintf(out, tr("cli.rule_fired"),
+					now.Format(time.DateTime), firing.Rule.Name, target.ID(), now.Sub(firing.DownSince).Round(time.Second))
+				extra := map[string]string{"CM_DOWN_SINCE": firing.DownSince.Format(time.RFC3339), "CM_ERROR": results[i].Error}
+				go func() {
+					if err := runHook(firing.Rule, ruleEventDown, firing.Target, extra); err != nil {
+						fmt.Fprintf(out, tr("cli.hook_failed"), time.Now().Format(time.DateTime), firing.Rule.Name, err)
+					}
+				}()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		}
+		// 服务发现的连接会随调度变化，每轮重新获取
+		if len(discoverySources()) > 0 {
+			refreshDiscovery()
+			targets = watchTargets(rules)
+		}
+	}
+}
+
+// 获取配置了 down 事件规则的连接
+func watchTargets(rules []automationRule) []connTarget {
+	var targets []connTarget
+	for _, target := range inventoryTargets(configuredModules()) {
+		if len(matchingRules(rules, ruleEventDown, target)) > 0 {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// credentials 子命令：审计未使用的私钥与失效的密码配置，存在问题时以非零码退出
+func runCredentialsCommand(out io.Writer) int {
+	report := auditCredentials(inventoryTargets(configuredModules()))
+	report.write(out)
+	if report.issues() > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// report 子命令：输出清单统计报告
+func runReportCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	format := flags.String("format", "markdown", tr("cli.flag_format_report"))
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	report := buildInventoryReport(inventoryTargets(configuredModules()), time.Now())
+	switch *format {
+	case "markdown", "md":
+		fmt.Fprint(out, report.markdown())
+	case "html":
+		fmt.Fprint(out, report.html())
+	default:
+		fmt.Fprintln(os.Stderr, tr("cli.unsupported_format", *format))
+		return exitUsage
+	}
+	return exitOK
+}
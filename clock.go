@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 状态栏时钟的默认时间格式
+const defaultClockFormat = "15:04:05"
+
+// 是否在状态栏显示时钟（配置项 ui.clock，默认关闭）
+func clockEnabled() bool {
+	return configBool("ui.clock")
+}
+
+// 时钟的时间格式（配置项 ui.clock_format）
+func clockFormat() string {
+	if layout := configString("ui.clock_format"); layout != "" {
+		return layout
+	}
+	return defaultClockFormat
+}
+
+// 渲染时钟：本机时间，选中 SSH 连接且已知其时区时附上主机当地时间
+func (a *App) renderClock(now time.Time) string {
+	layout := clockFormat()
+	text := fmt.Sprintf("[white]%s %s[-]", now.Format(layout), now.Format("MST"))
+	if !a.inTreeView || a.selected.Level != 2 {
+		return text
+	}
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		return text
+	}
+	zone, ok := hostTimezoneFor(target, func() {
+		a.app.QueueUpdateDraw(a.updateClock)
+	})
+	if !ok {
+		return text + tr("clock.unknown_zone")
+	}
+	local := now.In(zone.location())
+	name := zone.Name
+	if name == "" {
+		name = local.Format("-0700")
+	}
+	return text + fmt.Sprintf(" [gray]│[-] [yellow]%s %s %s[-]", tview.Escape(target.Conn.Name), local.Format(layout), tview.Escape(name))
+}
+
+// 刷新时钟，宽度随内容调整
+func (a *App) updateClock() {
+	if a.clock == nil || !clockEnabled() {
+		return
+	}
+	text := a.renderClock(time.Now())
+	a.clock.SetText(text)
+	a.statusRow.ResizeItem(a.clock, tview.TaggedStringWidth(text)+3, 0)
+}
+
+// 每秒刷新状态栏时钟
+func (a *App) startClock() {
+	if !clockEnabled() {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.app.QueueUpdateDraw(a.updateClock)
+		}
+	}()
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// viper 不是并发安全的：界面 goroutine 会写入和重新加载配置，健康检查、监视、发现和远程执行等后台 goroutine 同时在读取，
+// 所有配置访问都经过下面的函数并由 configMu 保护（当前工作区名称也由它保护）
+var configMu sync.RWMutex
+
+func configString(key string) string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetString(key)
+}
+
+func configBool(key string) bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetBool(key)
+}
+
+func configInt(key string) int {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetInt(key)
+}
+
+func configDuration(key string) time.Duration {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetDuration(key)
+}
+
+func configStringSlice(key string) []string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetStringSlice(key)
+}
+
+func configStringMapString(key string) map[string]string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.GetStringMapString(key)
+}
+
+// 返回副本，调用方可以直接修改后通过 setConfig 写回
+func configStringMap(key string) map[string]any {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return maps.Clone(viper.GetStringMap(key))
+}
+
+func configIsSet(key string) bool {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.IsSet(key)
+}
+
+func unmarshalConfig(key string, out any) error {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.UnmarshalKey(key, out)
+}
+
+func setConfig(key string, value any) {
+	configMu.Lock()
+	defer configMu.Unlock()
+	viper.Set(key, value)
+	invalidateStore()
+}
+
+// 当前使用的配置文件路径，尚无配置文件时为空
+func configFileUsed() string {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return viper.ConfigFileUsed()
+}
+
+// 把已加载配置中的若干配置项（如 inventory、become.connections）写回当前工作区的配置文件，尚无配置文件时在工作区目录中创建；
+// 只改写这些配置项对应的部分，文件中其他配置、注释和键的顺序保持不变
+func writeConfigSections(keys ...string) error {
+	configMu.Lock()
+	defer configMu.Unlock()
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		dir, err := workspaceDir(activeWorkspace)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		path = filepath.Join(dir, "config.yaml")
+	}
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return errors.New(tr("config.not_mapping", path))
+	}
+	for _, key := range keys {
+		var value yaml.Node
+		if err := value.Encode(viper.Get(key)); err != nil {
+			return err
+		}
+		nameFirst(&value)
+		setYAMLPath(doc.Content[0], strings.Split(key, "."), &value)
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		return err
+	}
+	viper.SetConfigFile(path)
+	return nil
+}
+
+// 在映射节点中按路径（键不区分大小写，与 viper 一致）设置值，缺少的中间映射和键追加在末尾
+func setYAMLPath(mapping *yaml.Node, path []string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !strings.EqualFold(mapping.Content[i].Value, path[0]) {
+			continue
+		}
+		if len(path) == 1 {
+			mapping.Content[i+1] = mergeYAMLNode(mapping.Content[i+1], value)
+			return
+		}
+		if mapping.Content[i+1].Kind != yaml.MappingNode {
+			mapping.Content[i+1] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		setYAMLPath(mapping.Content[i+1], path[1:], value)
+		return
+	}
+	child := value
+	if len(path) > 1 {
+		child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setYAMLPath(child, path[1:], value)
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}, child)
+}
+
+// 把新值合并到文件中原有的节点上，尽量保留原有的注释、顺序和写法：映射按键（不区分大小写）对应，
+// 带 name 的列表项（项目、环境、连接等）按名称对应，值相同的标量保持原样；类型不同时直接换成新值
+func mergeYAMLNode(old, value *yaml.Node) *yaml.Node {
+	if old == nil || old.Kind != value.Kind {
+		return value
+	}
+	switch old.Kind {
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		used := make([]bool, len(value.Content)/2)
+		for i := 0; i+1 < len(old.Content); i += 2 {
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				if !used[j/2] && strings.EqualFold(old.Content[i].Value, value.Content[j].Value) {
+					used[j/2] = true
+					content = append(content, old.Content[i], mergeYAMLNode(old.Content[i+1], value.Content[j+1]))
+					break
+				}
+			}
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			if !used[j/2] {
+				content = append(content, value.Content[j], value.Content[j+1])
+			}
+		}
+		old.Content = content
+	case yaml.SequenceNode:
+		content := make([]*yaml.Node, 0, len(value.Content))
+		used := make([]bool, len(old.Content))
+		for _, item := range value.Content {
+			merged := item
+			if name := yamlItemName(item); name != "" {
+				for i, previous := range old.Content {
+					if !used[i] && yamlItemName(previous) == name {
+						used[i] = true
+						merged = mergeYAMLNode(previous, item)
+						break
+					}
+				}
+			}
+			content = append(content, merged)
+		}
+		old.Content = content
+	case yaml.ScalarNode:
+		if old.Value != value.Value || old.ShortTag() != value.ShortTag() {
+			old.Value, old.Tag, old.Style = value.Value, value.Tag, value.Style
+		}
+	default:
+		return value
+	}
+	return old
+}
+
+// 编码映射时键按字母排序，把 name 移到最前面，新写入的项目、环境和连接与手写的清单一致
+func nameFirst(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 2; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "name" {
+				pair := slices.Clone(node.Content[i : i+2])
+				copy(node.Content[2:i+2], node.Content[:i])
+				copy(node.Content, pair)
+				break
+			}
+		}
+	}
+	for _, child := range node.Content {
+		nameFirst(child)
+	}
+}
+
+// 列表项映射中 name 键的值，不是映射或没有 name 时为空
+func yamlItemName(item *yaml.Node) string {
+	if item.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		if strings.EqualFold(item.Content[i].Value, "name") {
+			return item.Content[i+1].Value
+		}
+	}
+	return ""
+}
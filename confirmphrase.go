@@ -0,0 +1,85 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 环境的确认短语：配置项 confirm_phrases.<环境> 优先（为空表示不需要输入），受保护环境默认为环境名
+func confirmPhrase(env string) string {
+	for name, phrase := range configStringMapString("confirm_phrases") {
+		if strings.EqualFold(name, env) {
+			return phrase
+		}
+	}
+	if isProtectedEnv(env) {
+		return env
+	}
+	return ""
+}
+
+// 涉及的环境需要输入的确认短语（去重）
+func envPhrases(envs []string) []string {
+	var phrases []string
+	for _, env := range envs {
+		if phrase := confirmPhrase(env); phrase != "" && !slices.Contains(phrases, phrase) {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// 破坏性操作的确认：涉及的环境配置了确认短语时需依次输入短语，否则显示 Y/N 确认
+func (a *App) confirmEnvs(title, message string, envs []string, onYes func()) {
+	phrases := envPhrases(envs)
+	if len(phrases) == 0 {
+		a.confirm(title, message, onYes)
+		return
+	}
+	a.confirmPhrases(title, message, phrases, onYes)
+}
+
+// 依次要求输入确认短语，全部输入正确后执行回调
+func (a *App) confirmPhrases(title, message string, phrases []string, onYes func()) {
+	if len(phrases) == 0 {
+		onYes()
+		return
+	}
+	phrase := phrases[0]
+	text := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetWrap(true).
+		SetText(tr("confirm.phrase", message, tview.Escape(phrase)))
+	input := tview.NewInputField().
+		SetLabel(tr("confirm.label")).
+		SetFieldWidth(0)
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(text, 0, 1, false).
+		AddItem(input, 1, 0, true)
+	layout.SetBorder(true).
+		SetTitle(title + tr("confirm.keys")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorRed)
+
+	a.pushOverlay(centered(layout, 64, 14), input, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if input.GetText() != phrase {
+				input.SetLabel(tr("confirm.mismatch"))
+				return nil
+			}
+			a.popOverlay()
+			a.confirmPhrases(title, message, phrases[1:], onYes)
+			return nil
+		}
+		return event
+	})
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 查询执行超时时间
+const queryTimeout = 30 * time.Second
+
+// 嵌入式查询控制台：上方输入SQL，下方显示结果表格
+type queryConsole struct {
+	target     connTarget      // 控制台连接的目标
+	layout     *tview.Flex     // 控制台整体布局
+	input      *tview.TextArea // SQL输入区域
+	results    *tview.Table    // 结果表格
+	status     *tview.TextView // 执行状态行
+	rows       [][]string      // 最近一次查询的结果集（第一行为表头）
+	running    bool            // 是否有查询正在执行
+	writeGuard bool            // 写语句是否在事务中执行并等待确认
+	search     *paneSearch     // 结果表格中的搜索
+	history    []string        // 该目标执行过的查询，最近的在前（Ctrl+↑/↓ 调出）
+	historyPos int             // 当前调出的历史下标，-1 表示正在编辑的新语句
+	draft      string          // 调出历史前输入区域中的内容
+}
+
+// 打开当前选中数据库连接的查询控制台（Redis 连接打开键浏览器）
+func (a *App) showQueryConsole() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	kind := moduleType(target.Module)
+	if kind == "Redis" {
+		a.showRedisBrowser()
+		return
+	}
+	if kind != "MySQL" && kind != "PostgreSQL" {
+		a.statusBar.SetText(tr("console.unsupported", target.Module))
+		return
+	}
+
+	console := &queryConsole{
+		target:     target,
+		writeGuard: true,
+		history:    recentQueries(target),
+		historyPos: -1,
+		input: tview.NewTextArea().
+			SetPlaceholder(tr("console.placeholder")),
+		results: tview.NewTable().
+			SetBorders(false).
+			SetFixed(1, 0).
+			SetSelectable(true, false),
+		status: tview.NewTextView().
+			SetDynamicColors(true).
+			SetText(tr("console.hint")),
+	}
+	console.input.SetBorder(true).SetTitle("SQL").SetTitleAlign(tview.AlignLeft)
+	console.results.SetBorder(true).SetTitle(tr("console.results")).SetTitleAlign(tview.AlignLeft)
+	console.search = newTableSearch(console.results)
+
+	console.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(console.input, 8, 0, true).
+		AddItem(console.results, 0, 1, false).
+		AddItem(console.status, 1, 0, false)
+	console.layout.SetBorder(true).
+		SetTitle(tr("console.title", target.Conn.Name, target.Conn.Host, target.Conn.Port)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.console = console
+	a.pushOverlay(console.layout, console.input, a.handleConsoleKey)
+}
+
+// 关闭查询控制台
+func (a *App) hideQueryConsole() {
+	a.console = nil
+	a.popOverlay()
+}
+
+// 处理查询控制台中的键盘事件
+func (a *App) handleConsoleKey(event *tcell.EventKey) *tcell.EventKey {
+	console := a.console
+	switch event.Key() {
+	case tcell.KeyEsc:
+		a.hideQueryConsole()
+		return nil
+	case tcell.KeyF5:
+		a.executeConsoleQuery(console.input.GetText())
+		return nil
+	case tcell.KeyEnter, tcell.KeyCtrlJ:
+		// 多数终端把 Ctrl+Enter 发送为换行符（Ctrl+J），支持扩展键盘协议的终端带 Ctrl 修饰
+		if event.Key() == tcell.KeyCtrlJ || event.Modifiers()&tcell.ModCtrl != 0 {
+			a.executeConsoleQuery(console.input.GetText())
+			return nil
+		}
+	case tcell.KeyUp, tcell.KeyDown:
+		if event.Modifiers()&tcell.ModCtrl != 0 && console.input.HasFocus() {
+			step := 1
+			if event.Key() == tcell.KeyDown {
+				step = -1
+			}
+			console.recallHistory(step)
+			return nil
+		}
+	case tcell.KeyF3:
+		a.showQueryHistory(console.target)
+		return nil
+	case tcell.KeyF6:
+		a.showExportForm(console.rows, func(message string) {
+			console.status.SetText(message)
+		})
+		return nil
+	case tcell.KeyF7:
+		a.explainConsoleQuery(console.input.GetText(), false)
+		return nil
+	case tcell.KeyF9:
+		a.explainConsoleQuery(console.input.GetText(), true)
+		return nil
+	case tcell.KeyF8:
+		a.toggleWriteGuard()
+		return nil
+	case tcell.KeyTab:
+		if console.input.HasFocus() {
+			a.app.SetFocus(console.results)
+		} else {
+			a.app.SetFocus(console.input)
+		}
+		return nil
+	}
+	// 焦点在结果表格时支持 / 搜索和 n/N 跳转
+	if console.results.HasFocus() && console.search.handleKey(a, event) {
+		return nil
+	}
+	return event
+}
+
+// 在后台执行查询，完成后刷新结果表格并记录历史
+func (a *App) executeConsoleQuery(query string) {
+	console := a.console
+	query = strings.TrimSpace(query)
+	if query == "" || console.running {
+		return
+	}
+	if console.writeGuard && hasLeadingKeyword(query, writeKeywords) {
+		console.remember(query)
+		a.executeGuardedWrite(query)
+		return
+	}
+	console.remember(query)
+	console.running = true
+	console.status.SetText(tr("common.running"))
+
+	go func() {
+		start := time.Now()
+		output, err := runQuery(console.target, query)
+		elapsed := time.Since(start)
+		recordQueryHistory(console.target, query, elapsed, err)
+
+		a.app.QueueUpdateDraw(func() {
+			console.running = false
+			if err != nil {
+				console.status.SetText(tr("console.failed", formatDuration(elapsed), tview.Escape(firstLine(output, err))))
+				return
+			}
+			rows := parseTSV(output)
+			console.rows = rows
+			fillTable(console.results, rows)
+			count := len(rows) - 1
+			if count < 0 {
+				count = 0
+			}
+			console.status.SetText(tr("console.succeeded", count, formatDuration(elapsed)))
+		})
+	}()
+}
+
+// 目标的查询历史语句（去掉连续重复），最近的在前
+func recentQueries(target connTarget) []string {
+	var queries []string
+	for _, entry := range loadQueryHistory(target) {
+		if n := len(queries); n == 0 || queries[n-1] != entry.Query {
+			queries = append(queries, entry.Query)
+		}
+	}
+	return queries
+}
+
+// 把执行的语句放到控制台历史最前面，并回到编辑新语句的状态
+func (c *queryConsole) remember(query string) {
+	if len(c.history) == 0 || c.history[0] != query {
+		c.history = append([]string{query}, c.history...)
+	}
+	c.historyPos = -1
+}
+
+// 在输入区域中调出更早（step 为 1）或更近（step 为 -1）的历史语句，回到最近之后恢复调出前的输入
+func (c *queryConsole) recallHistory(step int) {
+	pos := c.historyPos + step
+	if pos < -1 || pos >= len(c.history) {
+		return
+	}
+	if c.historyPos == -1 {
+		c.draft = c.input.GetText()
+	}
+	c.historyPos = pos
+	if pos == -1 {
+		c.input.SetText(c.draft, true)
+		return
+	}
+	c.input.SetText(c.history[pos], true)
+}
+
+// 切换写语句事务保护，受保护环境中始终开启
+func (a *App) toggleWriteGuard() {
+	console := a.console
+	if isProtectedEnv(console.target.Env) {
+		console.status.SetText(tr("console.guard_required"))
+		return
+	}
+	console.writeGuard = !console.writeGuard
+	if console.writeGuard {
+		console.status.SetText(tr("console.guard_on"))
+	} else {
+		console.status.SetText(tr("console.guard_off"))
+	}
+}
+
+// 使用非交互客户端对目标执行单条查询
+func runQuery(target connTarget, query string) (string, error) {
+	conn, err := clientEndpoint(target)
+	if err != nil {
+		return "", err
+	}
+	base := batchClientCommand(target.Module, conn)
+	args := append([]string{}, base[1:]...)
+	switch moduleType(target.Module) {
+	case "MySQL":
+		args = append(args, "-e", query)
+	case "PostgreSQL":
+		args = append(args, "-v", "ON_ERROR_STOP=1", "-c", query)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, base[0], args...)
+	cmd.Env = clientEnv(target)
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
+
+// 提取命令输出的第一行，输出为空时使用错误信息
+func firstLine(output string, err error) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if line == "" && err != nil {
+		return err.Error()
+	}
+	return line
+}
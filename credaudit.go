@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ssh 在未指定密钥时默认尝试的私钥文件
+var defaultIdentityFiles = []string{"id_rsa", "id_ecdsa", "id_ecdsa_sk", "id_ed25519", "id_ed25519_sk", "id_dsa"}
+
+// 展开路径开头的 ~
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// 列出目录下的 SSH 私钥文件（根据文件头判断）
+func sshPrivateKeys(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasSuffix(entry.Name(), ".pub") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		header, _ := bufio.NewReader(file).ReadString('\n')
+		file.Close()
+		if strings.HasPrefix(header, "-----BEGIN") && strings.Contains(header, "PRIVATE KEY") {
+			keys = append(keys, path)
+		}
+	}
+	return keys
+}
+
+// 凭据审计报告
+type credentialReport struct {
+	UnusedKeys      []string // 未被任何连接使用的私钥
+	MissingKeys     []string // 连接引用但不存在的私钥（连接标识: 路径）
+	OrphanedSecrets []string // 未匹配任何连接或环境的提权密码配置
+	BrokenSecrets   []string // 无法读取的密码来源
+}
+
+// 生成凭据审计报告：检查 ~/.ssh 下的私钥使用情况与提权密码配置
+func auditCredentials(targets []connTarget) credentialReport {
+	var report credentialReport
+
+	used := make(map[string]bool)
+	usesDefault := false
+	for _, target := range targets {
+		if moduleType(target.Module) != "SSH" {
+			continue
+		}
+		if target.Conn.IdentityFile == "" {
+			usesDefault = true
+			continue
+		}
+		path, _ := filepath.Abs(expandHome(target.Conn.IdentityFile))
+		used[path] = true
+		if _, err := os.Stat(path); err != nil {
+			report.MissingKeys = append(report.MissingKeys, fmt.Sprintf("%s: %s", target.ID(), target.Conn.IdentityFile))
+		}
+	}
+	// 未指定密钥的连接会使用 ssh 的默认私钥
+	if usesDefault {
+		for _, name := range defaultIdentityFiles {
+			used[expandHome("~/.ssh/"+name)] = true
+		}
+	}
+	for _, key := range sshPrivateKeys(expandHome("~/.ssh")) {
+		if !used[key] {
+			report.UnusedKeys = append(report.UnusedKeys, key)
+		}
+	}
+
+	ids := make(map[string]connTarget)
+	envs := make(map[string]bool)
+	for _, target := range targets {
+		ids[strings.ToLower(target.ID())] = target
+		envs[strings.ToLower(target.Env)] = true
+	}
+	checkSecret := func(scope string, target connTarget, config becomeConfig) {
+		if config.Password == "" {
+			return
+		}
+		if _, err := becomePassword(target, config.Password); err != nil {
+			report.BrokenSecrets = append(report.BrokenSecrets, fmt.Sprintf("%s: %s (%v)", scope, config.Password, err))
+		}
+	}
+	var global becomeConfig
+	if unmarshalConfig("become.default", &global) == nil {
+		checkSecret("become.default", connTarget{}, global)
+	}
+	var byEnv map[string]becomeConfig
+	if unmarshalConfig("become.environments", &byEnv) == nil {
+		for env, config := range byEnv {
+			if !envs[env] && config.Password != "" {
+				report.OrphanedSecrets = append(report.OrphanedSecrets, fmt.Sprintf("become.environments.%s: %s", env, config.Password))
+			}
+			checkSecret("become.environments."+env, connTarget{Env: env}, config)
+		}
+	}
+	var byConn map[string]becomeConfig
+	if unmarshalConfig("become.connections", &byConn) == nil {
+		for id, config := range byConn {
+			target, ok := ids[id]
+			if !ok && config.Password != "" {
+				report.OrphanedSecrets = append(report.OrphanedSecrets, fmt.Sprintf("become.connections.%s: %s", id, config.Password))
+			}
+			checkSecret("become.connections."+id, target, config)
+		}
+	}
+
+	for _, list := range [][]string{report.UnusedKeys, report.MissingKeys, report.OrphanedSecrets, report.BrokenSecrets} {
+		sort.Strings(list)
+	}
+	return report
+}
+
+// 输出凭据审计报告
+func (r credentialReport) write(out io.Writer) {
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{tr("credaudit.unused_keys"), r.UnusedKeys},
+		{tr("credaudit.missing_keys"), r.MissingKeys},
+		{tr("credaudit.orphaned"), r.OrphanedSecrets},
+		{tr("credaudit.broken"), r.BrokenSecrets},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(out, "%s: %d\n", section.title, len(section.items))
+		for _, item := range section.items {
+			fmt.Fprintf(out, "  %s\n", item)
+		}
+	}
+}
+
+// 问题总数
+func (r credentialReport) issues() int {
+	return len(r.UnusedKeys) + len(r.MissingKeys) + len(r.OrphanedSecrets) + len(r.BrokenSecrets)
+}
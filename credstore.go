@@ -0,0 +1,474 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 加密凭据库的文件名（位于工作区数据目录）
+const credentialStoreFile = "credentials.enc"
+
+// 由主密码派生密钥时的 PBKDF2 迭代次数
+const credentialIterations = 600000
+
+// 表单中连接密码字段的标识，密码只保存在凭据库中，不写入配置文件
+const passwordField = "password"
+
+// SSH 询问密码程序从该环境变量指定的本地套接字读取一次密码（程序自身作为 SSH_ASKPASS 运行时）
+const askpassSocketEnv = "CONNECTIONMANAGER_ASKPASS_SOCKET"
+
+// 代理命令（含跳板）的前缀：去掉询问密码的环境变量，避免跳板或代理进程中的 ssh 先取走目标主机的密码，
+// 这些进程需要密码时改为在终端中询问
+const askpassProxyPrefix = "env -u SSH_ASKPASS -u SSH_ASKPASS_REQUIRE -u " + askpassSocketEnv + " "
+
+// 凭据库未解锁时记录的待处理改名和删除（位于工作区数据目录），解锁后应用
+const credentialMovesFile = "credential_moves.json"
+
+// 凭据库中连接密码的改名（To 为空表示删除），From、To 为节点路径（模块/项目[/环境[/连接]]）
+type credentialMove struct {
+	From string `json:"from"`
+	To   string `json:"to,omitempty"`
+}
+
+// 主密码错误
+var errWrongPassphrase error = messageError("credstore.wrong_passphrase")
+
+// 凭据库文件：密码表整体用 AES-256-GCM 加密，密钥由主密码经 PBKDF2-SHA256 派生
+type credentialFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Iterations int    `json:"iterations"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// 已解锁的凭据库，只保存在内存中
+var (
+	credentialsMu   sync.Mutex
+	credentialKey   []byte            // 为空表示未解锁
+	credentialSalt  []byte            // 派生密钥使用的盐
+	credentialTable map[string]string // 密钥名称（连接密码为连接标识）-> 密码
+)
+
+// 凭据库文件是否存在
+func credentialStoreExists() bool {
+	dir, err := dataDir()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, credentialStoreFile))
+	return err == nil
+}
+
+// 凭据库是否已解锁
+func credentialsUnlocked() bool {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	return credentialKey != nil
+}
+
+// 锁定凭据库并清空内存中的密钥、密码表、密码缓存和认证重试密码（切换工作区时调用）
+func lockCredentials() {
+	credentialsMu.Lock()
+	clear(credentialKey)
+	credentialKey, credentialSalt, credentialTable = nil, nil, nil
+	credentialsMu.Unlock()
+	passwordCacheMu.Lock()
+	clear(passwordCache)
+	passwordCacheMu.Unlock()
+	retryPasswordsMu.Lock()
+	clear(retryPasswords)
+	retryPasswordsMu.Unlock()
+}
+
+// 由主密码派生加密密钥
+func deriveCredentialKey(passphrase string, salt []byte, iterations int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+}
+
+// 用主密码解锁凭据库，凭据库不存在时以该主密码新建
+func unlockCredentials(passphrase string) error {
+	var file credentialFile
+	if err := readJSONFile(credentialStoreFile, &file); err != nil {
+		return err
+	}
+	if file.Version == 0 {
+		salt := make([]byte, 16)
+		rand.Read(salt)
+		key, err := deriveCredentialKey(passphrase, salt, credentialIterations)
+		if err != nil {
+			return err
+		}
+		credentialsMu.Lock()
+		defer credentialsMu.Unlock()
+		credentialKey, credentialSalt, credentialTable = key, salt, make(map[string]string)
+		return saveCredentialsLocked()
+	}
+
+	key, err := deriveCredentialKey(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+	gcm, err := credentialCipher(key)
+	if err != nil {
+		return err
+	}
+	plain, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return errWrongPassphrase
+	}
+	table := make(map[string]string)
+	if err := json.Unmarshal(plain, &table); err != nil {
+		return fmt.Errorf(tr("credstore.corrupt"), err)
+	}
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	credentialKey, credentialSalt, credentialTable = key, file.Salt, table
+	// 应用锁定期间重命名或删除节点留下的改名
+	var moves []credentialMove
+	if err := readJSONFile(credentialMovesFile, &moves); err != nil || len(moves) == 0 {
+		return err
+	}
+	for _, move := range moves {
+		applyCredentialMove(table, move)
+	}
+	if err := saveCredentialsLocked(); err != nil {
+		return err
+	}
+	return writeJSONFile(credentialMovesFile, []credentialMove{})
+}
+
+// 在密码表中把 From 节点下的连接密码移到 To 节点下，To 为空时删除
+func applyCredentialMove(table map[string]string, move credentialMove) {
+	for name, secret := range table {
+		rest, ok := strings.CutPrefix(name, move.From)
+		if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+			continue
+		}
+		delete(table, name)
+		if move.To != "" {
+			table[move.To+rest] = secret
+		}
+	}
+}
+
+// 节点重命名或删除后移动（to 为空时删除）其下连接保存的密码；凭据库未解锁时记下，解锁后应用
+func moveCredentials(from, to string) error {
+	move := credentialMove{From: from, To: to}
+	passwordCacheMu.Lock()
+	applyCredentialMove(passwordCache, credentialMove{From: from})
+	passwordCacheMu.Unlock()
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	if credentialKey != nil {
+		applyCredentialMove(credentialTable, move)
+		return saveCredentialsLocked()
+	}
+	if !credentialStoreExists() {
+		return nil
+	}
+	var moves []credentialMove
+	if err := readJSONFile(credentialMovesFile, &moves); err != nil {
+		return err
+	}
+	return writeJSONFile(credentialMovesFile, append(moves, move))
+}
+
+// 创建 AES-GCM 加密器
+func credentialCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// 加密并写回凭据库，调用方需持有 credentialsMu
+func saveCredentialsLocked() error {
+	gcm, err := credentialCipher(credentialKey)
+	if err != nil {
+		return err
+	}
+	plain, err := json.Marshal(credentialTable)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	return writeJSONFile(credentialStoreFile, credentialFile{
+		Version:    1,
+		Salt:       credentialSalt,
+		Iterations: credentialIterations,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plain, nil),
+	})
+}
+
+// 读取凭据库中的密钥
+func credentialSecret(name string) (string, error) {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	if credentialKey == nil {
+		return "", errors.New(tr("credstore.locked_read", name))
+	}
+	secret, ok := credentialTable[name]
+	if !ok {
+		return "", errors.New(tr("credstore.missing", name))
+	}
+	return secret, nil
+}
+
+// 写入凭据库中的密钥，secret 为空时删除
+func setCredentialSecret(name, secret string) error {
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	if credentialKey == nil {
+		return errors.New(tr("credstore.locked"))
+	}
+	if secret == "" {
+		delete(credentialTable, name)
+	} else {
+		credentialTable[name] = secret
+	}
+	return saveCredentialsLocked()
+}
+
+// 本次运行中从外部密钥后端读取到的连接密码（连接标识 -> 密码），读取失败时不缓存，下次重新读取
+var (
+	passwordCacheMu sync.Mutex
+	passwordCache   = make(map[string]string)
+)
+
+// 连接密码是否保存在外部密钥后端（secrets.backend 等配置为 local 以外的后端）
+func externalPasswordBackend(target connTarget) bool {
+	backend := resolveSecretBackend(target)
+	return backend != "" && backend != "local"
+}
+
+// 连接的密码：认证重试时输入的密码优先，其次按连接解析的密钥后端读取，未配置后端时使用内置凭据库；凭据库未解锁或没有密码时返回空字符串
+func connectionPassword(target connTarget) string {
+	if password, ok := retryPassword(target); ok {
+		return password
+	}
+	if !externalPasswordBackend(target) {
+		password, _ := credentialSecret(target.ID())
+		return password
+	}
+	passwordCacheMu.Lock()
+	defer passwordCacheMu.Unlock()
+	password, ok := passwordCache[target.ID()]
+	if !ok {
+		password, _ = lookupSecret(target, target.ID())
+		if password != "" {
+			passwordCache[target.ID()] = password
+		}
+	}
+	return password
+}
+
+// 数据库客户端的进程环境：保存了密码时通过客户端支持的环境变量传入，避免出现在命令行参数中；没有密码时为 nil（继承当前环境）
+func clientEnv(target connTarget) []string {
+	password := connectionPassword(target)
+	if password == "" {
+		return nil
+	}
+	switch moduleType(target.Module) {
+	case "MySQL":
+		return append(os.Environ(), "MYSQL_PWD="+password)
+	case "PostgreSQL":
+		return append(os.Environ(), "PGPASSWORD="+password)
+	case "Redis":
+		return append(os.Environ(), "REDISCLI_AUTH="+password)
+	}
+	return nil
+}
+
+// SSH 会话的进程环境和结束后的清理函数：密码认证且保存了密码时以本程序作为 SSH_ASKPASS 回答密码提示，
+// 密码经一次性的本地套接字交给询问密码进程，不出现在 ssh 及其代理命令、跳板子进程的环境中，
+// 代理命令也不继承套接字（见 askpassProxyPrefix）；否则环境为 nil
+func sshPasswordEnv(target connTarget) ([]string, func()) {
+	if target.Conn.Auth != "password" {
+		return nil, func() {}
+	}
+	password := connectionPassword(target)
+	if password == "" {
+		return nil, func() {}
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, func() {}
+	}
+	socket, stop, err := serveAskpass(password)
+	if err != nil {
+		return nil, func() {}
+	}
+	return append(os.Environ(), "SSH_ASKPASS="+executable, "SSH_ASKPASS_REQUIRE=force", askpassSocketEnv+"="+socket), stop
+}
+
+// 在只有当前用户可访问的临时目录中监听本地套接字，向第一个连接写出密码后立即关闭并删除；stop 提前关闭
+func serveAskpass(secret string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "connectionmanager-askpass-*")
+	if err != nil {
+		return "", nil, err
+	}
+	socket := filepath.Join(dir, "askpass.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			listener.Close()
+			os.RemoveAll(dir)
+		})
+	}
+	go func() {
+		c, err := listener.Accept()
+		stop()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		c.SetDeadline(time.Now().Add(askpassTimeout))
+		io.WriteString(c, secret+"\n")
+	}()
+	return socket, stop, nil
+}
+
+// 询问密码进程读取密码的超时时间
+const askpassTimeout = 5 * time.Second
+
+// 作为 SSH_ASKPASS 运行时输出密码：只回答密码提示，其他提示（如确认主机密钥）返回失败；不是此模式时返回 false
+func runAskpass(args []string) (int, bool) {
+	socket, ok := os.LookupEnv(askpassSocketEnv)
+	if !ok {
+		return 0, false
+	}
+	if len(args) == 0 || !strings.Contains(strings.ToLower(args[len(args)-1]), "password") {
+		return exitFailure, true
+	}
+	c, err := net.DialTimeout("unix", socket, askpassTimeout)
+	if err != nil {
+		return exitFailure, true
+	}
+	defer c.Close()
+	c.SetDeadline(time.Now().Add(askpassTimeout))
+	secret, err := io.ReadAll(c)
+	if err != nil || len(secret) == 0 {
+		return exitFailure, true
+	}
+	os.Stdout.Write(secret)
+	return exitOK, true
+}
+
+// 显示输入主密码的表单：凭据库已存在时解锁，不存在时设置主密码并新建；成功后执行回调
+func (a *App) unlockCredentialForm(onUnlock func()) {
+	creating := !credentialStoreExists()
+	passphrase := ""
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addSecretField(tr("credstore.passphrase"), 40, func(text string) {
+		passphrase = text
+	}, fixedRules(ruleRequired))
+	if creating {
+		validator.addSecretField(tr("credstore.confirm_passphrase"), 40, nil, func() []fieldRule {
+			return []fieldRule{ruleSameAs(func() string { return passphrase })}
+		})
+	}
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		if err := unlockCredentials(passphrase); err != nil {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		a.popOverlay()
+		if creating {
+			recordAudit(auditEvent{Action: "credentials_create", Detail: credentialStoreFile})
+			a.statusBar.SetText(tr("credstore.created"))
+		} else {
+			a.statusBar.SetText(tr("credstore.unlocked"))
+		}
+		if onUnlock != nil {
+			onUnlock()
+		}
+	}
+	title := tr("credstore.unlock_title")
+	if creating {
+		title = tr("credstore.create_title")
+	}
+	form.AddButton(tr("common.ok"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	height := 9
+	if creating {
+		height = 12
+	}
+	a.pushForm(validator, centered(validator.root(), 56, height), submit)
+}
+
+// 保存连接密码到连接对应的密钥后端（内置凭据库未解锁时先要求输入主密码），成功后执行 done；password 为空时不做修改
+func (a *App) saveConnectionPassword(target connTarget, password string, done func()) {
+	if password == "" {
+		return
+	}
+	if externalPasswordBackend(target) {
+		go func() {
+			err := storeSecret(target, target.ID(), password)
+			a.app.QueueUpdateDraw(func() {
+				if err != nil {
+					a.statusBar.SetText(tr("credstore.save_failed", tview.Escape(err.Error())))
+					return
+				}
+				passwordCacheMu.Lock()
+				passwordCache[target.ID()] = password
+				passwordCacheMu.Unlock()
+				recordAudit(auditEvent{Action: "secret_store", Target: target.ID(), Detail: tr("credstore.audit_password")})
+				if done != nil {
+					done()
+				}
+			})
+		}()
+		return
+	}
+	save := func() {
+		if err := setCredentialSecret(target.ID(), password); err != nil {
+			a.statusBar.SetText(tr("credstore.save_failed", tview.Escape(err.Error())))
+			return
+		}
+		recordAudit(auditEvent{Action: "secret_store", Target: target.ID(), Detail: tr("credstore.audit_password")})
+		if done != nil {
+			done()
+		}
+	}
+	if credentialsUnlocked() {
+		save()
+		return
+	}
+	a.unlockCredentialForm(save)
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 后台服务的套接字文件名（位于工作区数据目录）
+const daemonSocketName = "daemon.sock"
+
+// 与后台服务通信的超时时间（建立隧道的请求除外）
+const daemonCallTimeout = 2 * time.Second
+
+// 后台服务未运行
+var errDaemonNotRunning error = messageError("daemon.not_running")
+
+// 当前进程是否为后台服务（此时隧道由本进程直接建立）
+var inDaemon bool
+
+// 发给后台服务的请求
+type daemonRequest struct {
+	Op     string     `json:"op"`     // endpoint、use、release、session、status 或 stop
+	Target connTarget `json:"target"` // 请求涉及的连接
+}
+
+// 后台服务的应答
+type daemonResponse struct {
+	Error    string         `json:"error,omitempty"`
+	Conn     Connection     `json:"conn"`     // endpoint：客户端实际连接的地址
+	Tunnels  []daemonTunnel `json:"tunnels"`  // status：仍在运行的隧道
+	Sessions []connTarget   `json:"sessions"` // status：主连接仍然存活的 SSH 会话，按打开顺序
+}
+
+// 后台服务持有的隧道
+type daemonTunnel struct {
+	ID      string    `json:"id"`      // 连接标识
+	Port    int       `json:"port"`    // 本地监听端口
+	Started time.Time `json:"started"` // 建立时间
+	Active  time.Time `json:"active"`  // 最近活动时间
+	InUse   int       `json:"in_use"`  // 正在使用隧道的客户端会话数
+}
+
+// 界面中缓存的后台服务状态，由隧道回收器定期刷新
+var (
+	daemonStateMu sync.Mutex
+	daemonTunnels []daemonTunnel
+)
+
+// 后台服务的套接字路径
+func daemonSocketPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, daemonSocketName), nil
+}
+
+// SSH 主连接的控制套接字路径模板（%C 由 ssh 展开为主机、端口和用户的哈希）
+func controlPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cm-%C"), nil
+}
+
+// 向后台服务发送请求；服务未运行时返回 errDaemonNotRunning
+func daemonCall(request daemonRequest, timeout time.Duration) (daemonResponse, error) {
+	path, err := daemonSocketPath()
+	if err != nil {
+		return daemonResponse{}, err
+	}
+	conn, err := net.DialTimeout("unix", path, daemonCallTimeout)
+	if err != nil {
+		return daemonResponse{}, errDaemonNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	var response daemonResponse
+	if err := json.NewEncoder(conn).Encode(request); err != nil {
+		return response, err
+	}
+	if err := json.NewDecoder(conn).Decode(&response); err != nil {
+		return response, err
+	}
+	if response.Error != "" {
+		return response, errors.New(response.Error)
+	}
+	return response, nil
+}
+
+// 后台服务是否正在运行
+func daemonRunning() bool {
+	if inDaemon {
+		return false
+	}
+	_, err := daemonCall(daemonRequest{Op: "status"}, daemonCallTimeout)
+	return !errors.Is(err, errDaemonNotRunning)
+}
+
+// 通过后台服务获取客户端连接地址；服务未运行时 ok 为 false
+func daemonEndpoint(target connTarget) (conn Connection, ok bool, err error) {
+	if inDaemon {
+		return Connection{}, false, nil
+	}
+	response, err := daemonCall(daemonRequest{Op: "endpoint", Target: target}, tunnelStartTimeout+daemonCallTimeout)
+	if errors.Is(err, errDaemonNotRunning) {
+		return Connection{}, false, nil
+	}
+	refreshDaemonState()
+	return response.Conn, true, err
+}
+
+// 刷新界面缓存的后台服务隧道，返回服务的完整状态
+func refreshDaemonState() (daemonResponse, bool) {
+	response, err := daemonCall(daemonRequest{Op: "status"}, daemonCallTimeout)
+	daemonStateMu.Lock()
+	defer daemonStateMu.Unlock()
+	daemonTunnels = response.Tunnels
+	return response, err == nil
+}
+
+// 后台服务持有的隧道的活动时间与空闲标记
+func (t daemonTunnel) activityText(now time.Time) string {
+	return (&localTunnel{started: t.Started, active: t.Active, inUse: t.InUse}).activityText(now)
+}
+
+// 后台服务运行时为 SSH 会话启用由其管理的主连接：首个会话认证后主连接转入后台，界面退出后仍然保持
+func managedSessionArgs(target connTarget, args []string) ([]string, bool) {
+	if resolveTransport(target).kind() == transportTeleport || !daemonRunning() {
+		return args, false
+	}
+	path, err := controlPath()
+	if err != nil {
+		return args, false
+	}
+	persist := "yes"
+	if timeout := resolveSessionPolicy(target).IdleTimeout; timeout > 0 {
+		persist = fmt.Sprintf("%ds", int(timeout.Seconds()))
+	}
+	args = insertSSHOptions(target, args, "-o", "ControlMaster=auto", "-o", "ControlPath="+path, "-o", "ControlPersist="+persist)
+	_, err = daemonCall(daemonRequest{Op: "session", Target: target}, daemonCallTimeout)
+	return args, err == nil
+}
+
+// 启动时连接后台服务：恢复仍然存活的会话标签，并显示服务持有的隧道
+func (a *App) attachDaemon() {
+	go func() {
+		response, ok := refreshDaemonState()
+		if !ok {
+			return
+		}
+		for _, target := range response.Sessions {
+			setSessionStatus(target, "detached")
+		}
+		a.app.QueueUpdateDraw(func() {
+			a.statusBar.SetText(tr("daemon.attached", len(response.Sessions), len(response.Tunnels)))
+			a.updateMainPanel()
+			a.updateSidePanels()
+		})
+	}()
+}
+
+// 后台服务管理的 SSH 会话
+type daemonSession struct {
+	target  connTarget
+	started time.Time
+}
+
+// 后台服务的运行状态
+type daemonServer struct {
+	mu       sync.Mutex
+	sessions []daemonSession
+	out      io.Writer
+	stop     context.CancelFunc
+}
+
+// daemon 子命令：常驻持有本地隧道和 SSH 主连接，界面重启后重新连接；daemon status 查看状态，daemon stop 停止服务
+func runDaemonCommand(args []string, out io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "status":
+			return runDaemonStatus(out)
+		case "stop":
+			if _, err := daemonCall(daemonRequest{Op: "stop"}, daemonCallTimeout); err != nil {
+				fmt.Fprintf(os.Stderr, tr("daemon.stop_failed"), err)
+				return exitFailure
+			}
+			fmt.Fprintln(out, tr("daemon.stopped"))
+			return exitOK
+		}
+		fmt.Fprintf(os.Stderr, tr("daemon.unknown_arg"), args[0])
+		return exitUsage
+	}
+
+	path, err := daemonSocketPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("daemon.start_failed"), err)
+		return exitFailure
+	}
+	if daemonRunning() {
+		fmt.Fprintln(os.Stderr, tr("daemon.already_running"))
+		return exitFailure
+	}
+	// 上次异常退出时残留的套接字文件
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("daemon.start_failed"), err)
+		return exitFailure
+	}
+	defer os.Remove(path)
+	inDaemon = true
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &daemonServer{out: out, stop: stop}
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	go server.maintain(ctx)
+
+	fmt.Fprintf(out, tr("daemon.started"), path)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			break
+		}
+		go server.serve(conn)
+	}
+	server.shutdown()
+	fmt.Fprintln(out, tr("daemon.exited"))
+	return exitOK
+}
+
+// 处理一个连接上的请求
+func (s *daemonServer) serve(conn net.Conn) {
+	defer conn.Close()
+	var request daemonRequest
+	if err := json.NewDecoder(conn).Decode(&request); err != nil {
+		return
+	}
+	response, err := s.handle(request)
+	if err != nil {
+		response.Error = err.Error()
+	}
+	json.NewEncoder(conn).Encode(response)
+}
+
+// 执行请求
+func (s *daemonServer) handle(request daemonRequest) (daemonResponse, error) {
+	var response daemonResponse
+	switch request.Op {
+	case "endpoint":
+		conn, err := clientEndpoint(request.Target)
+		if err == nil {
+			s.logf(tr("daemon.tunnel_ready"), request.Target.ID(), conn.Host, conn.Port)
+		}
+		response.Conn = conn
+		return response, err
+	case "use":
+		useTunnel(request.Target)
+	case "release":
+		tunnelsMu.Lock()
+		if t, ok := tunnels[request.Target.ID()]; ok && t.inUse > 0 {
+			t.inUse--
+			t.active, t.warned = time.Now(), false
+		}
+		tunnelsMu.Unlock()
+	case "session":
+		s.mu.Lock()
+		s.sessions = slices.DeleteFunc(s.sessions, func(session daemonSession) bool { return session.target.ID() == request.Target.ID() })
+		s.sessions = append(s.sessions, daemonSession{target: request.Target, started: time.Now()})
+		s.mu.Unlock()
+		s.logf(tr("daemon.session_managed"), request.Target.ID())
+	case "status":
+		response.Tunnels = tunnelSnapshot()
+		s.mu.Lock()
+		for _, session := range s.sessions {
+			response.Sessions = append(response.Sessions, session.target)
+		}
+		s.mu.Unlock()
+	case "stop":
+		s.stop()
+	default:
+		return response, errors.New(tr("daemon.unknown_request", request.Op))
+	}
+	return response, nil
+}
+
+// 定期关闭空闲隧道，并移除主连接已经退出的会话
+func (s *daemonServer) maintain(ctx context.Context) {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, message := range reapTunnels(time.Now()) {
+			s.logf("%s", message)
+		}
+		s.mu.Lock()
+		sessions := slices.Clone(s.sessions)
+		s.mu.Unlock()
+		for _, session := range sessions {
+			if controlMaster(session.target, "check") != nil {
+				s.mu.Lock()
+				s.sessions = slices.DeleteFunc(s.sessions, func(other daemonSession) bool { return other.target.ID() == session.target.ID() })
+				s.mu.Unlock()
+				s.logf(tr("daemon.session_ended"), session.target.ID(), time.Since(session.started).Round(time.Second))
+			}
+		}
+	}
+}
+
+// 停止服务：关闭全部隧道和 SSH 主连接
+func (s *daemonServer) shutdown() {
+	closeTunnels()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, session := range s.sessions {
+		controlMaster(session.target, "exit")
+	}
+	s.sessions = nil
+}
+
+// 向 SSH 主连接发送控制命令（check 或 exit）
+func controlMaster(target connTarget, command string) error {
+	path, err := controlPath()
+	if err != nil {
+		return err
+	}
+	args := insertSSHOptions(target, sshBaseArgs(target, resolveSessionPolicy(target)), "-o", "ControlPath="+path, "-O", command)
+	ctx, cancel := context.WithTimeout(context.Background(), daemonCallTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, args[0], args[1:]...).Run()
+}
+
+// 带时间的服务日志
+func (s *daemonServer) logf(format string, args ...any) {
+	fmt.Fprintf(s.out, "%s %s\n", time.Now().Format(time.DateTime), fmt.Sprintf(format, args...))
+}
+
+// 当前运行的隧道
+func tunnelSnapshot() []daemonTunnel {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	var snapshot []daemonTunnel
+	for id, t := range tunnels {
+		if t.alive() {
+			snapshot = append(snapshot, daemonTunnel{ID: id, Port: t.port, Started: t.started, Active: t.active, InUse: t.inUse})
+		}
+	}
+	return snapshot
+}
+
+// daemon status：列出后台服务持有的隧道和会话
+func runDaemonStatus(out io.Writer) int {
+	response, err := daemonCall(daemonRequest{Op: "status"}, daemonCallTimeout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	now := time.Now()
+	fmt.Fprintf(out, tr("daemon.tunnel_count"), len(response.Tunnels))
+	for _, t := range response.Tunnels {
+		fmt.Fprintf(out, tr("daemon.tunnel_idle"), t.ID, t.Port, now.Sub(t.Active).Round(time.Second))
+	}
+	fmt.Fprintf(out, tr("daemon.session_count"), len(response.Sessions))
+	for _, target := range response.Sessions {
+		fmt.Fprintf(out, "  %s\n", target.ID())
+	}
+	return exitOK
+}
+
+// 渲染会话面板中后台服务持有的隧道
+func renderDaemonTunnels(now time.Time) []string {
+	daemonStateMu.Lock()
+	defer daemonStateMu.Unlock()
+	var lines []string
+	for _, t := range daemonTunnels {
+		lines = append(lines, tr("daemon.tunnel_line", tview.Escape(t.ID), t.Port, t.activityText(now)))
+	}
+	return lines
+}
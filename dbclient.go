@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 客户端命令模板中可引用的字段，如 {{.Host}}、{{.Database}}、{{tag "role"}}
+type clientTemplateData struct {
+	proxyTemplateData
+	Database string
+}
+
+// 构建交互式数据库客户端命令：配置了 module_settings.<模块>.command 模板时经 sh -c 执行（代入的值按 shell 单引号转义），否则按模块类型组装参数
+func interactiveClientCommand(target connTarget, conn Connection) ([]string, error) {
+	command := settingsFor(target.Module).Command
+	if command == "" {
+		return clientCommand(target.Module, conn, false), nil
+	}
+	tmpl, err := parseProxyTemplate(command, conn.Tags)
+	if err != nil {
+		return nil, fmt.Errorf(tr("dbclient.template"), err)
+	}
+	// 空值不代入，与未转义时一样不产生多余的空参数
+	quote := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return shellQuote(value)
+	}
+	tmpl.Funcs(template.FuncMap{
+		"tag": func(key string) string { return quote(tagValue(conn.Tags, key)) },
+	})
+	var out strings.Builder
+	err = tmpl.Execute(&out, clientTemplateData{
+		proxyTemplateData: proxyTemplateData{
+			Module:  quote(target.Module),
+			Project: quote(target.Project),
+			Env:     quote(target.Env),
+			Name:    quote(conn.Name),
+			Host:    quote(conn.Host),
+			Port:    conn.Port,
+			User:    quote(conn.User),
+		},
+		Database: quote(conn.Database),
+	})
+	if err != nil {
+		return nil, fmt.Errorf(tr("dbclient.template"), err)
+	}
+	return []string{"sh", "-c", strings.TrimSpace(out.String())}, nil
+}
+
+// 打开数据库连接的交互式客户端：需要隧道时先在后台建立，然后挂起界面运行客户端，结束后恢复界面
+func (a *App) openClientSession(target connTarget) {
+	setSessionStatus(target, "connecting")
+	a.updateMainPanel()
+	a.statusBar.SetText(tr("dbclient.connecting", tview.Escape(target.Conn.Name)))
+	go func() {
+		conn, err := clientEndpoint(target)
+		if err == nil {
+			err = startupProbe(target, conn)
+		}
+		var args []string
+		if err == nil {
+			args, err = interactiveClientCommand(target, conn)
+		}
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				setSessionStatus(target, "failed")
+				a.updateMainPanel()
+				recordFailure(failureDetail{Target: target, Summary: err.Error(), Command: args})
+				a.statusBar.SetText(tr("common.connect_failed", tview.Escape(err.Error())))
+				return
+			}
+			a.runClientSession(target, args)
+		})
+	}()
+}
+
+// 挂起界面运行客户端命令，记录会话审计并更新连接状态
+func (a *App) runClientSession(target connTarget, args []string) {
+	var runErr error
+	var stderr bytes.Buffer
+	start := time.Now()
+	setSessionStatus(target, "connected")
+	release := useTunnel(target)
+	a.suspend(func() {
+		if zone, ok := cachedHostTimezone(target); ok {
+			fmt.Printf(tr("dbclient.local_time"), target.Conn.Name, zone.localTime(time.Now()))
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = clientEnv(target)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+		runErr = cmd.Run()
+	})
+	release()
+	duration := time.Since(start)
+	addSessionUsage(target, transferStats{Duration: duration})
+
+	event := auditEvent{Action: "session", Target: target.ID(), Duration: duration, Detail: args[0]}
+	if runErr != nil {
+		event.Detail += "; " + runErr.Error()
+	}
+	recordAudit(event)
+
+	// 客户端无法连接或认证失败时以非零码退出
+	if runErr != nil {
+		setSessionStatus(target, "failed")
+	} else {
+		setSessionStatus(target, "disconnected")
+	}
+	a.updateMainPanel()
+	if runErr != nil {
+		a.statusBar.SetText(tr("dbclient.failed", tview.Escape(runErr.Error())))
+		recordFailure(failureDetail{Target: target, Summary: runErr.Error(), Command: args, Output: stderr.String()})
+	}
+	if authFailed(target.Module, stderr.String()) {
+		a.offerAuthRetry(target, a.openClientSession)
+		return
+	}
+	if runErr != nil {
+		a.showLastFailure()
+		return
+	}
+	a.statusBar.SetText(tr("dbclient.ended", tview.Escape(target.Conn.Name), formatDuration(duration)))
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 读取连接依赖关系（配置项 dependencies：连接标识 -> 其依赖的连接标识列表）
+func connectionDependencies() map[string][]string {
+	deps := make(map[string][]string)
+	for id := range configStringMap("dependencies") {
+		// viper 会将键转为小写，这里从原始配置中取值以保留大小写
+		deps[id] = configStringSlice("dependencies." + id)
+	}
+	return normalizeDependencyKeys(deps)
+}
+
+// 将小写化的依赖键还原为清单中的连接标识
+func normalizeDependencyKeys(deps map[string][]string) map[string][]string {
+	ids := make(map[string]string)
+	for _, target := range inventoryTargets(configuredModules()) {
+		ids[strings.ToLower(target.ID())] = target.ID()
+	}
+	result := make(map[string][]string, len(deps))
+	for key, upstream := range deps {
+		if id, ok := ids[strings.ToLower(key)]; ok {
+			key = id
+		}
+		result[key] = upstream
+	}
+	return result
+}
+
+// 按标识查找连接目标
+func findTarget(id string) (connTarget, bool) {
+	for _, target := range inventoryTargets(configuredModules()) {
+		if target.ID() == id {
+			return target, true
+		}
+	}
+	return connTarget{}, false
+}
+
+// 获取依赖指定连接的下游连接
+func dependents(deps map[string][]string, id string) []string {
+	var result []string
+	for downstream, upstream := range deps {
+		for _, dep := range upstream {
+			if dep == id {
+				result = append(result, downstream)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// 按依赖关系对连接排序（被依赖的连接排在前面），存在循环依赖时返回错误
+func dependencyOrder(deps map[string][]string, ids []string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var order []string
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case visiting:
+			return errors.New(tr("deps.cycle", strings.Join(append(path, id), " -> ")))
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		for _, dep := range deps[id] {
+			if err := visit(dep, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+	for _, id := range ids {
+		if err := visit(id, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// 查找导致连接不可用的上游故障（递归查找最上游的故障连接）
+func failureCause(deps map[string][]string, id string, failed map[string]bool) []string {
+	var causes []string
+	seen := make(map[string]bool)
+	var walk func(id string)
+	walk = func(id string) {
+		for _, dep := range deps[id] {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			if failed[dep] {
+				upstream := len(causes)
+				walk(dep)
+				if len(causes) == upstream {
+					causes = append(causes, dep) // 上游均正常，说明该依赖本身是根因
+				}
+			} else {
+				walk(dep)
+			}
+		}
+	}
+	walk(id)
+	return causes
+}
+
+// 渲染依赖树（向上游或下游展开）
+func renderDependencyTree(builder *strings.Builder, id string, next func(string) []string, prefix string, seen map[string]bool, status map[string]string) {
+	children := next(id)
+	for i, child := range children {
+		branch, childPrefix := "├─ ", prefix+"│  "
+		if i == len(children)-1 {
+			branch, childPrefix = "└─ ", prefix+"   "
+		}
+		builder.WriteString(fmt.Sprintf("%s%s%s %s\n", prefix, branch, tview.Escape(child), status[child]))
+		if seen[child] {
+			builder.WriteString(tr("deps.cycle_marker", childPrefix))
+			continue
+		}
+		seen[child] = true
+		renderDependencyTree(builder, child, next, childPrefix, seen, status)
+		delete(seen, child)
+	}
+}
+
+// 显示当前连接的依赖关系图，并检查相关连接的健康状态以解释级联故障
+func (a *App) showDependencyGraph() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	deps := connectionDependencies()
+	id := target.ID()
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true)
+	view.SetBorder(true).
+		SetTitle(tr("deps.title", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	render := func(status map[string]string, failed map[string]bool) {
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("[yellow]%s[-] %s\n\n", tview.Escape(id), status[id]))
+		builder.WriteString(tr("deps.upstream"))
+		if len(deps[id]) == 0 {
+			builder.WriteString(tr("deps.none"))
+		}
+		renderDependencyTree(&builder, id, func(n string) []string { return deps[n] }, "  ", map[string]bool{id: true}, status)
+		builder.WriteString(tr("deps.downstream"))
+		if len(dependents(deps, id)) == 0 {
+			builder.WriteString(tr("deps.none"))
+		}
+		renderDependencyTree(&builder, id, func(n string) []string { return dependents(deps, n) }, "  ", map[string]bool{id: true}, status)
+
+		if failed != nil {
+			if causes := failureCause(deps, id, failed); len(causes) > 0 {
+				builder.WriteString(tr("deps.cascade"))
+				for _, cause := range causes {
+					builder.WriteString(fmt.Sprintf("  • %s\n", tview.Escape(cause)))
+				}
+			}
+		}
+		view.SetText(builder.String())
+	}
+
+	// 收集图中涉及的全部连接，先渲染结构再在后台检查健康状态
+	related := map[string]bool{id: true}
+	var collect func(string, func(string) []string)
+	collect = func(n string, next func(string) []string) {
+		for _, child := range next(n) {
+			if !related[child] {
+				related[child] = true
+				collect(child, next)
+			}
+		}
+	}
+	collect(id, func(n string) []string { return deps[n] })
+	collect(id, func(n string) []string { return dependents(deps, n) })
+
+	status := make(map[string]string)
+	for n := range related {
+		status[n] = tr("deps.checking")
+	}
+	render(status, nil)
+
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q')) {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+
+	// 逐个检查相关连接，进度弹窗中可取消剩余检查
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := a.startProgress(tr("deps.progress"), cancel)
+	go func() {
+		defer cancel()
+		checked := make(map[string]string)
+		failed := make(map[string]bool)
+		done := 0
+		for n := range related {
+			done++
+			if ctx.Err() != nil {
+				checked[n] = tr("deps.cancelled")
+				continue
+			}
+			progress.update(n, int64(done-1), int64(len(related)))
+			t, ok := findTarget(n)
+			if !ok {
+				checked[n] = tr("deps.not_found")
+				failed[n] = true
+				continue
+			}
+			result := checkTCP(t.Conn, defaultHealthTimeout)
+			recordHealth(t, result)
+			if result.OK {
+				checked[n] = tr("deps.ok", formatDuration(result.Latency))
+			} else {
+				checked[n] = tr("deps.down")
+				failed[n] = true
+			}
+		}
+		progress.finish(func() {
+			render(checked, failed)
+		})
+	}()
+}
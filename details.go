@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 详情面板中横幅最多显示的行数
+const detailBannerLines = 8
+
+// 渲染当前选中连接的详情面板
+func (a *App) renderConnectionDetails() string {
+	target, ok := a.currentTarget()
+	if !ok {
+		return ""
+	}
+	conn := target.Conn
+	content := tr("details.header")
+	content += tr("details.id", tview.Escape(target.ID()))
+	content += tr("details.address", conn.Host, conn.Port)
+	if conn.User != "" {
+		content += tr("details.user", conn.User)
+	}
+	if conn.Database != "" {
+		content += tr("details.database", conn.Database)
+	}
+	content += "\n"
+	if moduleType(target.Module) == "SSH" {
+		zone, ok := hostTimezoneFor(target, func() {
+			a.app.QueueUpdateDraw(a.updateMainPanel)
+		})
+		if ok {
+			content += tr("details.host_time", tview.Escape(zone.localTime(time.Now())))
+		}
+	}
+	if conn.ProxyCommand != "" {
+		content += tr("details.proxy", tview.Escape(conn.ProxyCommand))
+	}
+	if conn.JumpHost != "" {
+		if chain, err := jumpChain(target); err != nil {
+			content += tr("details.jump_error", tview.Escape(err.Error()))
+		} else {
+			var hops []string
+			for _, hop := range chain {
+				hops = append(hops, hop.Conn.Name)
+			}
+			content += tr("details.jump", tview.Escape(strings.Join(hops, pathSeparator)))
+		}
+	}
+	if conn.SSHTunnel != "" {
+		content += tr("details.tunnel", tview.Escape(conn.SSHTunnel))
+	}
+	if len(conn.Addresses) > 0 {
+		content += tr("details.addresses", tview.Escape(strings.Join(conn.Addresses, ", ")))
+	}
+	if choice, ok := lastEndpointChoice(target); ok {
+		content += tr("details.endpoint", tview.Escape(choice.String()), formatShortTime(choice.Time))
+	}
+	if path := latestSessionPath(target); len(path) > 0 {
+		content += tr("details.path", tview.Escape(strings.Join(path, pathSeparator)))
+	}
+	for _, note := range openNotes(target) {
+		content += tr("details.note", tview.Escape(note.Text), tview.Escape(note.Author), formatShortTime(note.Time))
+	}
+	content += renderUptimeHistory(target, time.Now())
+
+	if record, ok := latestBanner(target); ok {
+		content += tr("details.banner", formatTime(record.Time))
+		lines := strings.Split(strings.TrimSpace(record.Banner+"\n"+record.MOTD), "\n")
+		if len(lines) > detailBannerLines {
+			lines = append(lines[:detailBannerLines], "...")
+		}
+		for _, line := range lines {
+			content += "  [gray]│[-] " + tview.Escape(line) + "\n"
+		}
+	}
+	return content
+}
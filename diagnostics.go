@@ -0,0 +1,344 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 诊断命令超时时间
+const diagTimeout = 10 * time.Second
+
+// 诊断查询定义：每个标签页对应一条只读诊断命令
+type diagQuery struct {
+	Title string                         // 标签页标题的消息标识
+	Args  []string                       // 追加到客户端命令后的参数
+	Parse func(output string) [][]string // 输出解析函数，第一行为表头
+}
+
+// 诊断面板，包含标签栏和每个标签页对应的表格
+type diagPanel struct {
+	target  connTarget      // 诊断的目标连接
+	queries []diagQuery     // 当前模块的诊断查询
+	current int             // 当前标签页索引
+	layout  *tview.Flex     // 面板整体布局
+	tabBar  *tview.TextView // 顶部标签栏
+	pages   *tview.Pages    // 标签页内容
+	tables  []*tview.Table  // 每个标签页的结果表格
+}
+
+// 获取模块对应的只读诊断查询列表
+func diagnosticQueries(module string) []diagQuery {
+	switch moduleType(module) {
+	case "MySQL":
+		return []diagQuery{
+			{Title: "diag.tab.activity", Args: []string{"-e", "SHOW FULL PROCESSLIST"}, Parse: parseTSV},
+			{Title: "diag.tab.locks", Args: []string{"-e", "SELECT trx_id, trx_state, trx_started, trx_wait_started, trx_mysql_thread_id, trx_query FROM information_schema.INNODB_TRX"}, Parse: parseTSV},
+			{Title: "diag.tab.slow_stats", Args: []string{"-e", "SHOW GLOBAL STATUS WHERE Variable_name IN ('Slow_queries','Threads_connected','Threads_running','Questions','Uptime')"}, Parse: parseTSV},
+		}
+	case "PostgreSQL":
+		return []diagQuery{
+			{Title: "diag.tab.activity", Args: []string{"-c", "SELECT pid, usename, state, now() - query_start AS duration, wait_event_type, left(query, 120) AS query FROM pg_stat_activity WHERE state <> 'idle' ORDER BY query_start"}, Parse: parseTSV},
+			{Title: "diag.tab.locks", Args: []string{"-c", "SELECT l.pid, l.locktype, l.mode, l.relation::regclass AS relation, a.usename, left(a.query, 120) AS query FROM pg_locks l JOIN pg_stat_activity a ON a.pid = l.pid WHERE NOT l.granted"}, Parse: parseTSV},
+			{Title: "diag.tab.db_stats", Args: []string{"-c", "SELECT datname, numbackends, xact_commit, xact_rollback, blks_hit, blks_read, deadlocks FROM pg_stat_database WHERE datname IS NOT NULL"}, Parse: parseTSV},
+		}
+	case "Redis":
+		return []diagQuery{
+			{Title: "diag.tab.slowlog", Args: []string{"SLOWLOG", "GET", "20"}, Parse: parseLines},
+			{Title: "diag.tab.keyspace", Args: []string{"INFO", "keyspace"}, Parse: parseRedisInfo},
+			{Title: "diag.tab.stats", Args: []string{"INFO", "stats"}, Parse: parseRedisInfo},
+			{Title: "diag.tab.clients", Args: []string{"CLIENT", "LIST"}, Parse: parseRedisClientList},
+		}
+	}
+	return nil
+}
+
+// 构建连接对应的非交互客户端基础命令（不含查询参数）
+func batchClientCommand(module string, conn Connection) []string {
+	return clientCommand(module, conn, true)
+}
+
+// 构建连接对应的客户端命令，batch 为 true 时使用便于解析的非交互输出格式
+func clientCommand(module string, conn Connection, batch bool) []string {
+	settings := settingsFor(module)
+	tlsArgs, tlsConninfo := databaseTLSArgs(module, conn, settings.TLSMode)
+	switch moduleType(module) {
+	case "MySQL":
+		args := []string{moduleClient(module, "mysql")}
+		if batch {
+			args = append(args, "--batch")
+		}
+		args = append(args, "-h", conn.Host, "-P", strconv.Itoa(conn.Port))
+		if conn.User != "" {
+			args = append(args, "-u", conn.User)
+		}
+		if settings.TLSMode != "" {
+			args = append(args, "--ssl-mode="+settings.TLSMode)
+		}
+		args = append(args, tlsArgs...)
+		if conn.Database != "" {
+			args = append(args, conn.Database)
+		}
+		return args
+	case "PostgreSQL":
+		args := []string{moduleClient(module, "psql")}
+		if batch {
+			args = append(args, "-X", "-A", "-F", "\t", "-P", "footer=off")
+		}
+		args = append(args, "-h", conn.Host, "-p", strconv.Itoa(conn.Port))
+		if conn.User != "" {
+			args = append(args, "-U", conn.User)
+		}
+		// sslmode 通过连接串传递
+		var conninfo []string
+		if conn.Database != "" {
+			conninfo = append(conninfo, "dbname="+conn.Database)
+		}
+		if settings.TLSMode != "" {
+			conninfo = append(conninfo, "sslmode="+settings.TLSMode)
+		}
+		conninfo = append(conninfo, tlsConninfo...)
+		if len(conninfo) > 0 {
+			args = append(args, "-d", strings.Join(conninfo, " "))
+		}
+		return args
+	case "Redis":
+		args := []string{moduleClient(module, "redis-cli"), "-h", conn.Host, "-p", strconv.Itoa(conn.Port)}
+		if settings.TLSMode != "" && !strings.EqualFold(settings.TLSMode, "disable") {
+			args = append(args, "--tls")
+		}
+		args = append(args, tlsArgs...)
+		if conn.Database != "" {
+			args = append(args, "-n", conn.Database)
+		}
+		return args
+	}
+	return nil
+}
+
+// 解析制表符分隔的输出（mysql --batch / psql -A），第一行为表头
+func parseTSV(output string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	return rows
+}
+
+// 按行解析输出，每行作为单列
+func parseLines(output string) [][]string {
+	rows := [][]string{{tr("diag.output")}}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		rows = append(rows, []string{line})
+	}
+	return rows
+}
+
+// 解析 Redis INFO 输出为键值两列
+func parseRedisInfo(output string) [][]string {
+	rows := [][]string{{tr("diag.metric"), tr("diag.value")}}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, _ := strings.Cut(line, ":")
+		rows = append(rows, []string{key, value})
+	}
+	return rows
+}
+
+// 解析 Redis CLIENT LIST 输出，每个客户端一行，列为 key=value 中的 key
+func parseRedisClientList(output string) [][]string {
+	columns := []string{"id", "addr", "name", "age", "idle", "db", "cmd"}
+	rows := [][]string{columns}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := make(map[string]string)
+		for _, token := range strings.Fields(line) {
+			key, value, _ := strings.Cut(token, "=")
+			fields[key] = value
+		}
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = fields[column]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// 打开当前选中连接的诊断面板
+func (a *App) showDiagnostics() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	module, conn := target.Module, target.Conn
+	queries := diagnosticQueries(module)
+	if len(queries) == 0 {
+		a.statusBar.SetText(tr("diag.unsupported", module))
+		return
+	}
+
+	panel := &diagPanel{
+		target:  target,
+		queries: queries,
+		tabBar: tview.NewTextView().
+			SetDynamicColors(true).
+			SetRegions(true).
+			SetWrap(false),
+		pages: tview.NewPages(),
+	}
+
+	for i, query := range queries {
+		table := tview.NewTable().
+			SetBorders(false).
+			SetFixed(1, 0).
+			SetSelectable(true, false)
+		table.SetCell(0, 0, tview.NewTableCell(tr("common.loading")).SetTextColor(tcell.ColorGray))
+		panel.tables = append(panel.tables, table)
+		panel.pages.AddPage(query.Title, table, true, i == 0)
+	}
+
+	hint := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(tr("diag.hint"))
+
+	panel.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(panel.tabBar, 1, 0, false).
+		AddItem(panel.pages, 0, 1, true).
+		AddItem(hint, 1, 0, false)
+	panel.layout.SetBorder(true).
+		SetTitle(tr("diag.title", conn.Name, conn.Host, conn.Port)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.diagnostics = panel
+	a.updateDiagnosticsTabs()
+	a.refreshDiagnostics()
+	a.pushOverlay(panel.layout, panel.tables[0], a.handleDiagnosticsKey)
+}
+
+// 关闭诊断面板，返回主界面
+func (a *App) hideDiagnostics() {
+	a.diagnostics = nil
+	a.popOverlay()
+}
+
+// 更新诊断面板的标签栏
+func (a *App) updateDiagnosticsTabs() {
+	panel := a.diagnostics
+	content := " "
+	for i, query := range panel.queries {
+		if i == panel.current {
+			content += fmt.Sprintf("[white:blue:b] %d.%s [-:-:-] ", i+1, tr(query.Title))
+		} else {
+			content += fmt.Sprintf(" %d.%s  ", i+1, tr(query.Title))
+		}
+	}
+	panel.tabBar.SetText(content)
+}
+
+// 切换诊断面板的标签页
+func (a *App) switchDiagnosticsTab(delta int) {
+	panel := a.diagnostics
+	panel.current = (panel.current + delta + len(panel.queries)) % len(panel.queries)
+	panel.pages.SwitchToPage(panel.queries[panel.current].Title)
+	a.app.SetFocus(panel.tables[panel.current])
+	a.updateDiagnosticsTabs()
+}
+
+// 在后台执行所有诊断查询，完成后刷新对应表格
+func (a *App) refreshDiagnostics() {
+	panel := a.diagnostics
+	go func() {
+		// 需要隧道时先建立本地监听，所有查询共用
+		conn, err := clientEndpoint(panel.target)
+		if err != nil {
+			a.app.QueueUpdateDraw(func() {
+				for _, table := range panel.tables {
+					fillTable(table, [][]string{{tr("common.error")}, {err.Error()}})
+				}
+			})
+			return
+		}
+		base := batchClientCommand(panel.target.Module, conn)
+		for i, query := range panel.queries {
+			table := panel.tables[i]
+			args := append(append([]string{}, base[1:]...), query.Args...)
+			parse := query.Parse
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), diagTimeout)
+				defer cancel()
+				cmd := exec.CommandContext(ctx, base[0], args...)
+				cmd.Env = clientEnv(panel.target)
+				output, err := cmd.CombinedOutput()
+				a.app.QueueUpdateDraw(func() {
+					if err != nil {
+						fillTable(table, [][]string{{tr("common.error")}, {err.Error()}, {strings.TrimSpace(string(output))}})
+						return
+					}
+					fillTable(table, parse(string(output)))
+				})
+			}()
+		}
+	}()
+}
+
+// 使用二维字符串数据填充表格，第一行作为表头
+func fillTable(table *tview.Table, rows [][]string) {
+	table.Clear()
+	for r, row := range rows {
+		for c, value := range row {
+			cell := tview.NewTableCell(tview.Escape(value)).SetExpansion(1)
+			if r == 0 {
+				cell.SetTextColor(tcell.ColorYellow).SetSelectable(false)
+			}
+			table.SetCell(r, c, cell)
+		}
+	}
+	table.ScrollToBeginning()
+}
+
+// 处理诊断面板中的键盘事件
+func (a *App) handleDiagnosticsKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc:
+		a.hideDiagnostics()
+		return nil
+	case tcell.KeyTab:
+		a.switchDiagnosticsTab(1)
+		return nil
+	case tcell.KeyBacktab:
+		a.switchDiagnosticsTab(-1)
+		return nil
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'q', 'Q':
+			a.hideDiagnostics()
+			return nil
+		case 'l', 'L':
+			a.switchDiagnosticsTab(1)
+			return nil
+		case 'h', 'H':
+			a.switchDiagnosticsTab(-1)
+			return nil
+		case 'r', 'R':
+			a.refreshDiagnostics()
+			return nil
+		}
+	}
+	return event
+}
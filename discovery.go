@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 服务发现请求超时时间
+const discoveryTimeout = 10 * time.Second
+
+// 服务发现来源（配置项 discovery.sources）
+type discoverySource struct {
+	Name    string `mapstructure:"name"`    // 来源名称
+	Type    string `mapstructure:"type"`    // consul 或 nomad
+	Address string `mapstructure:"address"` // API 地址，默认读取 CONSUL_HTTP_ADDR/NOMAD_ADDR
+	Token   string `mapstructure:"token"`   // 访问令牌，支持 ${ENV} 形式，默认读取 CONSUL_HTTP_TOKEN/NOMAD_TOKEN
+	Scope   string `mapstructure:"scope"`   // Consul 数据中心或 Nomad 命名空间
+	Tag     string `mapstructure:"tag"`     // 只发现带有该标签的服务
+	Module  string `mapstructure:"module"`  // 存放到的模块，为空时按服务名和标签推断类型
+	Project string `mapstructure:"project"` // 存放到名称匹配的项目
+	Env     string `mapstructure:"env"`     // 存放到匹配的环境
+}
+
+// 获取服务发现来源
+func discoverySources() []discoverySource {
+	var sources []discoverySource
+	if err := unmarshalConfig("discovery.sources", &sources); err != nil {
+		return nil
+	}
+	return sources
+}
+
+// 服务发现刷新间隔（配置项 discovery.interval，默认 30 秒）
+func discoveryInterval() time.Duration {
+	if interval := configDuration("discovery.interval"); interval > 0 {
+		return interval
+	}
+	return 30 * time.Second
+}
+
+// 来源显示名称
+func (s discoverySource) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Type
+}
+
+// API 地址
+func (s discoverySource) address() string {
+	address := s.Address
+	if address == "" {
+		switch s.Type {
+		case "consul":
+			address = os.Getenv("CONSUL_HTTP_ADDR")
+		case "nomad":
+			address = os.Getenv("NOMAD_ADDR")
+		}
+	}
+	if address == "" {
+		if s.Type == "nomad" {
+			address = "http://127.0.0.1:4646"
+		} else {
+			address = "http://127.0.0.1:8500"
+		}
+	}
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	return strings.TrimRight(address, "/")
+}
+
+// 请求 API 并解析 JSON 响应
+func (s discoverySource) get(ctx context.Context, path string, query url.Values, v any) error {
+	endpoint := s.address() + path
+	if encoded := query.Encode(); encoded != "" {
+		endpoint += "?" + encoded
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	token := os.ExpandEnv(s.Token)
+	switch {
+	case s.Type == "consul" && token == "":
+		token = os.Getenv("CONSUL_HTTP_TOKEN")
+	case s.Type == "nomad" && token == "":
+		token = os.Getenv("NOMAD_TOKEN")
+	}
+	if token != "" {
+		if s.Type == "nomad" {
+			req.Header.Set("X-Nomad-Token", token)
+		} else {
+			req.Header.Set("X-Consul-Token", token)
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", s.label(), path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// 已发现的服务实例
+type discoveredService struct {
+	Service string   // 服务名
+	Name    string   // 实例名称
+	Host    string   // 地址
+	Port    int      // 端口
+	Healthy bool     // 是否健康
+	Tags    []string // 标签
+}
+
+// 从 Consul 目录读取服务实例及其健康状态
+func (s discoverySource) consulServices(ctx context.Context) ([]discoveredService, error) {
+	query := url.Values{}
+	if s.Scope != "" {
+		query.Set("dc", s.Scope)
+	}
+	var catalog map[string][]string
+	if err := s.get(ctx, "/v1/catalog/services", query, &catalog); err != nil {
+		return nil, err
+	}
+	var services []discoveredService
+	for name, tags := range catalog {
+		if name == "consul" || (s.Tag != "" && !containsFold(tags, s.Tag)) {
+			continue
+		}
+		var entries []struct {
+			Node struct {
+				Node    string
+				Address string
+			}
+			Service struct {
+				ID      string
+				Tags    []string
+				Address string
+				Port    int
+			}
+			Checks []struct {
+				Status string
+			}
+		}
+		if err := s.get(ctx, "/v1/health/service/"+url.PathEscape(name), query, &entries); err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			healthy := true
+			for _, check := range entry.Checks {
+				if check.Status == "critical" {
+					healthy = false
+				}
+			}
+			host := entry.Service.Address
+			if host == "" {
+				host = entry.Node.Address
+			}
+			services = append(services, discoveredService{
+				Service: name,
+				Name:    fmt.Sprintf("%s@%s", name, entry.Node.Node),
+				Host:    host,
+				Port:    entry.Service.Port,
+				Healthy: healthy,
+				Tags:    append([]string{"consul", "service=" + name}, entry.Service.Tags...),
+			})
+		}
+	}
+	return services, nil
+}
+
+// 从 Nomad 服务注册读取运行中分配的服务实例
+func (s discoverySource) nomadServices(ctx context.Context) ([]discoveredService, error) {
+	namespace := s.Scope
+	if namespace == "" {
+		namespace = "*"
+	}
+	var catalog []struct {
+		Namespace string
+		Services  []struct {
+			ServiceName string
+			Tags        []string
+		}
+	}
+	if err := s.get(ctx, "/v1/services", url.Values{"namespace": {namespace}}, &catalog); err != nil {
+		return nil, err
+	}
+	var services []discoveredService
+	for _, ns := range catalog {
+		for _, svc := range ns.Services {
+			if s.Tag != "" && !containsFold(svc.Tags, s.Tag) {
+				continue
+			}
+			var registrations []struct {
+				JobID   string
+				AllocID string
+				Address string
+				Port    int
+				Tags    []string
+			}
+			if err := s.get(ctx, "/v1/service/"+url.PathEscape(svc.ServiceName), url.Values{"namespace": {ns.Namespace}}, &registrations); err != nil {
+				return nil, err
+			}
+			for _, reg := range registrations {
+				alloc := reg.AllocID
+				if len(alloc) > 8 {
+					alloc = alloc[:8]
+				}
+				services = append(services, discoveredService{
+					Service: svc.ServiceName,
+					Name:    fmt.Sprintf("%s@%s", svc.ServiceName, alloc),
+					Host:    reg.Address,
+					Port:    reg.Port,
+					Healthy: true,
+					Tags:    append([]string{"nomad", "job=" + reg.JobID, "service=" + svc.ServiceName}, reg.Tags...),
+				})
+			}
+		}
+	}
+	return services, nil
+}
+
+// 不区分大小写判断列表是否包含指定值
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// 根据服务名和标签推断模块类型，无法识别时视为 SSH 主机
+func serviceType(service discoveredService) string {
+	for _, name := range append([]string{service.Service}, service.Tags...) {
+		if kind := engineType(name); kind != "" {
+			return kind
+		}
+	}
+	return "SSH"
+}
+
+// 查询来源中的服务并转换为连接
+func (s discoverySource) discover(ctx context.Context) ([]inventoryEntry, error) {
+	var services []discoveredService
+	var err error
+	switch s.Type {
+	case "consul":
+		services, err = s.consulServices(ctx)
+	case "nomad":
+		services, err = s.nomadServices(ctx)
+	default:
+		return nil, errors.New(tr("discovery.unsupported", s.Type))
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
+	var entries []inventoryEntry
+	for _, service := range services {
+		var entry inventoryEntry
+		var ok bool
+		if s.Module != "" {
+			entry, ok = placeInModule(s.Module, s.Project, s.Env)
+		} else {
+			entry, ok = placeConnection(serviceType(service), s.Project, s.Env)
+		}
+		if !ok || service.Host == "" {
+			continue
+		}
+		status := "connected"
+		if !service.Healthy {
+			status = "disconnected"
+		}
+		entry.Conn = Connection{
+			Name:   service.Name,
+			Status: status,
+			Host:   service.Host,
+			Port:   service.Port,
+			User:   defaultUser(entry.Module),
+			Tags:   splitTags(strings.Join(service.Tags, ",")),
+		}
+		if entry.Conn.Port == 0 {
+			entry.Conn.Port = defaultPort(entry.Module)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// 服务发现结果缓存
+var (
+	discoveryMu     sync.Mutex
+	discovered      = make(map[string][]inventoryEntry) // 来源名称 -> 发现的连接
+	discoveryErrors = make(map[string]string)           // 来源名称 -> 最近一次刷新错误
+)
+
+// 刷新全部来源；某个来源失败时保留其上一次的结果
+func refreshDiscovery() {
+	sources := discoverySources()
+	results := make([][]inventoryEntry, len(sources))
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+			defer cancel()
+			results[i], errs[i] = source.discover(ctx)
+		}()
+	}
+	wg.Wait()
+
+	discoveryMu.Lock()
+	defer discoveryMu.Unlock()
+	for i, source := range sources {
+		if errs[i] != nil {
+			discoveryErrors[source.label()] = errs[i].Error()
+			continue
+		}
+		delete(discoveryErrors, source.label())
+		discovered[source.label()] = results[i]
+	}
+}
+
+// 清空服务发现结果（切换工作区时调用）
+func resetDiscovery() {
+	discoveryMu.Lock()
+	defer discoveryMu.Unlock()
+	discovered = make(map[string][]inventoryEntry)
+	discoveryErrors = make(map[string]string)
+}
+
+// 获取指定环境中发现的连接
+func discoveredConnections(module, project, env string) []Connection {
+	discoveryMu.Lock()
+	defer discoveryMu.Unlock()
+	var conns []Connection
+	for _, entries := range discovered {
+		for _, entry := range entries {
+			if entry.Module == module && entry.Project == project && entry.Env == env {
+				conns = append(conns, entry.Conn)
+			}
+		}
+	}
+	return conns
+}
+
+// 服务发现失败信息
+func discoveryFailures() []string {
+	discoveryMu.Lock()
+	defer discoveryMu.Unlock()
+	var failures []string
+	for name, err := range discoveryErrors {
+		failures = append(failures, fmt.Sprintf("%s: %s", name, err))
+	}
+	return failures
+}
+
+// 在后台定期刷新服务发现结果和 Mesh 节点并重绘界面
+func (a *App) startDiscovery() {
+	go func() {
+		for {
+			sources, mesh := len(discoverySources()) > 0, meshEnabled()
+			if sources {
+				refreshDiscovery()
+			}
+			var meshErr error
+			if mesh {
+				meshErr = refreshMesh()
+			}
+			if sources || mesh {
+				failures := discoveryFailures()
+				if meshErr != nil {
+					failures = append(failures, "mesh: "+meshErr.Error())
+				}
+				a.app.QueueUpdateDraw(func() {
+					a.updateMainPanel()
+					if len(failures) > 0 && len(a.overlays) == 0 {
+						a.statusBar.SetText(tr("discovery.failed", tview.Escape(strings.Join(failures, "; "))))
+					}
+				})
+			}
+			time.Sleep(discoveryInterval())
+		}
+	}()
+}
@@ -0,0 +1,690 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 节点是否已被删除
+func (m moduleInventory) isRemoved(path string) bool {
+	return slices.Contains(m.Removed, path)
+}
+
+// 获取项目的配置条目，不存在时新建
+func (m *moduleInventory) project(name string) *inventoryProject {
+	for i := range m.Projects {
+		if m.Projects[i].Name == name {
+			return &m.Projects[i]
+		}
+	}
+	m.Projects = append(m.Projects, inventoryProject{Name: name})
+	return &m.Projects[len(m.Projects)-1]
+}
+
+// 删除节点：配置清单中的项目、环境或连接直接移除，示例节点记入 removed；同时清理节点路径下的删除记录
+func (m *moduleInventory) remove(path string, builtin bool) {
+	parts := strings.Split(path, "/")
+	switch len(parts) {
+	case 1:
+		m.Projects = slices.DeleteFunc(m.Projects, func(p inventoryProject) bool { return p.Name == parts[0] })
+	case 2:
+		for i := range m.Projects {
+			if m.Projects[i].Name == parts[0] {
+				m.Projects[i].Environments = slices.DeleteFunc(m.Projects[i].Environments, func(env inventoryEnv) bool { return env.Name == parts[1] })
+			}
+		}
+	case 3:
+		if env := m.environment(parts[0], parts[1]); env != nil {
+			env.Connections = slices.DeleteFunc(env.Connections, func(conn configConnection) bool { return conn.Name == parts[2] })
+		}
+	}
+	m.Removed = slices.DeleteFunc(m.Removed, func(removed string) bool { return strings.HasPrefix(removed, path+"/") })
+	if builtin && !m.isRemoved(path) {
+		m.Removed = append(m.Removed, path)
+	}
+}
+
+// 获取配置清单中的环境，不存在时返回 nil
+func (m *moduleInventory) environment(project, env string) *inventoryEnv {
+	for i := range m.Projects {
+		if m.Projects[i].Name != project {
+			continue
+		}
+		for j := range m.Projects[i].Environments {
+			if m.Projects[i].Environments[j].Name == env {
+				return &m.Projects[i].Environments[j]
+			}
+		}
+	}
+	return nil
+}
+
+// 获取配置清单中的连接，不存在时返回 nil
+func (m *moduleInventory) connection(project, env, name string) *configConnection {
+	e := m.environment(project, env)
+	if e == nil {
+		return nil
+	}
+	for i := range e.Connections {
+		if e.Connections[i].Name == name {
+			return &e.Connections[i]
+		}
+	}
+	return nil
+}
+
+// 以连接的当前值更新配置条目；原来省略的主机、端口、用户名仍等于默认值时继续省略
+func (c configConnection) merge(module string, conn Connection) configConnection {
+	merged := configConnection{
+		Name:         conn.Name,
+		Host:         conn.Host,
+		Port:         conn.Port,
+		User:         conn.User,
+		Database:     conn.Database,
+		IdentityFile: conn.IdentityFile,
+		ProxyCommand: conn.ProxyCommand,
+		JumpHost:     conn.JumpHost,
+		Addresses:    conn.Addresses,
+		Tags:         conn.Tags,
+		Auth:         conn.Auth,
+		Certificate:  conn.Certificate,
+		TLS:          conn.TLS,
+		TLSCA:        conn.TLSCA,
+		TLSCert:      conn.TLSCert,
+		TLSKey:       conn.TLSKey,
+		SSHTunnel:    conn.SSHTunnel,
+	}
+	if c.Host == "" && merged.Host == merged.Name {
+		merged.Host = ""
+	}
+	if c.Port == 0 && merged.Port == defaultPort(module) {
+		merged.Port = 0
+	}
+	if c.User == "" && merged.User == defaultUser(module) {
+		merged.User = ""
+	}
+	return merged
+}
+
+// 把新建的连接写入配置文件的 inventory.<模块>，环境不在清单中（如示例环境）时一并添加
+func addInventoryConnections(entries []inventoryEntry) error {
+	inventories := make(map[string]moduleInventory)
+	for _, entry := range entries {
+		inventory, ok := inventories[entry.Module]
+		if !ok {
+			inventory = moduleInventoryFor(entry.Module)
+		}
+		env := inventory.environment(entry.Project, entry.Env)
+		if env == nil {
+			p := inventory.project(entry.Project)
+			p.Environments = append(p.Environments, inventoryEnv{Name: entry.Env})
+			env = &p.Environments[len(p.Environments)-1]
+		}
+		conn := configConnection{}.merge(entry.Module, entry.Conn)
+		if existing := inventory.connection(entry.Project, entry.Env, conn.Name); existing != nil {
+			*existing = conn
+		} else {
+			env.Connections = append(env.Connections, conn)
+		}
+		path := entry.Project + "/" + entry.Env + "/" + conn.Name
+		inventory.Removed = slices.DeleteFunc(inventory.Removed, func(removed string) bool { return removed == path })
+		inventories[entry.Module] = inventory
+	}
+	for module, inventory := range inventories {
+		if err := saveModuleInventory(module, inventory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 保存表单中新建的连接：写入配置文件的清单；启用审阅模式时与其他新增连接一样先暂存
+func saveFormConnection(entry inventoryEntry) error {
+	if reviewMode() {
+		return saveNewConnections([]inventoryEntry{entry})
+	}
+	return addInventoryConnections([]inventoryEntry{entry})
+}
+
+// 保存连接字段修改（连接标识 -> 字段 -> 新值）：配置清单中的连接写回配置文件的 inventory.<模块>，
+// 并清除这些字段原有的覆盖；示例、新增和发现的连接不在配置文件中，仍保存为字段覆盖
+func saveConnectionChanges(updates map[string]map[string]string) error {
+	inventories := make(map[string]moduleInventory)
+	overridden := make(map[string]map[string]string)
+	written := make(map[string][]string)
+	for id, fields := range updates {
+		parts := strings.SplitN(id, "/", 4)
+		if len(parts) != 4 {
+			overridden[id] = fields
+			continue
+		}
+		module := parts[0]
+		inventory, ok := inventories[module]
+		if !ok {
+			inventory = moduleInventoryFor(module)
+		}
+		entry := inventory.connection(parts[1], parts[2], parts[3])
+		if entry == nil || inventory.isRemoved(parts[1]+"/"+parts[2]+"/"+parts[3]) {
+			overridden[id] = fields
+			continue
+		}
+		conn := entry.connection(module)
+		for field, value := range fields {
+			if err := setConnectionField(&conn, field, value); err != nil {
+				return err
+			}
+			written[id] = append(written[id], field)
+		}
+		*entry = entry.merge(module, conn)
+		inventories[module] = inventory
+	}
+	for module, inventory := range inventories {
+		if err := saveModuleInventory(module, inventory); err != nil {
+			return err
+		}
+	}
+	if err := clearOverrides(written); err != nil {
+		return err
+	}
+	if len(overridden) == 0 {
+		return nil
+	}
+	return saveOverrides(overridden)
+}
+
+// 保存模块的清单配置并写回配置文件；settings 为随清单一并写回的其他配置项（见 moveConnectionSettings）
+func saveModuleInventory(module string, inventory moduleInventory, settings ...string) error {
+	all := make(map[string]any)
+	for name, config := range inventoryConfig() {
+		if !strings.EqualFold(name, module) {
+			all[name] = config.value()
+		}
+	}
+	all[module] = inventory.value()
+	setConfig("inventory", all)
+	resetInventory()
+	return writeConfigSections(append([]string{"inventory"}, settings...)...)
+}
+
+// 转换为写入配置文件的结构（键名与配置项一致）
+func (m moduleInventory) value() map[string]any {
+	projects := make([]map[string]any, 0, len(m.Projects))
+	for _, project := range m.Projects {
+		envs := make([]map[string]any, 0, len(project.Environments))
+		for _, env := range project.Environments {
+			conns := make([]map[string]any, 0, len(env.Connections))
+			for _, conn := range env.Connections {
+				conns = append(conns, conn.value())
+			}
+			envs = append(envs, map[string]any{"name": env.Name, "connections": conns})
+		}
+		projects = append(projects, map[string]any{"name": project.Name, "environments": envs})
+	}
+	value := map[string]any{"projects": projects}
+	if len(m.Removed) > 0 {
+		value["removed"] = m.Removed
+	}
+	return value
+}
+
+// 转换为写入配置文件的结构，省略未填写的字段
+func (c configConnection) value() map[string]any {
+	value := map[string]any{"name": c.Name}
+	set := func(key string, v any, empty bool) {
+		if !empty {
+			value[key] = v
+		}
+	}
+	set("host", c.Host, c.Host == "")
+	set("port", c.Port, c.Port == 0)
+	set("user", c.User, c.User == "")
+	set("database", c.Database, c.Database == "")
+	set("identity_file", c.IdentityFile, c.IdentityFile == "")
+	set("proxy_command", c.ProxyCommand, c.ProxyCommand == "")
+	set("jump_host", c.JumpHost, c.JumpHost == "")
+	set("addresses", c.Addresses, len(c.Addresses) == 0)
+	set("tags", c.Tags, len(c.Tags) == 0)
+	set("auth", c.Auth, c.Auth == "")
+	set("certificate", c.Certificate, c.Certificate == "")
+	set("tls", c.TLS, !c.TLS)
+	set("tls_ca", c.TLSCA, c.TLSCA == "")
+	set("tls_cert", c.TLSCert, c.TLSCert == "")
+	set("tls_key", c.TLSKey, c.TLSKey == "")
+	set("ssh_tunnel", c.SSHTunnel, c.SSHTunnel == "")
+	return value
+}
+
+// 进入或退出编辑模式（仅在树视图中）
+func (a *App) toggleEditMode() {
+	if !a.inTreeView {
+		return
+	}
+	if a.state == Edit {
+		a.state = Normal
+	} else {
+		a.state = Edit
+	}
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 编辑模式下的按键：A 添加同级节点，E 编辑，D 删除，ESC 或 Ctrl+E 返回正常模式
+func (a *App) handleEditKeys(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc, tcell.KeyCtrlE:
+		a.toggleEditMode()
+		return nil
+	case tcell.KeyUp:
+		a.moveTreeUp()
+		return nil
+	case tcell.KeyDown:
+		a.moveTreeDown()
+		return nil
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'k', 'K':
+			a.moveTreeUp()
+		case 'j', 'J':
+			a.moveTreeDown()
+		case ' ':
+			a.toggleExpansion()
+		case 'a', 'A':
+			a.addTreeNode()
+		case 'e', 'E':
+			a.editTreeNode()
+		case 'd', 'D':
+			a.deleteTreeNode()
+		}
+		return nil
+	}
+	return event
+}
+
+// 是否为显示中的示例项目
+func isDemoProject(module, project string) bool {
+	return demoDataEnabled() && slices.Contains(builtinProjects(module), Project{Name: project})
+}
+
+// 添加与选中节点同级的节点：项目、项目中的环境或环境中的连接
+func (a *App) addTreeNode() {
+	module, project := a.modules[a.currentModule], a.selected.Project
+	switch a.selected.Level {
+	case 0:
+		a.showNodeNameForm(tr("edit.new_project"), "", true, func(name, envs string) error {
+			if slices.Contains(projectList(module), Project{Name: name}) {
+				return errors.New(tr("edit.project_exists", name))
+			}
+			inventory := moduleInventoryFor(module)
+			inventory.Removed = slices.DeleteFunc(inventory.Removed, func(removed string) bool {
+				return removed == name || strings.HasPrefix(removed, name+"/")
+			})
+			p := inventory.project(name)
+			for _, env := range splitAddresses(envs) {
+				p.Environments = append(p.Environments, inventoryEnv{Name: env})
+			}
+			if err := saveModuleInventory(module, inventory); err != nil {
+				return err
+			}
+			recordAudit(auditEvent{Action: "project_add", Target: module + "/" + name})
+			return nil
+		})
+	case 1:
+		if project == meshProjectName {
+			a.statusBar.SetText(tr("edit.mesh_no_env"))
+			return
+		}
+		a.showNodeNameForm(tr("edit.new_env", project), "", false, func(name, _ string) error {
+			if slices.Contains(projectEnvironments(module, project), Environment{Name: name}) {
+				return errors.New(tr("edit.env_exists", project, name))
+			}
+			inventory := moduleInventoryFor(module)
+			inventory.Removed = slices.DeleteFunc(inventory.Removed, func(removed string) bool {
+				return removed == project+"/"+name || strings.HasPrefix(removed, project+"/"+name+"/")
+			})
+			if !slices.Contains(builtinEnvironments(module, project), Environment{Name: name}) {
+				p := inventory.project(project)
+				p.Environments = append(p.Environments, inventoryEnv{Name: name})
+			}
+			if err := saveModuleInventory(module, inventory); err != nil {
+				return err
+			}
+			recordAudit(auditEvent{Action: "env_add", Target: module + "/" + project + "/" + name})
+			return nil
+		})
+	case 2:
+		a.showNewConnectionForm(Connection{}, "")
+	}
+}
+
+// 编辑选中节点：连接打开字段表单，配置清单中的项目和环境可以重命名
+func (a *App) editTreeNode() {
+	module, project, env := a.modules[a.currentModule], a.selected.Project, a.selected.Env
+	if project == "" {
+		return
+	}
+	inventory := moduleInventoryFor(module)
+	switch a.selected.Level {
+	case 0:
+		if isDemoProject(module, project) || project == meshProjectName {
+			a.statusBar.SetText(tr("edit.demo_project_rename"))
+			return
+		}
+		a.showNodeNameForm(tr("edit.rename_project"), project, false, func(name, _ string) error {
+			if name == project {
+				return nil
+			}
+			if slices.Contains(projectList(module), Project{Name: name}) {
+				return errors.New(tr("edit.project_exists", name))
+			}
+			inventory.project(project).Name = name
+			return a.renameNode(module, project, name, inventory)
+		})
+	case 1:
+		if !slices.Contains(inventory.environments(project), Environment{Name: env}) || slices.Contains(builtinEnvironments(module, project), Environment{Name: env}) {
+			a.statusBar.SetText(tr("edit.demo_env_rename"))
+			return
+		}
+		a.showNodeNameForm(tr("edit.rename_env", project), env, false, func(name, _ string) error {
+			if name == env {
+				return nil
+			}
+			if slices.Contains(projectEnvironments(module, project), Environment{Name: name}) {
+				return errors.New(tr("edit.env_exists", project, name))
+			}
+			inventory.environment(project, env).Name = name
+			return a.renameNode(module, project+"/"+env, project+"/"+name, inventory)
+		})
+	case 2:
+		if target, ok := a.currentTarget(); ok {
+			a.showConnectionEditForm(target)
+		}
+	}
+}
+
+// 以连接标识为键的配置项：端口转发、提权、传输方式、会话策略和密钥后端
+var connectionKeyedSettings = []string{"forwards", "become.connections", "transport.connections", "session_policies.connections", "secrets.connections"}
+
+// 把以连接标识为键的配置从节点 from（模块/项目[/环境[/连接]]）下移到 to 下，to 为空时删除；
+// 只修改已加载的配置，返回修改过的配置项，由调用方随清单一并写回配置文件（配置中的键不区分大小写）
+func moveConnectionSettings(from, to string) []string {
+	prefix := strings.ToLower(from)
+	var changed []string
+	for _, key := range connectionKeyedSettings {
+		values := configStringMap(key)
+		moved := false
+		for id, value := range values {
+			lower := strings.ToLower(id)
+			if lower != prefix && !strings.HasPrefix(lower, prefix+"/") {
+				continue
+			}
+			delete(values, id)
+			if to != "" {
+				values[to+lower[len(prefix):]] = value
+			}
+			moved = true
+		}
+		if moved {
+			setConfig(key, values)
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
+
+// 保存重命名后的清单配置，并把节点下新增的连接、字段覆盖、删除记录、以连接标识为键的配置以及其他连接对它的跳板引用移到新路径
+func (a *App) renameNode(module, oldPath, newPath string, inventory moduleInventory) error {
+	for i, removed := range inventory.Removed {
+		if rest, ok := strings.CutPrefix(removed, oldPath+"/"); ok {
+			inventory.Removed[i] = newPath + "/" + rest
+		}
+	}
+	settings := moveConnectionSettings(module+"/"+oldPath, module+"/"+newPath)
+	if err := saveModuleInventory(module, inventory, settings...); err != nil {
+		return err
+	}
+	oldProject, oldEnv, _ := strings.Cut(oldPath, "/")
+	newProject, newEnv, _ := strings.Cut(newPath, "/")
+	err := updateAddedConnections(func(entries []inventoryEntry) []inventoryEntry {
+		for i := range entries {
+			if entries[i].Module == module && entries[i].Project == oldProject && (oldEnv == "" || entries[i].Env == oldEnv) {
+				entries[i].Project = newProject
+				if oldEnv != "" {
+					entries[i].Env = newEnv
+				}
+			}
+		}
+		return entries
+	})
+	if err != nil {
+		return err
+	}
+	if err := renameOverrides(module+"/"+oldPath+"/", module+"/"+newPath+"/"); err != nil {
+		return err
+	}
+	if err := renameReferences(module+"/"+oldPath, module+"/"+newPath); err != nil {
+		return err
+	}
+	if err := moveCredentials(module+"/"+oldPath, module+"/"+newPath); err != nil {
+		return err
+	}
+	recordAudit(auditEvent{Action: "rename", Target: module + "/" + oldPath, Detail: newPath})
+	return nil
+}
+
+// 删除选中节点（确认后）；配置清单和新增的连接中直接移除，示例节点在配置中记为已删除，以节点下连接标识为键的配置一并删除
+func (a *App) deleteTreeNode() {
+	module, project, env := a.modules[a.currentModule], a.selected.Project, a.selected.Env
+	if project == "" {
+		return
+	}
+	if project == meshProjectName {
+		a.statusBar.SetText(tr("edit.mesh_no_delete"))
+		return
+	}
+	var path, kind string
+	var builtin bool
+	envs := []string{env}
+	switch a.selected.Level {
+	case 0:
+		path, kind = project, tr("edit.kind_project")
+		builtin = isDemoProject(module, project)
+		envs = nil
+		for _, e := range projectEnvironments(module, project) {
+			envs = append(envs, e.Name)
+		}
+	case 1:
+		path, kind = project+"/"+env, tr("edit.kind_env")
+		builtin = slices.Contains(builtinEnvironments(module, project), Environment{Name: env})
+	case 2:
+		target, ok := a.currentTarget()
+		if !ok {
+			return
+		}
+		path, kind = project+"/"+env+"/"+target.Conn.Name, tr("edit.kind_connection")
+		named := func(conn Connection) bool { return conn.Name == target.Conn.Name }
+		builtin = !slices.ContainsFunc(addedConnections(module, project, env), named) &&
+			!slices.ContainsFunc(moduleInventoryFor(module).connections(module, project, env), named)
+	}
+	// 仍被其他连接用作跳板或 SSH 隧道时不能删除，否则这些连接的路径会失效
+	if refs := nodeReferences(module + "/" + path); len(refs) > 0 {
+		var users []string
+		for _, ref := range refs {
+			users = append(users, fmt.Sprintf("%s（%s）", ref.Target.ID(), fieldLabel(ref.Field)))
+		}
+		a.statusBar.SetText(tr("edit.still_referenced", tview.Escape(path), tview.Escape(strings.Join(users, ", "))))
+		return
+	}
+	// 预览删除的节点及其下的全部连接（其中新增的连接也会被删除）
+	preview := changePreview{Title: tr("edit.delete_title", kind), Header: []string{tr("edit.col_delete"), tr("edit.col_kind")}, Rows: [][]string{{module + "/" + path, kind}}, Envs: envs}
+	if a.selected.Level < 2 {
+		for _, environment := range projectEnvironments(module, project) {
+			if a.selected.Level == 1 && environment.Name != env {
+				continue
+			}
+			for _, conn := range envConnections(module, project, environment.Name) {
+				preview.Rows = append(preview.Rows, []string{fmt.Sprintf("%s/%s/%s/%s", module, project, environment.Name, conn.Name), tr("edit.kind_connection")})
+			}
+		}
+	}
+	a.showPreview(preview, func() {
+		inventory := moduleInventoryFor(module)
+		inventory.remove(path, builtin)
+		settings := moveConnectionSettings(module+"/"+path, "")
+		err := saveModuleInventory(module, inventory, settings...)
+		if err == nil {
+			err = updateAddedConnections(func(entries []inventoryEntry) []inventoryEntry {
+				return slices.DeleteFunc(entries, func(entry inventoryEntry) bool {
+					id := strings.TrimPrefix(entry.ID(), module+"/")
+					return entry.Module == module && (id == path || strings.HasPrefix(id, path+"/"))
+				})
+			})
+		}
+		if err == nil {
+			err = moveCredentials(module+"/"+path, "")
+		}
+		if err != nil {
+			a.statusBar.SetText(tr("edit.delete_failed", tview.Escape(err.Error())))
+			return
+		}
+		recordAudit(auditEvent{Action: "delete", Target: module + "/" + path})
+		a.updateMainPanel()
+		a.statusBar.SetText(tr("edit.deleted", kind, tview.Escape(path)))
+	})
+}
+
+// 项目或环境的名称表单；withEnvs 为 true 时同时填写新项目的环境列表（逗号分隔）
+func (a *App) showNodeNameForm(title, name string, withEnvs bool, onSave func(name, envs string) error) {
+	envs := "生产环境,测试环境"
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addInputField(tr("edit.name"), name, 40, func(text string) {
+		name = strings.TrimSpace(text)
+	}, fixedRules(ruleRequired, ruleNodeName))
+	if withEnvs {
+		validator.addInputField(tr("edit.envs"), envs, 40, func(text string) {
+			envs = text
+		}, nil)
+	}
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		if err := onSave(name, envs); err != nil {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		a.popOverlay()
+		a.updateMainPanel()
+		a.statusBar.SetText(tr("edit.saved", tview.Escape(name)))
+	}
+	form.AddButton(tr("common.save"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, centered(validator.root(), 64, 10), submit)
+}
+
+// 项目和环境名称不能包含路径分隔符
+func ruleNodeName(value string) string {
+	if strings.Contains(value, "/") {
+		return tr("edit.name_slash")
+	}
+	return ""
+}
+
+// 编辑连接字段：按模块的字段定义显示，清单中的连接写回配置文件，其他连接保存为字段覆盖（启用审阅模式时暂存）
+func (a *App) showConnectionEditForm(target connTarget) {
+	module := target.Module
+	schema := slices.DeleteFunc(slices.Clone(fieldSchema(module)), func(spec fieldSpec) bool { return spec.Field == nameField })
+	values := schemaValues(target.Conn, schema)
+	initial := maps.Clone(values)
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	grid := centered(validator.root(), 64, 0).(*tview.Grid)
+	var visible []string
+	var build func(focus string)
+	build = func(focus string) {
+		form.Clear(false)
+		validator.reset()
+		visible = validator.addSchemaFields(module, schema, values, func(field string) {
+			a.app.QueueUpdateDraw(func() { build(field) })
+		})
+		grid.SetRows(0, 3*len(visible)+7, 0)
+		if focus != "" {
+			form.SetFocus(form.GetFormItemIndex(fieldLabel(focus)))
+			a.app.SetFocus(form)
+		}
+	}
+	build("")
+
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		// 只记录改动过的字段；隐藏的字段（如切换为密码认证后的密钥文件）清空
+		var changes []fieldChange
+		for _, spec := range schema {
+			if spec.Secret {
+				continue
+			}
+			value := values[spec.Field]
+			if !slices.Contains(visible, spec.Field) {
+				value = ""
+			} else if value == initial[spec.Field] {
+				continue
+			}
+			// 通过设置到副本上校验并规范化新值
+			conn := target.Conn
+			if err := setConnectionField(&conn, spec.Field, value); err != nil {
+				a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+			if old, value := connectionField(target.Conn, spec.Field), connectionField(conn, spec.Field); old != value {
+				changes = append(changes, fieldChange{Target: target, Field: spec.Field, Old: old, New: value})
+			}
+		}
+		a.popOverlay()
+		password := values[passwordField]
+		if !slices.Contains(visible, passwordField) {
+			password = ""
+		}
+		if len(changes) == 0 {
+			if password != "" {
+				a.saveConnectionPassword(target, password, func() {
+					a.statusBar.SetText(tr("edit.password_saved"))
+				})
+				return
+			}
+			a.statusBar.SetText(tr("edit.no_changes"))
+			return
+		}
+		if err := applyFieldChanges(changes, "edit"); err != nil {
+			a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+			return
+		}
+		a.statusBar.SetText(changeSavedMessage(len(changes)))
+		a.updateMainPanel()
+		a.saveConnectionPassword(target, password, nil)
+	}
+	form.AddButton(tr("common.save"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("edit.edit_connection", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, grid, submit)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// 获取本地编辑器命令（$VISUAL > $EDITOR > vi）
+func editorCommand() string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := os.Getenv(name); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
+
+// 计算内容的 sha256
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// 获取远程文件内容的 sha256
+func remoteChecksum(ctx context.Context, target connTarget, remotePath string) (string, error) {
+	output, err := runRemote(ctx, target, "sha256sum "+shellQuote(remotePath))
+	if err != nil {
+		return "", err
+	}
+	sum, _, _ := strings.Cut(strings.TrimSpace(output), " ")
+	return sum, nil
+}
+
+// 在本地编辑器中编辑远程文件：在后台下载到临时文件，保存后检查冲突并写回
+func (a *App) editRemoteFile(target connTarget, remotePath string, useSudo bool) {
+	a.setBrowserStatus(tr("editor.reading", tview.Escape(remotePath)))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+		defer cancel()
+		content, err := runRemote(ctx, target, "cat "+shellQuote(remotePath))
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				a.setBrowserStatus(tr("editor.read_failed", tview.Escape(err.Error())))
+				return
+			}
+			a.editDownloadedFile(target, remotePath, []byte(content), useSudo)
+		})
+	}()
+}
+
+// 在本地编辑器中编辑已下载的远程文件内容，修改后在后台检查冲突并写回
+func (a *App) editDownloadedFile(target connTarget, remotePath string, original []byte, useSudo bool) {
+	// 临时文件保留原扩展名，便于编辑器识别语法
+	local, err := os.CreateTemp("", "connectionmanager-edit-*-"+path.Base(remotePath))
+	if err != nil {
+		a.setBrowserStatus(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	defer os.Remove(local.Name())
+	local.Write(original)
+	local.Close()
+
+	var editErr error
+	a.suspend(func() {
+		fields := strings.Fields(editorCommand())
+		cmd := exec.Command(fields[0], append(fields[1:], local.Name())...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		editErr = cmd.Run()
+	})
+	if editErr != nil {
+		a.setBrowserStatus(tr("editor.crashed", tview.Escape(editErr.Error())))
+		return
+	}
+
+	edited, err := os.ReadFile(local.Name())
+	if err != nil {
+		a.setBrowserStatus(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	if bytes.Equal(edited, original) {
+		a.setBrowserStatus(tr("editor.unchanged"))
+		return
+	}
+
+	// 冲突检查：编辑期间服务器上的文件是否被他人修改（编辑耗时不计入超时）
+	a.setBrowserStatus(tr("editor.saving", tview.Escape(remotePath)))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+		defer cancel()
+		current, err := remoteChecksum(ctx, target, remotePath)
+		if err == nil && current == sha256Hex(original) {
+			a.writeRemoteFile(target, remotePath, edited, useSudo)
+			return
+		}
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				a.setBrowserStatus(tr("editor.check_failed", tview.Escape(err.Error())))
+				return
+			}
+			a.confirm(tr("editor.conflict"), tr("editor.conflict_confirm", tview.Escape(remotePath)), func() {
+				a.setBrowserStatus(tr("editor.saving", tview.Escape(remotePath)))
+				go a.writeRemoteFile(target, remotePath, edited, useSudo)
+			})
+		})
+	}()
+}
+
+// 将内容写回远程文件（在后台 goroutine 中调用，使用新的超时），useSudo 时按提权配置通过 tee 写入
+func (a *App) writeRemoteFile(target connTarget, remotePath string, data []byte, useSudo bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), browserTimeout)
+	defer cancel()
+
+	var err error
+	if useSudo {
+		// 按提权配置写入，未配置时使用免密 sudo 切换到 root
+		_, err = runRemoteBecome(ctx, target, resolveBecome(target), "tee "+shellQuote(remotePath)+" > /dev/null", bytes.NewReader(data))
+	} else {
+		_, err = runRemoteInput(ctx, target, "cat > "+shellQuote(remotePath), bytes.NewReader(data))
+	}
+	if err == nil {
+		recordAudit(auditEvent{Action: "edit", Target: target.ID(), Detail: remotePath, BytesSent: int64(len(data))})
+	}
+	a.app.QueueUpdateDraw(func() {
+		if err != nil {
+			a.setBrowserStatus(tr("editor.write_failed", tview.Escape(err.Error())))
+			return
+		}
+		a.setBrowserStatus(tr("editor.saved", tview.Escape(remotePath), formatBytes(int64(len(data)))))
+		if a.browser != nil {
+			a.loadBrowserDir(a.browser.dir)
+		}
+	})
+}
+
+// 在文件浏览器状态行显示消息（浏览器未打开时显示在主状态栏）
+func (a *App) setBrowserStatus(message string) {
+	if a.browser != nil {
+		a.browser.status.SetText(message)
+		return
+	}
+	a.statusBar.SetText(message)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 最近一次自动选择的端点
+type endpointChoice struct {
+	Address    string        // 选中的地址
+	Latency    time.Duration // 探测延迟
+	Candidates int           // 候选地址数量
+	Healthy    int           // 可达的候选数量
+	Time       time.Time     // 选择时间
+}
+
+// 按连接标识记录的端点选择结果
+var (
+	endpointMu      sync.Mutex
+	endpointChoices = make(map[string]endpointChoice)
+)
+
+// 将逗号分隔的地址拆分为去重的列表（保持原有顺序）
+func splitAddresses(value string) []string {
+	seen := make(map[string]bool)
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" && !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// 连接的全部候选端点：主地址在前，备用地址未指定端口时沿用主端口
+func endpointCandidates(conn Connection) []Connection {
+	candidates := []Connection{conn}
+	for _, address := range conn.Addresses {
+		candidate := conn
+		if host, port, err := net.SplitHostPort(address); err == nil {
+			candidate.Host = host
+			candidate.Port, _ = strconv.Atoi(port)
+		} else {
+			candidate.Host = address
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// 是否可以在本机探测候选端点（隧道、Teleport、跳板和自定义代理命令由远端解析地址）
+func endpointSelectable(target connTarget) bool {
+	return len(target.Conn.Addresses) > 0 &&
+		target.Conn.ProxyCommand == "" &&
+		target.Conn.SSHTunnel == "" &&
+		target.Conn.JumpHost == "" &&
+		resolveTransport(target).kind() == transportSSH
+}
+
+// 并发探测候选端点，选择延迟最低的可达端点；全部不可达时保留主地址
+func selectEndpoint(target connTarget) connTarget {
+	if !endpointSelectable(target) {
+		return target
+	}
+	candidates := endpointCandidates(target.Conn)
+	results := make([]healthResult, len(candidates))
+	var wg sync.WaitGroup
+	for i, candidate := range candidates {
+		wg.Add(1)
+		go func(i int, candidate Connection) {
+			defer wg.Done()
+			results[i] = checkTCP(candidate, defaultHealthTimeout)
+		}(i, candidate)
+	}
+	wg.Wait()
+
+	best := -1
+	healthy := 0
+	for i, result := range results {
+		if !result.OK {
+			continue
+		}
+		healthy++
+		if best < 0 || result.Latency < results[best].Latency {
+			best = i
+		}
+	}
+	choice := endpointChoice{Candidates: len(candidates), Healthy: healthy, Time: time.Now()}
+	if best >= 0 {
+		target.Conn.Host, target.Conn.Port = candidates[best].Host, candidates[best].Port
+		choice.Latency = results[best].Latency
+	}
+	choice.Address = net.JoinHostPort(target.Conn.Host, strconv.Itoa(target.Conn.Port))
+
+	endpointMu.Lock()
+	endpointChoices[target.ID()] = choice
+	endpointMu.Unlock()
+	return target
+}
+
+// 获取目标最近一次的端点选择
+func lastEndpointChoice(target connTarget) (endpointChoice, bool) {
+	endpointMu.Lock()
+	defer endpointMu.Unlock()
+	choice, ok := endpointChoices[target.ID()]
+	return choice, ok
+}
+
+// 端点选择的说明文字
+func (c endpointChoice) String() string {
+	if c.Healthy == 0 {
+		return tr("endpoint.all_down", c.Address, c.Candidates)
+	}
+	return tr("endpoint.chosen", c.Address, formatDuration(c.Latency), c.Healthy, c.Candidates)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 环境位置：模块内的项目与环境名称
+type envRef struct {
+	Project string
+	Env     string
+	Label   string // 项目/环境
+}
+
+// 获取模块内全部环境
+func moduleEnvironments(module string) []envRef {
+	var refs []envRef
+	for _, project := range projectList(module) {
+		for _, env := range projectEnvironments(module, project.Name) {
+			refs = append(refs, envRef{Project: project.Name, Env: env.Name, Label: project.Name + "/" + env.Name})
+		}
+	}
+	return refs
+}
+
+// 对比两个环境的连接，返回着色的文本报告
+func diffEnvironments(module string, left, right envRef) string {
+	leftConns := make(map[string]Connection)
+	for _, conn := range envConnections(module, left.Project, left.Env) {
+		leftConns[conn.Name] = conn
+	}
+	rightConns := make(map[string]Connection)
+	for _, conn := range envConnections(module, right.Project, right.Env) {
+		rightConns[conn.Name] = conn
+	}
+
+	var names []string
+	for name := range leftConns {
+		names = append(names, name)
+	}
+	for name := range rightConns {
+		if _, ok := leftConns[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	content := fmt.Sprintf("[white::b]A: %s\nB: %s[-::-]\n\n", tview.Escape(left.Label), tview.Escape(right.Label))
+	same := 0
+	for _, name := range names {
+		a, inLeft := leftConns[name]
+		b, inRight := rightConns[name]
+		switch {
+		case !inRight:
+			content += tr("envdiff.only_a", tview.Escape(name))
+		case !inLeft:
+			content += tr("envdiff.only_b", tview.Escape(name))
+		default:
+			var diffs []string
+			for _, field := range connectionFields {
+				if va, vb := connectionField(a, field), connectionField(b, field); va != vb {
+					diffs = append(diffs, fmt.Sprintf("    %s: [red]%s[-] → [green]%s[-]", fieldLabel(field), tview.Escape(va), tview.Escape(vb)))
+				}
+			}
+			if len(diffs) == 0 {
+				same++
+				continue
+			}
+			content += fmt.Sprintf("[yellow]~ %s[-]\n", tview.Escape(name))
+			for _, diff := range diffs {
+				content += diff + "\n"
+			}
+		}
+	}
+	content += tr("envdiff.summary", len(names), same)
+	return content
+}
+
+// 打开环境对比表单，默认以当前选中的环境作为 A
+func (a *App) showEnvironmentDiff() {
+	module := a.modules[a.currentModule]
+	refs := moduleEnvironments(module)
+	if len(refs) < 2 {
+		a.statusBar.SetText(tr("envdiff.too_few"))
+		return
+	}
+	labels := make([]string, len(refs))
+	left, right := 0, 1
+	for i, ref := range refs {
+		labels[i] = ref.Label
+		if ref.Project == a.selected.Project && ref.Env == a.selected.Env {
+			left, right = i, (i+1)%len(refs)
+		}
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown(tr("envdiff.env_a"), labels, left, func(option string, index int) {
+		left = index
+	}).
+		AddDropDown(tr("envdiff.env_b"), labels, right, func(option string, index int) {
+			right = index
+		}).
+		AddButton(tr("envdiff.compare"), func() {
+			a.popOverlay()
+			a.showEnvironmentDiffResult(module, refs[left], refs[right])
+		}).
+		AddButton(tr("common.cancel"), func() {
+			a.popOverlay()
+		})
+	form.SetBorder(true).
+		SetTitle(tr("envdiff.title", module)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(form, 60, 9), form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
+
+// 显示环境对比结果
+func (a *App) showEnvironmentDiffResult(module string, left, right envRef) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(diffEnvironments(module, left, right))
+	view.SetBorder(true).
+		SetTitle(tr("envdiff.result_title", module)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
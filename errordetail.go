@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 获取主机密钥指纹的超时时间
+const hostKeyScanTimeout = 10 * time.Second
+
+// 连接失败的详细信息
+type failureDetail struct {
+	Target  connTarget // 失败的连接
+	Summary string     // 简短的错误说明
+	Command []string   // 实际执行的命令（已隐去密码）
+	Output  string     // 客户端输出的末尾部分
+	Time    time.Time  // 失败时间
+}
+
+// 本次运行中各连接最近一次失败，以及全局最近一次失败
+var (
+	failuresMu  sync.Mutex
+	failures    = make(map[string]failureDetail)
+	lastFailure *failureDetail
+)
+
+// 常见错误的处理建议
+type errorHint struct {
+	Patterns []string // 错误输出中出现任一内容时适用（不区分大小写）
+	Text     string   // 建议的消息标识
+	HostKey  bool     // 是否可以按 F 查看主机密钥指纹
+}
+
+// 常见错误及建议，按匹配顺序显示
+var errorHints = []errorHint{
+	{Patterns: []string{"REMOTE HOST IDENTIFICATION HAS CHANGED", "Host key verification failed"}, Text: "hint.host_key", HostKey: true},
+	{Patterns: []string{"Permission denied", "Too many authentication failures"}, Text: "hint.auth"},
+	{Patterns: []string{"UNPROTECTED PRIVATE KEY FILE"}, Text: "hint.key_perms"},
+	{Patterns: []string{"no such identity", "not accessible"}, Text: "hint.key_missing"},
+	{Patterns: []string{"Could not resolve hostname", "no such host", "Name or service not known"}, Text: "hint.resolve"},
+	{Patterns: []string{"Connection refused", "拒绝连接"}, Text: "hint.refused"},
+	{Patterns: []string{"timed out", "超时", "No route to host", "Network is unreachable"}, Text: "hint.unreachable"},
+	{Patterns: []string{"Access denied for user", "password authentication failed", "WRONGPASS", "NOAUTH"}, Text: "hint.db_auth"},
+	{Patterns: []string{"executable file not found", "command not found"}, Text: "hint.client_missing"},
+	{Patterns: []string{"SSL connection error", "SSL error", "certificate verify failed", "TLS handshake"}, Text: "hint.tls"},
+}
+
+// 需要隐去值的命令行选项
+var secretFlags = []string{"--password", "--pass", "--auth", "--token"}
+
+// 形如 key=value 且键名表示密钥的参数片段
+var secretAssignment = regexp.MustCompile(`(?i)\b([\w.-]*(?:password|passwd|secret|token)[\w.-]*)=(\S+)`)
+
+// URL 中的用户名和密码
+var urlCredentials = regexp.MustCompile(`://([^:/@\s]+):([^@\s]+)@`)
+
+// 隐去命令参数中的密码：密码类选项的值、key=value 形式的密钥、URL 中的密码、mysql 的 -p<密码> 和 redis-cli 的 -a <密码>
+func redactArgs(args []string) []string {
+	var client string
+	if len(args) > 0 {
+		client = filepath.Base(args[0])
+	}
+	mysql := strings.Contains(client, "mysql")
+	redis := strings.Contains(client, "redis")
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case i > 0 && (slices.Contains(secretFlags, args[i-1]) || redis && args[i-1] == "-a"):
+			arg = "***"
+		case mysql && strings.HasPrefix(arg, "-p") && len(arg) > 2:
+			arg = "-p***"
+		default:
+			arg = secretAssignment.ReplaceAllString(arg, "$1=***")
+			arg = urlCredentials.ReplaceAllString(arg, "://$1:***@")
+		}
+		redacted[i] = arg
+	}
+	return redacted
+}
+
+// 记录连接失败（命令中的密码会被隐去）
+func recordFailure(detail failureDetail) {
+	detail.Command = redactArgs(detail.Command)
+	detail.Output = secretAssignment.ReplaceAllString(detail.Output, "$1=***")
+	detail.Time = time.Now()
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+	failures[detail.Target.ID()] = detail
+	lastFailure = &detail
+}
+
+// 适用于该失败的建议
+func (d failureDetail) hints() []errorHint {
+	text := strings.ToLower(d.Summary + "\n" + d.Output)
+	var hints []errorHint
+	for _, hint := range errorHints {
+		for _, pattern := range hint.Patterns {
+			if strings.Contains(text, strings.ToLower(pattern)) {
+				hints = append(hints, hint)
+				break
+			}
+		}
+	}
+	return hints
+}
+
+// 是否有查看主机密钥指纹的建议
+func (d failureDetail) hostKeyHint() bool {
+	for _, hint := range d.hints() {
+		if hint.HostKey {
+			return true
+		}
+	}
+	return false
+}
+
+// 纯文本形式的错误详情，用于复制
+func (d failureDetail) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, tr("failure.text_header"), d.Target.ID(), d.Time.Format(time.DateTime), d.Summary)
+	if len(d.Command) > 0 {
+		fmt.Fprintf(&b, tr("failure.text_command"), strings.Join(d.Command, " "))
+	}
+	for _, hint := range d.hints() {
+		fmt.Fprintf(&b, tr("failure.text_hint"), tr(hint.Text))
+	}
+	if output := strings.TrimSpace(d.Output); output != "" {
+		fmt.Fprintf(&b, tr("failure.text_output"), output)
+	}
+	return b.String()
+}
+
+// 显示错误详情：完整错误、实际执行的命令、处理建议和客户端输出
+func (a *App) showFailureDetail(detail failureDetail) {
+	content := tr("failure.header",
+		tview.Escape(detail.Target.ID()), formatTime(detail.Time), tview.Escape(detail.Summary))
+	if len(detail.Command) > 0 {
+		content += tr("failure.command", tview.Escape(strings.Join(detail.Command, " ")))
+	}
+	if hints := detail.hints(); len(hints) > 0 {
+		content += tr("failure.hints")
+		for _, hint := range hints {
+			content += fmt.Sprintf("  • %s\n", tview.Escape(tr(hint.Text)))
+		}
+	}
+	if output := strings.TrimSpace(detail.Output); output != "" {
+		content += tr("failure.output") + tview.Escape(output) + "\n"
+	}
+
+	keys := tr("failure.keys")
+	if detail.hostKeyHint() {
+		keys = tr("failure.keys_host_key")
+	}
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(content)
+	view.SetBorder(true).
+		SetTitle(tr("failure.title", tview.Escape(detail.Target.Conn.Name), keys)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorRed)
+
+	a.pushOverlay(centered(view, 100, 30), view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+		switch event.Rune() {
+		case 'y', 'Y':
+			if err := copyToClipboard(detail.text()); err != nil {
+				a.statusBar.SetText(tr("common.copy_failed", tview.Escape(err.Error())))
+			} else {
+				a.statusBar.SetText(tr("failure.copied"))
+			}
+			return nil
+		case 'f', 'F':
+			if detail.hostKeyHint() {
+				a.showHostKeyFingerprints(detail.Target)
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// 显示当前连接最近一次失败的详情，当前节点不是失败过的连接时显示全局最近一次失败
+func (a *App) showLastFailure() {
+	failuresMu.Lock()
+	var detail *failureDetail
+	if target, ok := a.currentTarget(); ok {
+		if d, found := failures[target.ID()]; found {
+			detail = &d
+		}
+	}
+	if detail == nil {
+		detail = lastFailure
+	}
+	failuresMu.Unlock()
+	if detail == nil {
+		a.statusBar.SetText(tr("failure.none"))
+		return
+	}
+	a.showFailureDetail(*detail)
+}
+
+// 对比 known_hosts 中记录的指纹与服务器当前提供的指纹
+func (a *App) showHostKeyFingerprints(target connTarget) {
+	a.statusBar.SetText(tr("failure.fetching_host_key", tview.Escape(target.Conn.Host)))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hostKeyScanTimeout)
+		defer cancel()
+		host := target.Conn.Host
+		port := target.Conn.Port
+		if port == 0 {
+			port = 22
+		}
+		known := host
+		if port != 22 {
+			known = fmt.Sprintf("[%s]:%d", host, port)
+		}
+		recorded, _ := exec.CommandContext(ctx, "ssh-keygen", "-l", "-F", known).Output()
+		scanned, scanErr := exec.CommandContext(ctx, "ssh-keyscan", "-p", strconv.Itoa(port), host).Output()
+		var current []byte
+		if scanErr == nil {
+			cmd := exec.CommandContext(ctx, "ssh-keygen", "-l", "-f", "-")
+			cmd.Stdin = strings.NewReader(string(scanned))
+			current, scanErr = cmd.Output()
+		}
+
+		content := tr("failure.known_hosts")
+		if lines := strings.TrimSpace(string(recorded)); lines != "" {
+			content += tview.Escape(lines) + "\n"
+		} else {
+			content += tr("failure.known_hosts_none")
+		}
+		content += tr("failure.server_keys")
+		if scanErr != nil {
+			content += tr("failure.scan_failed", tview.Escape(scanErr.Error()))
+		} else {
+			content += tview.Escape(strings.TrimSpace(string(current))) + "\n"
+		}
+		content += tr("failure.remove_hint", tview.Escape(known))
+
+		a.app.QueueUpdateDraw(func() {
+			a.statusBar.SetText("")
+			view := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(true).
+				SetText(content)
+			view.SetBorder(true).
+				SetTitle(tr("failure.host_key_title", tview.Escape(known))).
+				SetTitleAlign(tview.AlignLeft).
+				SetBorderColor(tcell.ColorYellow)
+			a.pushOverlay(centered(view, 100, 20), view, func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEsc {
+					a.popOverlay()
+					return nil
+				}
+				return event
+			})
+		})
+	}()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 单条远程命令的执行超时时间
+const execTimeout = 2 * time.Minute
+
+// 打开单行输入框，在当前SSH主机上执行一条命令
+func (a *App) showExecPrompt() {
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		a.statusBar.SetText(tr("exec.ssh_only"))
+		return
+	}
+	a.prompt(tr("exec.title", target.Conn.Name), "$ ", a.lastExecCommand, func(command string) {
+		a.lastExecCommand = command
+		a.rememberAction(tr("repeat.exec", command), func(a *App) { a.repeatExec(command) })
+		a.execCommand(target, command)
+	})
+}
+
+// 在当前选中的 SSH 主机上直接执行上一次的命令（用于 . 重复）
+func (a *App) repeatExec(command string) {
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		a.statusBar.SetText(tr("exec.ssh_only"))
+		return
+	}
+	a.execCommand(target, command)
+}
+
+// 执行命令，受保护环境先确认
+func (a *App) execCommand(target connTarget, command string) {
+	if isProtectedEnv(target.Env) || confirmPhrase(target.Env) != "" {
+		a.confirmEnvs(tr("exec.confirm_title"), tr("exec.confirm", tview.Escape(target.ID()), tview.Escape(command)), []string{target.Env}, func() {
+			a.showExecResult(target, command)
+		})
+		return
+	}
+	a.showExecResult(target, command)
+}
+
+// 执行命令并在可滚动的结果面板中显示输出
+func (a *App) showExecResult(target connTarget, command string) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(tr("common.running"))
+	view.SetBorder(true).
+		SetTitle(tr("exec.output_title", target.Conn.Name, command)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	search := newTextSearch(view)
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			cancel()
+			a.popOverlay()
+			return nil
+		}
+		if search.handleKey(a, event) {
+			return nil
+		}
+		return event
+	})
+
+	go func() {
+		defer cancel()
+		start := time.Now()
+		output, err := runExecAction(ctx, target, command)
+		duration := time.Since(start)
+
+		event := auditEvent{Action: "exec", Target: target.ID(), Detail: command, Duration: duration}
+		if err != nil {
+			event.Detail += ": " + err.Error()
+		}
+		recordAudit(event)
+
+		text := tview.Escape(strings.TrimRight(output, "\n"))
+		if err != nil {
+			text += fmt.Sprintf("\n\n[red]%s[-]", tview.Escape(err.Error()))
+		} else {
+			text += tr("exec.done", formatDuration(duration))
+		}
+		a.app.QueueUpdateDraw(func() {
+			view.SetText(text)
+			view.ScrollToBeginning()
+		})
+	}()
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 执行计划节点
+type planNode struct {
+	Label    string      // 节点名称（如 Seq Scan on users）
+	Details  []string    // 附加信息（访问方式、过滤条件等）
+	Cost     float64     // 节点总成本
+	Rows     float64     // 预估（或实际）行数
+	Warn     bool        // 是否为需要关注的节点（如全表扫描）
+	Children []*planNode // 子节点
+}
+
+// 在后台执行 EXPLAIN 并显示执行计划树
+func (a *App) explainConsoleQuery(query string, analyze bool) {
+	console := a.console
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	if query == "" || console.running {
+		return
+	}
+	if analyze && hasLeadingKeyword(query, writeKeywords) {
+		console.status.SetText(tr("explain.write_refused"))
+		return
+	}
+
+	var statement string
+	switch {
+	case moduleType(console.target.Module) == "PostgreSQL" && analyze:
+		statement = "EXPLAIN (ANALYZE, FORMAT JSON) " + query
+	case moduleType(console.target.Module) == "PostgreSQL":
+		statement = "EXPLAIN (FORMAT JSON) " + query
+	case analyze:
+		statement = "EXPLAIN ANALYZE " + query // MySQL 的 ANALYZE 仅支持 TREE 格式
+	default:
+		statement = "EXPLAIN FORMAT=JSON " + query
+	}
+
+	console.running = true
+	console.status.SetText(tr("explain.loading"))
+	go func() {
+		start := time.Now()
+		output, err := runQuery(console.target, statement)
+		elapsed := time.Since(start)
+		a.app.QueueUpdateDraw(func() {
+			console.running = false
+			if err != nil {
+				console.status.SetText(tr("explain.fetch_failed", tview.Escape(firstLine(output, err))))
+				return
+			}
+			content, err := renderExplainOutput(console.target.Module, output, analyze)
+			if err != nil {
+				console.status.SetText(tr("explain.parse_failed", tview.Escape(err.Error())))
+				return
+			}
+			console.status.SetText(tr("explain.done", formatDuration(elapsed)))
+			a.showPlan(content, analyze)
+		})
+	}()
+}
+
+// 去除客户端输出中的表头行，并还原 mysql --batch 转义的换行
+func explainBody(module, output string) string {
+	_, body, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if moduleType(module) == "MySQL" {
+		body = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\\`, `\`).Replace(body)
+	}
+	return body
+}
+
+// 将 EXPLAIN 输出渲染为带颜色的缩进树文本
+func renderExplainOutput(module, output string, analyze bool) (string, error) {
+	body := explainBody(module, output)
+	if moduleType(module) == "MySQL" && analyze {
+		return renderTreeText(body), nil
+	}
+
+	var root *planNode
+	var err error
+	if moduleType(module) == "PostgreSQL" {
+		root, err = parsePostgresPlan(body)
+	} else {
+		root, err = parseMySQLPlan(body)
+	}
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	renderPlanNode(&builder, root, "", true, true, root.Cost)
+	return builder.String(), nil
+}
+
+// 解析 PostgreSQL 的 JSON 格式执行计划
+func parsePostgresPlan(body string) (*planNode, error) {
+	var plans []struct {
+		Plan map[string]any `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(body), &plans); err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, errors.New(tr("explain.empty"))
+	}
+	return convertPostgresNode(plans[0].Plan), nil
+}
+
+// 转换单个 PostgreSQL 计划节点
+func convertPostgresNode(raw map[string]any) *planNode {
+	node := &planNode{Label: fmt.Sprint(raw["Node Type"])}
+	if relation, ok := raw["Relation Name"]; ok {
+		node.Label += fmt.Sprintf(" on %v", relation)
+	}
+	if index, ok := raw["Index Name"]; ok {
+		node.Label += fmt.Sprintf(" using %v", index)
+	}
+	node.Cost, _ = raw["Total Cost"].(float64)
+	node.Rows, _ = raw["Plan Rows"].(float64)
+	if actual, ok := raw["Actual Rows"].(float64); ok {
+		node.Rows = actual
+		node.Details = append(node.Details, tr("explain.actual", raw["Actual Total Time"], raw["Actual Loops"]))
+	}
+	for _, key := range []string{"Filter", "Index Cond", "Hash Cond", "Join Filter", "Sort Key"} {
+		if value, ok := raw[key]; ok {
+			node.Details = append(node.Details, fmt.Sprintf("%s: %v", key, value))
+		}
+	}
+	node.Warn = node.Label == "Seq Scan" || strings.HasPrefix(node.Label, "Seq Scan ")
+	if children, ok := raw["Plans"].([]any); ok {
+		for _, child := range children {
+			if childMap, ok := child.(map[string]any); ok {
+				node.Children = append(node.Children, convertPostgresNode(childMap))
+			}
+		}
+	}
+	return node
+}
+
+// 解析 MySQL 的 JSON 格式执行计划
+func parseMySQLPlan(body string) (*planNode, error) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		return nil, err
+	}
+	block, ok := raw["query_block"].(map[string]any)
+	if !ok {
+		return nil, errors.New(tr("explain.no_query_block"))
+	}
+	return convertMySQLNode("query_block", block), nil
+}
+
+// 转换 MySQL 计划中的对象节点，table 节点展示访问方式与行数
+func convertMySQLNode(name string, raw map[string]any) *planNode {
+	node := &planNode{Label: name}
+	if costInfo, ok := raw["cost_info"].(map[string]any); ok {
+		for _, key := range []string{"query_cost", "prefix_cost", "sort_cost"} {
+			if value, ok := costInfo[key].(string); ok {
+				fmt.Sscan(value, &node.Cost)
+				break
+			}
+		}
+	}
+	if table, ok := raw["table_name"]; ok {
+		accessType := fmt.Sprint(raw["access_type"])
+		node.Label = fmt.Sprintf("table %v (%s)", table, accessType)
+		node.Warn = accessType == "ALL"
+		if rows, ok := raw["rows_examined_per_scan"].(float64); ok {
+			node.Rows = rows
+		}
+		for _, key := range []string{"key", "attached_condition"} {
+			if value, ok := raw[key]; ok {
+				node.Details = append(node.Details, fmt.Sprintf("%s: %v", key, value))
+			}
+		}
+	}
+
+	// 按键名排序以保证输出稳定
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := raw[key].(type) {
+		case map[string]any:
+			if key != "cost_info" {
+				node.Children = append(node.Children, convertMySQLNode(key, value))
+			}
+		case []any:
+			for _, item := range value {
+				if itemMap, ok := item.(map[string]any); ok {
+					if table, ok := itemMap["table"].(map[string]any); ok && len(itemMap) == 1 {
+						itemMap = table
+					}
+					node.Children = append(node.Children, convertMySQLNode(key, itemMap))
+				}
+			}
+		}
+	}
+	return node
+}
+
+// 递归渲染计划节点，成本占比超过一半的节点标红，全表扫描标黄
+func renderPlanNode(builder *strings.Builder, node *planNode, prefix string, isRoot, isLast bool, rootCost float64) {
+	branch := ""
+	childPrefix := prefix
+	if !isRoot {
+		if isLast {
+			branch = "└─ "
+			childPrefix += "   "
+		} else {
+			branch = "├─ "
+			childPrefix += "│  "
+		}
+	}
+
+	color := "white"
+	switch {
+	case rootCost > 0 && node.Cost >= rootCost/2 && !isRoot:
+		color = "red"
+	case node.Warn:
+		color = "yellow"
+	}
+	builder.WriteString(fmt.Sprintf("%s%s[%s]%s[-]", prefix, branch, color, tview.Escape(node.Label)))
+	if node.Cost > 0 || node.Rows > 0 {
+		builder.WriteString(fmt.Sprintf(" [gray](cost=%.2f rows=%.0f)[-]", node.Cost, node.Rows))
+	}
+	if node.Warn {
+		builder.WriteString(tr("explain.full_scan"))
+	}
+	builder.WriteString("\n")
+	for _, detail := range node.Details {
+		builder.WriteString(fmt.Sprintf("%s    [gray]%s[-]\n", childPrefix, tview.Escape(detail)))
+	}
+	for i, child := range node.Children {
+		renderPlanNode(builder, child, childPrefix, false, i == len(node.Children)-1, rootCost)
+	}
+}
+
+// 渲染 MySQL EXPLAIN ANALYZE 的 TREE 格式文本，高亮成本与实际耗时
+func renderTreeText(body string) string {
+	var builder strings.Builder
+	for _, line := range strings.Split(body, "\n") {
+		line = tview.Escape(line)
+		if index := strings.Index(line, "(cost="); index >= 0 {
+			line = line[:index] + "[gray]" + line[index:]
+		}
+		line = strings.Replace(line, "(actual time=", "[yellow](actual time=", 1)
+		builder.WriteString(line + "[-]\n")
+	}
+	return builder.String()
+}
+
+// 显示执行计划
+func (a *App) showPlan(content string, analyze bool) {
+	title := tr("explain.title")
+	if analyze {
+		title = tr("explain.title_analyze")
+	}
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true).
+		SetText(content)
+	view.SetBorder(true).
+		SetTitle(title + tr("common.esc_back_suffix")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q')) {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 结果集导出格式
+var exportFormats = []string{"CSV", "JSON Lines", "Markdown"}
+
+// 将结果集（第一行为表头）格式化为指定格式的文本
+func formatRows(rows [][]string, format string) (string, error) {
+	if len(rows) == 0 {
+		return "", nil
+	}
+	header, data := rows[0], rows[1:]
+
+	var buf bytes.Buffer
+	switch format {
+	case "CSV":
+		writer := csv.NewWriter(&buf)
+		if err := writer.WriteAll(rows); err != nil {
+			return "", err
+		}
+	case "JSON Lines":
+		encoder := json.NewEncoder(&buf)
+		for _, row := range data {
+			record := make(map[string]string, len(header))
+			for i, column := range header {
+				if i < len(row) {
+					record[column] = row[i]
+				}
+			}
+			if err := encoder.Encode(record); err != nil {
+				return "", err
+			}
+		}
+	case "Markdown":
+		escape := func(value string) string {
+			return strings.ReplaceAll(value, "|", "\\|")
+		}
+		writeRow := func(row []string) {
+			cells := make([]string, len(header))
+			for i := range header {
+				if i < len(row) {
+					cells[i] = escape(row[i])
+				}
+			}
+			buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		}
+		writeRow(header)
+		buf.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+		for _, row := range data {
+			writeRow(row)
+		}
+	default:
+		return "", errors.New(tr("export.unsupported", format))
+	}
+	return buf.String(), nil
+}
+
+// 显示结果集导出表单，可保存到文件或复制到剪贴板
+func (a *App) showExportForm(rows [][]string, onDone func(message string)) {
+	if len(rows) < 2 {
+		onDone(tr("export.nothing"))
+		return
+	}
+
+	format := exportFormats[0]
+	toClipboard := false
+	path := "result.csv"
+
+	form := tview.NewForm()
+	form.AddDropDown(tr("export.format"), exportFormats, 0, func(option string, index int) {
+		format = option
+	}).
+		AddDropDown(tr("common.col_target"), []string{tr("export.file"), tr("export.clipboard")}, 0, func(option string, index int) {
+			toClipboard = index == 1
+		}).
+		AddInputField(tr("export.path"), path, 40, nil, func(text string) {
+			path = text
+		}).
+		AddButton(tr("export.button"), func() {
+			text, err := formatRows(rows, format)
+			if err == nil {
+				if toClipboard {
+					err = copyToClipboard(text)
+				} else {
+					err = os.WriteFile(path, []byte(text), 0o644)
+				}
+			}
+			a.popOverlay()
+			switch {
+			case err != nil:
+				onDone(tr("export.failed", tview.Escape(err.Error())))
+			case toClipboard:
+				onDone(tr("export.copied", len(rows)-1, format))
+			default:
+				onDone(tr("export.written", len(rows)-1, tview.Escape(path)))
+			}
+		}).
+		AddButton(tr("common.cancel"), func() {
+			a.popOverlay()
+		})
+	form.SetBorder(true).
+		SetTitle(tr("export.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(form, 60, 11), form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
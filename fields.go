@@ -0,0 +1,280 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 连接字段覆盖文件名（位于数据目录中）
+const overridesFile = "overrides.json"
+
+// 可对比和编辑的连接字段（名称与状态不在其中）；字段标识与配置文件中的键一致，保存在字段覆盖和暂存修改中，界面上显示 fieldLabel
+var connectionFields = []string{"host", "port", "user", "database", "auth", "identity_file", "certificate", "proxy_command", "jump_host", "addresses", "tls", "tls_ca", "tls_cert", "tls_key", "ssh_tunnel", "tags"}
+
+// 连接名称字段，只出现在连接表单中
+const nameField = "name"
+
+// 旧版本以中文字段名保存的字段覆盖和暂存修改，读取时换成字段标识
+var legacyFieldIDs = map[string]string{
+	"名称": nameField, "主机": "host", "端口": "port", "用户": "user", "数据库": "database", "认证方式": "auth",
+	"密钥文件": "identity_file", "证书文件": "certificate", "代理命令": "proxy_command", "跳板": "jump_host",
+	"备用地址": "addresses", "TLS": "tls", "CA证书": "tls_ca", "客户端证书": "tls_cert", "客户端密钥": "tls_key",
+	"SSH隧道": "ssh_tunnel", "标签": "tags",
+}
+
+// 字段在界面上显示的名称
+func fieldLabel(field string) string {
+	return tr("field." + field)
+}
+
+// 一组字段在界面上显示的名称（下拉框选项）
+func fieldLabels(fields []string) []string {
+	labels := make([]string, len(fields))
+	for i, field := range fields {
+		labels[i] = fieldLabel(field)
+	}
+	return labels
+}
+
+// 字段名换成字段标识，兼容旧版本保存的中文字段名
+func fieldID(name string) string {
+	if id, ok := legacyFieldIDs[name]; ok {
+		return id
+	}
+	return name
+}
+
+// SSH 认证方式
+var authMethods = []string{"key", "password", "agent", "certificate"}
+
+// TLS 开关的取值
+var tlsSwitch = []string{"off", "on"}
+
+// 获取连接字段的值，标签以逗号分隔
+func connectionField(conn Connection, field string) string {
+	switch field {
+	case "host":
+		return conn.Host
+	case "port":
+		return strconv.Itoa(conn.Port)
+	case "user":
+		return conn.User
+	case "database":
+		return conn.Database
+	case "identity_file":
+		return conn.IdentityFile
+	case "proxy_command":
+		return conn.ProxyCommand
+	case "jump_host":
+		return conn.JumpHost
+	case "addresses":
+		return strings.Join(conn.Addresses, ",")
+	case "tags":
+		return strings.Join(conn.Tags, ",")
+	case "auth":
+		return conn.Auth
+	case "certificate":
+		return conn.Certificate
+	case "tls":
+		if conn.TLS {
+			return "on"
+		}
+		return "off"
+	case "tls_ca":
+		return conn.TLSCA
+	case "tls_cert":
+		return conn.TLSCert
+	case "tls_key":
+		return conn.TLSKey
+	case "ssh_tunnel":
+		return conn.SSHTunnel
+	}
+	return ""
+}
+
+// 设置连接字段的值
+func setConnectionField(conn *Connection, field, value string) error {
+	switch field {
+	case "host":
+		conn.Host = value
+	case "port":
+		port, err := strconv.Atoi(value)
+		if err != nil || port <= 0 || port > 65535 {
+			return errors.New(tr("field.invalid_port", value))
+		}
+		conn.Port = port
+	case "user":
+		conn.User = value
+	case "database":
+		conn.Database = value
+	case "identity_file":
+		conn.IdentityFile = value
+	case "proxy_command":
+		if _, err := parseProxyTemplate(value, nil); err != nil {
+			return fmt.Errorf(tr("field.invalid_proxy"), err)
+		}
+		conn.ProxyCommand = value
+	case "jump_host":
+		conn.JumpHost = value
+	case "addresses":
+		conn.Addresses = splitAddresses(value)
+	case "tags":
+		conn.Tags = splitTags(value)
+	case "auth":
+		if value != "" && !slices.Contains(authMethods, value) {
+			return errors.New(tr("field.invalid_auth", value, strings.Join(authMethods, ", ")))
+		}
+		conn.Auth = value
+	case "certificate":
+		conn.Certificate = value
+	case "tls":
+		switch strings.ToLower(value) {
+		case "on", "true", "yes", "1":
+			conn.TLS = true
+		case "off", "false", "no", "0", "":
+			conn.TLS = false
+		default:
+			return errors.New(tr("field.invalid_tls", value))
+		}
+	case "tls_ca":
+		conn.TLSCA = value
+	case "tls_cert":
+		conn.TLSCert = value
+	case "tls_key":
+		conn.TLSKey = value
+	case "ssh_tunnel":
+		conn.SSHTunnel = value
+	default:
+		return errors.New(tr("field.unknown", field))
+	}
+	return nil
+}
+
+// 将逗号分隔的标签拆分为去重且排序的列表
+func splitTags(value string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// 连接字段覆盖：连接标识 -> 字段 -> 值，在内置清单之上生效
+var (
+	overridesOnce sync.Once
+	overridesMu   sync.Mutex
+	overrides     map[string]map[string]string
+)
+
+// 获取已加载的字段覆盖（首次调用时从数据目录读取）
+func loadOverrides() map[string]map[string]string {
+	overridesOnce.Do(func() {
+		var saved map[string]map[string]string
+		_ = readJSONFile(overridesFile, &saved)
+		overrides = make(map[string]map[string]string, len(saved))
+		for id, fields := range saved {
+			overrides[id] = make(map[string]string, len(fields))
+			for field, value := range fields {
+				overrides[id][fieldID(field)] = value
+			}
+		}
+	})
+	return overrides
+}
+
+// 丢弃已加载的字段覆盖，下次使用时重新读取（切换工作区时调用）
+func resetOverrides() {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	overridesOnce = sync.Once{}
+	overrides = nil
+	invalidateStore()
+}
+
+// 对环境中的连接应用字段覆盖
+func applyOverrides(module, project, env string, conns []Connection) []Connection {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	all := loadOverrides()
+	if len(all) == 0 {
+		return conns
+	}
+	for i := range conns {
+		target := connTarget{Module: module, Project: project, Env: env, Conn: conns[i]}
+		for field, value := range all[target.ID()] {
+			_ = setConnectionField(&conns[i], field, value)
+		}
+	}
+	return conns
+}
+
+// 保存一组连接的字段修改，changes 为连接标识 -> 字段 -> 新值
+func saveOverrides(changes map[string]map[string]string) error {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	all := loadOverrides()
+	for id, fields := range changes {
+		if all[id] == nil {
+			all[id] = make(map[string]string)
+		}
+		for field, value := range fields {
+			all[id][field] = value
+		}
+	}
+	invalidateStore()
+	return writeJSONFile(overridesFile, all)
+}
+
+// 删除字段覆盖，fields 为连接标识 -> 字段（字段已写回配置文件时使用）
+func clearOverrides(fields map[string][]string) error {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	all := loadOverrides()
+	changed := false
+	for id, names := range fields {
+		for _, field := range names {
+			if _, ok := all[id][field]; ok {
+				delete(all[id], field)
+				changed = true
+			}
+		}
+		if values, ok := all[id]; ok && len(values) == 0 {
+			delete(all, id)
+		}
+	}
+	if !changed {
+		return nil
+	}
+	invalidateStore()
+	return writeJSONFile(overridesFile, all)
+}
+
+// 将标识以 oldPrefix 开头的字段覆盖移到 newPrefix 下（重命名项目或环境时使用）
+func renameOverrides(oldPrefix, newPrefix string) error {
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+	all := loadOverrides()
+	moved := false
+	for id, fields := range all {
+		if rest, ok := strings.CutPrefix(id, oldPrefix); ok {
+			delete(all, id)
+			all[newPrefix+rest] = fields
+			moved = true
+		}
+	}
+	if !moved {
+		return nil
+	}
+	invalidateStore()
+	return writeJSONFile(overridesFile, all)
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 允许直接显示统一差异的文件大小上限
+const maxDiffSize = 1 << 20
+
+// 两主机文件操作的超时时间
+const hostFileTimeout = 10 * time.Minute
+
+// 打开两主机文件对比/复制表单，需要恰好标记两个SSH连接
+func (a *App) showHostFileForm() {
+	targets := a.selectedTargets()
+	if len(targets) != 2 || moduleType(targets[0].Module) != "SSH" || moduleType(targets[1].Module) != "SSH" {
+		a.statusBar.SetText(tr("hostfiles.mark_two"))
+		return
+	}
+	first, second := targets[0], targets[1]
+
+	pathA, pathB := "", ""
+	action := 0
+	actions := []string{tr("hostfiles.diff"), tr("hostfiles.copy", first.Conn.Name, second.Conn.Name), tr("hostfiles.copy", second.Conn.Name, first.Conn.Name)}
+
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addInputField(tr("hostfiles.path", first.Conn.Name), "", 50, func(text string) {
+		pathA = text
+	}, fixedRules(ruleRequired))
+	form.AddInputField(tr("hostfiles.path", second.Conn.Name), "", 50, nil, func(text string) {
+		pathB = text
+	}).
+		AddDropDown(tr("common.operation"), actions, 0, func(option string, index int) {
+			action = index
+		}).
+		AddButton(tr("common.run"), func() {
+			if !validator.validate() {
+				return
+			}
+			if pathB == "" {
+				pathB = pathA // 未填写时两端使用相同路径
+			}
+			a.popOverlay()
+			switch action {
+			case 0:
+				a.showHostDiff(first, pathA, second, pathB)
+			case 1:
+				a.confirmHostCopy(first, pathA, second, pathB)
+			case 2:
+				a.confirmHostCopy(second, pathB, first, pathA)
+			}
+		}).
+		AddButton(tr("common.cancel"), func() {
+			a.popOverlay()
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("hostfiles.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(validator.root(), 80, 12), form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
+
+// 获取远程路径的类型与大小（f=文件, d=目录）
+func remoteStat(ctx context.Context, target connTarget, remotePath string) (kind string, size int64, err error) {
+	output, err := runRemote(ctx, target, fmt.Sprintf("if [ -d %[1]s ]; then echo d 0; else echo f $(wc -c < %[1]s); fi", shellQuote(remotePath)))
+	if err != nil {
+		return "", 0, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return "", 0, errors.New(tr("hostfiles.stat_failed", remotePath))
+	}
+	size, _ = strconv.ParseInt(fields[1], 10, 64)
+	return fields[0], size, nil
+}
+
+// 获取对比内容：文件返回内容，目录返回按路径排序的校验和清单
+func diffContent(ctx context.Context, target connTarget, remotePath string) (string, error) {
+	kind, size, err := remoteStat(ctx, target, remotePath)
+	if err != nil {
+		return "", err
+	}
+	if kind == "d" {
+		return runRemote(ctx, target, fmt.Sprintf("cd %s && find . -type f -exec sha256sum {} + | sort -k 2", shellQuote(remotePath)))
+	}
+	if size > maxDiffSize {
+		return "", errors.New(tr("hostfiles.too_large", remotePath, formatBytes(maxDiffSize)))
+	}
+	return runRemote(ctx, target, "cat "+shellQuote(remotePath))
+}
+
+// 使用本地 diff -u 生成统一差异
+func unifiedDiff(labelA, contentA, labelB, contentB string) (string, error) {
+	dir, err := os.MkdirTemp("", "connectionmanager-diff-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	fileA, fileB := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := os.WriteFile(fileA, []byte(contentA), 0o600); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(fileB, []byte(contentB), 0o600); err != nil {
+		return "", err
+	}
+	output, err := exec.Command("diff", "-u", "--label", labelA, "--label", labelB, fileA, fileB).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		err = nil // 退出码 1 表示存在差异
+	}
+	return string(output), err
+}
+
+// 为统一差异着色
+func colorizeDiff(diff string) string {
+	var builder strings.Builder
+	for _, line := range strings.Split(diff, "\n") {
+		escaped := tview.Escape(line)
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			builder.WriteString("[white::b]" + escaped + "[-::-]\n")
+		case strings.HasPrefix(line, "@@"):
+			builder.WriteString("[blue]" + escaped + "[-]\n")
+		case strings.HasPrefix(line, "+"):
+			builder.WriteString("[green]" + escaped + "[-]\n")
+		case strings.HasPrefix(line, "-"):
+			builder.WriteString("[red]" + escaped + "[-]\n")
+		default:
+			builder.WriteString(escaped + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// 显示两台主机上文件或目录的统一差异
+func (a *App) showHostDiff(first connTarget, pathA string, second connTarget, pathB string) {
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false).
+		SetScrollable(true).
+		SetText(tr("hostfiles.loading"))
+	view.SetBorder(true).
+		SetTitle(tr("hostfiles.diff_title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), hostFileTimeout)
+		defer cancel()
+
+		labelA := fmt.Sprintf("%s:%s", first.Conn.Name, pathA)
+		labelB := fmt.Sprintf("%s:%s", second.Conn.Name, pathB)
+		var text string
+		contentA, errA := diffContent(ctx, first, pathA)
+		contentB, errB := diffContent(ctx, second, pathB)
+		switch {
+		case errA != nil:
+			text = fmt.Sprintf("[red]%s: %s[-]", tview.Escape(labelA), tview.Escape(errA.Error()))
+		case errB != nil:
+			text = fmt.Sprintf("[red]%s: %s[-]", tview.Escape(labelB), tview.Escape(errB.Error()))
+		default:
+			diff, err := unifiedDiff(labelA, contentA, labelB, contentB)
+			switch {
+			case err != nil:
+				text = tr("hostfiles.diff_failed", tview.Escape(err.Error()))
+			case diff == "":
+				text = tr("hostfiles.identical")
+			default:
+				text = colorizeDiff(diff)
+			}
+		}
+		a.app.QueueUpdateDraw(func() {
+			view.SetText(text)
+			view.ScrollToBeginning()
+		})
+	}()
+}
+
+// 确认两主机间复制，目标位于受保护环境时额外提示
+func (a *App) confirmHostCopy(source connTarget, sourcePath string, dest connTarget, destPath string) {
+	message := tr("hostfiles.copy_confirm",
+		tview.Escape(source.Conn.Name+":"+sourcePath), tview.Escape(dest.Conn.Name+":"+destPath))
+	if isProtectedEnv(dest.Env) {
+		message += tr("hostfiles.protected")
+	}
+	a.confirmEnvs(tr("hostfiles.confirm_title"), message, []string{dest.Env}, func() {
+		a.copyBetweenHosts(source, sourcePath, dest, destPath)
+	})
+}
+
+// 通过管理器中转，以 tar 流的方式在两台主机之间复制文件或目录
+func (a *App) copyBetweenHosts(source connTarget, sourcePath string, dest connTarget, destPath string) {
+	ctx, cancel := context.WithTimeout(context.Background(), hostFileTimeout)
+	progress := a.startProgress(tr("hostfiles.progress", source.Conn.Name, sourcePath, dest.Conn.Name, destPath), cancel)
+
+	go func() {
+		defer cancel()
+		start := time.Now()
+
+		// 目标路径以 / 结尾时视为目录，保留源文件名；否则按目标路径重命名
+		sourceDir, sourceBase := path.Split(strings.TrimRight(sourcePath, "/"))
+		if sourceDir == "" {
+			sourceDir = "."
+		}
+		destDir, extract := destPath, ""
+		if !strings.HasSuffix(destPath, "/") {
+			destDir = path.Dir(destPath)
+			extract = fmt.Sprintf(" --transform %s", shellQuote(fmt.Sprintf("s,^%s,%s,", sourceBase, path.Base(destPath))))
+		}
+
+		readArgs := sshExecCommand(source, fmt.Sprintf("tar -C %s -cf - %s", shellQuote(sourceDir), shellQuote(sourceBase)))
+		writeArgs := sshExecCommand(dest, fmt.Sprintf("mkdir -p %s && tar -C %s -xf -%s", shellQuote(destDir), shellQuote(destDir), extract))
+		reader := exec.CommandContext(ctx, readArgs[0], readArgs[1:]...)
+		writer := exec.CommandContext(ctx, writeArgs[0], writeArgs[1:]...)
+
+		pipeReader, pipeWriter := io.Pipe()
+		counter := &countingWriter{writer: pipeWriter, onWrite: func(count int64) {
+			progress.update(tr("common.transferred", formatBytes(count)), 0, 0)
+		}}
+		reader.Stdout = counter
+		writer.Stdin = pipeReader
+		var readErr, writeErr strings.Builder
+		reader.Stderr = &readErr
+		writer.Stderr = &writeErr
+
+		err := writer.Start()
+		if err == nil {
+			err = reader.Run()
+			pipeWriter.CloseWithError(err)
+			if waitErr := writer.Wait(); err == nil {
+				err = waitErr
+			}
+		}
+		if err != nil {
+			if detail := strings.TrimSpace(readErr.String() + writeErr.String()); detail != "" {
+				err = fmt.Errorf("%w: %s", err, detail)
+			}
+		}
+
+		stats := transferStats{Received: counter.count, Duration: time.Since(start)}
+		event := auditEvent{
+			Action:        "copy",
+			Target:        dest.ID(),
+			Detail:        fmt.Sprintf("%s:%s -> %s", source.ID(), sourcePath, destPath),
+			Duration:      stats.Duration,
+			BytesReceived: stats.Received,
+		}
+		if err != nil {
+			event.Detail += ": " + err.Error()
+		}
+		recordAudit(event)
+
+		progress.finish(func() {
+			if err != nil {
+				a.statusBar.SetText(tr("common.copy_failed", tview.Escape(err.Error())))
+				return
+			}
+			a.statusBar.SetText(tr("hostfiles.done", formatBytes(counter.count), formatDuration(stats.Duration)))
+		})
+	}()
+}
+
+// 统计写入字节数的 Writer
+type countingWriter struct {
+	writer  io.Writer
+	count   int64
+	onWrite func(count int64) // 每次写入后回调累计字节数（可为空）
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	if w.onWrite != nil {
+		w.onWrite(w.count)
+	}
+	return n, err
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 状态变化后节点闪烁的默认时长
+const defaultFlashDuration = 3 * time.Second
+
+// 闪烁时高亮与恢复的切换间隔
+const flashInterval = 400 * time.Millisecond
+
+// 最近发生状态变化的连接
+var (
+	flashMu     sync.Mutex
+	flashes     = make(map[string]time.Time) // 连接标识 -> 状态变化时间
+	lastChanged *connTarget
+)
+
+// 闪烁时长（配置项 ui.flash_duration，设为 0 关闭闪烁）
+func flashDuration() time.Duration {
+	if configIsSet("ui.flash_duration") {
+		return configDuration("ui.flash_duration")
+	}
+	return defaultFlashDuration
+}
+
+// 记录连接状态变化，在闪烁期间定期重绘树视图（可在任意 goroutine 中调用）
+func (a *App) noteStatusChange(target connTarget) {
+	flashMu.Lock()
+	flashes[target.ID()] = time.Now()
+	lastChanged = &target
+	flashMu.Unlock()
+
+	duration := flashDuration()
+	if duration <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(flashInterval)
+		defer ticker.Stop()
+		for range int(duration/flashInterval) + 1 {
+			a.app.QueueUpdateDraw(func() {
+				if a.inTreeView {
+					a.updateMainPanel()
+				}
+			})
+			<-ticker.C
+		}
+	}()
+}
+
+// 节点当前是否处于闪烁的高亮阶段；path 为连接标识，或以 / 结尾的项目、环境路径（匹配其下全部连接）
+func flashOn(path string) bool {
+	flashMu.Lock()
+	defer flashMu.Unlock()
+	duration := flashDuration()
+	for id, changed := range flashes {
+		elapsed := time.Since(changed)
+		if elapsed >= duration {
+			delete(flashes, id)
+			continue
+		}
+		if (id == path || strings.HasSuffix(path, "/") && strings.HasPrefix(id, path)) && int(elapsed/flashInterval)%2 == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// 按闪烁状态渲染节点名称
+func flashName(name string, on bool) string {
+	if on {
+		return fmt.Sprintf("[black:yellow]%s[-:-]", name)
+	}
+	return name
+}
+
+// 跳转到最近发生状态变化的连接
+func (a *App) jumpToLastChanged() {
+	flashMu.Lock()
+	target := lastChanged
+	flashMu.Unlock()
+	if target == nil {
+		a.statusBar.SetText(tr("flash.none"))
+		return
+	}
+	if !a.focusTarget(*target) {
+		a.statusBar.SetText(tr("tabs.not_found", tview.Escape(target.ID())))
+	}
+}
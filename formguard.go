@@ -0,0 +1,85 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 表单各项的当前内容（输入框文本、下拉框选项、复选框状态），用于判断是否有未保存的修改
+func (v *formValidator) formValues() []string {
+	values := make([]string, v.form.GetFormItemCount())
+	for i := range values {
+		switch item := v.form.GetFormItem(i).(type) {
+		case *tview.InputField:
+			values[i] = item.GetText()
+		case *tview.DropDown:
+			_, values[i] = item.GetCurrentOption()
+		case *tview.Checkbox:
+			values[i] = strconv.FormatBool(item.IsChecked())
+		}
+	}
+	return values
+}
+
+// 将表单当前内容记为已保存
+func (v *formValidator) markClean() {
+	v.saved = v.formValues()
+}
+
+// 表单内容与打开时相比是否有修改
+func (v *formValidator) dirty() bool {
+	return !slices.Equal(v.saved, v.formValues())
+}
+
+// 以覆盖层打开编辑表单：ESC 与“取消”按钮经 closeForm 关闭，submit 为表单的提交操作
+func (a *App) pushForm(v *formValidator, root tview.Primitive, submit func()) {
+	v.markClean()
+	a.pushOverlay(root, v.form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.closeForm(v, submit)
+			return nil
+		}
+		return event
+	})
+}
+
+// 关闭编辑表单；有未保存的修改时询问提交、放弃还是继续编辑
+func (a *App) closeForm(v *formValidator, submit func()) {
+	if !v.dirty() {
+		a.popOverlay()
+		return
+	}
+	box := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetWrap(true)
+	box.SetBorder(true).
+		SetTitle(tr("formguard.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	box.SetText(tr("formguard.text"))
+
+	a.pushOverlay(centered(box, 60, 9), box, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 's', 'S':
+				// 回到表单后提交，校验失败时停留在表单上显示错误
+				a.popOverlay()
+				submit()
+			case 'd', 'D':
+				a.popOverlay()
+				a.popOverlay()
+			case 'c', 'C':
+				a.popOverlay()
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// 表单字段定义：模块类型以声明方式给出连接编辑器中的字段及其显示条件
+type fieldSpec struct {
+	Field   string   // 字段标识（nameField、passwordField 或 connectionFields 中的字段），表单标签为 fieldLabel
+	Options []string // 可选值，非空时显示为下拉框
+	When    string   // 显示条件依赖的字段，为空表示始终显示
+	In      []string // 依赖字段取这些值之一时显示
+	Secret  bool     // 密码字段：遮盖输入，保存到凭据库而不是连接字段
+}
+
+// SSH 连接的字段：密钥文件只在密钥/证书认证时显示，证书文件只在证书认证时显示
+var sshFieldSchema = []fieldSpec{
+	{Field: nameField},
+	{Field: "host"},
+	{Field: "port"},
+	{Field: "user"},
+	{Field: "auth", Options: authMethods},
+	{Field: "identity_file", When: "auth", In: []string{"key", "certificate"}},
+	{Field: "certificate", When: "auth", In: []string{"certificate"}},
+	{Field: passwordField, When: "auth", In: []string{"password"}, Secret: true},
+	{Field: "proxy_command"},
+	{Field: "jump_host"},
+	{Field: "tags"},
+}
+
+// 数据库连接的字段：证书与密钥只在启用 TLS 时显示
+var databaseFieldSchema = []fieldSpec{
+	{Field: nameField},
+	{Field: "host"},
+	{Field: "port"},
+	{Field: "user"},
+	{Field: "database"},
+	{Field: passwordField, Secret: true},
+	{Field: "tls", Options: tlsSwitch},
+	{Field: "tls_ca", When: "tls", In: []string{"on"}},
+	{Field: "tls_cert", When: "tls", In: []string{"on"}},
+	{Field: "tls_key", When: "tls", In: []string{"on"}},
+	{Field: "ssh_tunnel"},
+	{Field: "tags"},
+}
+
+// 各模块类型的字段定义，新增模块类型时在此登记
+var moduleFieldSchemas = map[string][]fieldSpec{
+	"SSH":        sshFieldSchema,
+	"MySQL":      databaseFieldSchema,
+	"PostgreSQL": databaseFieldSchema,
+	"Redis":      databaseFieldSchema,
+}
+
+// 获取模块的字段定义，未登记的类型只包含基本字段
+func fieldSchema(module string) []fieldSpec {
+	if schema, ok := moduleFieldSchemas[moduleType(module)]; ok {
+		return schema
+	}
+	return []fieldSpec{{Field: nameField}, {Field: "host"}, {Field: "port"}, {Field: "user"}, {Field: "tags"}}
+}
+
+// 判断字段在当前取值下是否显示
+func (s fieldSpec) visible(values map[string]string) bool {
+	return s.When == "" || slices.Contains(s.In, values[s.When])
+}
+
+// 连接在编辑器中的初始取值，下拉字段未设置时取默认项；密码字段始终为空
+func schemaValues(conn Connection, schema []fieldSpec) map[string]string {
+	values := map[string]string{nameField: conn.Name}
+	for _, field := range connectionFields {
+		values[field] = connectionField(conn, field)
+	}
+	if values["auth"] == "" {
+		values["auth"] = "agent"
+		if conn.IdentityFile != "" {
+			values["auth"] = "key"
+		}
+	}
+	for _, spec := range schema {
+		if len(spec.Options) > 0 && !slices.Contains(spec.Options, values[spec.Field]) {
+			values[spec.Field] = spec.Options[0]
+		}
+	}
+	return values
+}
+
+// 按字段定义在表单中添加当前可见的字段，返回可见字段列表；下拉框取值变化时调用 onSwitch
+func (v *formValidator) addSchemaFields(module string, schema []fieldSpec, values map[string]string, onSwitch func(field string)) []string {
+	var visible []string
+	for _, spec := range schema {
+		if !spec.visible(values) {
+			continue
+		}
+		field := spec.Field
+		visible = append(visible, field)
+		if spec.Secret {
+			// 密码不回显已保存的值，留空表示保持不变
+			v.addSecretField(fieldLabel(field), 40, func(text string) {
+				values[field] = text
+			}, fixedRules())
+			continue
+		}
+		if len(spec.Options) > 0 {
+			v.form.AddDropDown(fieldLabel(field), spec.Options, slices.Index(spec.Options, values[field]), func(option string, index int) {
+				if option == "" || option == values[field] {
+					return
+				}
+				values[field] = option
+				onSwitch(field)
+			})
+			continue
+		}
+		v.addInputField(fieldLabel(field), values[field], 40, func(text string) {
+			values[field] = strings.TrimSpace(text)
+		}, fixedRules(connectionFieldRules(module, field)...))
+	}
+	return visible
+}
+
+// 按认证方式生成 SSH 认证参数（密钥、证书、仅密码或使用 ssh-agent）
+func sshAuthArgs(conn Connection) []string {
+	switch conn.Auth {
+	case "password":
+		return []string{"-o", "PubkeyAuthentication=no", "-o", "PreferredAuthentications=password,keyboard-interactive"}
+	case "agent":
+		return nil
+	case "certificate":
+		var args []string
+		if conn.IdentityFile != "" {
+			args = append(args, "-i", conn.IdentityFile)
+		}
+		if conn.Certificate != "" {
+			args = append(args, "-o", "CertificateFile="+conn.Certificate)
+		}
+		return args
+	}
+	if conn.IdentityFile != "" {
+		return []string{"-i", conn.IdentityFile}
+	}
+	return nil
+}
+
+// 按连接的 TLS 设置生成数据库客户端参数；mode 为模块的默认 TLS 模式
+func databaseTLSArgs(module string, conn Connection, mode string) (args []string, conninfo []string) {
+	if !conn.TLS {
+		return nil, nil
+	}
+	switch moduleType(module) {
+	case "MySQL":
+		if mode == "" {
+			mode = "REQUIRED"
+			if conn.TLSCA != "" {
+				mode = "VERIFY_CA"
+			}
+			args = append(args, "--ssl-mode="+mode)
+		}
+		if conn.TLSCA != "" {
+			args = append(args, "--ssl-ca="+conn.TLSCA)
+		}
+		if conn.TLSCert != "" {
+			args = append(args, "--ssl-cert="+conn.TLSCert)
+		}
+		if conn.TLSKey != "" {
+			args = append(args, "--ssl-key="+conn.TLSKey)
+		}
+	case "PostgreSQL":
+		if mode == "" {
+			mode = "require"
+			if conn.TLSCA != "" {
+				mode = "verify-ca"
+			}
+			conninfo = append(conninfo, "sslmode="+mode)
+		}
+		if conn.TLSCA != "" {
+			conninfo = append(conninfo, "sslrootcert="+conn.TLSCA)
+		}
+		if conn.TLSCert != "" {
+			conninfo = append(conninfo, "sslcert="+conn.TLSCert)
+		}
+		if conn.TLSKey != "" {
+			conninfo = append(conninfo, "sslkey="+conn.TLSKey)
+		}
+	case "Redis":
+		if mode == "" || strings.EqualFold(mode, "disable") {
+			args = append(args, "--tls")
+		}
+		if conn.TLSCA != "" {
+			args = append(args, "--cacert", conn.TLSCA)
+		}
+		if conn.TLSCert != "" {
+			args = append(args, "--cert", conn.TLSCert)
+		}
+		if conn.TLSKey != "" {
+			args = append(args, "--key", conn.TLSKey)
+		}
+	}
+	return args, conninfo
+}
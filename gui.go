@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 远程图形程序
+type guiApp struct {
+	Name    string `mapstructure:"name"`    // 程序名称
+	Command string `mapstructure:"command"` // 远程执行的命令
+	Match   string `mapstructure:"match"`   // 适用的目标匹配模式（模块/项目/环境/连接，支持通配符）
+}
+
+// 读取适用于目标的远程图形程序（配置项 gui_apps）
+func guiApps(target connTarget) []guiApp {
+	var apps []guiApp
+	if err := unmarshalConfig("gui_apps", &apps); err != nil {
+		return nil
+	}
+	var matched []guiApp
+	for _, app := range apps {
+		if matchTargetPattern(app.Match, target) {
+			matched = append(matched, app)
+		}
+	}
+	return matched
+}
+
+// 显示当前SSH连接可启动的远程图形程序列表
+func (a *App) showGUIApps() {
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		a.statusBar.SetText(tr("gui.ssh_only"))
+		return
+	}
+	if os.Getenv("DISPLAY") == "" {
+		a.statusBar.SetText(tr("gui.no_display"))
+		return
+	}
+
+	apps := guiApps(target)
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("gui.title", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	rows := [][]string{{tr("common.col_name"), tr("common.col_command")}}
+	for _, app := range apps {
+		rows = append(rows, []string{app.Name, app.Command})
+	}
+	if len(apps) == 0 {
+		rows = append(rows, []string{tr("gui.none"), ""})
+	}
+	fillTable(table, rows)
+	table.Select(1, 0)
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row >= 1 && row <= len(apps) {
+				a.popOverlay()
+				a.launchGUIApp(target, apps[row-1])
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// 通过启用 X11 转发的SSH连接在后台启动远程图形程序
+func (a *App) launchGUIApp(target connTarget, app guiApp) {
+	policy := resolveSessionPolicy(target)
+	flag := x11Flag(policy)
+	if flag == "" {
+		flag = "-X" // 启动图形程序时总是需要转发
+	}
+	args := sshBaseArgs(target, policy)
+	args = insertSSHOptions(target, args, append([]string{flag}, batchModeOptions(target)...)...)
+	args = append(args, app.Command)
+
+	cmd := exec.Command(args[0], args[1:]...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		a.statusBar.SetText(tr("gui.launch_failed", tview.Escape(err.Error())))
+		return
+	}
+	a.statusBar.SetText(tr("gui.launched", target.Conn.Name, app.Name))
+
+	go func() {
+		err := cmd.Wait()
+		event := auditEvent{Action: "gui", Target: target.ID(), Detail: app.Command, Duration: time.Since(start)}
+		if err != nil {
+			event.Detail += ": " + err.Error()
+		}
+		recordAudit(event)
+		if err != nil {
+			message := strings.TrimSpace(stderr.String())
+			if message == "" {
+				message = err.Error()
+			}
+			a.app.QueueUpdateDraw(func() {
+				a.statusBar.SetText(tr("gui.crashed", app.Name, tview.Escape(message)))
+			})
+		}
+	}()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// 默认健康检查超时时间
+const defaultHealthTimeout = 3 * time.Second
+
+// 单次健康检查结果
+type healthResult struct {
+	OK      bool          // 是否可达
+	Latency time.Duration // 建立连接耗时
+	Error   string        // 失败原因
+	Checked time.Time     // 检查时间
+}
+
+// 对连接地址进行TCP拨号检查
+func checkTCP(conn Connection, timeout time.Duration) healthResult {
+	result := healthResult{Checked: time.Now()}
+	address := net.JoinHostPort(conn.Host, strconv.Itoa(conn.Port))
+	start := time.Now()
+	c, err := net.DialTimeout("tcp", address, timeout)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	c.Close()
+	result.OK = true
+	return result
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 后台健康检查的默认间隔与并发数
+const (
+	defaultHealthInterval    = 30 * time.Second
+	defaultHealthConcurrency = 8
+)
+
+// 树中可见连接的后台健康检查结果，以及触发立即检查的信号
+var (
+	healthMu      sync.Mutex
+	healthTargets []connTarget
+	healthResults = make(map[string]healthResult)
+	healthRefresh = make(chan struct{}, 1)
+)
+
+// 是否启用后台健康检查（配置项 health_check.enabled，默认启用）；关闭时树中显示清单中的状态
+func healthChecksEnabled() bool {
+	if configIsSet("health_check.enabled") {
+		return configBool("health_check.enabled")
+	}
+	return true
+}
+
+// 是否可以在本机直接探测连接（经隧道、Teleport、跳板或自定义代理命令连接时由远端解析地址）
+func healthCheckable(target connTarget) bool {
+	return target.Conn.ProxyCommand == "" && target.Conn.SSHTunnel == "" && target.Conn.JumpHost == "" && resolveTransport(target).kind() == transportSSH
+}
+
+// 按模块类型进行协议级探测：SSH 读取版本标识，MySQL 读取握手包，PostgreSQL 发送 SSLRequest，Redis 发送 PING。
+// 半开的握手会计入 MySQL 的 max_connect_errors、触发 fail2ban 等防护，只在模块设置 probe: protocol 时进行
+func protocolPing(module string, c net.Conn, timeout time.Duration) error {
+	if !strings.EqualFold(settingsFor(module).Probe, "protocol") {
+		return nil
+	}
+	c.SetDeadline(time.Now().Add(timeout))
+	reader := bufio.NewReader(c)
+	var err error
+	switch kind := moduleType(module); kind {
+	case "SSH":
+		var line string
+		if line, err = reader.ReadString('\n'); err == nil && !strings.HasPrefix(line, "SSH-") {
+			return errors.New(tr("health.not_ssh"))
+		}
+	case "MySQL":
+		// 握手包：3 字节长度、1 字节序号，之后为协议版本 10；0xff 表示服务端直接返回错误（如主机被拒绝）
+		header := make([]byte, 5)
+		if _, err = io.ReadFull(reader, header); err == nil && header[4] != 10 {
+			return errors.New(tr("health.rejected"))
+		}
+	case "PostgreSQL":
+		if _, err = c.Write([]byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}); err == nil {
+			var reply byte
+			if reply, err = reader.ReadByte(); err == nil && reply != 'S' && reply != 'N' {
+				return errors.New(tr("health.not_postgres"))
+			}
+		}
+	case "Redis":
+		if _, err = io.WriteString(c, "PING\r\n"); err == nil {
+			// 需要认证时返回 -NOAUTH，同样说明服务可用
+			var line string
+			if line, err = reader.ReadString('\n'); err == nil && !strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "-") {
+				return errors.New(tr("health.not_redis"))
+			}
+		}
+	default:
+		return nil
+	}
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return errors.New(tr("health.no_handshake_timeout", moduleType(module), timeout))
+		}
+		return fmt.Errorf(tr("health.no_handshake"), moduleType(module), err)
+	}
+	return nil
+}
+
+// 建立 TCP 连接并按模块设置进行协议级探测，延迟为建立连接的耗时
+func checkProtocol(target connTarget, timeout time.Duration) healthResult {
+	result := healthResult{Checked: time.Now()}
+	address := net.JoinHostPort(target.Conn.Host, strconv.Itoa(target.Conn.Port))
+	start := time.Now()
+	c, err := net.DialTimeout("tcp", address, timeout)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer c.Close()
+	if err := protocolPing(target.Module, c, timeout); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.OK = true
+	return result
+}
+
+// 记录树中当前可见的连接，出现尚未检查的连接时立即触发一次检查
+func setHealthTargets(targets []connTarget) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	healthTargets = targets
+	for _, target := range targets {
+		if _, ok := healthResults[target.ID()]; !ok && healthCheckable(target) {
+			select {
+			case healthRefresh <- struct{}{}:
+			default:
+			}
+			return
+		}
+	}
+}
+
+// 连接的健康状态：online、offline，尚未检查时为 unknown，无法在本机探测时为 unchecked
+func healthStatus(target connTarget) string {
+	if !healthCheckable(target) {
+		return "unchecked"
+	}
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	result, ok := healthResults[target.ID()]
+	switch {
+	case !ok:
+		return "unknown"
+	case result.OK:
+		return "online"
+	}
+	return "offline"
+}
+
+// 在线连接的延迟文字，其他状态为空
+func healthLatencyText(target connTarget, status string) string {
+	if status != "online" {
+		return ""
+	}
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	return fmt.Sprintf(" [gray]%s[-]", formatDuration(healthResults[target.ID()].Latency))
+}
+
+// 在后台定期检查树中可见的连接（配置项 health_check.interval、concurrency、timeout），结果更新到树中
+func (a *App) startHealthChecks() {
+	if !healthChecksEnabled() {
+		return
+	}
+	interval := configDuration("health_check.interval")
+	if interval <= 0 {
+		interval = defaultHealthInterval
+	}
+	concurrency := configInt("health_check.concurrency")
+	if concurrency < 1 {
+		concurrency = defaultHealthConcurrency
+	}
+	timeout := configDuration("health_check.timeout")
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			healthMu.Lock()
+			var targets []connTarget
+			for _, target := range healthTargets {
+				if healthCheckable(target) {
+					targets = append(targets, target)
+				}
+			}
+			healthMu.Unlock()
+
+			results := make([]healthResult, len(targets))
+			slots := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			for i, target := range targets {
+				wg.Add(1)
+				slots <- struct{}{}
+				go func() {
+					defer wg.Done()
+					defer func() { <-slots }()
+					results[i] = checkProtocol(target, timeout)
+				}()
+			}
+			wg.Wait()
+
+			healthMu.Lock()
+			var changed []connTarget
+			for i, target := range targets {
+				if previous, ok := healthResults[target.ID()]; ok && previous.OK != results[i].OK {
+					changed = append(changed, target)
+				}
+				healthResults[target.ID()] = results[i]
+			}
+			healthMu.Unlock()
+			for _, target := range changed {
+				a.noteStatusChange(target)
+			}
+			a.app.QueueUpdateDraw(func() {
+				if a.inTreeView {
+					a.updateMainPanel()
+				}
+			})
+
+			select {
+			case <-ticker.C:
+			case <-healthRefresh:
+			}
+		}
+	}()
+}
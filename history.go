@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 查询历史文件名（位于数据目录中）
+const historyFile = "history.jsonl"
+
+// 单条查询历史记录
+type queryHistoryEntry struct {
+	Target   string        `json:"target"`          // 目标连接标识（模块/项目/环境/连接）
+	Query    string        `json:"query"`           // 执行的查询语句
+	Time     time.Time     `json:"time"`            // 执行时间
+	Duration time.Duration `json:"duration"`        // 执行耗时
+	Error    string        `json:"error,omitempty"` // 执行错误（成功时为空）
+}
+
+// 记录一次查询执行
+func recordQueryHistory(target connTarget, query string, elapsed time.Duration, err error) {
+	entry := queryHistoryEntry{
+		Target:   target.ID(),
+		Query:    query,
+		Time:     time.Now().Add(-elapsed),
+		Duration: elapsed,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	_ = appendJSONLine(historyFile, entry) // 历史记录失败不影响查询本身
+}
+
+// 读取指定目标的查询历史，按时间倒序排列
+func loadQueryHistory(target connTarget) []queryHistoryEntry {
+	entries, _ := readJSONLines[queryHistoryEntry](historyFile)
+	var result []queryHistoryEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Target == target.ID() {
+			result = append(result, entries[i])
+		}
+	}
+	return result
+}
+
+// 显示查询历史列表，回车将选中的查询载入控制台并重新执行
+func (a *App) showQueryHistory(target connTarget) {
+	entries := loadQueryHistory(target)
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("history.title", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	rows := [][]string{{tr("common.col_time"), tr("common.col_duration"), tr("common.col_result"), tr("history.col_query")}}
+	for _, entry := range entries {
+		result := tr("common.success")
+		if entry.Error != "" {
+			result = tr("common.failure")
+		}
+		query := strings.Join(strings.Fields(entry.Query), " ")
+		rows = append(rows, []string{
+			formatTime(entry.Time),
+			formatDuration(entry.Duration),
+			result,
+			query,
+		})
+	}
+	fillTable(table, rows)
+	if len(entries) > 0 {
+		table.Select(1, 0)
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row < 1 || row > len(entries) {
+				return nil
+			}
+			a.popOverlay()
+			if a.console != nil {
+				a.console.input.SetText(entries[row-1].Query, true)
+				a.executeConsoleQuery(entries[row-1].Query)
+			}
+			return nil
+		}
+		return event
+	})
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 主机时区缓存文件名（位于数据目录中）
+const hostTimezoneFile = "timezones.json"
+
+// 时区缓存有效期，以及获取失败后再次尝试的间隔
+const (
+	hostTimezoneTTL   = 24 * time.Hour
+	hostTimezoneRetry = 10 * time.Minute
+)
+
+// 读取远程时区：时区名称（/etc/timezone、timedatectl 或 /etc/localtime 链接）与当前 UTC 偏移
+const hostTimezoneScript = `z=$(cat /etc/timezone 2>/dev/null || timedatectl show -p Timezone --value 2>/dev/null || readlink /etc/localtime 2>/dev/null | sed 's|.*zoneinfo/||'); echo "$z"; date +%z`
+
+// 远程主机的时区
+type hostTimezone struct {
+	Name    string    `json:"name"`    // IANA 时区名称（可能为空）
+	Offset  int       `json:"offset"`  // 获取时的 UTC 偏移（秒）
+	Fetched time.Time `json:"fetched"` // 获取时间
+}
+
+// 时区缓存与正在获取的目标
+var (
+	timezoneMu       sync.Mutex
+	timezoneAttempts = make(map[string]time.Time)
+)
+
+// 读取缓存的时区
+func cachedHostTimezone(target connTarget) (hostTimezone, bool) {
+	timezoneMu.Lock()
+	defer timezoneMu.Unlock()
+	var zones map[string]hostTimezone
+	_ = readJSONFile(hostTimezoneFile, &zones)
+	zone, ok := zones[target.ID()]
+	return zone, ok
+}
+
+// 解析 date +%z 输出的偏移（如 +0800）
+func parseUTCOffset(value string) (int, error) {
+	if len(value) != 5 || (value[0] != '+' && value[0] != '-') {
+		return 0, errors.New(tr("hosttime.invalid_offset", value))
+	}
+	hours, err1 := strconv.Atoi(value[1:3])
+	minutes, err2 := strconv.Atoi(value[3:5])
+	if err1 != nil || err2 != nil {
+		return 0, errors.New(tr("hosttime.invalid_offset", value))
+	}
+	offset := hours*3600 + minutes*60
+	if value[0] == '-' {
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// 从远程主机获取时区并写入缓存
+func fetchHostTimezone(ctx context.Context, target connTarget) (hostTimezone, error) {
+	output, err := runRemote(ctx, target, hostTimezoneScript)
+	if err != nil {
+		return hostTimezone{}, err
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	offset, err := parseUTCOffset(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil {
+		return hostTimezone{}, err
+	}
+	zone := hostTimezone{Offset: offset, Fetched: time.Now()}
+	if len(lines) > 1 {
+		zone.Name = strings.TrimSpace(lines[0])
+	}
+
+	timezoneMu.Lock()
+	defer timezoneMu.Unlock()
+	zones := make(map[string]hostTimezone)
+	_ = readJSONFile(hostTimezoneFile, &zones)
+	zones[target.ID()] = zone
+	return zone, writeJSONFile(hostTimezoneFile, zones)
+}
+
+// 主机时区对应的 Location：优先使用时区名称（可正确处理夏令时），否则使用获取时的固定偏移
+func (z hostTimezone) location() *time.Location {
+	if z.Name != "" {
+		if location, err := time.LoadLocation(z.Name); err == nil {
+			return location
+		}
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+d", z.Offset/3600), z.Offset)
+}
+
+// 格式化主机当地时间，如 2024-05-01 14:30 Asia/Tokyo (+0900)
+func (z hostTimezone) localTime(now time.Time) string {
+	local := now.In(z.location())
+	name := z.Name
+	if name == "" {
+		name = local.Format("MST")
+	}
+	return fmt.Sprintf("%s %s (%s)", local.Format("2006-01-02 15:04"), name, local.Format("-0700"))
+}
+
+// 获取目标的主机时区；缓存缺失或过期时在后台获取，完成后调用 onFetched
+func hostTimezoneFor(target connTarget, onFetched func()) (hostTimezone, bool) {
+	zone, ok := cachedHostTimezone(target)
+	if ok && time.Since(zone.Fetched) < hostTimezoneTTL {
+		return zone, true
+	}
+	id := target.ID()
+	timezoneMu.Lock()
+	if last, attempted := timezoneAttempts[id]; attempted && time.Since(last) < hostTimezoneRetry {
+		timezoneMu.Unlock()
+		return zone, ok
+	}
+	timezoneAttempts[id] = time.Now()
+	timezoneMu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), motdTimeout)
+		defer cancel()
+		if _, err := fetchHostTimezone(ctx, target); err == nil {
+			onFetched()
+		}
+	}()
+	return zone, ok
+}
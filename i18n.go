@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 默认语言，其他语言的目录缺少某条消息时回退到该语言
+const defaultLanguage = "zh"
+
+// 界面文本目录：语言 -> 消息标识 -> 文本（可含 fmt 占位符），新增语言时在此登记
+var messageCatalogs = map[string]map[string]string{
+	"zh": messagesZh,
+	"en": messagesEn,
+}
+
+// 按界面语言取出消息并格式化；当前语言缺少时回退到中文，都缺少时返回消息标识本身
+func tr(id string, args ...any) string {
+	text, ok := messageCatalogs[displayLocale()][id]
+	if !ok {
+		if text, ok = messageCatalogs[defaultLanguage][id]; !ok {
+			text = id
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// 按界面语言取出一组消息（下拉框选项等）
+func trAll(ids []string) []string {
+	texts := make([]string, len(ids))
+	for i, id := range ids {
+		texts[i] = tr(id)
+	}
+	return texts
+}
+
+// 以消息标识定义的哨兵错误：包级变量初始化时界面语言尚未确定，错误信息在使用时才按当前语言生成
+type messageError string
+
+func (e messageError) Error() string { return tr(string(e)) }
+
+// 系统语言：依次读取环境变量 LC_ALL、LC_MESSAGES、LANG，中文或未设置（C/POSIX）时为 zh，其他语言为 en
+func systemLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		switch lang := strings.ToLower(value); {
+		case strings.HasPrefix(lang, "zh"), lang == "c", lang == "posix", strings.HasPrefix(lang, "c."):
+			return "zh"
+		}
+		return "en"
+	}
+	return defaultLanguage
+}
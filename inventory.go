@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"sync"
+)
+
+// 新增连接文件名（位于数据目录中）
+const connectionsFile = "connections.json"
+
+// 内置清单之外新增的连接
+type inventoryEntry struct {
+	Module  string     `json:"module"`  // 所属模块
+	Project string     `json:"project"` // 所属项目名称
+	Env     string     `json:"env"`     // 所属环境名称
+	Conn    Connection `json:"conn"`    // 连接信息
+}
+
+// 连接标识
+func (e inventoryEntry) ID() string {
+	return connTarget{Module: e.Module, Project: e.Project, Env: e.Env, Conn: e.Conn}.ID()
+}
+
+// 已加载的新增连接
+var (
+	addedOnce sync.Once
+	addedMu   sync.Mutex
+	added     []inventoryEntry
+)
+
+// 获取新增连接（首次调用时从数据目录读取），调用方需持有 addedMu
+func loadAddedConnections() []inventoryEntry {
+	addedOnce.Do(func() {
+		added = nil
+		_ = readJSONFile(connectionsFile, &added)
+	})
+	return added
+}
+
+// 丢弃已加载的新增连接，下次使用时重新读取（切换工作区时调用）
+func resetAddedConnections() {
+	addedMu.Lock()
+	defer addedMu.Unlock()
+	addedOnce = sync.Once{}
+	added = nil
+	invalidateStore()
+}
+
+// 获取指定环境中新增的连接
+func addedConnections(module, project, env string) []Connection {
+	addedMu.Lock()
+	defer addedMu.Unlock()
+	var conns []Connection
+	for _, entry := range loadAddedConnections() {
+		if entry.Module == module && entry.Project == project && entry.Env == env {
+			conns = append(conns, entry.Conn)
+		}
+	}
+	return conns
+}
+
+// 保存新增连接，标识相同的已有连接会被替换
+func addConnections(entries []inventoryEntry) error {
+	addedMu.Lock()
+	defer addedMu.Unlock()
+	all := loadAddedConnections()
+	for _, entry := range entries {
+		replaced := false
+		for i := range all {
+			if all[i].ID() == entry.ID() {
+				all[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			all = append(all, entry)
+		}
+	}
+	if err := writeJSONFile(connectionsFile, all); err != nil {
+		return err
+	}
+	added = all
+	invalidateStore()
+	return nil
+}
+
+// 修改新增连接并写回数据目录，change 返回修改后的全部连接（删除或重命名项目、环境时使用）
+func updateAddedConnections(change func(entries []inventoryEntry) []inventoryEntry) error {
+	addedMu.Lock()
+	defer addedMu.Unlock()
+	all := change(slices.Clone(loadAddedConnections()))
+	if err := writeJSONFile(connectionsFile, all); err != nil {
+		return err
+	}
+	added = all
+	invalidateStore()
+	return nil
+}
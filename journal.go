@@ -0,0 +1,193 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 传输日志文件名（位于数据目录中），记录未完成的传输以便中断后恢复
+const transferJournalFile = "transfer_journal.json"
+
+// 写入传输进度的最小间隔
+const journalInterval = 2 * time.Second
+
+// 传输状态
+const (
+	transferRunning     = "running"     // 进行中
+	transferInterrupted = "interrupted" // 已中断（失败、取消或程序退出）
+)
+
+// 传输日志条目
+type journalEntry struct {
+	ID       string    `json:"id"`              // 条目标识（启动时间）
+	Name     string    `json:"name"`            // 配方名称
+	Args     []string  `json:"args"`            // 传输命令
+	Remotes  []string  `json:"remotes"`         // 涉及的远程连接标识
+	Source   string    `json:"source"`          // 传输源端点
+	Dest     string    `json:"destination"`     // 传输目标端点
+	Verify   bool      `json:"verify"`          // 完成后校验 sha256
+	PID      int       `json:"pid"`             // 执行传输的进程号
+	Status   string    `json:"status"`          // running 或 interrupted
+	Started  time.Time `json:"started"`         // 开始时间
+	Updated  time.Time `json:"updated"`         // 最近一次写入时间
+	Progress string    `json:"progress"`        // 最近的进度行
+	Error    string    `json:"error,omitempty"` // 中断原因
+}
+
+// 传输日志读写锁
+var journalMu sync.Mutex
+
+// 读取传输日志
+func loadJournal() []journalEntry {
+	var entries []journalEntry
+	_ = readJSONFile(transferJournalFile, &entries)
+	return entries
+}
+
+// 写入或更新传输日志条目
+func saveJournalEntry(entry journalEntry) {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+	entry.Updated = time.Now()
+	entries := loadJournal()
+	replaced := false
+	for i := range entries {
+		if entries[i].ID == entry.ID {
+			entries[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+	_ = writeJSONFile(transferJournalFile, entries)
+}
+
+// 删除传输日志条目（传输完成或放弃恢复时调用）
+func removeJournalEntry(id string) {
+	journalMu.Lock()
+	defer journalMu.Unlock()
+	var kept []journalEntry
+	for _, entry := range loadJournal() {
+		if entry.ID != id {
+			kept = append(kept, entry)
+		}
+	}
+	_ = writeJSONFile(transferJournalFile, kept)
+}
+
+// 进程是否仍在运行
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// 获取可恢复的传输：已中断的，以及执行进程已不存在（崩溃或断电）的进行中传输
+func interruptedTransfers() []journalEntry {
+	var result []journalEntry
+	for _, entry := range loadJournal() {
+		if entry.Status == transferRunning && (entry.PID == os.Getpid() || processAlive(entry.PID)) {
+			continue
+		}
+		if entry.Status == transferRunning {
+			entry.Status, entry.Error = transferInterrupted, tr("journal.process_exited")
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// 恢复传输使用的命令：rsync 增加 --append-verify 从部分文件续传，scp 无法续传只能重新执行
+func resumeArgs(args []string) []string {
+	if len(args) == 0 || args[0] != "rsync" {
+		return args
+	}
+	for _, arg := range args {
+		if arg == "--append-verify" {
+			return args
+		}
+	}
+	return append([]string{args[0], "--append-verify"}, args[1:]...)
+}
+
+// 显示未完成的传输：Enter 恢复，D 放弃
+func (a *App) showInterruptedTransfers() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("journal.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	var entries []journalEntry
+	render := func() {
+		entries = interruptedTransfers()
+		rows := [][]string{{tr("journal.col_recipe"), tr("journal.col_started"), tr("journal.col_progress"), tr("journal.col_reason")}}
+		for _, entry := range entries {
+			rows = append(rows, []string{entry.Name, formatTime(entry.Started), entry.Progress, entry.Error})
+		}
+		if len(entries) == 0 {
+			rows = append(rows, []string{tr("journal.empty"), "", "", ""})
+		}
+		fillTable(table, rows)
+		table.Select(1, 0)
+	}
+	render()
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := table.GetSelection()
+		var entry *journalEntry
+		if row >= 1 && row <= len(entries) {
+			entry = &entries[row-1]
+		}
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case event.Key() == tcell.KeyEnter && entry != nil:
+			var remotes []connTarget
+			for _, id := range entry.Remotes {
+				if target, ok := findTarget(id); ok {
+					remotes = append(remotes, target)
+				}
+			}
+			resumed := *entry
+			resumed.Args = resumeArgs(entry.Args)
+			message := tr("journal.confirm_resume", tview.Escape(entry.Name), tview.Escape(strings.Join(resumed.Args, " ")))
+			if resumed.Args[0] != "rsync" {
+				message += tr("journal.scp_restart")
+			}
+			a.confirm(tr("journal.resume"), message, func() {
+				a.popOverlay()
+				a.runTransfer(resumed, remotes)
+			})
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'd' || event.Rune() == 'D') && entry != nil:
+			id := entry.ID
+			a.confirm(tr("journal.abandon"), tr("journal.confirm_abandon", tview.Escape(entry.Name)), func() {
+				removeJournalEntry(id)
+				render()
+			})
+			return nil
+		}
+		return event
+	})
+}
+
+// 启动时提示上次未完成的传输
+func (a *App) notifyInterruptedTransfers() {
+	if n := len(interruptedTransfers()); n > 0 {
+		a.statusBar.SetText(tr("journal.pending", n))
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 连接的跳板链（字段“跳板”，跳板自身也可以设置跳板）：从离本机最近的跳板到离目标最近的跳板；
+// 跳板不存在、不是 SSH 连接、形成循环或超过 maxJumpDepth 层时返回错误
+func jumpChain(target connTarget) ([]connTarget, error) {
+	var chain []connTarget
+	seen := map[string]bool{target.ID(): true}
+	for id := target.Conn.JumpHost; id != ""; {
+		hop, ok := findTarget(id)
+		switch {
+		case !ok:
+			return nil, errors.New(tr("jump.not_found", id))
+		case moduleType(hop.Module) != "SSH":
+			return nil, errors.New(tr("jump.not_ssh", id))
+		case seen[hop.ID()]:
+			return nil, errors.New(tr("jump.cycle", id))
+		case len(chain) >= maxJumpDepth:
+			return nil, errors.New(tr("jump.too_deep", maxJumpDepth))
+		}
+		seen[hop.ID()] = true
+		chain = append([]connTarget{hop}, chain...)
+		id = hop.Conn.JumpHost
+	}
+	return chain, nil
+}
+
+// 经由跳板连接的 ProxyCommand：用跳板自身的 ssh 参数（包括它的认证方式和它的跳板）执行 ssh -W；
+// 嵌套在内层的 % 需要转义，只留给外层 ssh 展开 -W 的 %h:%p
+func jumpProxyCommand(target connTarget) (string, error) {
+	chain, err := jumpChain(target)
+	if err != nil || len(chain) == 0 {
+		return "", err
+	}
+	hop := chain[len(chain)-1]
+	args := sshBaseArgs(hop, resolveSessionPolicy(hop))
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(strings.ReplaceAll(arg, "%", "%%"))
+	}
+	quoted = insertSSHOptions(hop, quoted, "-W", "%h:%p")
+	return strings.Join(quoted, " "), nil
+}
+
+// 跳板在连接路径中的显示：用户@主机:端口（默认端口省略）
+func jumpHopLabel(hop connTarget) string {
+	host := hop.Conn.Host
+	if hop.Conn.Port != 0 && hop.Conn.Port != 22 {
+		host += ":" + strconv.Itoa(hop.Conn.Port)
+	}
+	if hop.Conn.User != "" {
+		host = hop.Conn.User + "@" + host
+	}
+	return host
+}
+
+// 以节点（模块/项目[/环境[/连接]]）下的连接作为跳板或 SSH 隧道的其他连接，Old 为原引用
+func nodeReferences(node string) []fieldChange {
+	var refs []fieldChange
+	for _, target := range inventoryTargets(configuredModules()) {
+		if id := target.ID(); id == node || strings.HasPrefix(id, node+"/") {
+			continue
+		}
+		for _, field := range []string{"jump_host", "ssh_tunnel"} {
+			if ref := connectionField(target.Conn, field); ref == node || strings.HasPrefix(ref, node+"/") {
+				refs = append(refs, fieldChange{Target: target, Field: field, Old: ref})
+			}
+		}
+	}
+	return refs
+}
+
+// 节点重命名后把其他连接中指向旧路径的跳板和 SSH 隧道改为新路径
+func renameReferences(oldNode, newNode string) error {
+	refs := nodeReferences(oldNode)
+	if len(refs) == 0 {
+		return nil
+	}
+	updates := make(map[string]map[string]string)
+	for i, ref := range refs {
+		refs[i].New = newNode + strings.TrimPrefix(ref.Old, oldNode)
+		id := ref.Target.ID()
+		if updates[id] == nil {
+			updates[id] = make(map[string]string)
+		}
+		updates[id][ref.Field] = refs[i].New
+	}
+	if err := saveConnectionChanges(updates); err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		recordAudit(auditEvent{Action: "rename", Target: ref.Target.ID(), Detail: fmt.Sprintf("%s: %s -> %s", ref.Field, ref.Old, ref.New)})
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 跳转历史最多保留的位置数
+const maxJumpList = 100
+
+// 跳转历史中的一个位置：模块栏中悬停的模块，或树中选中的节点
+type jumpPosition struct {
+	InTree bool         // 是否在树视图中
+	Node   paletteEntry // 模块下标与节点名称（Level 为 -1 时只有模块）
+}
+
+// 当前所在的位置
+func (a *App) currentPosition() jumpPosition {
+	if !a.inTreeView {
+		return jumpPosition{Node: paletteEntry{Level: -1, Module: a.hoveredModule}}
+	}
+	return jumpPosition{InTree: true, Node: paletteEntry{
+		Level: a.selected.Level, Module: a.currentModule,
+		Project: a.selected.Project, Env: a.selected.Env, Conn: a.selected.Conn,
+	}}
+}
+
+// 在跳转（搜索跳转、切换会话、进入模块、到首尾等）之前记录当前位置，并清空前进历史
+func (a *App) recordJump() {
+	position := a.currentPosition()
+	if n := len(a.jumpBack); n > 0 && a.jumpBack[n-1] == position {
+		return
+	}
+	a.jumpBack = append(a.jumpBack, position)
+	if len(a.jumpBack) > maxJumpList {
+		a.jumpBack = a.jumpBack[1:]
+	}
+	a.jumpForward = nil
+}
+
+// 回到上一个位置（Ctrl+O）
+func (a *App) jumpOlder() {
+	if len(a.jumpBack) == 0 {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tr("jump.no_older")))
+		return
+	}
+	a.jumpForward = append(a.jumpForward, a.currentPosition())
+	position := a.jumpBack[len(a.jumpBack)-1]
+	a.jumpBack = a.jumpBack[:len(a.jumpBack)-1]
+	a.restorePosition(position)
+}
+
+// 前进到回退之前的位置（Ctrl+I / Tab）
+func (a *App) jumpNewer() {
+	if len(a.jumpForward) == 0 {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tr("jump.no_newer")))
+		return
+	}
+	a.jumpBack = append(a.jumpBack, a.currentPosition())
+	position := a.jumpForward[len(a.jumpForward)-1]
+	a.jumpForward = a.jumpForward[:len(a.jumpForward)-1]
+	a.restorePosition(position)
+}
+
+// 恢复到历史中的位置；其间删除或重命名过节点时选中仍存在的上级节点
+func (a *App) restorePosition(position jumpPosition) {
+	node := position.Node
+	node.Module = clampIndex(node.Module, len(a.modules))
+	if !position.InTree {
+		a.hoveredModule = node.Module
+		a.inTreeView = false
+		a.updateModuleBar()
+		a.updateMainPanel()
+		a.updateStatusBar()
+		return
+	}
+	a.showNode(node)
+	a.statusBar.SetText(fmt.Sprintf("[gray]%s[-]", tr("jump.position", len(a.jumpBack)+1, len(a.jumpBack)+len(a.jumpForward)+1)))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// 密钥链中使用的服务名
+const keychainService = "connectionmanager"
+
+// Windows 凭据管理器的读写脚本：通过 P/Invoke 调用 CredReadW/CredWriteW，写入的密码从标准输入读取
+const windowsCredentialScript = `$ErrorActionPreference = 'Stop'
+Add-Type -TypeDefinition @"
+using System;
+using System.Runtime.InteropServices;
+using System.Runtime.InteropServices.ComTypes;
+using System.Text;
+public static class CMCredential {
+  [StructLayout(LayoutKind.Sequential, CharSet = CharSet.Unicode)]
+  struct CREDENTIAL {
+    public int Flags; public int Type; public string TargetName; public string Comment;
+    public FILETIME LastWritten; public int CredentialBlobSize; public IntPtr CredentialBlob;
+    public int Persist; public int AttributeCount; public IntPtr Attributes;
+    public string TargetAlias; public string UserName;
+  }
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  static extern bool CredReadW(string target, int type, int flags, out IntPtr credential);
+  [DllImport("advapi32.dll", CharSet = CharSet.Unicode, SetLastError = true)]
+  static extern bool CredWriteW(ref CREDENTIAL credential, int flags);
+  [DllImport("advapi32.dll")]
+  static extern void CredFree(IntPtr credential);
+  public static string Read(string target) {
+    IntPtr p;
+    if (!CredReadW(target, 1, 0, out p)) throw new Exception("not found: " + target);
+    try {
+      var c = (CREDENTIAL)Marshal.PtrToStructure(p, typeof(CREDENTIAL));
+      return Marshal.PtrToStringUni(c.CredentialBlob, c.CredentialBlobSize / 2);
+    } finally { CredFree(p); }
+  }
+  public static void Write(string target, string secret) {
+    var blob = Encoding.Unicode.GetBytes(secret);
+    var c = new CREDENTIAL { Type = 1, TargetName = target, Persist = 2, UserName = Environment.UserName,
+      CredentialBlobSize = blob.Length, CredentialBlob = Marshal.AllocHGlobal(blob.Length) };
+    try {
+      Marshal.Copy(blob, 0, c.CredentialBlob, blob.Length);
+      if (!CredWriteW(ref c, 0)) throw new Exception("CredWrite failed: " + Marshal.GetLastWin32Error());
+    } finally { Marshal.FreeHGlobal(c.CredentialBlob); }
+  }
+}
+"@
+`
+
+// PowerShell 单引号字符串转义
+func powershellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// 读取系统密钥链中的密钥的命令：macOS 钥匙串、Windows 凭据管理器或 libsecret
+func keychainLookupCommand(name string) []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"security", "find-generic-password", "-s", keychainService, "-a", name, "-w"}
+	case "windows":
+		script := windowsCredentialScript + "[Console]::Out.Write([CMCredential]::Read(" + powershellQuote(keychainService+":"+name) + "))"
+		return []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", script}
+	}
+	return []string{"secret-tool", "lookup", "service", keychainService, "account", name}
+}
+
+// 将密钥写入系统密钥链；密码只通过标准输入传递
+func keychainStore(name, secret string) error {
+	var args []string
+	input := secret
+	switch runtime.GOOS {
+	case "darwin":
+		// security -i 从标准输入读取命令，避免密码出现在进程列表中
+		args = []string{"security", "-i"}
+		input = fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+			strconv.Quote(keychainService), strconv.Quote(name), strconv.Quote(secret))
+	case "windows":
+		script := windowsCredentialScript + "[CMCredential]::Write(" + powershellQuote(keychainService+":"+name) + ", [Console]::In.ReadToEnd())"
+		args = []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		args = []string{"secret-tool", "store", "--label=" + keychainService + " " + name, "service", keychainService, "account", name}
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	// 不返回命令输出，避免后端回显的内容进入状态栏
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(tr("keychain.write_failed"), err)
+	}
+	return nil
+}
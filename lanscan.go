@@ -0,0 +1,565 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 局域网扫描参数
+const (
+	lanListenWindow = 2 * time.Second        // mDNS/SSDP 等待响应的时间
+	lanProbeTimeout = 400 * time.Millisecond // 端口探测超时
+	lanConcurrency  = 128                    // 端口探测并发数
+	lanMaxHosts     = 1024                   // 子网扫描的最大主机数
+)
+
+// mDNS 服务类型对应的模块类型
+var mdnsServiceTypes = map[string]string{
+	"_ssh._tcp.local":        "SSH",
+	"_sftp-ssh._tcp.local":   "SSH",
+	"_mysql._tcp.local":      "MySQL",
+	"_postgresql._tcp.local": "PostgreSQL",
+	"_redis._tcp.local":      "Redis",
+}
+
+// 端口探测的默认端口及对应的模块类型
+var lanProbePorts = map[int]string{
+	22:   "SSH",
+	3306: "MySQL",
+	5432: "PostgreSQL",
+	6379: "Redis",
+}
+
+// 局域网中发现的服务
+type lanService struct {
+	Kind   string // 模块类型
+	Name   string // 名称
+	Host   string // 地址
+	Port   int    // 端口
+	Source string // 发现方式：mdns、ssdp 或 sweep
+	Known  bool   // 清单中已存在相同地址的连接
+}
+
+// 服务唯一键
+func (s lanService) key() string {
+	return fmt.Sprintf("%s|%s|%d", s.Kind, s.Host, s.Port)
+}
+
+// 构造 mDNS PTR 查询报文（请求单播响应）
+func mdnsQuery(names []string) []byte {
+	var buf bytes.Buffer
+	header := make([]byte, 12)
+	binary.BigEndian.PutUint16(header[4:], uint16(len(names)))
+	buf.Write(header)
+	for _, name := range names {
+		for _, label := range strings.Split(name, ".") {
+			buf.WriteByte(byte(len(label)))
+			buf.WriteString(label)
+		}
+		buf.WriteByte(0)
+		buf.Write([]byte{0, 12, 0x80, 1}) // PTR, IN + 单播响应位
+	}
+	return buf.Bytes()
+}
+
+// 读取 DNS 报文中的域名（支持压缩指针），返回域名和之后的偏移
+func readDNSName(msg []byte, offset int) (string, int, error) {
+	var labels []string
+	end := -1
+	for jumps := 0; jumps < 16; {
+		if offset >= len(msg) {
+			return "", 0, errors.New(tr("lan.name_out_of_range"))
+		}
+		length := int(msg[offset])
+		switch {
+		case length == 0:
+			if end < 0 {
+				end = offset + 1
+			}
+			return strings.Join(labels, "."), end, nil
+		case length&0xC0 == 0xC0:
+			if offset+1 >= len(msg) {
+				return "", 0, errors.New(tr("lan.name_out_of_range"))
+			}
+			if end < 0 {
+				end = offset + 2
+			}
+			offset = int(binary.BigEndian.Uint16(msg[offset:]) & 0x3FFF)
+			jumps++
+		default:
+			if offset+1+length > len(msg) {
+				return "", 0, errors.New(tr("lan.name_out_of_range"))
+			}
+			labels = append(labels, string(msg[offset+1:offset+1+length]))
+			offset += 1 + length
+		}
+	}
+	return "", 0, errors.New(tr("lan.too_many_pointers"))
+}
+
+// SRV 记录指向的主机和端口
+type mdnsTarget struct {
+	host string
+	port int
+}
+
+// mDNS 响应中的记录
+type mdnsRecords struct {
+	ptr  map[string][]string   // 服务类型 -> 实例名
+	srv  map[string]mdnsTarget // 实例名 -> 主机和端口
+	addr map[string]string     // 主机名 -> IPv4 地址
+}
+
+// 解析 mDNS 响应中的 PTR、SRV 和 A 记录
+func parseMDNS(msg []byte, records *mdnsRecords) error {
+	if len(msg) < 12 {
+		return errors.New(tr("lan.short_packet"))
+	}
+	qd := int(binary.BigEndian.Uint16(msg[4:]))
+	rr := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+	offset := 12
+	for i := 0; i < qd; i++ {
+		_, next, err := readDNSName(msg, offset)
+		if err != nil {
+			return err
+		}
+		offset = next + 4
+	}
+	for i := 0; i < rr; i++ {
+		name, next, err := readDNSName(msg, offset)
+		if err != nil {
+			return err
+		}
+		if next+10 > len(msg) {
+			return errors.New(tr("lan.record_out_of_range"))
+		}
+		rtype := binary.BigEndian.Uint16(msg[next:])
+		rdlen := int(binary.BigEndian.Uint16(msg[next+8:]))
+		rdata := next + 10
+		if rdata+rdlen > len(msg) {
+			return errors.New(tr("lan.record_out_of_range"))
+		}
+		switch rtype {
+		case 1: // A
+			if rdlen == 4 {
+				records.addr[name] = net.IP(msg[rdata : rdata+4]).String()
+			}
+		case 12: // PTR
+			if target, _, err := readDNSName(msg, rdata); err == nil {
+				records.ptr[name] = append(records.ptr[name], target)
+			}
+		case 33: // SRV
+			if rdlen > 6 {
+				if target, _, err := readDNSName(msg, rdata+6); err == nil {
+					records.srv[name] = mdnsTarget{target, int(binary.BigEndian.Uint16(msg[rdata+4:]))}
+				}
+			}
+		}
+		offset = rdata + rdlen
+	}
+	return nil
+}
+
+// 通过 mDNS 查询局域网中公布的服务
+func mdnsDiscover(ctx context.Context) ([]lanService, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	var names []string
+	for name := range mdnsServiceTypes {
+		names = append(names, name)
+	}
+	if _, err := conn.WriteToUDP(mdnsQuery(names), &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}); err != nil {
+		return nil, err
+	}
+
+	var services []lanService
+	buf := make([]byte, 9000)
+	deadline := time.Now().Add(lanListenWindow)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		conn.SetReadDeadline(deadline)
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			break
+		}
+		records := mdnsRecords{ptr: map[string][]string{}, srv: map[string]mdnsTarget{}, addr: map[string]string{}}
+		if parseMDNS(buf[:n], &records) != nil {
+			continue
+		}
+		for serviceType, instances := range records.ptr {
+			kind, ok := mdnsServiceTypes[strings.ToLower(serviceType)]
+			if !ok {
+				continue
+			}
+			for _, instance := range instances {
+				service := lanService{Kind: kind, Name: strings.TrimSuffix(instance, "."+serviceType), Host: from.IP.String(), Port: defaultPortForType(kind), Source: "mdns"}
+				if srv, ok := records.srv[instance]; ok {
+					service.Port = srv.port
+					if ip := records.addr[srv.host]; ip != "" {
+						service.Host = ip
+					}
+				}
+				services = append(services, service)
+			}
+		}
+	}
+	return services, nil
+}
+
+// 通过 SSDP 查找局域网中响应的设备地址
+func ssdpDiscover(ctx context.Context) (map[string]string, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	search := "M-SEARCH * HTTP/1.1\r\nHOST: 239.255.255.250:1900\r\nMAN: \"ssdp:discover\"\r\nMX: 1\r\nST: ssdp:all\r\n\r\n"
+	if _, err := conn.WriteToUDP([]byte(search), &net.UDPAddr{IP: net.IPv4(239, 255, 255, 250), Port: 1900}); err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]string) // 地址 -> SERVER 头
+	buf := make([]byte, 4096)
+	deadline := time.Now().Add(lanListenWindow)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		conn.SetReadDeadline(deadline)
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			break
+		}
+		server := ""
+		if resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil); err == nil {
+			server = resp.Header.Get("Server")
+			resp.Body.Close()
+		}
+		if _, seen := hosts[from.IP.String()]; !seen || server != "" {
+			hosts[from.IP.String()] = server
+		}
+	}
+	return hosts, nil
+}
+
+// 展开子网中的主机地址（不含网络地址和广播地址）
+func subnetHosts(cidr string) ([]string, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	if ip.To4() == nil {
+		return nil, errors.New(tr("lan.ipv4_only", cidr))
+	}
+	ones, bits := network.Mask.Size()
+	if size := 1 << (bits - ones); size > lanMaxHosts+2 {
+		return nil, errors.New(tr("lan.subnet_too_large", lanMaxHosts, cidr))
+	}
+	start := binary.BigEndian.Uint32(network.IP.To4())
+	size := uint32(1) << (bits - ones)
+	var hosts []string
+	for i := uint32(0); i < size; i++ {
+		if size > 2 && (i == 0 || i == size-1) {
+			continue
+		}
+		addr := make(net.IP, 4)
+		binary.BigEndian.PutUint32(addr, start+i)
+		hosts = append(hosts, addr.String())
+	}
+	return hosts, nil
+}
+
+// 探测主机上的默认服务端口，每完成一次探测调用 probed
+func probeHosts(ctx context.Context, hosts []string, source string, probed func()) []lanService {
+	var mu sync.Mutex
+	var services []lanService
+	semaphore := make(chan struct{}, lanConcurrency)
+	var wg sync.WaitGroup
+	dialer := net.Dialer{Timeout: lanProbeTimeout}
+	for _, host := range hosts {
+		for port, kind := range lanProbePorts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer probed()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+				c, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+				if err != nil {
+					return
+				}
+				c.Close()
+				mu.Lock()
+				services = append(services, lanService{Kind: kind, Name: fmt.Sprintf("%s-%s", strings.ToLower(kind), host), Host: host, Port: port, Source: source})
+				mu.Unlock()
+			}()
+		}
+	}
+	wg.Wait()
+	return services
+}
+
+// 模块类型的默认端口
+func defaultPortForType(kind string) int {
+	for port, k := range lanProbePorts {
+		if k == kind {
+			return port
+		}
+	}
+	return 0
+}
+
+// 扫描局域网：mDNS 与 SSDP 并行发现，SSDP 设备和指定子网再做端口探测；结果去重并标记已存在的连接。
+// report 接收当前阶段和端口探测的进度（总量为 0 表示未知）
+func scanLAN(ctx context.Context, subnet string, report func(message string, current, total int64)) ([]lanService, error) {
+	var sweep []string
+	if subnet != "" {
+		hosts, err := subnetHosts(subnet)
+		if err != nil {
+			return nil, err
+		}
+		sweep = hosts
+	}
+
+	report(tr("lan.progress_discover"), 0, 0)
+	var mdns []lanService
+	var ssdp map[string]string
+	var mdnsErr, ssdpErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		mdns, mdnsErr = mdnsDiscover(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		ssdp, ssdpErr = ssdpDiscover(ctx)
+	}()
+	wg.Wait()
+	if mdnsErr != nil && ssdpErr != nil && len(sweep) == 0 {
+		return nil, mdnsErr
+	}
+
+	found := mdns
+	var ssdpHosts []string
+	for host := range ssdp {
+		ssdpHosts = append(ssdpHosts, host)
+	}
+	message := tr("lan.progress_probe", len(ssdpHosts)+len(sweep))
+	total := int64((len(ssdpHosts) + len(sweep)) * len(lanProbePorts))
+	var done atomic.Int64
+	probed := func() {
+		report(message, done.Add(1), total)
+	}
+	for _, service := range probeHosts(ctx, ssdpHosts, "ssdp", probed) {
+		if server := ssdp[service.Host]; server != "" {
+			service.Name = fmt.Sprintf("%s-%s", strings.ToLower(service.Kind), strings.Fields(server)[0])
+		}
+		found = append(found, service)
+	}
+	found = append(found, probeHosts(ctx, sweep, "sweep", probed)...)
+
+	known := make(map[string]bool)
+	for _, target := range inventoryTargets(configuredModules()) {
+		known[lanService{Kind: moduleType(target.Module), Host: target.Conn.Host, Port: target.Conn.Port}.key()] = true
+	}
+	seen := make(map[string]bool)
+	var services []lanService
+	for _, service := range found {
+		if seen[service.key()] {
+			continue
+		}
+		seen[service.key()] = true
+		service.Known = known[service.key()]
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Kind != services[j].Kind {
+			return services[i].Kind < services[j].Kind
+		}
+		return services[i].Host < services[j].Host
+	})
+	return services, nil
+}
+
+// 打开局域网发现表单
+func (a *App) showLANScanForm() {
+	subnet, project, env := "", "", ""
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addInputField(tr("lan.subnet"), "", 24, func(text string) {
+		subnet = strings.TrimSpace(text)
+	}, fixedRules(ruleCIDR))
+	form.AddInputField(tr("lan.project"), "", 24, nil, func(text string) {
+		project = text
+	}).
+		AddInputField(tr("lan.env"), "", 24, nil, func(text string) {
+			env = text
+		}).
+		AddButton(tr("lan.scan"), func() {
+			if !validator.validate() {
+				return
+			}
+			a.popOverlay()
+			a.showLANScanResults(subnet, project, env)
+		}).
+		AddButton(tr("common.cancel"), func() {
+			a.popOverlay()
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("lan.form_title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(validator.root(), 64, 12), form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
+
+// 执行扫描并显示结果，Space 标记，Enter 添加标记的（或当前）服务，A 添加全部新服务
+func (a *App) showLANScanResults(subnet, project, env string) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("lan.scanning")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	fillTable(table, [][]string{{"", tr("common.col_type"), tr("common.col_name"), tr("common.col_address"), tr("lan.col_source")}})
+
+	var services []lanService
+	marked := make(map[int]bool)
+	render := func() {
+		rows := [][]string{{"", tr("common.col_type"), tr("common.col_name"), tr("common.col_address"), tr("lan.col_source")}}
+		for i, service := range services {
+			mark := " "
+			switch {
+			case service.Known:
+				mark = "="
+			case marked[i]:
+				mark = "*"
+			}
+			rows = append(rows, []string{mark, service.Kind, service.Name, net.JoinHostPort(service.Host, strconv.Itoa(service.Port)), service.Source})
+		}
+		row, _ := table.GetSelection()
+		fillTable(table, rows)
+		table.Select(max(row, 1), 0)
+	}
+
+	add := func(indexes []int) {
+		var entries []inventoryEntry
+		var added []int
+		for _, i := range indexes {
+			service := services[i]
+			if service.Known {
+				continue
+			}
+			entry, ok := placeConnection(service.Kind, project, env)
+			if !ok {
+				continue
+			}
+			entry.Conn = Connection{Name: service.Name, Status: "disconnected", Host: service.Host, Port: service.Port, User: defaultUser(entry.Module), Tags: []string{"lan", service.Source}}
+			entries = append(entries, entry)
+			added = append(added, i)
+		}
+		preview := changePreview{Title: tr("lan.title"), Header: connectionPreviewHeader(), Rows: connectionPreviewRows(entries), Empty: tr("lan.nothing_to_add")}
+		a.showPreview(preview, func() {
+			if err := saveNewConnections(entries); err != nil {
+				a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+				return
+			}
+			for _, entry := range entries {
+				recordAudit(auditEvent{Action: "lan_add", Target: entry.ID(), Detail: entry.Conn.Tags[1]})
+			}
+			for _, i := range added {
+				services[i].Known = true
+				delete(marked, i)
+			}
+			render()
+			a.updateMainPanel()
+			if reviewMode() {
+				a.statusBar.SetText(tr("common.staged_connections", len(entries)))
+			} else {
+				a.statusBar.SetText(tr("common.added_connections", len(entries)))
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := table.GetSelection()
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			cancel()
+			a.popOverlay()
+			return nil
+		case len(services) == 0:
+			return event
+		case event.Key() == tcell.KeyEnter:
+			var indexes []int
+			for i := range services {
+				if marked[i] {
+					indexes = append(indexes, i)
+				}
+			}
+			if len(indexes) == 0 && row >= 1 {
+				indexes = []int{row - 1}
+			}
+			add(indexes)
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			if row >= 1 && !services[row-1].Known {
+				marked[row-1] = !marked[row-1]
+				render()
+			}
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'a' || event.Rune() == 'A'):
+			indexes := make([]int, len(services))
+			for i := range services {
+				indexes[i] = i
+			}
+			add(indexes)
+			return nil
+		}
+		return event
+	})
+
+	progress := a.startProgress(tr("lan.title"), cancel)
+	go func() {
+		result, err := scanLAN(ctx, subnet, progress.update)
+		progress.finish(func() {
+			if ctx.Err() != nil {
+				// 取消扫描时关闭结果表格（弹窗已关闭，表格位于最上层）
+				a.popOverlay()
+				a.statusBar.SetText(tr("lan.cancelled"))
+				return
+			}
+			if err != nil {
+				table.SetTitle(tr("lan.failed", tview.Escape(err.Error())))
+				return
+			}
+			services = result
+			table.SetTitle(tr("lan.results", len(services)))
+			render()
+		})
+	}()
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 布局记录文件名（位于数据目录中），按模块保存最近使用的布局
+const layoutFile = "layouts.json"
+
+// 主界面布局预设
+const (
+	layoutTree      = "tree"      // 仅树
+	layoutDetails   = "details"   // 树 + 详情
+	layoutSession   = "session"   // 树 + 会话
+	layoutDashboard = "dashboard" // 仪表盘：树 + 详情 + 会话
+)
+
+// 布局预设顺序，对应数字键 1-4
+var layoutPresets = []string{layoutTree, layoutDetails, layoutSession, layoutDashboard}
+
+// 布局预设显示名称的消息标识
+var layoutLabels = map[string]string{
+	layoutTree:      "layout.tree",
+	layoutDetails:   "layout.details",
+	layoutSession:   "layout.session",
+	layoutDashboard: "layout.dashboard",
+}
+
+// 会话面板中最多显示的最近会话数
+const sessionPanelRecent = 8
+
+// 模块最近使用的布局，未记录时使用配置项 layout.default，默认为树+详情
+func savedLayout(module string) string {
+	layouts := make(map[string]string)
+	_ = readJSONFile(layoutFile, &layouts)
+	if layoutLabels[layouts[module]] != "" {
+		return layouts[module]
+	}
+	if name := strings.ToLower(configString("layout.default")); layoutLabels[name] != "" {
+		return name
+	}
+	return layoutDetails
+}
+
+// 记录模块最近使用的布局
+func saveLayout(module, name string) error {
+	layouts := make(map[string]string)
+	_ = readJSONFile(layoutFile, &layouts)
+	layouts[module] = name
+	return writeJSONFile(layoutFile, layouts)
+}
+
+// 按布局重新排列主面板与侧边面板
+func (a *App) applyLayout(name string) {
+	a.layout = name
+	a.body.Clear()
+	a.body.AddItem(a.mainPanel, 0, 1, false)
+	switch name {
+	case layoutDetails:
+		a.body.AddItem(a.detailPanel, 0, 1, false)
+	case layoutSession:
+		a.body.AddItem(a.sessionPanel, 0, 1, false)
+	case layoutDashboard:
+		side := tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(a.detailPanel, 0, 3, false).
+			AddItem(a.sessionPanel, 0, 2, false)
+		a.body.AddItem(side, 0, 1, false)
+	}
+	a.updateSidePanels()
+}
+
+// 切换到第 index 个布局预设，并记为当前模块的布局
+func (a *App) switchLayout(index int) {
+	if index < 0 || index >= len(layoutPresets) {
+		return
+	}
+	name := layoutPresets[index]
+	a.applyLayout(name)
+	module := a.modules[a.currentModule]
+	if err := saveLayout(module, name); err != nil {
+		a.statusBar.SetText(tr("layout.save_failed", err))
+		return
+	}
+	a.statusBar.SetText(tr("layout.applied", tr(layoutLabels[name]), module))
+}
+
+// 刷新侧边面板内容（仅在当前布局包含该面板时）
+func (a *App) updateSidePanels() {
+	if a.layout == layoutDetails || a.layout == layoutDashboard {
+		content := ""
+		if a.inTreeView && a.selected.Level == 2 {
+			content = a.renderConnectionDetails()
+		}
+		if content == "" {
+			content = tr("details.empty")
+		}
+		a.detailPanel.SetText(content)
+	}
+	if a.layout == layoutSession || a.layout == layoutDashboard {
+		a.sessionPanel.SetText(renderSessionPanel(a.modules[a.currentModule]))
+	}
+}
+
+// 渲染会话面板：本地隧道、进行中的传输和当前模块最近的会话
+func renderSessionPanel(module string) string {
+	content := tr("sessions.tunnels")
+	tunnelsMu.Lock()
+	var lines []string
+	now := time.Now()
+	for id, t := range tunnels {
+		if t.alive() {
+			lines = append(lines, fmt.Sprintf("  %s → 127.0.0.1:%d%s\n", tview.Escape(id), t.port, t.activityText(now)))
+		}
+	}
+	tunnelsMu.Unlock()
+	lines = append(lines, renderDaemonTunnels(now)...)
+	sort.Strings(lines)
+	if len(lines) == 0 {
+		lines = []string{tr("sessions.none")}
+	}
+	content += strings.Join(lines, "")
+
+	content += tr("sessions.tabs")
+	content += renderSessionTabs()
+
+	content += tr("sessions.transfers")
+	running := 0
+	for _, entry := range loadJournal() {
+		if entry.Status == transferRunning && processAlive(entry.PID) {
+			content += fmt.Sprintf("  %s %s\n", tview.Escape(entry.Name), tview.Escape(entry.Progress))
+			running++
+		}
+	}
+	if running == 0 {
+		content += tr("sessions.none")
+	}
+
+	content += tr("sessions.recent")
+	events := loadAuditEvents()
+	shown := 0
+	for i := len(events) - 1; i >= 0 && shown < sessionPanelRecent; i-- {
+		event := events[i]
+		if event.Action != "session" || !strings.HasPrefix(event.Target, module+"/") {
+			continue
+		}
+		content += fmt.Sprintf("  %s %s %s %s\n", formatShortTime(event.Time), tview.Escape(event.User),
+			tview.Escape(strings.TrimPrefix(event.Target, module+"/")), formatDuration(event.Duration))
+		shown++
+	}
+	if shown == 0 {
+		content += tr("sessions.none")
+	}
+	return content
+}
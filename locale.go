@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 默认的时间格式与紧凑时间格式（用于面板中的列表）
+const (
+	defaultTimeFormat      = "2006-01-02 15:04"
+	defaultShortTimeFormat = "01-02 15:04"
+)
+
+// 相对时间只用于最近的时间点，更早的仍显示绝对时间
+const relativeTimeWindow = 7 * 24 * time.Hour
+
+// 界面语言（配置项 ui.locale）：zh 或 en，未配置时按系统语言（LANG 等环境变量）选择；影响界面文本、时长和相对时间的写法
+func displayLocale() string {
+	locale := configString("ui.locale")
+	if locale == "" {
+		return systemLocale()
+	}
+	if strings.HasPrefix(strings.ToLower(locale), "en") {
+		return "en"
+	}
+	return "zh"
+}
+
+// 格式化时间：开启 ui.relative_time 时最近 7 天内显示相对时间（如“3 分钟前”），否则按 ui.time_format
+func formatTime(t time.Time) string {
+	return formatTimeLayout(t, "ui.time_format", defaultTimeFormat)
+}
+
+// 格式化列表中的紧凑时间（配置项 ui.short_time_format），同样遵循 ui.relative_time
+func formatShortTime(t time.Time) string {
+	return formatTimeLayout(t, "ui.short_time_format", defaultShortTimeFormat)
+}
+
+// 按配置项 key 中的格式（为空时使用 fallback）格式化时间
+func formatTimeLayout(t time.Time, key, fallback string) string {
+	if configBool("ui.relative_time") {
+		if text, ok := formatRelative(t, time.Now()); ok {
+			return text
+		}
+	}
+	layout := configString(key)
+	if layout == "" {
+		layout = fallback
+	}
+	return t.Format(layout)
+}
+
+// 相对时间，如“3 分钟前”“2 小时后”，超出 relativeTimeWindow 时返回 false
+func formatRelative(t, now time.Time) (string, bool) {
+	d := now.Sub(t).Round(time.Second)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d >= relativeTimeWindow {
+		return "", false
+	}
+	if d < time.Minute {
+		return tr("time.just_now"), true
+	}
+
+	var amount int
+	var unit string
+	switch {
+	case d < time.Hour:
+		amount, unit = int(d/time.Minute), "m"
+	case d < 24*time.Hour:
+		amount, unit = int(d/time.Hour), "h"
+	default:
+		amount, unit = int(d/(24*time.Hour)), "d"
+	}
+	if future {
+		return tr("time.relative_future", amount, tr("time.relative_unit."+unit)), true
+	}
+	return tr("time.relative_past", amount, tr("time.relative_unit."+unit)), true
+}
+
+// 格式化时长：1 秒以内显示毫秒，1 分钟以内保留一位小数，更长的按天、时、分、秒显示（省略为零的部分）
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d < time.Second:
+		return tr("time.milliseconds", sign, d.Round(time.Millisecond)/time.Millisecond)
+	case d < time.Minute:
+		return tr("time.seconds", sign, d.Seconds())
+	}
+
+	d = d.Round(time.Second)
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	var parts []string
+	for _, unit := range units {
+		n := d / unit.size
+		if n == 0 {
+			continue
+		}
+		d -= n * unit.size
+		parts = append(parts, fmt.Sprintf("%d%s", n, tr("time.unit."+unit.name)))
+	}
+	return sign + strings.Join(parts, tr("time.unit_separator"))
+}
+
+// 将字节数格式化为可读单位：默认 KiB/MiB/GiB 等二进制单位，ui.size_units 为 si 时使用 kB/MB/GB 十进制单位
+func formatBytes(n int64) string {
+	unit, suffix, prefixes := int64(1024), "iB", "KMGTPE"
+	if strings.EqualFold(configString("ui.size_units"), "si") {
+		unit, suffix, prefixes = 1000, "B", "kMGTPE"
+	}
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := unit, 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %c%s", float64(n)/float64(div), prefixes[exp], suffix)
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
-	"github.com/spf13/viper"
 )
 
 // 应用程序状态枚举
@@ -17,49 +20,76 @@ const (
 	Edit                   // 编辑状态，用于编辑连接信息等
 )
 
+// 内置模块列表，同时也是可用的模块类型（未配置 modules 时使用）
+var defaultModules = []string{"SSH", "MySQL", "PostgreSQL", "Redis"}
+
 // 应用程序主结构体，包含所有UI组件和状态信息
 type App struct {
-	app         *tview.Application // 主应用程序实例
-	grid        *tview.Grid        // 主Grid布局容器
-	moduleBar   *tview.TextView    // 顶部模块栏，显示模块选择
-	mainPanel   *tview.TextView    // 中间主面板，显示主要内容
-	statusBar   *tview.TextView    // 底部状态栏，显示当前状态信息
-	confirmBox  *tview.TextView    // 确认退出的文本框
-	confirmGrid *tview.Grid        // 确认对话框的网格布局
+	app          *tview.Application // 主应用程序实例
+	grid         *tview.Grid        // 主Grid布局容器
+	moduleBar    *tview.TextView    // 顶部模块栏，显示模块选择
+	mainPanel    *tview.Flex        // 中间主面板，显示概览或连接树
+	overview     *tview.TextView    // 主面板中的模块概览（非树状导航模式）
+	tree         *tview.TreeView    // 主面板中的连接树
+	treeHints    *tview.TextView    // 连接树下方的操作提示
+	body         *tview.Flex        // 中间区域，按布局排列主面板与侧边面板
+	detailPanel  *tview.TextView    // 侧边详情面板
+	sessionPanel *tview.TextView    // 侧边会话面板
+	watchBar     *tview.TextView    // 监视栏，显示钉住的连接
+	statusBar    *tview.TextView    // 底部状态栏，显示当前状态信息
+	clock        *tview.TextView    // 状态栏右侧的时钟（配置项 ui.clock）
+	statusRow    *tview.Flex        // 底部一行：状态栏与时钟
+	confirmBox   *tview.TextView    // 确认退出的文本框
+	confirmGrid  *tview.Grid        // 确认对话框的网格布局
 
 	// 应用程序状态
-	state          AppState // 当前应用状态（Normal或Edit）
-	modules        []string // 可用的模块列表
-	currentModule  int      // 当前选中的模块索引
-	hoveredModule  int      // 当前悬停的模块索引（键盘导航）
-	showingConfirm bool     // 是否正在显示确认对话框
+	state           AppState        // 当前应用状态（Normal或Edit）
+	modules         []string        // 可用的模块列表
+	currentModule   int             // 当前选中的模块索引
+	hoveredModule   int             // 当前悬停的模块索引（键盘导航）
+	showingConfirm  bool            // 是否正在显示确认对话框
+	overlays        []overlay       // 当前打开的覆盖层栈
+	diagnostics     *diagPanel      // 当前打开的诊断面板（nil表示未打开）
+	console         *queryConsole   // 当前打开的查询控制台（nil表示未打开）
+	browser         *fileBrowser    // 当前打开的远程文件浏览器（nil表示未打开）
+	lastExecCommand string          // 上一次执行的远程命令
+	lastAction      *repeatAction   // 可用 . 重复的上一个操作（nil表示没有）
+	pendingCount    int             // 树视图中已输入的计数前缀（0表示没有）
+	countSeq        int             // 计数前缀的输入序号，用于判断单独的 1-4 是否应切换布局
+	jumpBack        []jumpPosition  // 跳转历史：可以回退到的位置，最近的在末尾
+	jumpForward     []jumpPosition  // 跳转历史：回退后可以前进到的位置
+	toasts          []toast         // 右上角显示中的提醒提示框
+	ringBell        bool            // 下次绘制时响铃
+	pendingMark     rune            // 按下 m 或 ' 后等待标记字母（0表示没有）
+	panes           []*terminalPane // 内嵌终端中运行的 SSH 会话，按打开顺序
+	activePane      *terminalPane   // 主面板中显示的内嵌终端（nil表示未显示）
+	paneFocused     bool            // 按键是否发送给内嵌终端
+	layout          string          // 当前布局预设
+	zoomed          bool            // 是否放大了单个面板
 
 	// 树状结构导航状态
-	inTreeView      bool            // 是否进入了树状视图导航模式
-	selectedProject int             // 当前选中的项目索引
-	selectedEnv     int             // 当前选中的环境索引
-	selectedConn    int             // 当前选中的连接索引
-	treeLevel       int             // 当前所在的树级别 (0=项目, 1=环境, 2=连接)
-	expandedNodes   map[string]bool // 展开状态记录
+	inTreeView    bool                       // 是否进入了树状视图导航模式
+	selected      TreeNode                   // 选中节点（连接树当前节点的引用），重建连接树后按标识恢复
+	expandedNodes map[string]bool            // 展开的项目和环境，按节点标识记录
+	markedConns   map[string]bool            // 已标记的连接（用于批量操作），按连接标识记录
+	treeNodes     map[string]*tview.TreeNode // 连接树中的节点，按节点标识索引
+	treeShape     string                     // 连接树中可见节点的标识序列，变化时才重建节点
 }
 
 // 创建新的应用程序实例，初始化所有默认值
 func NewApp() *App {
 	return &App{
-		app:            tview.NewApplication(),                          // 创建tview应用实例
-		state:          Normal,                                          // 初始状态为Normal
-		modules:        []string{"SSH", "MySQL", "PostgreSQL", "Redis"}, // 定义可用模块列表
-		currentModule:  0,                                               // 默认选中第一个模块（SSH）
-		hoveredModule:  0,                                               // 默认悬停模块与选中模块一致
-		showingConfirm: false,                                           // 初始不显示确认对话框
+		app:            tview.NewApplication(), // 创建tview应用实例
+		state:          Normal,                 // 初始状态为Normal
+		modules:        configuredModules(),    // 定义可用模块列表
+		currentModule:  0,                      // 默认选中第一个模块（SSH）
+		hoveredModule:  0,                      // 默认悬停模块与选中模块一致
+		showingConfirm: false,                  // 初始不显示确认对话框
 
 		// 树状结构导航初始状态
-		inTreeView:      false,                 // 初始不在树状视图中
-		selectedProject: 0,                     // 默认选中第一个项目
-		selectedEnv:     0,                     // 默认选中第一个环境
-		selectedConn:    0,                     // 默认选中第一个连接
-		treeLevel:       0,                     // 初始在项目级别
-		expandedNodes:   make(map[string]bool), // 初始化展开状态映射
+		inTreeView:    false,                 // 初始不在树状视图中
+		expandedNodes: make(map[string]bool), // 初始化展开状态映射
+		markedConns:   make(map[string]bool), // 初始化连接标记映射
 	}
 }
 
@@ -84,20 +114,58 @@ func (a *App) initUI() {
 		SetRegions(true).
 		SetWrap(false).
 		SetScrollable(false)
-	a.moduleBar.SetBorder(true).SetTitle("模块选择").SetTitleAlign(tview.AlignLeft)
+	a.moduleBar.SetBorder(true).SetTitle(tr("title.modules")).SetTitleAlign(tview.AlignLeft)
+
+	// 创建中间主面板 - 概览模式显示模块概览，树状导航模式显示连接树与操作提示
+	a.overview = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetScrollable(true)
+	a.tree = tview.NewTreeView().
+		SetTopLevel(1).    // 隐藏根节点，项目作为第一层
+		SetGraphics(false) // 与原先的缩进风格一致，不画连线
+	a.tree.SetChangedFunc(a.handleTreeChanged)
+	// 左键只选中节点，不抢走模块栏的焦点（按键始终由全局处理器分发）
+	a.tree.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if action == tview.MouseLeftDown {
+			return action, nil
+		}
+		return action, event
+	})
+	a.treeHints = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	a.mainPanel = tview.NewFlex().SetDirection(tview.FlexRow)
+	a.mainPanel.SetBorder(true).SetTitle(tr("title.main")).SetTitleAlign(tview.AlignLeft)
 
-	// 创建中间主面板 - 显示主要内容
-	a.mainPanel = tview.NewTextView().
+	// 创建侧边详情面板与会话面板，按布局显示在主面板右侧
+	a.detailPanel = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetScrollable(true)
+	a.detailPanel.SetBorder(true).SetTitle(tr("title.details")).SetTitleAlign(tview.AlignLeft)
+	a.sessionPanel = tview.NewTextView().
 		SetDynamicColors(true).
 		SetWrap(true).
 		SetScrollable(true)
-	a.mainPanel.SetBorder(true).SetTitle("主要内容").SetTitleAlign(tview.AlignLeft)
+	a.sessionPanel.SetBorder(true).SetTitle(tr("title.sessions")).SetTitleAlign(tview.AlignLeft)
+	a.body = tview.NewFlex()
 
 	// 创建底部状态栏组件，用于显示应用程序状态信息
 	a.statusBar = tview.NewTextView().
 		SetDynamicColors(true).
-		SetText("准备就绪...")
-	a.statusBar.SetBorder(true).SetTitle("状态").SetTitleAlign(tview.AlignLeft)
+		SetText(tr("status.ready"))
+	a.statusBar.SetBorder(true).SetTitle(tr("title.status")).SetTitleAlign(tview.AlignLeft)
+
+	// 创建状态栏右侧的时钟，显示本机时间和选中 SSH 连接所在主机的当地时间
+	a.clock = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	a.clock.SetBorder(true).SetTitle(tr("title.clock")).SetTitleAlign(tview.AlignLeft)
+	a.statusRow = tview.NewFlex().AddItem(a.statusBar, 0, 1, false)
+	if clockEnabled() {
+		a.statusRow.AddItem(a.clock, 0, 0, false)
+	}
 
 	// 创建确认退出对话框的Grid布局 - 居中显示小框
 	a.confirmGrid = tview.NewGrid().
@@ -111,16 +179,21 @@ func (a *App) initUI() {
 		SetTextAlign(tview.AlignCenter).
 		SetWrap(false)
 	a.confirmBox.SetBorder(true).
-		SetTitle("确认退出").
+		SetTitle(tr("title.confirm_exit")).
 		SetTitleAlign(tview.AlignLeft).
 		SetBorderColor(tcell.ColorYellow)
 
 	// 将确认框添加到Grid中央
 	a.confirmGrid.AddItem(a.confirmBox, 1, 1, 1, 1, 0, 0, true)
 
-	// 使用Grid布局创建垂直三行布局
+	// 创建监视栏 - 显示钉住连接的状态与延迟，有钉住连接时显示在模块栏下方
+	a.watchBar = tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(false)
+	a.watchBar.SetBorder(true).SetTitle(tr("title.watch")).SetTitleAlign(tview.AlignLeft)
+
+	// 使用Grid布局创建垂直布局：模块栏、（监视栏、）主面板、状态栏
 	a.grid = tview.NewGrid().
-		SetRows(3, 0, 3). // 3行：模块栏(3行含边框), 主面板(占据剩余空间), 状态栏(3行含边框)
 		SetColumns(0).    // 1列：占据全部宽度
 		SetBorders(false) // 关闭Grid边框，使用各组件自己的边框
 
@@ -129,11 +202,10 @@ func (a *App) initUI() {
 	a.grid.SetTitleAlign(tview.AlignCenter)
 
 	// 添加组件到Grid（垂直排列）
-	a.grid.AddItem(a.moduleBar, 0, 0, 1, 1, 0, 0, true). // 模块栏：第0行，可聚焦
-								AddItem(a.mainPanel, 1, 0, 1, 1, 0, 0, false). // 主面板：第1行
-								AddItem(a.statusBar, 2, 0, 1, 1, 0, 0, false)  // 状态栏：第2行
+	a.arrangeGrid()
 
 	// 初始化更新界面内容
+	a.applyLayout(savedLayout(a.modules[a.currentModule]))
 	a.updateModuleBar()
 	a.updateMainPanel()
 	a.updateStatusBar()
@@ -143,6 +215,15 @@ func (a *App) initUI() {
 
 	// 设置全局键盘事件处理器，捕获用户的键盘输入
 	a.app.SetInputCapture(a.handleKeyEvent)
+	// 提醒提示框绘制在全部界面之上
+	a.app.SetAfterDrawFunc(a.drawToasts)
+
+	// 启用括号粘贴，粘贴内容整体送入输入框（密码框粘贴时不会逐键触发快捷键）
+	a.app.EnablePaste(true)
+
+	// 启用鼠标时右键主面板打开操作菜单
+	a.app.EnableMouse(mouseEnabled())
+	a.mainPanel.SetMouseCapture(a.handleMainPanelMouse)
 
 	// 设置根界面组件并启用全屏模式
 	a.app.SetRoot(a.grid, true)
@@ -152,6 +233,10 @@ func (a *App) initUI() {
 func (a *App) setInitialFocus() {
 	a.moduleBar.SetBorderColor(tcell.ColorYellow)
 	a.mainPanel.SetBorderColor(tcell.ColorWhite)
+	if a.paneFocused && a.activePane != nil {
+		a.app.SetFocus(a.activePane)
+		return
+	}
 	a.app.SetFocus(a.moduleBar)
 }
 
@@ -183,147 +268,259 @@ func (a *App) updateModuleBar() {
 func (a *App) updateMainPanel() {
 	currentModule := a.modules[a.currentModule]
 	// 更新主面板标题为当前选中的模块
-	a.mainPanel.SetTitle(fmt.Sprintf("%s 连接管理", currentModule))
-
-	if a.inTreeView {
-		content := a.renderTreeView()
-		a.mainPanel.SetText(content)
+	a.mainPanel.SetTitle(tr("main.title", currentModule))
+
+	a.mainPanel.Clear()
+	if a.inTreeView && a.activePane != nil {
+		// 内嵌终端占据主面板，连接树缩为左侧栏
+		a.renderTree()
+		a.treeHints.SetText(a.renderTreeHints())
+		sidebar := tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(a.tree, 0, 1, false).
+			AddItem(a.treeHints, 3, 0, false)
+		a.mainPanel.AddItem(tview.NewFlex().
+			AddItem(sidebar, paneSidebarWidth, 0, false).
+			AddItem(a.activePane, 0, 1, false), 0, 1, false)
+	} else if a.inTreeView {
+		a.renderTree()
+		a.treeHints.SetText(a.renderTreeHints())
+		a.mainPanel.AddItem(a.tree, 0, 1, false).
+			AddItem(a.treeHints, 3, 0, false)
 	} else {
-		content := a.renderOverview()
-		a.mainPanel.SetText(content)
+		a.overview.SetText(a.renderOverview())
+		a.mainPanel.AddItem(a.overview, 0, 1, false)
 	}
+	a.updateSidePanels()
 }
 
 // 渲染概览视图（非树状导航模式）
 func (a *App) renderOverview() string {
 	currentModule := a.modules[a.currentModule]
-	content := fmt.Sprintf("[yellow]%s 连接管理概览[-]\n\n", currentModule)
-	content += "按 [white:blue]Enter[-] 或 [white:blue]Space[-] 进入树状导航模式\n\n"
+	content := tr("overview.title", currentModule)
+	content += tr("overview.enter")
 
-	switch currentModule {
-	case "SSH":
-		content += "📁 可用项目:\n"
-		content += "  • Web服务器项目 (3个环境, 9个连接)\n"
-		content += "  • 数据库项目 (2个环境, 6个连接)\n"
-		content += "  • 开发环境项目 (2个环境, 4个连接)\n\n"
-	case "MySQL":
-		content += "📁 可用项目:\n"
-		content += "  • 生产数据库 (3个环境, 9个实例)\n"
-		content += "  • 分析数据库 (2个环境, 6个实例)\n"
-		content += "  • 测试数据库 (1个环境, 3个实例)\n\n"
-	case "PostgreSQL":
-		content += "📁 可用项目:\n"
-		content += "  • 主业务数据库 (3个环境, 9个实例)\n"
-		content += "  • 报表数据库 (2个环境, 6个实例)\n"
-		content += "  • 备份数据库 (1个环境, 3个实例)\n\n"
-	case "Redis":
-		content += "📁 可用项目:\n"
-		content += "  • 缓存集群 (3个环境, 9个实例)\n"
-		content += "  • 会话存储 (2个环境, 6个实例)\n"
-		content += "  • 消息队列 (2个环境, 4个实例)\n\n"
+	// 项目：名称、环境数、连接（实例）数
+	type overviewProject struct {
+		name        string
+		envs, conns int
+	}
+	var projects []overviewProject
+	for _, project := range projectList(currentModule) {
+		item := overviewProject{name: project.Name}
+		for _, env := range projectEnvironments(currentModule, project.Name) {
+			item.envs++
+			item.conns += len(envConnections(currentModule, project.Name, env.Name))
+		}
+		projects = append(projects, item)
+	}
+	unit := "overview.project_instances"
+	if moduleType(currentModule) == "SSH" {
+		unit = "overview.project_connections"
+	}
+	if len(projects) > 0 {
+		content += tr("overview.projects")
+		for _, project := range projects {
+			content += tr(unit, project.name, project.envs, project.conns)
+		}
+		content += "\n"
 	}
 
-	content += "[dim]按 Enter 进入树状导航，在树状模式中可以管理具体的连接[-]"
+	content += tr("overview.footer")
 	return content
 }
 
-// 渲染树状视图
-func (a *App) renderTreeView() string {
+// 连接树中的一行：节点引用、显示文字和展开状态
+type treeItem struct {
+	ref      TreeNode
+	text     string
+	expanded bool
+}
+
+// 按数据模型刷新连接树：可见节点的标识序列不变时沿用原有节点只更新文字，否则重建；
+// 选中节点按标识恢复，节点已被删除或改名时选中原位置上的节点，不可见时选中最近的可见上级
+func (a *App) renderTree() {
 	currentModule := a.modules[a.currentModule]
-	content := fmt.Sprintf("[yellow]%s 树状导航模式[-]\n\n", currentModule)
+	var items []treeItem
+
+	// 展开的连接交给后台健康检查
+	var visible []connTarget
+	defer func() { setHealthTargets(visible) }()
+
+	alerts := loadAlerts()
+	for _, project := range a.getProjectList() {
+		// 收起的项目在其下连接状态变化时闪烁
+		projectNode := TreeNode{Level: 0, Module: currentModule, Project: project.Name}
+		projectID := projectNode.ID()
+		isProjectExpanded := a.expandedNodes[projectID]
+		items = append(items, treeItem{
+			ref:      projectNode,
+			text:     fmt.Sprintf("%s %s", expandIcon(isProjectExpanded), flashName(project.Name, !isProjectExpanded && flashOn(projectID+"/"))),
+			expanded: isProjectExpanded,
+		})
+		if !isProjectExpanded {
+			continue
+		}
 
-	// 获取项目列表
-	projects := a.getProjectList()
+		for _, env := range a.getEnvironmentList(project.Name) {
+			envNode := TreeNode{Level: 1, Module: currentModule, Project: project.Name, Env: env.Name}
+			envID := envNode.ID()
+			isEnvExpanded := a.expandedNodes[envID]
+			vpnText := vpnStatusText(connTarget{Module: currentModule, Project: project.Name, Env: env.Name})
+			envFlash := !isEnvExpanded && flashOn(envID+"/")
+			items = append(items, treeItem{
+				ref:      envNode,
+				text:     fmt.Sprintf("%s %s%s", expandIcon(isEnvExpanded), flashName(env.Name, envFlash), vpnText),
+				expanded: isEnvExpanded,
+			})
+			if !isEnvExpanded {
+				continue
+			}
 
-	for i, project := range projects {
-		// 左侧箭头指示器（始终在最左侧）
-		arrowIndicator := ""
-		if a.treeLevel == 0 && i == a.selectedProject {
-			arrowIndicator = "[yellow]►[-] "
-		} else {
-			arrowIndicator = "  "
-		}
+			for _, conn := range a.getConnectionList(project.Name, env.Name) {
+				statusColor, statusText := connectionStatusStyle(conn.Status)
 
-		// 项目展开状态
-		projectKey := fmt.Sprintf("%s-proj-%d", currentModule, i)
-		isProjectExpanded := a.expandedNodes[projectKey]
-		expandIcon := "+"
-		if isProjectExpanded {
-			expandIcon = "-"
-		}
+				maintenanceText := ""
+				target := connTarget{Module: currentModule, Project: project.Name, Env: env.Name, Conn: conn}
+				visible = append(visible, target)
+				statusText += healthLatencyText(target, conn.Status)
+				if window, ok := inMaintenance(target, time.Now()); ok {
+					maintenanceText = tr("tree.maintenance", window.Name)
+				}
 
-		content += fmt.Sprintf("%s\t[%s] %s\n", arrowIndicator, expandIcon, project.Name)
-
-		// 如果项目展开，显示环境
-		if isProjectExpanded {
-			environments := a.getEnvironmentList(i)
-			for j, env := range environments {
-				// 左侧箭头指示器（始终在最左侧）
-				arrowIndicator := ""
-				if a.treeLevel == 1 && i == a.selectedProject && j == a.selectedEnv {
-					arrowIndicator = "[yellow]►[-] "
-				} else {
-					arrowIndicator = "  "
+				markIndicator := ""
+				if a.markedConns[target.ID()] {
+					markIndicator = "[green]*[-]"
 				}
 
-				// 环境展开状态
-				envKey := fmt.Sprintf("%s-proj-%d-env-%d", currentModule, i, j)
-				isEnvExpanded := a.expandedNodes[envKey]
-				envExpandIcon := "+"
-				if isEnvExpanded {
-					envExpandIcon = "-"
+				// 连接单独要求了与环境不同的 VPN 时在连接行提示
+				connVPNText := ""
+				if text := vpnStatusText(target); text != vpnText {
+					connVPNText = text
 				}
 
-				content += fmt.Sprintf("%s\t\t[%s] %s\n", arrowIndicator, envExpandIcon, env.Name)
-
-				// 如果环境展开，显示连接
-				if isEnvExpanded {
-					connections := a.getConnectionList(i, j)
-					for k, conn := range connections {
-						// 左侧箭头指示器（始终在最左侧）
-						connArrowIndicator := ""
-						if a.treeLevel == 2 && i == a.selectedProject && j == a.selectedEnv && k == a.selectedConn {
-							connArrowIndicator = "[yellow]►[-] "
-						} else {
-							connArrowIndicator = "  "
-						}
-
-						statusColor := "green"
-						statusText := "已连接"
-						switch conn.Status {
-						case "connected":
-							statusColor = "green"
-							statusText = "已连接"
-						case "disconnected":
-							statusColor = "red"
-							statusText = "断开"
-						case "connecting":
-							statusColor = "yellow"
-							statusText = "连接中"
-						}
-
-						content += fmt.Sprintf("%s\t\t\t%s ([%s]%s[-])\n", connArrowIndicator, conn.Name, statusColor, statusText)
-					}
+				// 打开过会话的连接显示会话标签编号
+				tabText := ""
+				if number := sessionTabNumber(target); number > 0 {
+					tabText = fmt.Sprintf(" [blue]#%d[-]", number)
+				}
+				// 开启了变化提醒的连接
+				if slices.Contains(alerts, target.ID()) {
+					tabText += tr("tree.alert")
 				}
+
+				items = append(items, treeItem{
+					ref:  TreeNode{Level: 2, Module: currentModule, Project: project.Name, Env: env.Name, Conn: conn.Name},
+					text: fmt.Sprintf("%s%s ([%s]%s[-])%s%s%s", markIndicator, flashName(conn.Name, flashOn(target.ID())), statusColor, statusText, tabText, maintenanceText, connVPNText),
+				})
 			}
 		}
 	}
 
-	// 添加操作提示
-	content += "\n[dim]"
-	switch a.treeLevel {
-	case 0:
-		content += "项目级别 - ↑↓/JK: 导航, Space: 展开/收缩, ESC/Q: 退出"
-	case 1:
-		content += "环境级别 - ↑↓/JK: 导航, Space: 展开/收缩, ESC/Q: 退出"
-	case 2:
-		content += "连接级别 - ↑↓/JK: 导航, Enter: 连接/断开, ESC/Q: 退出"
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ref.ID()
+	}
+	previous := strings.Split(a.treeShape, "\n")[1:] // 上次的可见节点（第一行为模块）
+	if shape := currentModule + "\n" + strings.Join(ids, "\n"); shape != a.treeShape {
+		a.treeShape = shape
+		a.treeNodes = make(map[string]*tview.TreeNode, len(items))
+		root := tview.NewTreeNode(currentModule)
+		parents := []*tview.TreeNode{root}
+		for _, item := range items {
+			node := tview.NewTreeNode("")
+			parents = parents[:item.ref.Level+1]
+			parents[item.ref.Level].AddChild(node)
+			parents = append(parents, node)
+			a.treeNodes[item.ref.ID()] = node
+		}
+		a.tree.SetRoot(root)
+	}
+	for i, item := range items {
+		a.treeNodes[ids[i]].SetText(item.text).SetReference(item.ref).SetExpanded(item.expanded)
+	}
+
+	current := a.treeNodes[a.selected.ID()]
+	if i := slices.Index(previous, a.selected.ID()); current == nil && i >= 0 && len(ids) > 0 {
+		current = a.treeNodes[ids[min(i, len(ids)-1)]]
+	}
+	for node := a.selected; current == nil && node.Level > 0; {
+		node = node.parent()
+		current = a.treeNodes[node.ID()]
+	}
+	if current == nil && len(ids) > 0 {
+		current = a.treeNodes[ids[0]]
+	}
+	a.tree.SetCurrentNode(current)
+	a.selected = TreeNode{}
+	if current != nil {
+		a.selected = current.GetReference().(TreeNode)
+	}
+}
+
+// 节点展开状态图标
+func expandIcon(expanded bool) string {
+	if expanded {
+		return "[-[]"
+	}
+	return "[+[]"
+}
+
+// 连接树中选中的节点变化（方向键、鼠标点击）时同步选中节点
+func (a *App) handleTreeChanged(node *tview.TreeNode) {
+	ref, ok := node.GetReference().(TreeNode)
+	if !ok {
+		return
+	}
+	if ref == a.selected {
+		return
+	}
+	a.setCurrentNode(ref)
+	a.treeHints.SetText(a.renderTreeHints())
+	a.updateSidePanels()
+	a.updateStatusBar()
+}
+
+// 连接树下方的操作提示
+func (a *App) renderTreeHints() string {
+	content := "[dim]"
+	switch {
+	case a.state == Edit:
+		content += tr("hints.edit")
+	case a.selected.Level == 0:
+		content += tr("hints.project")
+	case a.selected.Level == 1:
+		content += tr("hints.env")
+	case a.selected.Level == 2:
+		content += tr("hints.connection")
 	}
 	content += "[-]"
 
 	return content
 }
 
+// 连接状态的显示颜色与文字
+func connectionStatusStyle(status string) (color, text string) {
+	switch status {
+	case "disconnected":
+		return "red", tr("conn.disconnected")
+	case "connecting":
+		return "yellow", tr("conn.connecting")
+	case "failed":
+		return "red", tr("conn.failed")
+	case "detached":
+		return "teal", tr("conn.detached")
+	case "online":
+		return "green", tr("conn.online")
+	case "offline":
+		return "red", tr("conn.offline")
+	case "unknown":
+		return "gray", tr("conn.unknown")
+	case "unchecked":
+		return "gray", tr("conn.unchecked")
+	}
+	return "green", tr("conn.connected")
+}
+
 // 项目数据结构
 type Project struct {
 	Name string
@@ -334,14 +531,51 @@ type Environment struct {
 }
 
 type Connection struct {
-	Name   string
-	Status string
+	Name         string
+	Status       string
+	Host         string   // 主机地址
+	Port         int      // 端口
+	User         string   // 用户名
+	Database     string   // 数据库名（MySQL/PostgreSQL）或库编号（Redis）
+	IdentityFile string   // SSH 私钥文件
+	ProxyCommand string   // SSH ProxyCommand 模板
+	JumpHost     string   // SSH 跳板连接（连接标识），跳板自身也可以设置跳板形成多级跳转
+	Addresses    []string // 备用地址（副本），形如 host 或 host:port，连接时按延迟自动选择
+	Tags         []string // 标签
+	Auth         string   // SSH 认证方式：key、password、agent、certificate，为空时有密钥文件即用密钥
+	Certificate  string   // SSH 证书文件（认证方式为 certificate 时使用）
+	TLS          bool     // 数据库连接启用 TLS
+	TLSCA        string   // TLS CA 证书
+	TLSCert      string   // TLS 客户端证书
+	TLSKey       string   // TLS 客户端密钥
+	SSHTunnel    string   // 数据库连接经由的 SSH 连接（连接标识），客户端通过本地端口转发访问
 }
 
 // 获取项目列表
 func (a *App) getProjectList() []Project {
-	currentModule := a.modules[a.currentModule]
-	switch currentModule {
+	return projectList(a.modules[a.currentModule])
+}
+
+// 获取指定模块的项目列表：示例项目、Mesh 项目和清单中的项目（数据模型见 loadStore）
+func projectList(module string) []Project {
+	mesh := moduleType(module) == "SSH" && hasMeshPeers()
+	var projects []Project
+	for _, project := range loadStore(module).Projects {
+		if mesh && !project.Demo {
+			projects = append(projects, Project{Name: meshProjectName})
+			mesh = false
+		}
+		projects = append(projects, Project{Name: project.Name})
+	}
+	if mesh {
+		projects = append(projects, Project{Name: meshProjectName})
+	}
+	return projects
+}
+
+// 获取模块类型的示例项目列表
+func builtinProjects(module string) []Project {
+	switch moduleType(module) {
 	case "SSH":
 		return []Project{
 			{Name: "Web服务器项目"},
@@ -370,9 +604,39 @@ func (a *App) getProjectList() []Project {
 	return []Project{}
 }
 
-// 获取环境列表
-func (a *App) getEnvironmentList(projectIndex int) []Environment {
-	if projectIndex == 2 { // 第三个项目只有1个环境
+// 获取当前模块中项目的环境列表
+func (a *App) getEnvironmentList(project string) []Environment {
+	return projectEnvironments(a.modules[a.currentModule], project)
+}
+
+// 获取项目的环境列表：示例环境和清单中的环境
+func projectEnvironments(module, project string) []Environment {
+	if project == meshProjectName {
+		return []Environment{{Name: meshEnvName}}
+	}
+	var envs []Environment
+	for _, p := range loadStore(module).Projects {
+		if p.Name == project {
+			for _, env := range p.Envs {
+				envs = append(envs, Environment{Name: env.Name})
+			}
+		}
+	}
+	return envs
+}
+
+// 获取示例项目的环境列表，配置清单中的项目没有示例环境
+func builtinEnvironments(module, project string) []Environment {
+	if project == meshProjectName { // 自动生成的 Mesh 网络项目
+		return []Environment{{Name: meshEnvName}}
+	}
+	if !demoDataEnabled() {
+		return nil
+	}
+	switch slices.Index(builtinProjects(module), Project{Name: project}) {
+	case -1:
+		return nil
+	case 2: // 第三个项目只有1个环境
 		return []Environment{{Name: "开发环境"}}
 	}
 	return []Environment{
@@ -381,20 +645,59 @@ func (a *App) getEnvironmentList(projectIndex int) []Environment {
 	}
 }
 
-// 获取连接列表
-func (a *App) getConnectionList(projectIndex, envIndex int) []Connection {
-	currentModule := a.modules[a.currentModule]
-	baseConnections := []Connection{
-		{Name: fmt.Sprintf("%s-01", currentModule), Status: "connected"},
-		{Name: fmt.Sprintf("%s-02", currentModule), Status: "disconnected"},
-		{Name: fmt.Sprintf("%s-03", currentModule), Status: "connecting"},
+// 获取当前模块中环境下的连接列表：示例连接、清单中的连接、新增和发现的连接
+func (a *App) getConnectionList(project, env string) []Connection {
+	return envConnections(a.modules[a.currentModule], project, env)
+}
+
+// 获取模块的默认端口（可由 module_settings 覆盖）
+func defaultPort(module string) int {
+	if port := settingsFor(module).Port; port > 0 {
+		return port
 	}
-	return baseConnections
+	switch moduleType(module) {
+	case "SSH":
+		return 22
+	case "MySQL":
+		return 3306
+	case "PostgreSQL":
+		return 5432
+	case "Redis":
+		return 6379
+	}
+	return 0
+}
+
+// 获取模块的默认用户名（可由 module_settings 覆盖）
+func defaultUser(module string) string {
+	if user := settingsFor(module).User; user != "" {
+		return user
+	}
+	switch moduleType(module) {
+	case "SSH", "MySQL":
+		return "root"
+	case "PostgreSQL":
+		return "postgres"
+	}
+	return ""
+}
+
+// 获取当前选中的连接（仅在连接级别有效）
+func (a *App) currentConnection() (Connection, bool) {
+	if !a.inTreeView || a.selected.Level != 2 {
+		return Connection{}, false
+	}
+	for _, conn := range a.getConnectionList(a.selected.Project, a.selected.Env) {
+		if conn.Name == a.selected.Conn {
+			return conn, true
+		}
+	}
+	return Connection{}, false
 }
 
 // 更新确认对话框显示
 func (a *App) updateConfirmBox() {
-	content := "\n[yellow]确定要退出程序吗？[-]\n\n"
+	content := tr("confirm.exit")
 	content += "[green]Yes (Y)[-]    [red]No (N)[-]\n"
 
 	a.confirmBox.SetText(content)
@@ -410,16 +713,14 @@ func (a *App) updateStatusBar() {
 
 	var statusText string
 	if a.inTreeView {
-		levelNames := []string{"项目", "环境", "连接"}
-		currentLevel := levelNames[a.treeLevel]
-		statusText = fmt.Sprintf("[yellow]状态: %s[-] | [blue]模块: %s[-] | [green]层级: %s[-] | [gray]↑↓/JK: 导航, Space: 展开/收缩, ESC: 退出[-]",
-			stateText, a.modules[a.currentModule], currentLevel)
+		levelNames := []string{"level.project", "level.env", "level.connection"}
+		statusText = tr("status.tree", stateText, a.modules[a.currentModule], tr(levelNames[a.selected.Level]))
 	} else {
-		statusText = fmt.Sprintf("[yellow]状态: %s[-] | [blue]当前模块: %s[-] | [green]悬停: %s[-] | [gray]←→/H/L: 导航, Enter/Space: 选择, Q: 退出[-]",
-			stateText, a.modules[a.currentModule], a.modules[a.hoveredModule])
+		statusText = tr("status.modules", stateText, a.modules[a.currentModule], a.modules[a.hoveredModule])
 	}
 
 	a.statusBar.SetText(statusText)
+	a.updateClock()
 }
 
 // 处理键盘事件
@@ -440,9 +741,73 @@ func (a *App) handleKeyEvent(event *tcell.EventKey) *tcell.EventKey {
 		return event
 	}
 
-	// 正常模式下的按键处理
-	if a.state != Normal {
-		return event
+	// 内嵌终端获得焦点时按键全部发送给终端，Ctrl+] 切回连接树
+	if a.paneFocused && a.activePane != nil && len(a.overlays) == 0 {
+		if event.Key() == tcell.KeyCtrlRightSq {
+			a.setPaneFocus(false)
+			return nil
+		}
+		a.activePane.sendKey(event)
+		return nil
+	}
+
+	// 如果正在显示覆盖层，交由最上层覆盖层处理
+	if len(a.overlays) > 0 {
+		return a.overlays[len(a.overlays)-1].handler(event)
+	}
+
+	// Alt+Z 放大或还原当前面板，放大时 ESC 先还原（覆盖层打开时不处理）
+	if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 && (event.Rune() == 'z' || event.Rune() == 'Z') {
+		a.toggleZoom()
+		return nil
+	}
+	if a.zoomed && event.Key() == tcell.KeyEsc {
+		a.restoreZoom()
+		return nil
+	}
+
+	// Alt+数字切换会话标签（先于布局切换处理）
+	if a.state == Normal && a.handleSessionSwitch(event) {
+		return nil
+	}
+
+	// 编辑模式下的按键处理
+	if a.state == Edit {
+		return a.handleEditKeys(event)
+	}
+
+	// Ctrl+P 打开命令面板
+	if event.Key() == tcell.KeyCtrlP {
+		a.showPalette("")
+		return nil
+	}
+
+	// Ctrl+O / Ctrl+I（终端中与 Tab 相同）在跳转历史中回退、前进
+	if event.Key() == tcell.KeyCtrlO {
+		a.jumpOlder()
+		return nil
+	}
+	if event.Key() == tcell.KeyTab {
+		a.jumpNewer()
+		return nil
+	}
+
+	// Ctrl+] 把按键切换到主面板中的内嵌终端
+	if event.Key() == tcell.KeyCtrlRightSq && a.inTreeView && a.activePane != nil {
+		a.setPaneFocus(true)
+		return nil
+	}
+
+	// F2 打开会话管理面板
+	if event.Key() == tcell.KeyF2 {
+		a.showSessionManager()
+		return nil
+	}
+
+	// 数字键 1-4 切换布局预设（树视图中数字先作为计数前缀，见 motion.go）
+	if !a.inTreeView && event.Key() == tcell.KeyRune && event.Rune() >= '1' && event.Rune() <= '4' {
+		a.switchLayout(int(event.Rune() - '1'))
+		return nil
 	}
 
 	if a.inTreeView {
@@ -474,6 +839,24 @@ func (a *App) handleKeyEvent(event *tcell.EventKey) *tcell.EventKey {
 			case 'q', 'Q':
 				a.showExitConfirmation()
 				return nil
+			case 'w', 'W':
+				a.showWorkspaceSwitcher()
+				return nil
+			case 'r', 'R':
+				a.showStagedReview()
+				return nil
+			case 'd', 'D':
+				a.showLANScanForm()
+				return nil
+			case 't', 'T':
+				a.showTunnels()
+				return nil
+			case 'i', 'I':
+				a.showSSHConfigImport()
+				return nil
+			case 's', 'S':
+				a.showStateImport()
+				return nil
 			}
 		}
 	}
@@ -484,6 +867,7 @@ func (a *App) handleKeyEvent(event *tcell.EventKey) *tcell.EventKey {
 // 显示退出确认对话框
 func (a *App) showExitConfirmation() {
 	a.showingConfirm = true
+	a.zoomed = false
 	a.updateConfirmBox()
 	a.app.SetRoot(a.confirmGrid, true)
 }
@@ -512,12 +896,11 @@ func (a *App) moveToNextHover() {
 
 // 进入树状视图
 func (a *App) enterTreeView() {
+	a.recordJump()
 	a.currentModule = a.hoveredModule
 	a.inTreeView = true
-	a.treeLevel = 0
-	a.selectedProject = 0
-	a.selectedEnv = 0
-	a.selectedConn = 0
+	a.setCurrentNode(a.firstNode())
+	a.applyLayout(savedLayout(a.modules[a.currentModule]))
 	a.updateMainPanel()
 	a.updateStatusBar()
 	a.updateModuleBar()
@@ -531,33 +914,143 @@ func (a *App) exitTreeView() {
 
 // 处理树状视图中的键盘导航
 func (a *App) handleTreeNavigation(event *tcell.EventKey) *tcell.EventKey {
-	switch event.Key() {
-	case tcell.KeyUp:
-		a.moveTreeUp()
+	// m 或 ' 之后的标记字母
+	if a.handleMarkKey(event) {
 		return nil
-	case tcell.KeyDown:
-		a.moveTreeDown()
+	}
+	// 计数前缀（如 5j），作用于紧随其后的移动按键，其他按键会丢弃计数
+	if a.handleCountKey(event) {
 		return nil
+	}
+	if a.handleTreeMotion(event, a.takeCount()) {
+		return nil
+	}
+	// 组合键（如 g g、<leader> t）的前缀优先于同名的单键快捷键
+	if key := chordKeyName(event); key != "" && a.advanceChord([]string{key}) {
+		return nil
+	}
+	switch event.Key() {
 	case tcell.KeyEsc:
 		a.exitTreeView()
 		return nil
 	case tcell.KeyEnter:
 		a.activateTreeItem()
 		return nil
+	case tcell.KeyCtrlE:
+		a.toggleEditMode()
+		return nil
 	case tcell.KeyRune:
 		switch event.Rune() {
-		case 'k', 'K':
-			a.moveTreeUp()
-			return nil
-		case 'j', 'J':
-			a.moveTreeDown()
-			return nil
 		case 'q', 'Q':
 			a.exitTreeView()
 			return nil
 		case ' ':
 			a.toggleExpansion()
 			return nil
+		case 'i', 'I':
+			a.showDiagnostics()
+			return nil
+		case 'v', 'V':
+			a.toggleMark()
+			return nil
+		case 'x', 'X':
+			a.runSQLFile()
+			return nil
+		case 'c', 'C':
+			a.showQueryConsole()
+			return nil
+		case 'G':
+			// 小写 g 是组合键前缀（见 chords.go）
+			a.showDependencyGraph()
+			return nil
+		case 'r', 'R':
+			a.showTransferRecipes()
+			return nil
+		case 'f', 'F':
+			a.showHostFileForm()
+			return nil
+		case 'b', 'B':
+			a.showFileBrowser()
+			return nil
+		case 'a':
+			a.addTreeNode()
+			return nil
+		case 'e':
+			a.editTreeNode()
+			return nil
+		case 'd':
+			a.deleteTreeNode()
+			return nil
+		case 'E':
+			// 小写 e 用于编辑选中节点
+			a.showExecPrompt()
+			return nil
+		case 'w', 'W':
+			a.showGUIApps()
+			return nil
+		case 'M':
+			// 小写 m 用于设置书签（见 bookmarks.go）
+			a.showBulkEditForm()
+			return nil
+		case 'm', '\'':
+			a.startMark(event.Rune())
+			return nil
+		case 's', 'S':
+			a.showRegexReplaceForm()
+			return nil
+		case 'p', 'P':
+			a.showInventoryReport()
+			return nil
+		case 'o', 'O':
+			a.showPromoteForm()
+			return nil
+		case 'D':
+			// 小写 d 用于删除选中节点
+			if a.selected.Level >= 1 {
+				a.showEnvironmentDiff()
+			}
+			return nil
+		case 'n', 'N':
+			if a.selected.Level >= 1 {
+				a.showNewConnectionForm(Connection{}, "")
+			}
+			return nil
+		case 'A':
+			// 小写 a 用于添加同级节点
+			if a.selected.Level >= 1 {
+				a.pasteToAdd()
+			}
+			return nil
+		case 't', 'T':
+			a.showNotes()
+			return nil
+		case 'u', 'U':
+			a.togglePin()
+			return nil
+		case 'z', 'Z':
+			a.toggleZoom()
+			return nil
+		case 'y', 'Y':
+			a.showScrollback()
+			return nil
+		case 'l', 'L':
+			a.showSecretForm()
+			return nil
+		case '?':
+			a.showActionMenu()
+			return nil
+		case ';':
+			a.jumpToLastChanged()
+			return nil
+		case '^':
+			a.toggleAlert()
+			return nil
+		case '!':
+			a.showLastFailure()
+			return nil
+		case '.':
+			a.repeatLastAction()
+			return nil
 		}
 	}
 	return event
@@ -565,39 +1058,40 @@ func (a *App) handleTreeNavigation(event *tcell.EventKey) *tcell.EventKey {
 
 // 在树状视图中向上移动
 func (a *App) moveTreeUp() {
-	// 找到上一个可见的节点，不考虑层级
-	a.moveToPreviousVisibleNode()
-	a.updateMainPanel()
+	a.moveTree(-1)
 }
 
 // 在树状视图中向下移动
 func (a *App) moveTreeDown() {
-	// 找到下一个可见的节点，不考虑层级
-	a.moveToNextVisibleNode()
-	a.updateMainPanel()
+	a.moveTree(1)
+}
+
+// 在可见节点间移动选中位置（不考虑层级），选中节点始终保持在可视区域内；
+// 只移动现有节点的选中状态，由 handleTreeChanged 同步选中节点，不重建连接树
+func (a *App) moveTree(offset int) {
+	a.tree.Move(offset)
 }
 
 // 展开节点或向下移动层级（保留，但不在键盘导航中使用）
 func (a *App) expandOrMoveDown() {
-	switch a.treeLevel {
+	node := a.selected
+	switch node.Level {
 	case 0: // 从项目进入环境
 		// 展开当前项目
-		projectKey := fmt.Sprintf("%s-proj-%d", a.modules[a.currentModule], a.selectedProject)
-		a.expandedNodes[projectKey] = true
+		a.expandedNodes[node.ID()] = true
 
-		if len(a.getEnvironmentList(a.selectedProject)) > 0 {
-			a.treeLevel = 1
-			a.selectedEnv = 0
+		if envs := a.getEnvironmentList(node.Project); len(envs) > 0 {
+			node.Level, node.Env = 1, envs[0].Name
+			a.setCurrentNode(node)
 			a.updateMainPanel()
 		}
 	case 1: // 从环境进入连接
 		// 展开当前环境
-		envKey := fmt.Sprintf("%s-proj-%d-env-%d", a.modules[a.currentModule], a.selectedProject, a.selectedEnv)
-		a.expandedNodes[envKey] = true
+		a.expandedNodes[node.ID()] = true
 
-		if len(a.getConnectionList(a.selectedProject, a.selectedEnv)) > 0 {
-			a.treeLevel = 2
-			a.selectedConn = 0
+		if conns := a.getConnectionList(node.Project, node.Env); len(conns) > 0 {
+			node.Level, node.Conn = 2, conns[0].Name
+			a.setCurrentNode(node)
 			a.updateMainPanel()
 		}
 	}
@@ -605,150 +1099,228 @@ func (a *App) expandOrMoveDown() {
 
 // 切换节点展开状态
 func (a *App) toggleExpansion() {
-	switch a.treeLevel {
-	case 0: // 项目级别
-		projectKey := fmt.Sprintf("%s-proj-%d", a.modules[a.currentModule], a.selectedProject)
-		a.expandedNodes[projectKey] = !a.expandedNodes[projectKey]
-	case 1: // 环境级别
-		envKey := fmt.Sprintf("%s-proj-%d-env-%d", a.modules[a.currentModule], a.selectedProject, a.selectedEnv)
-		a.expandedNodes[envKey] = !a.expandedNodes[envKey]
+	// 项目和环境可以展开，连接没有子节点
+	if id := a.selected.ID(); a.selected.Level <= 1 && id != "" {
+		a.expandedNodes[id] = !a.expandedNodes[id]
 	}
 	a.updateMainPanel()
 }
 
-// 移动到上一个可见的节点
-func (a *App) moveToPreviousVisibleNode() {
-	// 构建所有可见节点的列表
-	visibleNodes := a.getVisibleNodes()
-
-	// 找到当前节点在列表中的位置
-	currentIndex := a.findCurrentNodeIndex(visibleNodes)
-
-	// 移动到上一个节点
-	if currentIndex > 0 {
-		prevNode := visibleNodes[currentIndex-1]
-		a.setCurrentNode(prevNode)
-	}
+// 连接树节点的引用：按名称定位，增删、改名其他节点后仍指向同一节点；项目为空表示没有选中节点
+type TreeNode struct {
+	Level   int // 0=项目, 1=环境, 2=连接
+	Module  string
+	Project string
+	Env     string // 环境名称（环境和连接节点）
+	Conn    string // 连接名称（连接节点）
 }
 
-// 移动到下一个可见的节点
-func (a *App) moveToNextVisibleNode() {
-	// 构建所有可见节点的列表
-	visibleNodes := a.getVisibleNodes()
-
-	// 找到当前节点在列表中的位置
-	currentIndex := a.findCurrentNodeIndex(visibleNodes)
-
-	// 移动到下一个节点
-	if currentIndex < len(visibleNodes)-1 {
-		nextNode := visibleNodes[currentIndex+1]
-		a.setCurrentNode(nextNode)
+// 节点标识：模块/项目[/环境[/连接]]，没有选中节点时为空
+func (n TreeNode) ID() string {
+	if n.Project == "" {
+		return ""
+	}
+	id := n.Module + "/" + n.Project
+	if n.Level >= 1 {
+		id += "/" + n.Env
+	}
+	if n.Level >= 2 {
+		id += "/" + n.Conn
 	}
+	return id
 }
 
-// 节点表示结构
-type TreeNode struct {
-	Level   int // 0=项目, 1=环境, 2=连接
-	Project int
-	Env     int
-	Conn    int
-}
-
-// 获取所有可见的节点
-func (a *App) getVisibleNodes() []TreeNode {
-	var nodes []TreeNode
-	projects := a.getProjectList()
-
-	for i := range projects {
-		// 添加项目节点
-		nodes = append(nodes, TreeNode{Level: 0, Project: i, Env: -1, Conn: -1})
-
-		// 检查项目是否展开
-		projectKey := fmt.Sprintf("%s-proj-%d", a.modules[a.currentModule], i)
-		if a.expandedNodes[projectKey] {
-			environments := a.getEnvironmentList(i)
-			for j := range environments {
-				// 添加环境节点
-				nodes = append(nodes, TreeNode{Level: 1, Project: i, Env: j, Conn: -1})
-
-				// 检查环境是否展开
-				envKey := fmt.Sprintf("%s-proj-%d-env-%d", a.modules[a.currentModule], i, j)
-				if a.expandedNodes[envKey] {
-					connections := a.getConnectionList(i, j)
-					for k := range connections {
-						// 添加连接节点
-						nodes = append(nodes, TreeNode{Level: 2, Project: i, Env: j, Conn: k})
-					}
-				}
-			}
-		}
+// 上一级节点，项目节点返回自身
+func (n TreeNode) parent() TreeNode {
+	switch n.Level {
+	case 2:
+		n.Level, n.Conn = 1, ""
+	case 1:
+		n.Level, n.Env = 0, ""
 	}
+	return n
+}
 
-	return nodes
+// 节点所在的项目
+func (n TreeNode) projectNode() TreeNode {
+	return TreeNode{Level: 0, Module: n.Module, Project: n.Project}
 }
 
-// 找到当前节点在可见节点列表中的索引
-func (a *App) findCurrentNodeIndex(visibleNodes []TreeNode) int {
-	for i, node := range visibleNodes {
-		if node.Level == a.treeLevel &&
-			node.Project == a.selectedProject &&
-			(node.Level == 0 || node.Env == a.selectedEnv) &&
-			(node.Level <= 1 || node.Conn == a.selectedConn) {
-			return i
-		}
+// 当前模块的第一个项目，模块中没有项目时为空节点
+func (a *App) firstNode() TreeNode {
+	module := a.modules[a.currentModule]
+	if projects := projectList(module); len(projects) > 0 {
+		return TreeNode{Level: 0, Module: module, Project: projects[0].Name}
 	}
-	return 0
+	return TreeNode{Module: module}
 }
 
-// 设置当前节点
+// 设置当前节点，连接树在下次刷新时选中它
 func (a *App) setCurrentNode(node TreeNode) {
-	a.treeLevel = node.Level
-	a.selectedProject = node.Project
-	if node.Level >= 1 {
-		a.selectedEnv = node.Env
-	}
-	if node.Level >= 2 {
-		a.selectedConn = node.Conn
-	}
+	a.selected = node
 }
 
 // 激活当前选中的树项目
 func (a *App) activateTreeItem() {
-	// 这里可以实现连接操作等
+	// SSH 连接：打开交互式会话，已在内嵌终端中运行时切换到该终端
+	if target, ok := a.currentTarget(); ok && moduleType(target.Module) == "SSH" {
+		a.rememberAction(tr("repeat.connect"), (*App).activateTreeItem)
+		if pane := a.paneFor(target); pane != nil {
+			a.showPane(pane, true)
+			return
+		}
+		a.requireVPN(target, func() {
+			setSessionStatus(target, "connecting")
+			a.updateMainPanel()
+			if !endpointSelectable(target) {
+				a.probeThen(target, func() { a.openSSHSession(target) })
+				return
+			}
+			// 探测备用地址可能耗时数秒，在后台完成后再打开会话，取消则不连接
+			ctx, cancel := context.WithCancel(context.Background())
+			progress := a.startProgress(tr("main.probe_title", target.Conn.Name), cancel)
+			go func() {
+				selected := selectEndpoint(target)
+				cancelled := ctx.Err() != nil
+				cancel()
+				progress.finish(func() {
+					if cancelled {
+						setSessionStatus(target, "disconnected")
+						a.updateMainPanel()
+						a.statusBar.SetText(tr("main.connect_cancelled"))
+						return
+					}
+					a.probeThen(selected, func() { a.openSSHSession(selected) })
+				})
+			}()
+		})
+		return
+	}
+	// 数据库连接：打开对应的交互式客户端，配置了 enter: console 时打开查询控制台（Redis 为键浏览器）
+	if target, ok := a.currentTarget(); ok {
+		switch kind := moduleType(target.Module); kind {
+		case "MySQL", "PostgreSQL", "Redis":
+			a.rememberAction(tr("repeat.connect"), (*App).activateTreeItem)
+			if settingsFor(target.Module).Enter == "console" {
+				a.requireVPN(target, a.showQueryConsole)
+				return
+			}
+			a.requireVPN(target, func() {
+				a.openClientSession(target)
+			})
+			return
+		}
+	}
 	a.updateStatusBar()
 }
 
 // 运行应用程序
 func (a *App) Run() error {
+	// 退出时关闭为数据库客户端建立的隧道、手动启动的端口转发和内嵌终端中的会话（后台服务持有的隧道不受影响）
+	defer closeTunnels()
+	defer closeForwards()
+	defer a.closePanes()
 	return a.app.Run()
 }
 
 // 主函数
 func main() {
-	// 初始化配置
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("$HOME/.connectionmanager")
-	viper.AutomaticEnv()
-
-	// 读取配置文件（如果存在）
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			fmt.Printf("读取配置文件错误: %v\n", err)
+	// 作为 SSH_ASKPASS 被 ssh 调用时只输出保存的密码
+	if code, ok := runAskpass(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
+	// 选择工作区并读取其配置文件（如果存在）
+	workspace, args := parseWorkspaceFlag(os.Args[1:])
+	// 启动位置（--start）可以带工作区前缀，如 ops:ssh/prod
+	start, args := parseStartFlag(args)
+	if name, path, ok := splitStartWorkspace(start); ok {
+		workspace, start = name, path
+	}
+	if err := loadConfig(workspace); err != nil {
+		fmt.Println(tr("main.config_error", err))
+		os.Exit(1)
+	}
+
+	// 通过链接打开（open <URI>）时先定位连接（或准备新建），界面启动后确认再连接
+	var opened *connTarget
+	var openedNew bool
+	if len(args) == 2 && args[0] == "open" {
+		target, created, err := resolveURLTarget(args[1])
+		if err != nil {
+			fmt.Println(tr("main.open_url_failed", err))
+			os.Exit(exitFailure)
+		}
+		opened, openedNew = &target, created
+	} else if code := runSubcommand(args); code >= 0 {
+		// 执行命令行子命令（如 check）
+		os.Exit(code)
+	}
+
+	// 命令行未指定启动位置时使用配置项 start
+	if start == "" && opened == nil {
+		var err error
+		if start, err = configuredStart(); err != nil {
+			fmt.Println(tr("main.config_error", err))
 			os.Exit(1)
 		}
 	}
 
-	// 创建应用程序
+	// 创建应用程序；示例数据只在交互界面中显示（open <URI> 解析时构建的数据模型不含示例数据，需要重建）
+	interactiveUI = true
+	invalidateStore()
 	app := NewApp()
 
 	// 初始化界面
 	app.initUI()
 
+	// 启动服务发现的后台刷新
+	app.startDiscovery()
+
+	// 在后台检查是否有新版本
+	app.startUpdateCheck()
+
+	// 定期检查钉住的连接
+	app.startWatchStrip()
+
+	// 定期检查树中可见的连接
+	app.startHealthChecks()
+
+	// 关闭空闲的本地隧道
+	app.startTunnelReaper()
+
+	// 刷新状态栏时钟
+	app.startClock()
+
+	// 重新连接后台服务持有的会话和隧道
+	app.attachDaemon()
+
+	// 存在凭据库时先输入主密码解锁，取消后连接时不使用保存的密码
+	if credentialStoreExists() {
+		app.unlockCredentialForm(nil)
+	}
+
+	// 提示上次中断的传输
+	app.notifyInterruptedTransfers()
+
+	// 按配置导入 ~/.ssh/config 中的新主机
+	app.importSSHConfigOnStart()
+
+	if opened != nil {
+		target := *opened
+		app.app.QueueUpdateDraw(func() {
+			app.confirmURLTarget(target, openedNew)
+		})
+	} else if start != "" {
+		// 直接进入启动位置，跳过模块栏
+		app.app.QueueUpdateDraw(func() {
+			app.applyStartTarget(start)
+		})
+	}
+
 	// 运行应用程序
 	if err := app.Run(); err != nil {
-		fmt.Printf("运行应用程序错误: %v\n", err)
+		fmt.Println(tr("main.run_failed", err))
 		os.Exit(1)
 	}
 }
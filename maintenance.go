@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// 维护窗口：窗口期内的健康检查失败不告警，并标记为维护中
+type maintenanceWindow struct {
+	Name  string   `mapstructure:"name"`  // 窗口名称
+	Match string   `mapstructure:"match"` // 目标匹配模式（模块/项目/环境/连接，支持通配符）
+	Start string   `mapstructure:"start"` // 一次性窗口开始时间（RFC3339）
+	End   string   `mapstructure:"end"`   // 一次性窗口结束时间（RFC3339）
+	Days  []string `mapstructure:"days"`  // 周期窗口生效的星期（mon..sun，为空表示每天）
+	From  string   `mapstructure:"from"`  // 周期窗口每日开始时间（HH:MM）
+	To    string   `mapstructure:"to"`    // 周期窗口每日结束时间（HH:MM），早于开始时间表示跨天
+}
+
+// 读取配置中的维护窗口（配置项 maintenance_windows）
+func maintenanceWindows() []maintenanceWindow {
+	var windows []maintenanceWindow
+	if err := unmarshalConfig("maintenance_windows", &windows); err != nil {
+		return nil
+	}
+	return windows
+}
+
+// 判断目标是否匹配窗口的匹配模式
+func (w maintenanceWindow) matches(target connTarget) bool {
+	return matchTargetPattern(w.Match, target)
+}
+
+// 判断指定时间是否处于窗口期内
+func (w maintenanceWindow) active(now time.Time) bool {
+	if w.Start != "" || w.End != "" {
+		start, err1 := time.Parse(time.RFC3339, w.Start)
+		end, err2 := time.Parse(time.RFC3339, w.End)
+		return err1 == nil && err2 == nil && !now.Before(start) && now.Before(end)
+	}
+	if w.From == "" || w.To == "" {
+		return false
+	}
+
+	from, err1 := time.Parse("15:04", w.From)
+	to, err2 := time.Parse("15:04", w.To)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minutes := now.Hour()*60 + now.Minute()
+	start := from.Hour()*60 + from.Minute()
+	end := to.Hour()*60 + to.Minute()
+
+	day := now
+	var inRange bool
+	if start <= end {
+		inRange = minutes >= start && minutes < end
+	} else {
+		// 跨天窗口：凌晨部分属于前一天的窗口
+		inRange = minutes >= start || minutes < end
+		if minutes < end {
+			day = now.AddDate(0, 0, -1)
+		}
+	}
+	return inRange && w.onDay(day.Weekday())
+}
+
+// 判断窗口在指定星期是否生效
+func (w maintenanceWindow) onDay(weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	name := strings.ToLower(weekday.String()[:3])
+	for _, day := range w.Days {
+		if strings.ToLower(day) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 获取目标当前所处的维护窗口
+func inMaintenance(target connTarget, now time.Time) (maintenanceWindow, bool) {
+	for _, window := range maintenanceWindows() {
+		if window.matches(target) && window.active(now) {
+			return window, true
+		}
+	}
+	return maintenanceWindow{}, false
+}
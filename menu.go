@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 树节点上的一个可用操作
+type nodeAction struct {
+	Key   rune   // 对应的树视图快捷键（区分大小写），0 表示只能从菜单执行
+	Label string // 菜单中显示的名称
+	Run   func()
+}
+
+// 操作菜单中名称列的宽度
+const menuLabelWidth = 28
+
+// 无需引号的命令行参数
+var plainArgPattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// 是否启用鼠标（配置项 ui.mouse，默认启用，右键打开操作菜单）
+func mouseEnabled() bool {
+	return !configIsSet("ui.mouse") || configBool("ui.mouse")
+}
+
+// 当前选中节点适用的操作，按层级与模块类型筛选
+func (a *App) nodeActions() []nodeAction {
+	var actions []nodeAction
+	add := func(key rune, label string, run func()) {
+		actions = append(actions, nodeAction{Key: key, Label: label, Run: run})
+	}
+	if a.selected.Level < 2 {
+		add(' ', tr("menu.toggle"), a.toggleExpansion)
+	}
+	add('a', tr("menu.add_node"), a.addTreeNode)
+	add('e', tr("menu.edit_node"), a.editTreeNode)
+	add('d', tr("menu.delete_node"), a.deleteTreeNode)
+	if a.selected.Level >= 1 {
+		add('n', tr("menu.new_connection"), func() { a.showNewConnectionForm(Connection{}, "") })
+		add('A', tr("menu.paste_add"), a.pasteToAdd)
+		add('D', tr("menu.env_diff"), a.showEnvironmentDiff)
+	}
+	if target, ok := a.currentTarget(); ok {
+		kind := moduleType(target.Module)
+		add(0, tr("menu.connect"), a.activateTreeItem)
+		add('i', tr("menu.diagnostics"), a.showDiagnostics)
+		if kind == "MySQL" || kind == "PostgreSQL" {
+			add('c', tr("menu.console"), a.showQueryConsole)
+			add('x', tr("menu.sql_file"), a.runSQLFile)
+		}
+		if kind == "Redis" {
+			add('c', tr("menu.redis"), a.showRedisBrowser)
+		}
+		if kind == "SSH" {
+			add('E', tr("menu.exec"), a.showExecPrompt)
+			add('b', tr("menu.browse"), a.showFileBrowser)
+			add('w', tr("menu.gui"), a.showGUIApps)
+			add('f', tr("menu.host_files"), a.showHostFileForm)
+			add('y', tr("menu.scrollback"), a.showScrollback)
+			add(0, tr("menu.copy_command"), a.copyConnectCommand)
+		}
+		add('r', tr("menu.recipes"), a.showTransferRecipes)
+		add('g', tr("menu.dependencies"), a.showDependencyGraph)
+		add('v', tr("menu.mark"), a.toggleMark)
+		add('M', tr("menu.bulk_edit"), a.showBulkEditForm)
+		add('s', tr("menu.replace"), a.showRegexReplaceForm)
+		add('o', tr("menu.promote"), a.showPromoteForm)
+		add('t', tr("menu.notes"), a.showNotes)
+		add('u', tr("menu.pin"), a.togglePin)
+		add('^', tr("menu.alert"), a.toggleAlert)
+		add('l', tr("menu.secret"), a.showSecretForm)
+	}
+	add(0, tr("menu.edit_mode"), a.toggleEditMode)
+	add(';', tr("menu.last_changed"), a.jumpToLastChanged)
+	add('!', tr("menu.last_failure"), a.showLastFailure)
+	if a.lastAction != nil && a.selected.Level == 2 {
+		add('.', tr("menu.repeat", a.lastAction.Label), a.repeatLastAction)
+	}
+	add('p', tr("menu.report"), a.showInventoryReport)
+	add(0, tr("menu.sessions"), a.showSessionManager)
+	add(0, tr("menu.recordings"), a.showRecordings)
+	add(0, tr("menu.tunnels"), a.showTunnels)
+	add(0, tr("menu.reverse"), a.showReverseTunnels)
+	return actions
+}
+
+// 显示选中节点的操作菜单：列出全部适用操作及快捷键，Enter 或按快捷键执行
+func (a *App) showActionMenu() {
+	actions := a.nodeActions()
+	list := tview.NewList().ShowSecondaryText(false)
+	width := 0
+	for _, action := range actions {
+		key := " "
+		switch action.Key {
+		case 0:
+		case ' ':
+			key = "Space"
+		default:
+			key = strings.ToUpper(string(action.Key))
+			if unicode.IsUpper(action.Key) {
+				key = "Shift+" + key
+			}
+		}
+		// 按显示宽度补齐，使快捷键列对齐（中文字符占两列）
+		label := action.Label + strings.Repeat(" ", max(menuLabelWidth-tview.TaggedStringWidth(action.Label), 1)) + "[gray]" + key + "[-]"
+		width = max(width, tview.TaggedStringWidth(label))
+		list.AddItem(label, "", 0, func() {
+			a.popOverlay()
+			action.Run()
+		})
+	}
+	list.SetBorder(true).
+		SetTitle(tr("menu.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(list, max(width+10, 48), len(actions)+2), list, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		// 按快捷键直接执行：先找大小写完全一致的操作，没有时小写快捷键也接受大写（如开启了大写锁定）
+		if event.Key() == tcell.KeyRune {
+			for _, match := range []func(rune) bool{
+				func(key rune) bool { return key == event.Rune() },
+				func(key rune) bool { return key == unicode.ToLower(event.Rune()) },
+			} {
+				for _, action := range actions {
+					if action.Key != 0 && match(action.Key) {
+						a.popOverlay()
+						action.Run()
+						return nil
+					}
+				}
+			}
+		}
+		return event
+	})
+}
+
+// 右键点击主面板时打开操作菜单
+func (a *App) handleMainPanelMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	if action == tview.MouseRightClick && a.inTreeView && len(a.overlays) == 0 && a.state == Normal {
+		a.showActionMenu()
+		return tview.MouseConsumed, nil
+	}
+	return action, event
+}
+
+// 将当前 SSH 连接的完整命令复制到剪贴板
+func (a *App) copyConnectCommand() {
+	target, ok := a.currentTarget()
+	if !ok || moduleType(target.Module) != "SSH" {
+		return
+	}
+	args := sshCommand(target)
+	for i, arg := range args {
+		if !plainArgPattern.MatchString(arg) {
+			args[i] = shellQuote(arg)
+		}
+	}
+	if err := copyToClipboard(strings.Join(args, " ")); err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	a.statusBar.SetText(tr("menu.copied_command", tview.Escape(target.Conn.Name)))
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mesh 网络自动生成的项目和环境名称
+const (
+	meshProjectName = "Mesh 网络"
+	meshEnvName     = "对等节点"
+)
+
+// WireGuard 对等节点最近握手在该时间内视为在线
+const wireguardOnlineWindow = 3 * time.Minute
+
+// tailscaled 本地 API 的 unix socket 路径
+var tailscaleSockets = []string{"/var/run/tailscale/tailscaled.sock", "/run/tailscale/tailscaled.sock"}
+
+// Mesh 网络中的对等节点
+type meshPeer struct {
+	Name   string   // 主机名
+	Host   string   // Mesh 网络地址
+	Online bool     // 是否在线
+	Tags   []string // 标签
+}
+
+// 已发现的对等节点
+var (
+	meshMu    sync.Mutex
+	meshPeers []meshPeer
+)
+
+// 判断 Mesh 来源是否启用：配置为 true/false 时按配置，为空或 auto 时由 detect 决定
+func meshSourceEnabled(key string, detect func() bool) bool {
+	switch strings.ToLower(configString(key)) {
+	case "true", "yes", "on":
+		return true
+	case "", "auto":
+		return detect()
+	}
+	return false
+}
+
+// 本机是否安装了 Tailscale
+func tailscaleDetected() bool {
+	for _, socket := range tailscaleSockets {
+		if _, err := os.Stat(socket); err == nil {
+			return true
+		}
+	}
+	_, err := exec.LookPath("tailscale")
+	return err == nil
+}
+
+// 是否需要刷新 Mesh 节点（WireGuard 需要 root 权限，默认关闭）
+func meshEnabled() bool {
+	return meshSourceEnabled("mesh.tailscale", tailscaleDetected) || meshSourceEnabled("mesh.wireguard", func() bool { return false })
+}
+
+// 读取 Tailscale 状态：优先使用 tailscaled 本地 API，不可用时执行 tailscale status --json
+func tailscaleStatus(ctx context.Context) ([]byte, error) {
+	for _, socket := range tailscaleSockets {
+		if _, err := os.Stat(socket); err != nil {
+			continue
+		}
+		client := http.Client{Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://local-tailscaled.sock/localapi/v0/status", nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			continue
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			continue
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return exec.CommandContext(ctx, "tailscale", "status", "--json").Output()
+}
+
+// 获取 Tailscale 对等节点
+func tailscalePeers(ctx context.Context) ([]meshPeer, error) {
+	data, err := tailscaleStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var status struct {
+		Peer map[string]struct {
+			HostName     string
+			DNSName      string
+			OS           string
+			TailscaleIPs []string
+			Online       bool
+		}
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf(tr("mesh.parse_failed"), err)
+	}
+	var peers []meshPeer
+	for _, peer := range status.Peer {
+		if len(peer.TailscaleIPs) == 0 {
+			continue
+		}
+		name := strings.SplitN(peer.DNSName, ".", 2)[0]
+		if name == "" {
+			name = peer.HostName
+		}
+		tags := []string{"tailscale"}
+		if peer.OS != "" {
+			tags = append(tags, "os="+peer.OS)
+		}
+		peers = append(peers, meshPeer{Name: name, Host: peer.TailscaleIPs[0], Online: peer.Online, Tags: tags})
+	}
+	return peers, nil
+}
+
+// 获取 WireGuard 对等节点（wg show all dump），名称可通过 mesh.wireguard_names 按公钥指定
+func wireguardPeers(ctx context.Context) ([]meshPeer, error) {
+	output, err := exec.CommandContext(ctx, "wg", "show", "all", "dump").Output()
+	if err != nil {
+		return nil, fmt.Errorf("wg show: %w", err)
+	}
+	names := configStringMapString("mesh.wireguard_names")
+	var peers []meshPeer
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		// 对等节点行：接口 公钥 预共享密钥 端点 允许地址 最近握手 接收 发送 保活
+		fields := strings.Split(line, "\t")
+		if len(fields) != 9 {
+			continue
+		}
+		host, _, _ := strings.Cut(strings.Split(fields[4], ",")[0], "/")
+		if host == "" || host == "(none)" {
+			continue
+		}
+		handshake, _ := strconv.ParseInt(fields[5], 10, 64)
+		name := lookupFold(names, fields[1])
+		if name == "" {
+			name = fmt.Sprintf("%s-%s", fields[0], host)
+		}
+		peers = append(peers, meshPeer{
+			Name:   name,
+			Host:   host,
+			Online: handshake > 0 && time.Since(time.Unix(handshake, 0)) < wireguardOnlineWindow,
+			Tags:   []string{"wireguard", "iface=" + fields[0]},
+		})
+	}
+	return peers, nil
+}
+
+// 刷新 Mesh 节点；来源读取失败时保留上一次的结果
+func refreshMesh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), discoveryTimeout)
+	defer cancel()
+	var peers []meshPeer
+	var firstErr error
+	sources := []struct {
+		key    string
+		detect func() bool
+		fetch  func(context.Context) ([]meshPeer, error)
+	}{
+		{"mesh.tailscale", tailscaleDetected, tailscalePeers},
+		{"mesh.wireguard", func() bool { return false }, wireguardPeers},
+	}
+	for _, source := range sources {
+		if !meshSourceEnabled(source.key, source.detect) {
+			continue
+		}
+		found, err := source.fetch(ctx)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		peers = append(peers, found...)
+	}
+	if firstErr != nil && len(peers) == 0 {
+		return firstErr
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+	meshMu.Lock()
+	defer meshMu.Unlock()
+	meshPeers = peers
+	return firstErr
+}
+
+// 清空 Mesh 节点（切换工作区时调用）
+func resetMesh() {
+	meshMu.Lock()
+	defer meshMu.Unlock()
+	meshPeers = nil
+}
+
+// 是否存在 Mesh 节点（决定是否显示 Mesh 项目）
+func hasMeshPeers() bool {
+	meshMu.Lock()
+	defer meshMu.Unlock()
+	return len(meshPeers) > 0
+}
+
+// 将 Mesh 节点转换为 SSH 连接
+func meshConnections(module string) []Connection {
+	meshMu.Lock()
+	defer meshMu.Unlock()
+	conns := make([]Connection, 0, len(meshPeers))
+	for _, peer := range meshPeers {
+		status := "disconnected"
+		if peer.Online {
+			status = "connected"
+		}
+		conns = append(conns, Connection{
+			Name:   peer.Name,
+			Status: status,
+			Host:   peer.Host,
+			Port:   defaultPort(module),
+			User:   defaultUser(module),
+			Tags:   peer.Tags,
+		})
+	}
+	return conns
+}
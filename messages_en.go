@@ -0,0 +1,1140 @@
+package main
+
+// 英文界面文本
+var messagesEn = map[string]string{
+	// 面板标题
+	"title.modules":      "Modules",
+	"title.main":         "Main",
+	"title.details":      "Details",
+	"title.sessions":     "Sessions",
+	"title.status":       "Status",
+	"title.clock":        "Clock",
+	"title.confirm_exit": "Confirm exit",
+	"title.watch":        "Watch",
+	"main.title":         "%s connections",
+
+	// 状态栏与退出确认
+	"status.ready":     "Ready...",
+	"status.tree":      "[yellow]State: %s[-] | [blue]Module: %s[-] | [green]Level: %s[-] | [gray]↑↓/JK: navigate, digits: count, [/]: siblings, Space: expand/collapse, Ctrl+P: jump, F2: sessions, g/\\: chords, 1-4: layout, Z: zoom, ESC: back[-]",
+	"status.modules":   "[yellow]State: %s[-] | [blue]Module: %s[-] | [green]Hover: %s[-] | [gray]←→/H/L: navigate, Enter/Space: select, W: workspaces, R: review changes, D: LAN discovery, I: import ssh config, S: import infrastructure state, T: tunnels, F2: sessions, Ctrl+P: jump, 1-4: layout, Alt+Z: zoom, Q: quit[-]",
+	"level.project":    "project",
+	"level.env":        "environment",
+	"level.connection": "connection",
+	"confirm.exit":     "\n[yellow]Quit ConnectionManager?[-]\n\n",
+
+	// 模块概览
+	"overview.title":               "[yellow]%s overview[-]\n\n",
+	"overview.enter":               "Press [white:blue]Enter[-] or [white:blue]Space[-] to browse the tree\n\n",
+	"overview.projects":            "📁 Projects:\n",
+	"overview.project_connections": "  • %s (%d environments, %d connections)\n",
+	"overview.project_instances":   "  • %s (%d environments, %d instances)\n",
+	"overview.footer":              "[dim]Press Enter to browse the tree and manage individual connections[-]",
+
+	// 连接树
+	"tree.maintenance": " [blue]maintenance: %s[-]",
+	"hints.edit":       "[yellow]Edit mode[-] - ↑↓/JK: navigate, Space: expand/collapse, A: add sibling, E: edit/rename, D: delete, ESC/Ctrl+E: back",
+	"hints.project":    "Project - ↑↓/JK: navigate, Space: expand/collapse, ?: actions, a: new project, e: rename, d: delete, Ctrl+E: edit mode, ;: last change, ESC/Q: back",
+	"hints.env":        "Environment - ↑↓/JK: navigate, Space: expand/collapse, ?: actions, a: new environment, e: rename, d: delete, Ctrl+E: edit mode, ;: last change, Shift+D: compare environments, N: new connection, Shift+A: paste to add, ESC/Q: back",
+	"hints.connection": "Connection - ↑↓/JK: navigate, Enter: connect/disconnect, ?: actions, a: new connection, e: edit, d: delete, Ctrl+E: edit mode, ;: last change, I: diagnostics, C: console, G: dependencies, R: transfer recipes, F: files on two hosts, B: browse files, Shift+E: run command, W: GUI apps, V: mark, Shift+M: bulk edit, S: regex replace, P: inventory report, O: promote, X: run SQL file, T: notes, U: pin, Y: scrollback, !: error details, L: save secret, N: new connection, Shift+A: paste to add, Shift+D: compare environments, ESC/Q: back",
+
+	// 连接状态
+	"conn.disconnected": "disconnected",
+	"conn.connecting":   "connecting",
+	"conn.failed":       "failed",
+	"conn.detached":     "detached",
+	"conn.online":       "online",
+	"conn.offline":      "unreachable",
+	"conn.unknown":      "checking",
+	"conn.unchecked":    "unchecked",
+	"conn.connected":    "connected",
+
+	// 操作菜单
+	"menu.title":          "Actions (Enter/shortcut: run, ESC: back)",
+	"menu.toggle":         "Expand/collapse",
+	"menu.add_node":       "Add sibling node",
+	"menu.edit_node":      "Edit/rename",
+	"menu.delete_node":    "Delete",
+	"menu.new_connection": "New connection",
+	"menu.paste_add":      "Paste from clipboard to add",
+	"menu.env_diff":       "Compare environments",
+	"menu.connect":        "Connect",
+	"menu.diagnostics":    "Diagnostics",
+	"menu.console":        "Query console",
+	"menu.sql_file":       "Run SQL file",
+	"menu.exec":           "Run command",
+	"menu.browse":         "Browse files",
+	"menu.gui":            "GUI apps",
+	"menu.host_files":     "Compare/copy files on two hosts",
+	"menu.scrollback":     "Session scrollback",
+	"menu.copy_command":   "Copy connect command",
+	"menu.recipes":        "Transfer recipes",
+	"menu.dependencies":   "Dependencies",
+	"menu.mark":           "Mark/unmark",
+	"menu.bulk_edit":      "Edit (bulk edit marked connections)",
+	"menu.replace":        "Regex replace",
+	"menu.promote":        "Promote to another environment",
+	"menu.notes":          "Notes",
+	"menu.pin":            "Pin/unpin",
+	"menu.secret":         "Save secret",
+	"menu.edit_mode":      "Edit mode (Ctrl+E)",
+	"menu.last_changed":   "Jump to last status change",
+	"menu.last_failure":   "Last error details",
+	"menu.report":         "Inventory report",
+	"menu.tunnels":        "Tunnels and port forwards",
+	"menu.reverse":        "Reverse tunnels",
+	"menu.copied_command": "[green]Copied the connect command for %s[-]",
+
+	// 跳转面板
+	"palette.title": "Jump (Enter: connect/jump, Tab: jump, ↑↓: select, ESC: back)",
+
+	// 布局与侧边面板
+	"layout.tree":        "tree only",
+	"layout.details":     "tree + details",
+	"layout.session":     "tree + sessions",
+	"layout.dashboard":   "dashboard",
+	"layout.applied":     "[green]Layout: %s[-] (%s)",
+	"layout.save_failed": "[red]Failed to save layout: %s[-]",
+	"details.empty":      "\n[gray]Select a connection in the tree to see its details[-]",
+	"sessions.tunnels":   "\n[yellow]Local tunnels[-]\n",
+	"sessions.tabs":      "\n[yellow]Session tabs[-] [gray](Alt+digit: switch, Alt+0: tree)[-]\n",
+	"sessions.transfers": "\n[yellow]Running transfers[-]\n",
+	"sessions.recent":    "\n[yellow]Recent sessions[-]\n",
+	"sessions.none":      "  [gray]none[-]\n",
+
+	// 组合键
+	"chord.title":          "%s …",
+	"chord.more":           "+more",
+	"chord.unbound":        "Unbound key sequence: %s",
+	"chord.unknown_action": "Unknown chord action: %s",
+	"chord.top":            "Go to first project",
+	"chord.bottom":         "Go to last node",
+	"chord.expand_env":     "Expand all environments of the project",
+	"chord.collapse_all":   "Collapse all nodes",
+	"chord.test":           "Test connection",
+	"chord.palette":        "Jump",
+	"chord.actions":        "Action menu",
+	"chord.testing":        "Testing %s...",
+	"chord.test_ok":        "%s is reachable, latency %s",
+	"chord.test_failed":    "%s is unreachable: %s",
+	"chord.test_remote":    "%s is reached through a tunnel, Teleport, jump host or proxy command and cannot be tested locally",
+
+	// 重复上一个操作
+	"repeat.none":              "No action to repeat",
+	"repeat.select_connection": "Select a connection to repeat: %s",
+	"repeat.connect":           "Connect",
+	"repeat.test":              "Test connection",
+	"repeat.exec":              "Run %s",
+	"menu.repeat":              "Repeat: %s",
+
+	// 计数前缀
+	"motion.count": "Count: %d",
+
+	// 会话管理
+	"sessions.manager_title":     "Sessions (Enter/S: switch, X: disconnect, C: reconnect, R: refresh, ESC/F2: back)",
+	"sessions.manager_empty":     "(no sessions opened in this run yet)",
+	"sessions.col_conn":          "Connection",
+	"sessions.col_status":        "Status",
+	"sessions.col_uptime":        "Uptime",
+	"sessions.col_count":         "Sessions",
+	"sessions.col_total":         "Total time",
+	"sessions.col_sent":          "Sent",
+	"sessions.col_received":      "Received",
+	"sessions.col_tunnel":        "Local tunnel",
+	"sessions.not_held":          "%s has no open connection",
+	"sessions.disconnecting":     "Disconnecting %s...",
+	"sessions.disconnected":      "Disconnected %s",
+	"sessions.disconnect_failed": "Failed to disconnect %s: %v",
+	"menu.sessions":              "Session manager (F2)",
+	"sessions.closed_manually":   "closed manually",
+
+	// 内嵌终端
+	"pane.title":           "%s (Ctrl+]: switch focus)",
+	"pane.focused":         "Keys go to the embedded terminal %s | Ctrl+]: back to the tree",
+	"pane.fallback":        "Embedded terminal unavailable, suspending the UI instead: %v",
+	"termpane.unsupported": "the embedded terminal is not supported on this platform",
+
+	// 跳转历史
+	"jump.no_older": "Already at the oldest position in the jump list",
+	"jump.no_newer": "Already at the newest position in the jump list",
+	"jump.position": "Jump list %d/%d (Ctrl+O: back, Ctrl+I/Tab: forward)",
+
+	// 书签
+	"mark.set_prompt":  "Set bookmark: press a letter or digit as the mark (any other key cancels)",
+	"mark.jump_prompt": "[yellow]Jump to bookmark:[-] %s | [gray]': position before the last jump[-]",
+	"mark.none":        "[gray](no bookmarks)[-]",
+	"mark.invalid":     "Bookmark marks must be letters or digits: %s",
+	"mark.saved":       "Bookmark %s → %s",
+	"mark.save_failed": "Failed to save bookmark: %v",
+	"mark.unset":       "Bookmark %s is not set",
+	"mark.missing":     "Bookmark %s points to a node that no longer exists: %s",
+
+	// Status change alerts
+	"menu.alert":        "Toggle status change alert",
+	"tree.alert":        " [yellow](alert)[-]",
+	"alert.on":          "Alert enabled for %s: bell and popup when reachability changes",
+	"alert.off":         "Alert disabled for %s",
+	"alert.save_failed": "Failed to save alert list: %v",
+	"alert.title":       "Connection status changed",
+	"alert.offline":     "[red]%s is unreachable[-] [gray]%s[-]",
+	"alert.online":      "[green]%s is back online[-] [gray]%s[-]",
+
+	// Redis key browser
+	"menu.redis":                "Key browser and console",
+	"redis.unsupported":         "Module %s does not support the key browser",
+	"redis.title":               "Redis - %s (%s:%d)",
+	"redis.pattern_label":       "SCAN pattern: ",
+	"redis.keys_title":          "Keys (%d)",
+	"redis.detail_title":        "Details",
+	"redis.output_title":        "Command output",
+	"redis.command_placeholder": "Redis command, e.g. GET user:1; Enter runs, Up/Down recall history",
+	"redis.hint":                "Enter: scan/run, Tab: switch area, in key list R: rescan, /: edit pattern, :: command line, ESC: close",
+	"redis.loading":             "Loading...",
+	"redis.key":                 "Key",
+	"redis.type":                "Type",
+	"redis.size":                "Size",
+	"redis.ttl_none":            "none",
+	"redis.key_gone":            "Key no longer exists",
+	"redis.scanning":            "Scanning %s...",
+	"redis.scan_done":           "Found %d keys in %s",
+	"redis.scan_truncated":      "Showing the first %d keys only (%s); narrow the pattern",
+	"redis.scan_failed":         "Scan failed: %v",
+	"redis.running":             "Running...",
+	"redis.command_done":        "Done in %s",
+	"redis.command_failed":      "Command failed: %s",
+	"redis.confirm_title":       "Run Redis command",
+	"redis.confirm_message":     "About to run a write command in a protected environment\n[yellow]%s[-]\nTarget: %s",
+
+	// Session recordings
+	"menu.recordings":            "Session recordings",
+	"recordings.title":           "%d session recordings (Enter: view, A: export asciinema, H: export HTML player, ESC/Q: close)",
+	"recordings.col_time":        "Time",
+	"recordings.col_target":      "Connection",
+	"recordings.col_size":        "Size",
+	"recordings.col_timing":      "Replay",
+	"recordings.timed":           "timed",
+	"recordings.empty":           "No session recordings yet (enable with record: true in an automation rule)",
+	"recordings.load_failed":     "Failed to read session recordings: %v",
+	"recordings.export_title":    "Export session recording",
+	"recordings.export_label":    "File path: ",
+	"recordings.export_failed":   "Failed to export session recording: %v",
+	"recordings.exported":        "Exported session recording to %s",
+	"recordings.untimed":         " (no timing data; all output appears at the start)",
+	"recordings.view_title":      "Session recording - %s %s, %d lines (/: search, ESC: back)",
+	"recordings.overwrite_title": "Overwrite file",
+	"recordings.overwrite":       "%s already exists. Overwrite it?",
+	"url.confirm_title":          "Open link",
+	"url.confirm":                "Connect to %s %s port %d as user %s?",
+	"url.confirm_new":            "Not in the inventory; it will be saved as %s",
+	"url.confirm_existing":       "Using inventory connection %s",
+	"url.protected":              "The target is in a protected environment",
+	"url.save_failed":            "Failed to save the connection: %v",
+
+	// 连接字段（字段标识 -> 显示名称）
+	"field.name":          "Name",
+	"field.host":          "Host",
+	"field.port":          "Port",
+	"field.user":          "User",
+	"field.database":      "Database",
+	"field.auth":          "Auth method",
+	"field.identity_file": "Identity file",
+	"field.certificate":   "Certificate",
+	"field.proxy_command": "Proxy command",
+	"field.jump_host":     "Jump host",
+	"field.addresses":     "Fallback addresses",
+	"field.tls":           "TLS",
+	"field.tls_ca":        "CA certificate",
+	"field.tls_cert":      "Client certificate",
+	"field.tls_key":       "Client key",
+	"field.ssh_tunnel":    "SSH tunnel",
+	"field.tags":          "Tags",
+	"field.password":      "Password",
+
+	// 编辑模式
+	"edit.new_project":         "New project",
+	"edit.project_exists":      "A project with this name already exists: %s",
+	"edit.mesh_no_env":         "[red]The Mesh project is generated from peers; environments cannot be added[-]",
+	"edit.new_env":             "New environment - %s",
+	"edit.env_exists":          "%s already has an environment named %s",
+	"edit.demo_project_rename": "[red]Demo projects cannot be renamed[-]",
+	"edit.rename_project":      "Rename project",
+	"edit.demo_env_rename":     "[red]Demo environments cannot be renamed[-]",
+	"edit.rename_env":          "Rename environment - %s",
+	"edit.mesh_no_delete":      "[red]The Mesh project is generated from peers and cannot be deleted[-]",
+	"edit.kind_project":        "project",
+	"edit.kind_env":            "environment",
+	"edit.kind_connection":     "connection",
+	"edit.still_referenced":    "[red]%s is still referenced by: %s[-]",
+	"edit.col_delete":          "Delete",
+	"edit.col_kind":            "Type",
+	"edit.delete_failed":       "[red]Delete failed: %s[-]",
+	"config.not_mapping":       "the top level of config file %s is not a mapping and cannot be written back",
+	"edit.deleted":             "[green]Deleted %s %s[-]",
+	"edit.name":                "Name",
+	"edit.saved":               "[green]Saved %s[-]",
+	"edit.name_slash":          "Name cannot contain /",
+	"edit.password_saved":      "[green]Connection password saved[-]",
+	"edit.no_changes":          "[yellow]No changes[-]",
+	"edit.edit_connection":     "Edit connection - %s",
+	"edit.envs":                "Environments",
+	"edit.delete_title":        "Delete %s",
+
+	// 通用
+	"common.save":                 "Save",
+	"common.cancel":               "Cancel",
+	"common.save_failed":          "[red]Save failed: %s[-]",
+	"common.copy_failed":          "[red]Copy failed: %s[-]",
+	"common.col_type":             "Type",
+	"common.col_name":             "Name",
+	"common.col_address":          "Address",
+	"common.staged_connections":   "[green]Staged %d connections; press R in the module bar to review[-]",
+	"common.added_connections":    "[green]Added %d connections[-]",
+	"common.col_connection":       "Connection",
+	"common.col_status":           "Status",
+	"common.connection_not_found": "connection not found: %s",
+	"common.col_protected":        "Protected",
+	"common.col_port":             "Port",
+	"common.col_user":             "User",
+	"common.name_label":           "Name: ",
+	"common.operation":            "Operation",
+	"common.run":                  "Run",
+	"common.transferred":          "Transferred %s",
+	"common.col_target":           "Target",
+	"common.col_env":              "Environment",
+	"common.yes":                  "yes",
+	"common.col_result":           "Result",
+	"common.col_duration":         "Duration",
+	"common.col_output":           "Output",
+	"common.succeeded":            "[green]succeeded[-]",
+	"common.failed":               "[red]failed[-]",
+	"common.loading":              "Loading...",
+	"common.error":                "Error",
+	"common.import_failed":        "[red]Import failed: %s[-]",
+	"common.col_module":           "Module",
+	"common.add":                  "Add",
+	"common.ok":                   "OK",
+	"common.field":                "Field",
+	"common.value":                "Value",
+	"common.preview":              "Preview",
+	"common.col_old":              "Old value",
+	"common.col_new":              "New value",
+	"common.loading_status":       "[yellow]Loading...[-]",
+	"common.col_size":             "Size",
+	"common.col_modified":         "Modified",
+	"common.protected_target":     "\n\n[red]The target is a protected environment[-]",
+	"common.delete":               "Delete",
+	"common.select_connection":    "[red]Select a connection first[-]",
+	"common.col_time":             "Time",
+	"common.esc_back_suffix":      " - ESC: back",
+	"common.running":              "[yellow]Running...[-]",
+	"common.connect_failed":       "[red]Connection failed: %s[-] | !: error details",
+	"common.col_command":          "Command",
+	"common.path_label":           "File path: ",
+	"common.success":              "succeeded",
+	"common.failure":              "failed",
+
+	// 连接字段
+	"field.invalid_port":  "invalid port: %s",
+	"field.invalid_proxy": "invalid proxy command template: %w",
+	"field.invalid_auth":  "invalid auth method: %s (choose from %s)",
+	"field.invalid_tls":   "invalid TLS switch: %s (choose on or off)",
+	"field.unknown":       "unknown field: %s",
+
+	// 主界面
+	"main.probe_title":       "Probing candidate addresses - %s",
+	"main.connect_cancelled": "[yellow]Connection cancelled[-]",
+	"main.config_error":      "Failed to read the config file: %v",
+	"main.open_url_failed":   "Failed to open the link: %v",
+	"main.run_failed":        "Application error: %v",
+
+	// 错误建议
+	"hint.host_key":       "Host key changed or not trusted: press F to view fingerprints, confirm with the administrator, then remove the old entry with ssh-keygen -R <host>",
+	"hint.auth":           "Authentication failed: check the user name, auth method, identity file or password; with many keys loaded, set an identity file for the connection",
+	"hint.key_perms":      "Private key permissions are too open: run chmod 600 <identity file>",
+	"hint.key_missing":    "Private key file not found: check the identity file path of the connection",
+	"hint.resolve":        "Host name cannot be resolved: check the host address, DNS, or whether a VPN must be connected first",
+	"hint.refused":        "Port refused the connection: make sure the service is running, the port is correct and the firewall allows it",
+	"hint.unreachable":    "Network unreachable: check the network, VPN or firewall; raise startup_probe in the session policy if needed",
+	"hint.db_auth":        "Database authentication failed: check the user name and password; the password can be saved when editing the connection",
+	"hint.client_missing": "Client program not found: install the client or set module_settings.<module>.client/command",
+	"hint.tls":            "TLS handshake failed: check the TLS settings and certificate paths",
+
+	// 错误详情
+	"failure.text_header":       "Connection: %s\nTime: %s\nError: %s\n",
+	"failure.text_command":      "Command: %s\n",
+	"failure.text_hint":         "Suggestion: %s\n",
+	"failure.text_output":       "\nOutput:\n%s\n",
+	"failure.header":            "[yellow]Connection[-]  %s\n[yellow]Time[-]  %s\n[yellow]Error[-]  [red]%s[-]\n",
+	"failure.command":           "[yellow]Command[-]  %s\n",
+	"failure.hints":             "\n[yellow]Suggestions[-]\n",
+	"failure.output":            "\n[yellow]Output[-]\n",
+	"failure.title":             "Error details - %s (%s)",
+	"failure.copied":            "[green]Error details copied[-]",
+	"failure.none":              "[yellow]No connection has failed in this run yet[-]",
+	"failure.fetching_host_key": "[yellow]Fetching host key fingerprints of %s...[-]",
+	"failure.known_hosts":       "[yellow]Entries in known_hosts[-]\n",
+	"failure.known_hosts_none":  "  [gray]none[-]\n",
+	"failure.server_keys":       "\n[yellow]Host keys currently offered by the server[-]\n",
+	"failure.scan_failed":       "  [red]Failed to fetch: %s[-]\n",
+	"failure.remove_hint":       "\n[gray]Once the new fingerprint is confirmed as trusted, run ssh-keygen -R %s to remove the old entry[-]",
+	"failure.host_key_title":    "Host key fingerprints - %s (ESC: back)",
+	"failure.keys":              "Y: copy, ESC: back",
+	"failure.keys_host_key":     "F: host key fingerprints, Y: copy, ESC: back",
+
+	// 局域网发现
+	"lan.name_out_of_range":   "name out of range",
+	"lan.too_many_pointers":   "too many name compression pointers",
+	"lan.short_packet":        "packet too short",
+	"lan.record_out_of_range": "record out of range",
+	"lan.ipv4_only":           "only IPv4 subnets are supported: %s",
+	"lan.subnet_too_large":    "subnet too large (at most %d hosts): %s",
+	"lan.subnet":              "Subnet scan (optional)",
+	"lan.project":             "Add to project",
+	"lan.env":                 "Add to environment",
+	"lan.scan":                "Scan",
+	"lan.form_title":          "LAN discovery (mDNS/SSDP, subnet e.g. 192.168.1.0/24)",
+	"lan.scanning":            "LAN discovery - scanning...",
+	"lan.col_source":          "Source",
+	"lan.failed":              "LAN discovery - failed: %s",
+	"lan.results":             "LAN discovery - %d services (Space: mark, Enter: add, A: add all, ESC: back; = already exists)",
+	"lan.title":               "LAN discovery",
+	"lan.nothing_to_add":      "No services to add (already present or no matching project and environment)",
+	"lan.progress_discover":   "Discovering via mDNS/SSDP",
+	"lan.progress_probe":      "Probing service ports on %d hosts",
+	"lan.cancelled":           "LAN discovery cancelled",
+
+	// 端口转发
+	"forward.no_listen":        "port forward %s has no listen address",
+	"forward.no_to":            "port forward %s has no to address",
+	"forward.invalid_type":     "invalid port forward type: %s (choose local, remote or dynamic)",
+	"forward.label_remote":     "remote %s → local %s",
+	"forward.label_dynamic":    "SOCKS proxy %s",
+	"forward.label_local":      "local %s → %s",
+	"forward.tunnel_not_found": "SSH tunnel connection not found: %s",
+	"forward.already_running":  "port forward is already running: %s",
+	"forward.start_failed":     "failed to start port forward: %w",
+	"forward.stopped":          "[gray]not started[-]",
+	"forward.running_since":    "[green]running[-] [gray]%s[-]",
+	"forward.exited":           "exited",
+	"forward.title":            "Tunnels (Enter/S: start, X: stop, V: reverse tunnels, R: refresh, ESC: back)",
+	"forward.client_tunnel":    "Client tunnel",
+	"forward.client_label":     "local 127.0.0.1:%d → %s:%d (%s)",
+	"forward.col_forward":      "Forward",
+	"forward.empty":            "(no port forwards defined and no client tunnels running)",
+	"forward.starting":         "[yellow]Starting port forward %s...[-]",
+	"forward.status":           "Port forward %s: %s",
+	"forward.stopped_msg":      "[green]Stopped port forward %s[-]",
+	"forward.closed_manually":  "closed manually",
+	"forward.tunnel_closed":    "[green]Closed tunnel %s[-]",
+	"forward.via_tunnel":       "tunnel",
+	"forward.via_bastion":      "via %s",
+	"forward.running":          "[green]running[-]",
+
+	// 传输配方
+	"recipe.no_remote":         "neither the source nor the destination of recipe %s is a remote connection",
+	"recipe.unsupported_tool":  "unsupported transfer tool: %s",
+	"recipe.title":             "Transfer recipes (Enter: run, J: unfinished transfers, ESC: back)",
+	"recipe.col_tool":          "Tool",
+	"recipe.col_source":        "Source",
+	"recipe.col_dest":          "Destination",
+	"recipe.col_args":          "Arguments",
+	"recipe.empty":             "(transfer_recipes is not configured)",
+	"recipe.preview_title":     "Transfer recipe %s",
+	"recipe.col_connections":   "Connections",
+	"recipe.transfer_title":    "Transfer - %s (/: search, ESC: cancel/close)",
+	"recipe.starting":          "[yellow]Starting...[-]",
+	"recipe.done":              "\n\n[green]Transfer complete[-] in %s | %s",
+	"recipe.failed":            "\n\n[red]Transfer failed: %s[-]\n[gray]Press J in transfer recipes to resume[-]",
+	"recipe.verifying":         "\n[yellow]Verifying sha256...[-]",
+	"recipe.verify_progress":   "Verifying sha256",
+	"recipe.verify_matched":    "%s: %d files match",
+	"recipe.verify_failed":     "\n[red]Verification failed: %s[-]",
+	"recipe.verify_mismatched": "%s: %d files differ",
+	"recipe.checksum_mismatch": "\n[white:red] Checksum mismatch: %d files [-:-]\n[red]%s[-]",
+	"recipe.verify_ok":         "\n[green]sha256 verified (%d files)[-]",
+	"recipe.verify_alert":      "[white:red] Transfer %s failed verification [-:-]",
+
+	// 反向隧道
+	"reverse.title":          "Reverse tunnels (Enter: connect, N: register, D: delete, C: copy dial-in command, R: refresh, ESC: back)",
+	"reverse.col_note":       "Note",
+	"reverse.offline":        "[red]not dialed in[-]",
+	"reverse.online":         "[green]dialed in[-]",
+	"reverse.empty":          "(no registered devices; press N to register one)",
+	"reverse.not_dialed":     "[red]%s has not dialed in yet[-]",
+	"reverse.register":       "Register device",
+	"reverse.exists":         "[red]Device already registered: %s[-]",
+	"reverse.login_user":     "Login user: ",
+	"reverse.port_detail":    "port %d",
+	"reverse.registered":     "[green]Registered %s on port %d; press C to copy the dial-in command for the device[-]",
+	"reverse.delete_title":   "Delete registration",
+	"reverse.delete_confirm": "[yellow]Delete the reverse tunnel registration of %s?[-]",
+	"reverse.copied":         "[green]Copied the dial-in command for %s[-]",
+
+	// 两主机文件
+	"hostfiles.mark_two":      "[red]Mark exactly two SSH connections with V first[-]",
+	"hostfiles.diff":          "Compare",
+	"hostfiles.copy":          "Copy %s → %s",
+	"hostfiles.path":          "%s path",
+	"hostfiles.title":         "Compare/copy files on two hosts",
+	"hostfiles.stat_failed":   "cannot get information about %s",
+	"hostfiles.too_large":     "%s is larger than %s; not showing a diff",
+	"hostfiles.loading":       "[yellow]Fetching file contents...[-]",
+	"hostfiles.diff_title":    "File diff (ESC: back)",
+	"hostfiles.diff_failed":   "[red]Failed to compute the diff: %s[-]",
+	"hostfiles.identical":     "[green]Both files are identical[-]",
+	"hostfiles.copy_confirm":  "[yellow]Copy %s to %s?[-]",
+	"hostfiles.protected":     "\n\n[red]The destination is in a protected environment; an existing file with the same name will be overwritten[-]",
+	"hostfiles.confirm_title": "Confirm copy",
+	"hostfiles.progress":      "Copy %s:%s → %s:%s",
+	"hostfiles.done":          "[green]Copy complete[-] %s in %s",
+
+	// 执行SQL文件
+	"sqlfile.unsupported":     "[red]Module %s does not support running SQL files[-]",
+	"sqlfile.prompt_title":    "Run SQL file (%d targets)",
+	"sqlfile.prompt_label":    "SQL file: ",
+	"sqlfile.unreadable":      "[red]Cannot read SQL file: %s[-]",
+	"sqlfile.preview_title":   "Run SQL file %s",
+	"sqlfile.no_targets":      "No targets",
+	"sqlfile.results_title":   "Results - %s (/: search, ESC/Q: stop and close)",
+	"sqlfile.waiting":         "waiting",
+	"sqlfile.paused":          "%s failed; paused (C: continue with the remaining %d targets, ESC/Q: stop and close)",
+	"sqlfile.progress":        "Running SQL file %s",
+	"sqlfile.progress_target": "%s (%d/%d)",
+	"sqlfile.cancelled":       "Results - %s - cancelled (ESC/Q: close)",
+
+	// 命令行
+	"cli.flag_env":           "only check matching environments (prod/test/dev aliases are supported)",
+	"cli.flag_module":        "only check the given module (e.g. ssh, mysql)",
+	"cli.flag_format_check":  "report format: table or json",
+	"cli.flag_timeout":       "timeout for checking a single connection",
+	"cli.flag_concurrency":   "number of concurrent checks",
+	"cli.no_match":           "no matching connections",
+	"cli.summary":            "\n%d connections, %d failed\n",
+	"cli.flag_interval":      "check interval",
+	"cli.no_down_rules":      "no automation rules for the down event are configured (automation.rules)",
+	"cli.watching":           "watching %d connections every %s\n",
+	"cli.rule_fired":         "%s rule %s fired: %s has been unreachable for %s\n",
+	"cli.hook_failed":        "%s rule %s hook failed: %v\n",
+	"cli.flag_format_report": "report format: markdown or html",
+	"cli.unsupported_format": "unsupported report format: %s",
+
+	// 诊断面板
+	"diag.output":         "Output",
+	"diag.metric":         "Metric",
+	"diag.value":          "Value",
+	"diag.unsupported":    "[red]The %s module has no diagnostics panel[-]",
+	"diag.hint":           "[gray]Tab/H/L: switch tab, ↑↓/JK: scroll, R: refresh, ESC/Q: close[-]",
+	"diag.title":          "Diagnostics - %s (%s:%d)",
+	"diag.tab.activity":   "Active queries",
+	"diag.tab.locks":      "Lock waits",
+	"diag.tab.slow_stats": "Slow query stats",
+	"diag.tab.db_stats":   "Database stats",
+	"diag.tab.slowlog":    "Slow log",
+	"diag.tab.keyspace":   "Keyspace",
+	"diag.tab.stats":      "Runtime stats",
+	"diag.tab.clients":    "Clients",
+
+	// 自动更新
+	"update.parse_release":     "parse release info: %w",
+	"update.available":         "[yellow]New version %s available (current %s), run connectionmanager self-update to upgrade[-]",
+	"update.bad_key":           "invalid signing public key",
+	"update.bad_signature":     "checksum file signature verification failed",
+	"update.no_key":            "this build has no embedded release signing key and cannot verify the update source; download the new version manually",
+	"update.no_asset":          "release %s has no %s",
+	"update.no_checksums":      "release %s has no %s, cannot verify",
+	"update.no_signature":      "release %s has no signature file %s",
+	"update.signature_ok":      "Signature verified",
+	"update.no_checksum":       "%s has no checksum for %s",
+	"update.downloading":       "Downloading %s ...\n",
+	"update.checksum_mismatch": "checksum mismatch: expected %s, got %s",
+	"update.checksum_ok":       "Checksum verified",
+	"update.check_failed":      "Update check failed: %v\n",
+	"update.latest":            "Already at the latest version %s\n",
+	"update.newer":             "Current version %s, latest version %s %s\n",
+	"update.failed":            "Update failed: %v\n",
+	"update.updated":           "Updated to %s\n",
+
+	// 导入 ssh 配置
+	"sshconfig.include_depth": "%s: Include nested too deeply",
+	"sshconfig.no_env":        "no project and environment can hold SSH connections",
+	"sshconfig.audit_from":    "from ",
+	"sshconfig.import_failed": "[red]Import ssh config failed: %s[-]",
+	"sshconfig.staged":        "[green]Staged %d connections from ssh config, press R in the module column to review[-]",
+	"sshconfig.imported":      "[green]Imported %d connections from ssh config[-]",
+	"sshconfig.read_failed":   "[red]Read ssh config failed: %s[-]",
+	"sshconfig.cancelled":     "Reading ssh config cancelled",
+	"sshconfig.no_hosts":      "[yellow]No importable hosts in %s[-]",
+	"sshconfig.title":         "Import %s - %d hosts (Space: mark, Enter: preview import, A: preview import all, ESC: back; = exists)",
+	"sshconfig.preview_title": "Import ssh config",
+	"sshconfig.nothing_new":   "No new hosts to import",
+
+	// 清单报告
+	"report.never_used":      "never used",
+	"report.counts":          "Connections per module/environment",
+	"report.col_count":       "Count",
+	"report.unused":          "Connections unused for over %d days",
+	"report.col_last_used":   "Last used",
+	"report.failing":         "Connections that failed their last check",
+	"report.col_checked":     "Checked at",
+	"report.markdown_header": "# Connection inventory report\n\nGenerated: %s, total connections: %d\n",
+	"report.none_markdown":   "None\n",
+	"report.html_header":     "<h1>Connection inventory report</h1>\n<p>Generated: %s, total connections: %d</p>\n",
+	"report.none_html":       "<p>None</p>\n",
+	"report.title":           "Inventory report (M: copy Markdown, H: copy HTML, ESC: back)",
+	"report.copied":          "[green]Copied the %s report to the clipboard[-]",
+
+	// 后台服务
+	"daemon.attached":        "[green]Connected to the background service[-] | restored %d sessions, %d tunnels",
+	"daemon.stop_failed":     "Stop failed: %v\n",
+	"daemon.stopped":         "Background service stopped",
+	"daemon.unknown_arg":     "Unknown daemon argument: %s (use status or stop)\n",
+	"daemon.start_failed":    "Start failed: %v\n",
+	"daemon.already_running": "Background service is already running",
+	"daemon.started":         "Background service started: %s\n",
+	"daemon.exited":          "Background service exited",
+	"daemon.tunnel_ready":    "Tunnel ready: %s → %s:%d",
+	"daemon.session_managed": "Managing session: %s",
+	"daemon.unknown_request": "unknown request: %s",
+	"daemon.session_ended":   "Session ended: %s (lasted %s)",
+	"daemon.tunnel_count":    "%d tunnels\n",
+	"daemon.tunnel_idle":     "  %s → 127.0.0.1:%d idle %s\n",
+	"daemon.session_count":   "%d sessions\n",
+	"daemon.tunnel_line":     "  %s → 127.0.0.1:%d [gray](background)[-]%s\n",
+	"daemon.not_running":     "background service is not running",
+
+	// 快速添加
+	"quickadd.clipboard_empty":    "clipboard is empty",
+	"quickadd.no_ssh_host":        "the ssh command has no target host",
+	"quickadd.invalid_uri":        "invalid URI: %w",
+	"quickadd.unsupported_scheme": "unsupported scheme: %s",
+	"quickadd.no_uri_host":        "the URI has no host",
+	"quickadd.unrecognized":       "unrecognized connection text: %s",
+	"quickadd.no_module":          "[red]No module of type %s[-]",
+	"quickadd.no_env":             "[red]The module has no environment to add to[-]",
+	"quickadd.add_to":             "Add to",
+	"quickadd.duplicate":          "[red]%s already has a connection named %s[-]",
+	"quickadd.staged":             "[green]Staged new connection %s, press R in the module column to review[-]",
+	"quickadd.added":              "[green]Added connection %s[-]",
+	"quickadd.title":              "New connection - %s",
+
+	// 凭据库
+	"credstore.corrupt":            "credential store is corrupt: %w",
+	"credstore.locked_read":        "credential store is locked, cannot read %s",
+	"credstore.missing":            "credential store has no %s",
+	"credstore.locked":             "credential store is locked",
+	"credstore.passphrase":         "Master password",
+	"credstore.confirm_passphrase": "Confirm master password",
+	"credstore.created":            "[green]Credential store created[-]",
+	"credstore.unlocked":           "[green]Credential store unlocked[-]",
+	"credstore.unlock_title":       "Unlock credential store",
+	"credstore.create_title":       "Set credential store master password",
+	"credstore.save_failed":        "[red]Saving password failed: %s[-]",
+	"credstore.audit_password":     "connection password",
+	"credstore.wrong_passphrase":   "wrong master password",
+
+	// 批量编辑
+	"bulk.no_targets": "[red]Select a connection or mark connections with V first[-]",
+	"bulk.action":     "Bulk edit",
+	"bulk.title":      "Bulk edit - %d connections",
+	"bulk.nothing":    "No connections need changes",
+	"bulk.set_field":  "Set field",
+	"bulk.add_tag":    "Add tag",
+	"bulk.remove_tag": "Remove tag",
+
+	// 文件浏览器
+	"browser.ssh_only":        "[red]The file browser only supports SSH connections[-]",
+	"browser.trashed_hint":    "[green]Moved %s to the trash, press U before %s to undo[-] [gray]D: delete, R: refresh, ESC: close[-]",
+	"browser.hint":            "[gray]Enter: open directory/archive, Backspace: parent directory, E: edit, Ctrl+E: edit as root, D: delete, U: undo delete, R: refresh, ESC: close[-]",
+	"browser.title":           "Files - %s:%s",
+	"browser.confirm_trash":   "[yellow]Move %s to the trash %s?[-]\n\n[gray]Press U within %s to undo[-]",
+	"browser.confirm_delete":  "[red]Permanently delete %s? This cannot be undone[-]",
+	"browser.deleting":        "[yellow]Deleting...[-]",
+	"browser.nothing_to_undo": "[red]Nothing to undo (the undo window has passed or the delete was permanent)[-]",
+	"browser.restoring":       "[yellow]Restoring...[-]",
+	"browser.restore_failed":  "[red]Restore failed: %s[-]",
+
+	// 连接详情
+	"details.header":     "\n[yellow]Connection details[-]\n",
+	"details.id":         "  ID: %s\n",
+	"details.address":    "  Address: %s:%d",
+	"details.user":       "  User: %s",
+	"details.database":   "  Database: %s",
+	"details.host_time":  "  Host time: %s\n",
+	"details.proxy":      "  Proxy command: %s\n",
+	"details.jump_error": "  Jump host: [red]%s[-]\n",
+	"details.jump":       "  Jump host: %s\n",
+	"details.tunnel":     "  SSH tunnel: %s\n",
+	"details.addresses":  "  Fallback addresses: %s\n",
+	"details.endpoint":   "  Chosen endpoint: %s %s\n",
+	"details.path":       "  Connection path: %s\n",
+	"details.note":       "  [yellow]Note:[-] %s [gray](%s %s)[-]\n",
+	"details.banner":     "  [gray]Banner/MOTD (%s):[-]\n",
+
+	// 导入状态文件
+	"stateimport.parse_failed":   "parse state file: %w",
+	"stateimport.flag_project":   "import into the project whose name matches (default: the first project of each module)",
+	"stateimport.flag_env":       "import into the matching environment (prod/test/dev aliases supported)",
+	"stateimport.flag_dry_run":   "only show the connections that would be imported",
+	"stateimport.usage":          "Usage: import [--project name] [--env environment] [--dry-run] <state file|directory|->",
+	"stateimport.empty":          "state is empty",
+	"stateimport.read_failed":    "Reading state failed: %s\n",
+	"stateimport.header":         "Connection\tAddress\tTags",
+	"stateimport.skipped":        "Skipping %s: no module, project or environment can hold it\n",
+	"stateimport.total":          "%d connections in total\n",
+	"stateimport.save_failed":    "Save failed: %s\n",
+	"stateimport.staged":         "Staged %d connections, press R in the module column to review\n",
+	"stateimport.imported":       "Imported %d connections\n",
+	"stateimport.prompt_title":   "Import from Terraform/Pulumi state",
+	"stateimport.prompt_label":   "State file or directory: ",
+	"stateimport.need_path":      "[red]Enter a state file or a Terraform/Pulumi directory[-]",
+	"stateimport.progress_title": "Import infrastructure state",
+	"stateimport.cancelled":      "Infrastructure state import cancelled",
+	"stateimport.nothing":        "No importable connections in the state",
+	"stateimport.skipped_count":  ", [yellow]skipped %d resources with no place to store them[-]",
+
+	// 晋升连接
+	"promote.no_target":       "[red]No target environment to promote to[-]",
+	"promote.target_env":      "Target environment",
+	"promote.target_user":     "Target user",
+	"promote.target_identity": "Target identity file",
+	"promote.button":          "Promote",
+	"promote.confirm":         "[yellow]Promote %s to %s?[-]",
+	"promote.confirm_title":   "Confirm promotion",
+	"promote.title":           "Promote connection - %s",
+	"promote.failed":          "[red]Promotion failed: %s[-]",
+	"promote.staged":          "[green]Staged the promotion to %s, press R in the module column to review[-]",
+	"promote.done":            "[green]Promoted to %s[-]",
+
+	// 主机备注
+	"notes.title":          "Host notes - %s (N: new, Enter: resolve/reopen, D: delete, ESC: back)",
+	"notes.col_author":     "Author",
+	"notes.col_text":       "Text",
+	"notes.open":           "open",
+	"notes.resolved":       "resolved",
+	"notes.save_failed":    "[red]Saving note failed: %s[-]",
+	"notes.new":            "New note",
+	"notes.text_label":     "Text: ",
+	"notes.delete":         "Delete note",
+	"notes.confirm_delete": "Delete the note \"%s\"?",
+
+	// 依赖关系
+	"deps.cycle":        "dependency cycle: %s",
+	"deps.cycle_marker": "%s[red](cycle)[-]\n",
+	"deps.title":        "Dependencies - %s (ESC: back)",
+	"deps.upstream":     "[blue]Depends on:[-]\n",
+	"deps.none":         "  (none)\n",
+	"deps.downstream":   "\n[blue]Depended on by:[-]\n",
+	"deps.cascade":      "\n[red]Cascading failure: these upstream connections are down and may break this one:[-]\n",
+	"deps.checking":     "[gray](checking)[-]",
+	"deps.progress":     "Checking dependencies",
+	"deps.cancelled":    "[gray](cancelled)[-]",
+	"deps.not_found":    "[red](not found)[-]",
+	"deps.ok":           "[green](ok %s)[-]",
+	"deps.down":         "[red](down)[-]",
+
+	// 事务保护
+	"tx.exec_failed":        "statement failed, the transaction was rolled back",
+	"tx.exec_timeout":       "statement timed out, the transaction was rolled back",
+	"tx.client_gone":        "client exited: %w",
+	"tx.finish_timeout":     "timed out waiting for the client to end the transaction, outcome unknown",
+	"tx.client_failed":      "client exited abnormally: %w",
+	"tx.commit_rolled_back": "commit failed, the server rolled back the transaction",
+	"tx.running":            "[yellow]Running in a transaction...[-]",
+	"tx.title":              "Confirm transaction",
+	"tx.pending":            "[yellow]Statement ran in a transaction (%s) and is not committed yet:[-]\n%s\n\n",
+	"tx.mysql_ddl":          "[red]Warning: MySQL DDL commits implicitly, rollback cannot undo this statement[-]\n\n",
+	"tx.keys":               "[green]C: COMMIT[-]    [red]R/ESC: ROLLBACK[-]    [gray](automatic rollback in %s)[-]",
+	"tx.finishing":          "[yellow]Ending transaction...[-]",
+	"tx.commit_failed":      "[red]Transaction commit failed: %s[-]",
+	"tx.finish_failed":      "[red]Ending the transaction failed: %s[-]",
+	"tx.committed":          "[green]Transaction committed[-]",
+	"tx.rolled_back":        "[yellow]Transaction rolled back[-]",
+
+	// 表单校验
+	"validate.required":      "must not be empty",
+	"validate.port":          "port must be an integer between 1 and 65535",
+	"validate.host":          "not a valid host name or IP address",
+	"validate.no_file":       "file does not exist",
+	"validate.is_dir":        "must not be a directory",
+	"validate.template":      "template syntax error: ",
+	"validate.redis_db":      "Redis database number must be a non-negative integer",
+	"validate.subnet":        "not a valid subnet (e.g. 192.168.1.0/24)",
+	"validate.regexp":        "regular expression error: ",
+	"validate.no_connection": "connection not found (enter an ID of the form module/project/env/connection)",
+	"validate.ssh_only":      "must be an SSH connection",
+
+	// 保存密钥
+	"secret.reveal":              "Ctrl+R show/hide",
+	"secret.mismatch":            "the two entries do not match",
+	"secret.no_backend":          "no secret backend configured (secrets.backend), cannot save %s",
+	"secret.unsupported_backend": "unsupported secret backend: %s",
+	"secret.write_failed":        "writing to %s failed: %w",
+	"secret.name":                "Secret name",
+	"secret.confirm":             "Confirm password",
+	"secret.saving":              "[yellow]Saving secret %s...[-]",
+	"secret.saved":               "[green]Saved secret %s, reference it in become as secret:%s[-]",
+	"secret.title":               "Save secret - %s (%s)",
+
+	// 审阅暂存修改
+	"review.staged":          "[green]Staged %d changes, press R in the module column to review[-]",
+	"review.changed":         "[green]Changed %d fields[-]",
+	"review.nothing":         "no staged changes",
+	"review.title":           "Review staged changes (Y: commit, D: discard, ESC: back)",
+	"review.nothing_view":    "[gray]No staged changes[-]",
+	"review.commit_title":    "Commit changes",
+	"review.message_label":   "Message: ",
+	"review.default_message": "Update %d connection fields, add %d connections",
+	"review.commit_failed":   "[red]Commit failed: %s[-]",
+	"review.committed":       "[green]Staged changes committed[-]",
+	"review.discard_title":   "Discard changes",
+	"review.discard_confirm": "[red]Discard all staged changes?[-]",
+	"review.discarded":       "[green]Staged changes discarded[-]",
+
+	// 时间与时长
+	"time.just_now":        "just now",
+	"time.relative_future": "in %d%s",
+	"time.relative_past":   "%d%s ago",
+	"time.relative_unit.m": "m",
+	"time.relative_unit.h": "h",
+	"time.relative_unit.d": "d",
+	"time.milliseconds":    "%s%dms",
+	"time.seconds":         "%s%.1fs",
+	"time.unit.d":          "d",
+	"time.unit.h":          "h",
+	"time.unit.m":          "m",
+	"time.unit.s":          "s",
+	"time.unit_separator":  " ",
+
+	// 传输日志
+	"journal.process_exited":  "process exited",
+	"journal.title":           "Unfinished transfers (Enter: resume, D: abandon, ESC: back)",
+	"journal.col_recipe":      "Recipe",
+	"journal.col_started":     "Started",
+	"journal.col_progress":    "Last progress",
+	"journal.col_reason":      "Interrupted by",
+	"journal.empty":           "(no unfinished transfers)",
+	"journal.confirm_resume":  "[yellow]Resume transfer %s?[-]\n\n[gray]%s[-]",
+	"journal.scp_restart":     "\n\n[red]scp cannot resume, the transfer will start over[-]",
+	"journal.resume":          "Resume transfer",
+	"journal.abandon":         "Abandon transfer",
+	"journal.confirm_abandon": "[yellow]Abandon %s? Partially transferred files are not deleted[-]",
+	"journal.pending":         "[yellow]%d unfinished transfers, press R on a connection to open transfer recipes, then J to resume[-]",
+
+	// 导出结果集
+	"export.unsupported": "unsupported export format: %s",
+	"export.nothing":     "[red]No results to export[-]",
+	"export.format":      "Format",
+	"export.file":        "File",
+	"export.clipboard":   "Clipboard",
+	"export.path":        "File path",
+	"export.button":      "Export",
+	"export.failed":      "[red]Export failed: %s[-]",
+	"export.copied":      "[green]Copied %d rows of %s to the clipboard[-]",
+	"export.written":     "[green]Exported %d rows to %s[-]",
+	"export.title":       "Export results",
+
+	// 执行计划
+	"explain.write_refused":  "[red]EXPLAIN ANALYZE really runs the statement and is not allowed for writes[-]",
+	"explain.loading":        "[yellow]Fetching the execution plan...[-]",
+	"explain.fetch_failed":   "[red]Fetching the execution plan failed: %s[-]",
+	"explain.parse_failed":   "[red]Parsing the execution plan failed: %s[-]",
+	"explain.done":           "[green]Execution plan ready[-] took %s",
+	"explain.empty":          "execution plan is empty",
+	"explain.actual":         "actual time=%.3fms loops=%v",
+	"explain.no_query_block": "execution plan has no query_block",
+	"explain.full_scan":      " [yellow]⚠ full table scan[-]",
+	"explain.title":          "Execution plan (EXPLAIN)",
+	"explain.title_analyze":  "Execution plan (EXPLAIN ANALYZE)",
+
+	// 归档浏览
+	"archive.title":      "Archive - %s:%s",
+	"archive.hint":       "[gray]Enter/X: extract locally, ESC: back[-]",
+	"archive.loading":    "[yellow]Reading the archive listing...[-]",
+	"archive.count":      "%s [gray]%d entries[-]",
+	"archive.extract":    "Extract locally",
+	"archive.local_path": "Local path: ",
+	"archive.extracting": "Extract ",
+	"archive.failed":     "[red]Extract failed: %s[-]",
+	"archive.done":       "[green]Extracted to %s[-]",
+
+	// 正则替换
+	"replace.all_fields":     "All fields",
+	"replace.current_module": "Current module",
+	"replace.all_modules":    "All modules",
+	"replace.scope":          "Scope",
+	"replace.pattern":        "Pattern",
+	"replace.replacement":    "Replace with",
+	"replace.action":         "Regex replace",
+	"replace.dry_run":        "Dry run",
+	"replace.title":          "Regex find and replace ($1 refers to a group in the replacement)",
+
+	// 远程编辑
+	"editor.reading":          "[yellow]Reading %s...[-]",
+	"editor.read_failed":      "[red]Read failed: %s[-]",
+	"editor.crashed":          "[red]The editor exited abnormally: %s[-]",
+	"editor.unchanged":        "[gray]File not modified[-]",
+	"editor.saving":           "[yellow]Saving %s...[-]",
+	"editor.check_failed":     "[red]Checking the remote file failed: %s[-]",
+	"editor.conflict":         "File conflict",
+	"editor.conflict_confirm": "[red]%s was modified while you were editing.[-]\n\nOverwrite it with the local version anyway?",
+	"editor.write_failed":     "[red]Write back failed: %s[-]",
+	"editor.saved":            "[green]Saved %s (%s)[-]",
+
+	// 查询控制台
+	"console.unsupported":    "[red]The %s module has no query console[-]",
+	"console.placeholder":    "Enter SQL, F5 or Ctrl+Enter to run, Ctrl+↑/↓ for history",
+	"console.hint":           "[gray]F5/Ctrl+Enter: run, Ctrl+↑/↓: previous/next, F3: history, F6: export, F7/F9: EXPLAIN/ANALYZE, F8: toggle transaction guard, Tab: switch input/results, /: search results, ESC: close[-]",
+	"console.results":        "Results",
+	"console.title":          "Query console - %s (%s:%d)",
+	"console.failed":         "[red]Failed (%s): %s[-]",
+	"console.succeeded":      "[green]Succeeded[-] %d rows, took %s",
+	"console.guard_required": "[red]The transaction guard cannot be turned off in a protected environment[-]",
+	"console.guard_on":       "[green]Transaction guard on: writes run in a transaction and wait for confirmation[-]",
+	"console.guard_off":      "[yellow]Transaction guard off: writes run directly[-]",
+
+	// VPN
+	"vpn.down_marker": " [red]VPN %s not connected[-]",
+	"vpn.not_found":   "[red]VPN profile not found: %s[-]",
+	"vpn.no_up":       "[red]VPN %s is not connected and has no start command[-]",
+	"vpn.down_title":  "VPN not connected",
+	"vpn.confirm_up":  "[yellow]%s needs VPN %s, start it?[-]\n\n[gray]%s[-]",
+	"vpn.starting":    "Starting VPN %s: %s\n",
+	"vpn.up_failed":   "[red]Starting VPN %s failed: %s[-]",
+	"vpn.waiting":     "[yellow]Waiting for VPN %s to connect...[-]",
+	"vpn.timeout":     "[red]VPN %s start command ran, but no connection was detected within %s[-]",
+	"vpn.up":          "[green]VPN %s connected[-]",
+
+	// 环境对比
+	"envdiff.only_a":       "[red]- %s[-] only in A\n",
+	"envdiff.only_b":       "[green]+ %s[-] only in B\n",
+	"envdiff.summary":      "\n[gray]%d connections, %d identical[-]",
+	"envdiff.too_few":      "[red]The current module has fewer than two environments[-]",
+	"envdiff.env_a":        "Environment A",
+	"envdiff.env_b":        "Environment B",
+	"envdiff.compare":      "Compare",
+	"envdiff.title":        "Compare environments - %s",
+	"envdiff.result_title": "Compare environments - %s (ESC: back)",
+
+	// 认证重试
+	"authretry.agent":          "Use ssh-agent",
+	"authretry.saved_password": "Use the saved password",
+	"authretry.key":            "Use key ",
+	"authretry.enter_password": "Enter password...",
+	"authretry.details":        "Show error details",
+	"authretry.title":          "%s authentication failed, retry (Enter: choose, ESC: back)",
+	"authretry.save_password":  "Save password",
+	"authretry.connect":        "Connect",
+	"authretry.password_title": "Enter password - %s",
+
+	// URL 协议
+	"urlhandler.no_module":         "no module of type %s",
+	"urlhandler.staged":            "[yellow]The new connection is staged, it becomes available after review (R in the module column)[-]",
+	"urlhandler.removed":           "Removed %s\n",
+	"urlhandler.written":           "Wrote %s\n",
+	"urlhandler.registered":        "Registered %s\n",
+	"urlhandler.registered_scheme": "Registered %s://\n",
+	"urlhandler.needs_bundle":      "%s needs an application bundle to register a URL scheme; run %s <URI> in a terminal",
+	"urlhandler.failed":            "Registration failed: %v\n",
+
+	// SSH 会话
+	"session.last_login":  "Last connection: %s at %s",
+	"session.note":        "Note: %s (%s, %s)",
+	"session.maintenance": "Under maintenance: ",
+	"session.until":       " (until ",
+	"session.until_end":   ")",
+	"session.local_time":  "%s local time: %s",
+	"session.recording":   "recording: ",
+	"session.failed":      "[red]SSH session ended abnormally: %s[-]",
+	"session.ended":       "[green]SSH session ended[-] | duration %s | %s | %s",
+
+	// 远程图形程序
+	"gui.ssh_only":      "[red]Remote GUI apps only support SSH connections[-]",
+	"gui.no_display":    "[red]DISPLAY is not set locally, cannot forward X11[-]",
+	"gui.title":         "Remote GUI apps - %s (Enter: launch, ESC: back)",
+	"gui.none":          "(gui_apps not configured)",
+	"gui.launch_failed": "[red]Launch failed: %s[-]",
+	"gui.launched":      "[green]Launched %[2]s on %[1]s[-]",
+	"gui.crashed":       "[red]%s exited abnormally: %s[-]",
+
+	// 工作区
+	"workspace.load_failed":  "[red]Loading the workspace failed: %s[-]",
+	"workspace.switched":     "[green]Switched to workspace: %s[-]",
+	"workspace.default":      "default",
+	"workspace.title":        "Workspaces (Enter: switch, N: new, ESC: back)",
+	"workspace.current":      " [green](current)[-]",
+	"workspace.new":          "New workspace",
+	"workspace.invalid_name": "invalid workspace name: %s",
+
+	// 修改预览
+	"preview.title":          "%s - preview of %d items (Y: apply, /: search, ESC: cancel)",
+	"preview.commands":       "Commands to run",
+	"preview.confirm":        "[yellow]Apply %s (%d items)?[-]",
+	"preview.confirm_prefix": "Confirm: ",
+	"preview.col_new":        "New connection",
+
+	// 执行命令
+	"exec.ssh_only":      "[red]Running commands only supports SSH connections[-]",
+	"exec.title":         "Run command - %s",
+	"exec.confirm_title": "Confirm run",
+	"exec.confirm":       "[red]%s is in a protected environment[-]\n\nRun: %s",
+	"exec.output_title":  "%s $ %s (/: search, ESC: back)",
+	"exec.done":          "\n\n[green]Done[-] took %s",
+
+	// 钉住连接
+	"watch.save_failed": "[red]Saving the pinned list failed: %s[-]",
+	"watch.unpinned":    "[yellow]Unpinned %s[-]",
+	"watch.pinned":      "[green]Pinned %s[-]",
+	"watch.maintenance": " [blue]maintenance[-]",
+	"watch.down":        "[red]%s became unreachable[-]",
+	"watch.up":          "[green]%s recovered[-]",
+	"watch.jump_hint":   " | ;: jump",
+
+	// 空闲会话
+	"idle.times":           " [gray]opened %s · active %s[-]",
+	"idle.in_use":          " [green]in use[-]",
+	"idle.idle":            " [yellow]idle %s[-]",
+	"idle.audit_timeout":   "idle timeout",
+	"idle.closed":          "Closed idle tunnel %s",
+	"idle.closing":         "Tunnel %s has been idle for %s and closes in %s (connect again to keep it)",
+	"idle.session_warning": "\r\n*** Session idle for %s, it disconnects automatically in about %s ***\r\n",
+
+	// 会话回滚
+	"scrollback.none":     "[yellow]%s has no session scrollback in this run yet[-]",
+	"scrollback.title":    "Session scrollback - %s %d lines (/: search, S: save to file, Y: copy, ESC: back)",
+	"scrollback.failed":   "[red]Exporting scrollback failed: %s[-]",
+	"scrollback.exported": "[green]Exported %d lines of scrollback to %s[-]",
+	"scrollback.save":     "Save session scrollback",
+
+	// 查询历史
+	"history.title":     "Query history - %s (Enter: run again, ESC: back)",
+	"history.col_query": "Query",
+
+	// 数据库客户端
+	"dbclient.template":   "client command template error: %w",
+	"dbclient.connecting": "[yellow]Connecting to %s...[-]",
+	"dbclient.local_time": "%s local time: %s\n",
+	"dbclient.failed":     "[red]Client ended abnormally: %s[-]",
+	"dbclient.ended":      "[green]%s session ended[-] | duration %s",
+
+	// 端口探测
+	"probe.port_error":  "%s port %d: %w",
+	"probe.timeout":     "%s port %d unreachable (timed out after %s)",
+	"probe.refused":     "%s port %d refused the connection",
+	"probe.unreachable": "%s port %d unreachable: %w",
+	"probe.probing":     "[yellow]Probing %s...[-]",
+	"probe.failed":      "[red]%s[-] | !: error details",
+
+	// 健康检查
+	"health.not_ssh":              "not an SSH service",
+	"health.rejected":             "rejected the handshake",
+	"health.not_postgres":         "not a PostgreSQL service",
+	"health.not_redis":            "not a Redis service",
+	"health.no_handshake_timeout": "no %s handshake (timed out after %s)",
+	"health.no_handshake":         "no %s handshake: %w",
+
+	// 传输校验
+	"verify.source_failed":     "computing source checksums failed: %w",
+	"verify.target_failed":     "computing target checksums failed: %w",
+	"verify.missing_in_target": ": missing in target",
+	"verify.mismatch":          "%s: source %.12s target %.12s",
+	"verify.missing":           "path does not exist",
+
+	// 可用性
+	"uptime.none": "  [gray]Availability: no health check records yet[-]\n",
+	"uptime.24h":  "24h",
+	"uptime.30d":  "30d",
+	"uptime.line": "  Availability %-6s %s %s\n",
+
+	// 隧道
+	"tunnel.cloudflare_login": "Cloudflare Access login failed: %s",
+	"tunnel.start_failed":     "starting the tunnel failed: %w",
+	"tunnel.exited":           "tunnel process exited: %s",
+	"tunnel.timeout":          "timed out waiting for the tunnel (%s)",
+
+	// 跳板
+	"jump.not_found": "jump host connection not found: %s",
+	"jump.not_ssh":   "jump host must be an SSH connection: %s",
+	"jump.cycle":     "jump hosts form a cycle: %s",
+	"jump.too_deep":  "more than %d jump hosts",
+
+	// 凭据审计
+	"credaudit.unused_keys":  "Private keys not used by any connection (~/.ssh)",
+	"credaudit.missing_keys": "Private keys referenced by connections but missing",
+	"credaudit.orphaned":     "Become passwords that match no connection or environment",
+	"credaudit.broken":       "Unreadable password sources",
+
+	// 确认短语
+	"confirm.phrase":   "\n%s\n\nType [yellow::b]%s[-::-] to confirm",
+	"confirm.label":    "Confirm: ",
+	"confirm.keys":     " (Enter: confirm, ESC: cancel)",
+	"confirm.mismatch": "[red]No match, try again:[-] ",
+
+	// 提权
+	"become.env_unset":          "environment variable %s is not set",
+	"become.password_failed":    "getting the become password failed: %w",
+	"become.unsupported_source": "unsupported password source: %s",
+	"become.unsupported_method": "unsupported become method: %s",
+
+	// 连接路径
+	"sshpath.proxy": "proxy command ",
+	"sshpath.local": "localhost",
+
+	// 会话标签
+	"tabs.no_session": "[yellow]No session number %d[-]",
+	"tabs.not_found":  "[red]Connection not found: %s[-]",
+	"tabs.none":       "  [gray]none[-]\n",
+
+	// 密钥后端
+	"secrets.no_backend":  "no secret backend configured (secrets.backend), cannot read %s",
+	"secrets.read_failed": "reading %[2]s from %[1]s failed: %[3]w",
+
+	// 进度
+	"progress.elapsed":    "[gray]Elapsed %s[-]",
+	"progress.cancelling": "  [red]Cancelling...[-]",
+
+	// Mesh 网络
+	"mesh.parse_failed": "parsing Tailscale status failed: %w",
+
+	// 搜索
+	"search.title":    "Search",
+	"search.no_match": " [/%s no matches]",
+
+	// 主机时间
+	"hosttime.invalid_offset": "invalid time zone offset: %s",
+
+	// 未保存的修改
+	"formguard.title": "Unsaved changes",
+	"formguard.text":  "\nThe form has unsaved changes that are lost when it closes\n\n[green]Submit (S)[-]    [red]Discard (D)[-]    [yellow]Keep editing (C/ESC)[-]\n",
+
+	// 变化提醒
+	"flash.none": "[yellow]No connection has changed state yet[-]",
+
+	// 端点选择
+	"endpoint.all_down": "%s (all %d candidates unreachable, using the primary address)",
+	"endpoint.chosen":   "%s (latency %s, %d/%d candidates reachable)",
+
+	// 服务发现
+	"discovery.unsupported": "unsupported discovery type: %s",
+	"discovery.failed":      "[red]Service discovery failed: %s[-]",
+
+	// 剪贴板
+	"clipboard.no_copy":  "no clipboard command found (pbcopy/wl-copy/xclip/xsel)",
+	"clipboard.no_paste": "no clipboard command found (pbpaste/wl-paste/xclip/xsel)",
+
+	// 启动位置
+	"startup.not_found": "[red]Start location not found: %s[-]",
+
+	// 代理命令
+	"proxy.template": "proxy command template error: %w",
+
+	// 系统钥匙串
+	"keychain.write_failed": "writing to the keychain failed: %w",
+
+	// 主机时钟
+	"clock.unknown_zone": " [gray]│ host time zone unknown[-]",
+
+	// 带宽限制
+	"bandwidth.invalid": "invalid bandwidth: %s",
+
+	// 回收站
+	"trash.exists": "the original path already exists",
+}
@@ -0,0 +1,1138 @@
+package main
+
+// 中文界面文本
+var messagesZh = map[string]string{
+	// 面板标题
+	"title.modules":      "模块选择",
+	"title.main":         "主要内容",
+	"title.details":      "详情",
+	"title.sessions":     "会话",
+	"title.status":       "状态",
+	"title.clock":        "时钟",
+	"title.confirm_exit": "确认退出",
+	"title.watch":        "监视",
+	"main.title":         "%s 连接管理",
+
+	// 状态栏与退出确认
+	"status.ready":     "准备就绪...",
+	"status.tree":      "[yellow]状态: %s[-] | [blue]模块: %s[-] | [green]层级: %s[-] | [gray]↑↓/JK: 导航, 数字: 计数, [/]: 同级, Space: 展开/收缩, Ctrl+P: 跳转, F2: 会话, g/\\: 组合键, 1-4: 布局, Z: 放大, ESC: 退出[-]",
+	"status.modules":   "[yellow]状态: %s[-] | [blue]当前模块: %s[-] | [green]悬停: %s[-] | [gray]←→/H/L: 导航, Enter/Space: 选择, W: 工作区, R: 审阅修改, D: 局域网发现, I: 导入 ssh 配置, S: 导入基础设施状态, T: 隧道, F2: 会话, Ctrl+P: 跳转, 1-4: 布局, Alt+Z: 放大, Q: 退出[-]",
+	"level.project":    "项目",
+	"level.env":        "环境",
+	"level.connection": "连接",
+	"confirm.exit":     "\n[yellow]确定要退出程序吗？[-]\n\n",
+
+	// 模块概览
+	"overview.title":               "[yellow]%s 连接管理概览[-]\n\n",
+	"overview.enter":               "按 [white:blue]Enter[-] 或 [white:blue]Space[-] 进入树状导航模式\n\n",
+	"overview.projects":            "📁 可用项目:\n",
+	"overview.project_connections": "  • %s (%d个环境, %d个连接)\n",
+	"overview.project_instances":   "  • %s (%d个环境, %d个实例)\n",
+	"overview.footer":              "[dim]按 Enter 进入树状导航，在树状模式中可以管理具体的连接[-]",
+
+	// 连接树
+	"tree.maintenance": " [blue]维护中: %s[-]",
+	"hints.edit":       "[yellow]编辑模式[-] - ↑↓/JK: 导航, Space: 展开/收缩, A: 添加同级节点, E: 编辑/重命名, D: 删除, ESC/Ctrl+E: 返回",
+	"hints.project":    "项目级别 - ↑↓/JK: 导航, Space: 展开/收缩, ?: 操作菜单, a: 新建项目, e: 重命名, d: 删除, Ctrl+E: 编辑模式, ;: 最近变化, ESC/Q: 退出",
+	"hints.env":        "环境级别 - ↑↓/JK: 导航, Space: 展开/收缩, ?: 操作菜单, a: 新建环境, e: 重命名, d: 删除, Ctrl+E: 编辑模式, ;: 最近变化, Shift+D: 环境对比, N: 新建连接, Shift+A: 粘贴添加, ESC/Q: 退出",
+	"hints.connection": "连接级别 - ↑↓/JK: 导航, Enter: 连接/断开, ?: 操作菜单, a: 新建连接, e: 编辑, d: 删除, Ctrl+E: 编辑模式, ;: 最近变化, I: 诊断, C: 控制台, G: 依赖, R: 传输配方, F: 两主机文件, B: 浏览文件, Shift+E: 执行命令, W: 图形程序, V: 标记, Shift+M: 批量编辑, S: 正则替换, P: 清单报告, O: 晋升, X: 执行SQL文件, T: 备注, U: 钉住, Y: 会话回滚, !: 错误详情, L: 保存密钥, N: 新建连接, Shift+A: 粘贴添加, Shift+D: 环境对比, ESC/Q: 退出",
+
+	// 连接状态
+	"conn.disconnected": "断开",
+	"conn.connecting":   "连接中",
+	"conn.failed":       "连接失败",
+	"conn.detached":     "后台保持",
+	"conn.online":       "在线",
+	"conn.offline":      "不可达",
+	"conn.unknown":      "检查中",
+	"conn.unchecked":    "未检查",
+	"conn.connected":    "已连接",
+
+	// 操作菜单
+	"menu.title":          "操作 (Enter/快捷键: 执行, ESC: 返回)",
+	"menu.toggle":         "展开/收缩",
+	"menu.add_node":       "添加同级节点",
+	"menu.edit_node":      "编辑/重命名",
+	"menu.delete_node":    "删除",
+	"menu.new_connection": "新建连接",
+	"menu.paste_add":      "从剪贴板粘贴添加",
+	"menu.env_diff":       "环境对比",
+	"menu.connect":        "连接",
+	"menu.diagnostics":    "诊断",
+	"menu.console":        "查询控制台",
+	"menu.sql_file":       "执行 SQL 文件",
+	"menu.exec":           "执行命令",
+	"menu.browse":         "浏览文件",
+	"menu.gui":            "图形程序",
+	"menu.host_files":     "两主机文件对比/复制",
+	"menu.scrollback":     "会话回滚",
+	"menu.copy_command":   "复制连接命令",
+	"menu.recipes":        "传输配方",
+	"menu.dependencies":   "依赖关系",
+	"menu.mark":           "标记/取消标记",
+	"menu.bulk_edit":      "编辑（批量编辑标记的连接）",
+	"menu.replace":        "正则替换",
+	"menu.promote":        "晋升到其他环境",
+	"menu.notes":          "备注",
+	"menu.pin":            "钉住/取消钉住",
+	"menu.secret":         "保存密钥",
+	"menu.edit_mode":      "编辑模式 (Ctrl+E)",
+	"menu.last_changed":   "跳到最近状态变化",
+	"menu.last_failure":   "最近一次错误详情",
+	"menu.report":         "清单报告",
+	"menu.tunnels":        "隧道与端口转发",
+	"menu.reverse":        "反向隧道",
+	"menu.copied_command": "[green]已复制 %s 的连接命令[-]",
+
+	// 跳转面板
+	"palette.title": "跳转 (Enter: 连接/跳转, Tab: 跳转, ↑↓: 选择, ESC: 返回)",
+
+	// 布局与侧边面板
+	"layout.tree":        "仅树",
+	"layout.details":     "树+详情",
+	"layout.session":     "树+会话",
+	"layout.dashboard":   "仪表盘",
+	"layout.applied":     "[green]布局: %s[-]（%s）",
+	"layout.save_failed": "[red]保存布局失败: %s[-]",
+	"details.empty":      "\n[gray]在树中选中连接后显示详情[-]",
+	"sessions.tunnels":   "\n[yellow]本地隧道[-]\n",
+	"sessions.tabs":      "\n[yellow]会话标签[-] [gray](Alt+数字: 切换, Alt+0: 树视图)[-]\n",
+	"sessions.transfers": "\n[yellow]进行中的传输[-]\n",
+	"sessions.recent":    "\n[yellow]最近会话[-]\n",
+	"sessions.none":      "  [gray]无[-]\n",
+
+	// 组合键
+	"chord.title":          "%s …",
+	"chord.more":           "+更多",
+	"chord.unbound":        "未绑定的组合键: %s",
+	"chord.unknown_action": "未知的组合键操作: %s",
+	"chord.top":            "到第一个项目",
+	"chord.bottom":         "到最后一个节点",
+	"chord.expand_env":     "展开当前项目的全部环境",
+	"chord.collapse_all":   "收起全部节点",
+	"chord.test":           "测试连接",
+	"chord.palette":        "跳转",
+	"chord.actions":        "操作菜单",
+	"chord.testing":        "正在测试 %s...",
+	"chord.test_ok":        "%s 可达，延迟 %s",
+	"chord.test_failed":    "%s 不可达: %s",
+	"chord.test_remote":    "%s 经隧道、Teleport、跳板或代理命令连接，无法在本机测试",
+
+	// 重复上一个操作
+	"repeat.none":              "没有可重复的操作",
+	"repeat.select_connection": "请先选中一个连接再重复: %s",
+	"repeat.connect":           "连接",
+	"repeat.test":              "测试连接",
+	"repeat.exec":              "执行 %s",
+	"menu.repeat":              "重复: %s",
+
+	// 计数前缀
+	"motion.count": "计数: %d",
+
+	// 会话管理
+	"sessions.manager_title":     "会话 (Enter/S: 切换, X: 断开, C: 重新连接, R: 刷新, ESC/F2: 返回)",
+	"sessions.manager_empty":     "(本次运行中还没有打开过会话)",
+	"sessions.col_conn":          "连接",
+	"sessions.col_status":        "状态",
+	"sessions.col_uptime":        "在线时长",
+	"sessions.col_count":         "会话次数",
+	"sessions.col_total":         "累计时长",
+	"sessions.col_sent":          "发送",
+	"sessions.col_received":      "接收",
+	"sessions.col_tunnel":        "本地隧道",
+	"sessions.not_held":          "%s 没有保持中的连接",
+	"sessions.disconnecting":     "正在断开 %s...",
+	"sessions.disconnected":      "已断开 %s",
+	"sessions.disconnect_failed": "断开 %s 失败: %v",
+	"menu.sessions":              "会话管理 (F2)",
+	"sessions.closed_manually":   "手动断开",
+
+	// 内嵌终端
+	"pane.title":           "%s (Ctrl+]: 切换焦点)",
+	"pane.focused":         "按键发送到内嵌终端 %s | Ctrl+]: 返回连接树",
+	"pane.fallback":        "无法使用内嵌终端，改为挂起界面运行: %v",
+	"termpane.unsupported": "当前平台不支持内嵌终端",
+
+	// 跳转历史
+	"jump.no_older": "已经是跳转历史中最早的位置",
+	"jump.no_newer": "已经是跳转历史中最新的位置",
+	"jump.position": "跳转历史 %d/%d (Ctrl+O: 后退, Ctrl+I/Tab: 前进)",
+
+	// 书签
+	"mark.set_prompt":  "设置书签: 按字母或数字作为标记（其他键取消）",
+	"mark.jump_prompt": "[yellow]跳到书签:[-] %s | [gray]': 跳转前的位置[-]",
+	"mark.none":        "[gray](没有书签)[-]",
+	"mark.invalid":     "书签标记只能是字母或数字: %s",
+	"mark.saved":       "已设置书签 %s → %s",
+	"mark.save_failed": "保存书签失败: %v",
+	"mark.unset":       "书签 %s 未设置",
+	"mark.missing":     "书签 %s 指向的节点已不存在: %s",
+
+	// 变化提醒
+	"menu.alert":        "开启/关闭变化提醒",
+	"tree.alert":        " [yellow](提醒)[-]",
+	"alert.on":          "已开启 %s 的变化提醒：可达状态变化时响铃并弹出提示",
+	"alert.off":         "已关闭 %s 的变化提醒",
+	"alert.save_failed": "保存变化提醒列表失败: %v",
+	"alert.title":       "连接状态变化",
+	"alert.offline":     "[red]%s 变为不可达[-] [gray]%s[-]",
+	"alert.online":      "[green]%s 已恢复[-] [gray]%s[-]",
+	"flash.none":        "[yellow]还没有连接发生状态变化[-]",
+
+	// Redis 键浏览器
+	"menu.redis":                "键浏览器与命令行",
+	"redis.unsupported":         "%s 模块不支持键浏览器",
+	"redis.title":               "Redis - %s (%s:%d)",
+	"redis.pattern_label":       "SCAN 模式: ",
+	"redis.keys_title":          "键 (%d)",
+	"redis.detail_title":        "详情",
+	"redis.output_title":        "命令输出",
+	"redis.command_placeholder": "输入 Redis 命令，如 GET user:1，Enter 执行，↑/↓ 历史",
+	"redis.hint":                "Enter: 扫描/执行, Tab: 切换区域, 键列表中 R: 重新扫描, /: 修改模式, :: 命令行, ESC: 关闭",
+	"redis.loading":             "读取中...",
+	"redis.key":                 "键",
+	"redis.type":                "类型",
+	"redis.size":                "大小",
+	"redis.ttl_none":            "永久",
+	"redis.key_gone":            "键已不存在",
+	"redis.scanning":            "正在扫描 %s...",
+	"redis.scan_done":           "找到 %d 个键，耗时 %s",
+	"redis.scan_truncated":      "只显示前 %d 个键（耗时 %s），请缩小模式范围",
+	"redis.scan_failed":         "扫描失败: %v",
+	"redis.running":             "执行中...",
+	"redis.command_done":        "执行成功，耗时 %s",
+	"redis.command_failed":      "执行失败: %s",
+	"redis.confirm_title":       "执行 Redis 命令",
+	"redis.confirm_message":     "将在受保护环境中执行写入命令\n[yellow]%s[-]\n目标: %s",
+
+	// 会话录制
+	"menu.recordings":            "会话录制",
+	"recordings.title":           "会话录制 %d 个 (Enter: 查看, A: 导出 asciinema, H: 导出 HTML 回放, ESC/Q: 关闭)",
+	"recordings.col_time":        "时间",
+	"recordings.col_target":      "连接",
+	"recordings.col_size":        "大小",
+	"recordings.col_timing":      "回放",
+	"recordings.timed":           "有计时",
+	"recordings.empty":           "还没有会话录制（在自动化规则中设置 record: true 开启）",
+	"recordings.load_failed":     "读取会话录制失败: %v",
+	"recordings.export_title":    "导出会话录制",
+	"recordings.export_label":    "文件路径: ",
+	"recordings.export_failed":   "导出会话录制失败: %v",
+	"recordings.exported":        "已导出会话录制到 %s",
+	"recordings.untimed":         "（录制没有计时信息，全部输出显示在开头）",
+	"recordings.view_title":      "会话录制 - %s %s %d 行 (/: 搜索, ESC: 返回)",
+	"recordings.overwrite_title": "覆盖文件",
+	"recordings.overwrite":       "%s 已存在，要覆盖吗？",
+	"url.confirm_title":          "打开链接",
+	"url.confirm":                "连接 %s %s 端口 %d，用户 %s？",
+	"url.confirm_new":            "清单中没有该连接，确认后保存为 %s",
+	"url.confirm_existing":       "使用清单中的连接 %s",
+	"url.protected":              "目标为受保护环境",
+	"url.save_failed":            "保存连接失败: %v",
+
+	// 连接字段（字段标识 -> 显示名称）
+	"field.name":          "名称",
+	"field.host":          "主机",
+	"field.port":          "端口",
+	"field.user":          "用户",
+	"field.database":      "数据库",
+	"field.auth":          "认证方式",
+	"field.identity_file": "密钥文件",
+	"field.certificate":   "证书文件",
+	"field.proxy_command": "代理命令",
+	"field.jump_host":     "跳板",
+	"field.addresses":     "备用地址",
+	"field.tls":           "TLS",
+	"field.tls_ca":        "CA证书",
+	"field.tls_cert":      "客户端证书",
+	"field.tls_key":       "客户端密钥",
+	"field.ssh_tunnel":    "SSH隧道",
+	"field.tags":          "标签",
+	"field.password":      "密码",
+
+	// 编辑模式
+	"edit.new_project":         "新建项目",
+	"edit.project_exists":      "已有同名项目: %s",
+	"edit.mesh_no_env":         "[red]Mesh 项目由对等节点自动生成，不能添加环境[-]",
+	"edit.new_env":             "新建环境 - %s",
+	"edit.env_exists":          "%s 中已有同名环境: %s",
+	"edit.demo_project_rename": "[red]示例项目不能重命名[-]",
+	"edit.rename_project":      "重命名项目",
+	"edit.demo_env_rename":     "[red]示例环境不能重命名[-]",
+	"edit.rename_env":          "重命名环境 - %s",
+	"edit.mesh_no_delete":      "[red]Mesh 项目由对等节点自动生成，不能删除[-]",
+	"edit.kind_project":        "项目",
+	"edit.kind_env":            "环境",
+	"edit.kind_connection":     "连接",
+	"edit.still_referenced":    "[red]%s 仍被引用: %s[-]",
+	"edit.col_delete":          "删除",
+	"edit.col_kind":            "类型",
+	"edit.delete_failed":       "[red]删除失败: %s[-]",
+	"config.not_mapping":       "配置文件 %s 的顶层不是映射，无法写回",
+	"edit.deleted":             "[green]已删除%s %s[-]",
+	"edit.name":                "名称",
+	"edit.saved":               "[green]已保存 %s[-]",
+	"edit.name_slash":          "名称不能包含 /",
+	"edit.password_saved":      "[green]已保存连接密码[-]",
+	"edit.no_changes":          "[yellow]没有修改[-]",
+	"edit.edit_connection":     "编辑连接 - %s",
+	"edit.envs":                "环境",
+	"edit.delete_title":        "删除%s",
+
+	// 通用
+	"common.save":                 "保存",
+	"common.cancel":               "取消",
+	"common.save_failed":          "[red]保存失败: %s[-]",
+	"common.copy_failed":          "[red]复制失败: %s[-]",
+	"common.col_type":             "类型",
+	"common.col_name":             "名称",
+	"common.col_address":          "地址",
+	"common.staged_connections":   "[green]已暂存 %d 个连接，在模块栏按 R 审阅[-]",
+	"common.added_connections":    "[green]已添加 %d 个连接[-]",
+	"common.col_connection":       "连接",
+	"common.col_status":           "状态",
+	"common.connection_not_found": "未找到连接: %s",
+	"common.col_protected":        "受保护",
+	"common.col_port":             "端口",
+	"common.col_user":             "用户",
+	"common.name_label":           "名称: ",
+	"common.operation":            "操作",
+	"common.run":                  "执行",
+	"common.transferred":          "已传输 %s",
+	"common.col_target":           "目标",
+	"common.col_env":              "环境",
+	"common.yes":                  "是",
+	"common.col_result":           "结果",
+	"common.col_duration":         "耗时",
+	"common.col_output":           "输出",
+	"common.succeeded":            "[green]成功[-]",
+	"common.failed":               "[red]失败[-]",
+	"common.loading":              "加载中...",
+	"common.error":                "错误",
+	"common.import_failed":        "[red]导入失败: %s[-]",
+	"common.col_module":           "模块",
+	"common.add":                  "添加",
+	"common.ok":                   "确定",
+	"common.field":                "字段",
+	"common.value":                "值",
+	"common.preview":              "预览",
+	"common.col_old":              "原值",
+	"common.col_new":              "新值",
+	"common.loading_status":       "[yellow]加载中...[-]",
+	"common.col_size":             "大小",
+	"common.col_modified":         "修改时间",
+	"common.protected_target":     "\n\n[red]目标为受保护环境[-]",
+	"common.delete":               "删除",
+	"common.select_connection":    "[red]请先选中一个连接[-]",
+	"common.col_time":             "时间",
+	"common.esc_back_suffix":      " - ESC: 返回",
+	"common.running":              "[yellow]执行中...[-]",
+	"common.connect_failed":       "[red]连接失败: %s[-] | !: 错误详情",
+	"common.col_command":          "命令",
+	"common.path_label":           "文件路径: ",
+	"common.success":              "成功",
+	"common.failure":              "失败",
+
+	// 连接字段
+	"field.invalid_port":  "无效的端口: %s",
+	"field.invalid_proxy": "无效的代理命令模板: %w",
+	"field.invalid_auth":  "无效的认证方式: %s（可选 %s）",
+	"field.invalid_tls":   "无效的 TLS 开关: %s（可选 on、off）",
+	"field.unknown":       "未知字段: %s",
+
+	// 主界面
+	"main.probe_title":       "探测候选地址 - %s",
+	"main.connect_cancelled": "[yellow]已取消连接[-]",
+	"main.config_error":      "读取配置文件错误: %v",
+	"main.open_url_failed":   "打开链接失败: %v",
+	"main.run_failed":        "运行应用程序错误: %v",
+
+	// 错误建议
+	"hint.host_key":       "主机密钥已变化或未被信任：按 F 查看指纹，与管理员确认后用 ssh-keygen -R <主机> 移除旧记录",
+	"hint.auth":           "认证失败：检查用户名、认证方式、密钥文件或密码；密钥较多时为连接指定密钥文件",
+	"hint.key_perms":      "私钥文件权限过宽：执行 chmod 600 <密钥文件>",
+	"hint.key_missing":    "私钥文件不存在：检查连接的密钥文件路径",
+	"hint.resolve":        "主机名无法解析：检查主机地址、DNS 或是否需要先连接 VPN",
+	"hint.refused":        "端口拒绝连接：确认服务已启动、端口正确，以及防火墙是否放行",
+	"hint.unreachable":    "网络不可达：检查网络、VPN 或防火墙，必要时在会话策略中调大 startup_probe",
+	"hint.db_auth":        "数据库认证失败：检查用户名和密码，可在编辑连接时保存密码",
+	"hint.client_missing": "未找到客户端程序：安装对应客户端，或通过 module_settings.<模块>.client/command 指定",
+	"hint.tls":            "TLS 握手失败：检查 TLS 设置与证书路径",
+
+	// 错误详情
+	"failure.text_header":       "连接: %s\n时间: %s\n错误: %s\n",
+	"failure.text_command":      "命令: %s\n",
+	"failure.text_hint":         "建议: %s\n",
+	"failure.text_output":       "\n输出:\n%s\n",
+	"failure.header":            "[yellow]连接[-]  %s\n[yellow]时间[-]  %s\n[yellow]错误[-]  [red]%s[-]\n",
+	"failure.command":           "[yellow]命令[-]  %s\n",
+	"failure.hints":             "\n[yellow]建议[-]\n",
+	"failure.output":            "\n[yellow]输出[-]\n",
+	"failure.title":             "错误详情 - %s (%s)",
+	"failure.copied":            "[green]已复制错误详情[-]",
+	"failure.none":              "[yellow]本次运行中还没有连接失败[-]",
+	"failure.fetching_host_key": "[yellow]正在获取 %s 的主机密钥指纹...[-]",
+	"failure.known_hosts":       "[yellow]known_hosts 中的记录[-]\n",
+	"failure.known_hosts_none":  "  [gray]无[-]\n",
+	"failure.server_keys":       "\n[yellow]服务器当前的主机密钥[-]\n",
+	"failure.scan_failed":       "  [red]获取失败: %s[-]\n",
+	"failure.remove_hint":       "\n[gray]确认新指纹可信后执行 ssh-keygen -R %s 移除旧记录[-]",
+	"failure.host_key_title":    "主机密钥指纹 - %s (ESC: 返回)",
+	"failure.keys":              "Y: 复制, ESC: 返回",
+	"failure.keys_host_key":     "F: 主机密钥指纹, Y: 复制, ESC: 返回",
+
+	// 局域网发现
+	"lan.name_out_of_range":   "域名越界",
+	"lan.too_many_pointers":   "域名压缩指针过多",
+	"lan.short_packet":        "报文过短",
+	"lan.record_out_of_range": "记录越界",
+	"lan.ipv4_only":           "只支持 IPv4 子网: %s",
+	"lan.subnet_too_large":    "子网过大（最多 %d 个主机）: %s",
+	"lan.subnet":              "子网扫描 (可选)",
+	"lan.project":             "添加到项目",
+	"lan.env":                 "添加到环境",
+	"lan.scan":                "扫描",
+	"lan.form_title":          "局域网发现 (mDNS/SSDP，子网如 192.168.1.0/24)",
+	"lan.scanning":            "局域网发现 - 扫描中...",
+	"lan.col_source":          "来源",
+	"lan.failed":              "局域网发现 - 失败: %s",
+	"lan.results":             "局域网发现 - %d 个服务 (Space: 标记, Enter: 添加, A: 添加全部, ESC: 返回；= 已存在)",
+	"lan.title":               "局域网发现",
+	"lan.nothing_to_add":      "没有可添加的服务（已存在或没有匹配的项目和环境）",
+	"lan.progress_discover":   "mDNS/SSDP 发现中",
+	"lan.progress_probe":      "探测 %d 个主机的服务端口",
+	"lan.cancelled":           "已取消局域网发现",
+
+	// 端口转发
+	"forward.no_listen":        "端口转发 %s 未设置 listen",
+	"forward.no_to":            "端口转发 %s 未设置 to",
+	"forward.invalid_type":     "无效的端口转发类型: %s（可选 local、remote、dynamic）",
+	"forward.label_remote":     "远端 %s → 本机 %s",
+	"forward.label_dynamic":    "SOCKS 代理 %s",
+	"forward.label_local":      "本机 %s → %s",
+	"forward.tunnel_not_found": "未找到 SSH 隧道连接: %s",
+	"forward.already_running":  "端口转发已在运行: %s",
+	"forward.start_failed":     "启动端口转发失败: %w",
+	"forward.stopped":          "[gray]未启动[-]",
+	"forward.running_since":    "[green]运行中[-] [gray]%s[-]",
+	"forward.exited":           "已退出",
+	"forward.title":            "隧道 (Enter/S: 启动, X: 停止, V: 反向隧道, R: 刷新, ESC: 返回)",
+	"forward.client_tunnel":    "客户端隧道",
+	"forward.client_label":     "本机 127.0.0.1:%d → %s:%d（%s）",
+	"forward.col_forward":      "转发",
+	"forward.empty":            "(没有定义端口转发，也没有运行中的客户端隧道)",
+	"forward.starting":         "[yellow]正在启动端口转发 %s...[-]",
+	"forward.status":           "端口转发 %s: %s",
+	"forward.stopped_msg":      "[green]已停止端口转发 %s[-]",
+	"forward.closed_manually":  "手动关闭",
+	"forward.tunnel_closed":    "[green]已关闭隧道 %s[-]",
+	"forward.via_tunnel":       "隧道",
+	"forward.via_bastion":      "经 %s",
+	"forward.running":          "[green]运行中[-]",
+
+	// 传输配方
+	"recipe.no_remote":         "配方 %s 的源和目标都不是远程连接",
+	"recipe.unsupported_tool":  "不支持的传输工具: %s",
+	"recipe.title":             "传输配方 (Enter: 执行, J: 未完成的传输, ESC: 返回)",
+	"recipe.col_tool":          "工具",
+	"recipe.col_source":        "源",
+	"recipe.col_dest":          "目标",
+	"recipe.col_args":          "参数",
+	"recipe.empty":             "(未配置 transfer_recipes)",
+	"recipe.preview_title":     "传输配方 %s",
+	"recipe.col_connections":   "涉及连接",
+	"recipe.transfer_title":    "传输 - %s (/: 搜索, ESC: 取消/关闭)",
+	"recipe.starting":          "[yellow]启动中...[-]",
+	"recipe.done":              "\n\n[green]传输完成[-] 耗时 %s | %s",
+	"recipe.failed":            "\n\n[red]传输失败: %s[-]\n[gray]可在传输配方中按 J 恢复[-]",
+	"recipe.verifying":         "\n[yellow]正在校验 sha256...[-]",
+	"recipe.verify_progress":   "正在校验 sha256",
+	"recipe.verify_matched":    "%s: %d 个文件一致",
+	"recipe.verify_failed":     "\n[red]校验失败: %s[-]",
+	"recipe.verify_mismatched": "%s: %d 个文件不一致",
+	"recipe.checksum_mismatch": "\n[white:red] 校验和不一致：%d 个文件 [-:-]\n[red]%s[-]",
+	"recipe.verify_ok":         "\n[green]sha256 校验通过（%d 个文件）[-]",
+	"recipe.verify_alert":      "[white:red] 传输 %s 校验未通过 [-:-]",
+
+	// 反向隧道
+	"reverse.title":          "反向隧道 (Enter: 连接, N: 登记, D: 删除, C: 复制拨入命令, R: 刷新, ESC: 返回)",
+	"reverse.col_note":       "备注",
+	"reverse.offline":        "[red]未拨入[-]",
+	"reverse.online":         "[green]已拨入[-]",
+	"reverse.empty":          "(没有登记的设备，按 N 登记)",
+	"reverse.not_dialed":     "[red]%s 尚未拨入[-]",
+	"reverse.register":       "登记设备",
+	"reverse.exists":         "[red]设备已登记: %s[-]",
+	"reverse.login_user":     "登录用户: ",
+	"reverse.port_detail":    "端口 %d",
+	"reverse.registered":     "[green]已登记 %s，端口 %d，按 C 复制设备端拨入命令[-]",
+	"reverse.delete_title":   "删除登记",
+	"reverse.delete_confirm": "[yellow]删除设备 %s 的反向隧道登记？[-]",
+	"reverse.copied":         "[green]已复制 %s 的拨入命令[-]",
+
+	// 两主机文件
+	"hostfiles.mark_two":      "[red]请先用 V 标记恰好两个 SSH 连接[-]",
+	"hostfiles.diff":          "对比差异",
+	"hostfiles.copy":          "复制 %s → %s",
+	"hostfiles.path":          "%s 路径",
+	"hostfiles.title":         "两主机文件对比/复制",
+	"hostfiles.stat_failed":   "无法获取 %s 的信息",
+	"hostfiles.too_large":     "%s 超过 %s，不显示差异",
+	"hostfiles.loading":       "[yellow]获取文件内容...[-]",
+	"hostfiles.diff_title":    "文件差异 (ESC: 返回)",
+	"hostfiles.diff_failed":   "[red]生成差异失败: %s[-]",
+	"hostfiles.identical":     "[green]两端内容一致[-]",
+	"hostfiles.copy_confirm":  "[yellow]将 %s 复制到 %s ？[-]",
+	"hostfiles.protected":     "\n\n[red]目标位于受保护环境，已存在的同名文件将被覆盖[-]",
+	"hostfiles.confirm_title": "确认复制",
+	"hostfiles.progress":      "复制 %s:%s → %s:%s",
+	"hostfiles.done":          "[green]复制完成[-] %s，耗时 %s",
+
+	// 执行SQL文件
+	"sqlfile.unsupported":     "[red]%s 模块不支持执行SQL文件[-]",
+	"sqlfile.prompt_title":    "执行SQL文件 (%d 个目标)",
+	"sqlfile.prompt_label":    "SQL文件: ",
+	"sqlfile.unreadable":      "[red]无法读取SQL文件: %s[-]",
+	"sqlfile.preview_title":   "执行SQL文件 %s",
+	"sqlfile.no_targets":      "没有目标",
+	"sqlfile.results_title":   "执行结果 - %s (/: 搜索, ESC/Q: 停止并关闭)",
+	"sqlfile.waiting":         "等待中",
+	"sqlfile.paused":          "%s 执行失败，已暂停 (C: 继续剩余 %d 个目标, ESC/Q: 停止并关闭)",
+	"sqlfile.progress":        "执行SQL文件 %s",
+	"sqlfile.progress_target": "%s（%d/%d）",
+	"sqlfile.cancelled":       "执行结果 - %s - 已取消 (ESC/Q: 关闭)",
+
+	// 命令行
+	"cli.flag_env":           "只检查匹配的环境（支持 prod/test/dev 别名）",
+	"cli.flag_module":        "只检查指定模块（如 ssh、mysql）",
+	"cli.flag_format_check":  "报告格式：table 或 json",
+	"cli.flag_timeout":       "单个连接的检查超时时间",
+	"cli.flag_concurrency":   "并发检查数量",
+	"cli.no_match":           "没有匹配的连接",
+	"cli.summary":            "\n共 %d 个连接，%d 个失败\n",
+	"cli.flag_interval":      "检查间隔",
+	"cli.no_down_rules":      "没有配置 down 事件的自动化规则（配置项 automation.rules）",
+	"cli.watching":           "监视 %d 个连接，间隔 %s\n",
+	"cli.rule_fired":         "%s 规则 %s 触发: %s 已不可达 %s\n",
+	"cli.hook_failed":        "%s 规则 %s 钩子执行失败: %v\n",
+	"cli.flag_format_report": "报告格式：markdown 或 html",
+	"cli.unsupported_format": "不支持的报告格式: %s",
+
+	// 诊断面板
+	"diag.output":         "输出",
+	"diag.metric":         "指标",
+	"diag.value":          "值",
+	"diag.unsupported":    "[red]%s 模块不支持诊断面板[-]",
+	"diag.hint":           "[gray]Tab/H/L: 切换标签, ↑↓/JK: 滚动, R: 刷新, ESC/Q: 关闭[-]",
+	"diag.title":          "诊断 - %s (%s:%d)",
+	"diag.tab.activity":   "活动查询",
+	"diag.tab.locks":      "锁等待",
+	"diag.tab.slow_stats": "慢查询统计",
+	"diag.tab.db_stats":   "数据库统计",
+	"diag.tab.slowlog":    "慢日志",
+	"diag.tab.keyspace":   "键空间",
+	"diag.tab.stats":      "运行统计",
+	"diag.tab.clients":    "客户端",
+
+	// 自动更新
+	"update.parse_release":     "解析发布信息失败: %w",
+	"update.available":         "[yellow]新版本 %s 可用（当前 %s），执行 connectionmanager self-update 升级[-]",
+	"update.bad_key":           "无效的签名公钥",
+	"update.bad_signature":     "校验和文件签名验证失败",
+	"update.no_key":            "此构建没有内置发布签名公钥，无法验证更新来源，请手动下载新版本",
+	"update.no_asset":          "发布 %s 中没有 %s",
+	"update.no_checksums":      "发布 %s 中没有 %s，无法校验",
+	"update.no_signature":      "发布 %s 中没有签名文件 %s",
+	"update.signature_ok":      "签名验证通过",
+	"update.no_checksum":       "%s 中没有 %s 的校验和",
+	"update.downloading":       "下载 %s ...\n",
+	"update.checksum_mismatch": "校验和不匹配: 期望 %s，实际 %s",
+	"update.checksum_ok":       "校验和验证通过",
+	"update.check_failed":      "检查更新失败: %v\n",
+	"update.latest":            "已是最新版本 %s\n",
+	"update.newer":             "当前版本 %s，最新版本 %s %s\n",
+	"update.failed":            "更新失败: %v\n",
+	"update.updated":           "已更新到 %s\n",
+
+	// 导入 ssh 配置
+	"sshconfig.include_depth": "%s: Include 嵌套过深",
+	"sshconfig.no_env":        "没有可存放 SSH 连接的项目和环境",
+	"sshconfig.audit_from":    "来自 ",
+	"sshconfig.import_failed": "[red]导入 ssh 配置失败: %s[-]",
+	"sshconfig.staged":        "[green]已从 ssh 配置暂存 %d 个连接，在模块栏按 R 审阅[-]",
+	"sshconfig.imported":      "[green]已从 ssh 配置导入 %d 个连接[-]",
+	"sshconfig.read_failed":   "[red]读取 ssh 配置失败: %s[-]",
+	"sshconfig.cancelled":     "已取消读取 ssh 配置",
+	"sshconfig.no_hosts":      "[yellow]%s 中没有可导入的主机[-]",
+	"sshconfig.title":         "导入 %s - %d 个主机 (Space: 标记, Enter: 预览导入, A: 预览导入全部, ESC: 返回；= 已存在)",
+	"sshconfig.preview_title": "导入 ssh 配置",
+	"sshconfig.nothing_new":   "没有需要导入的新主机",
+
+	// 清单报告
+	"report.never_used":      "从未使用",
+	"report.counts":          "各模块/环境连接数",
+	"report.col_count":       "数量",
+	"report.unused":          "超过 %d 天未使用的连接",
+	"report.col_last_used":   "最近使用",
+	"report.failing":         "最近检查失败的连接",
+	"report.col_checked":     "检查时间",
+	"report.markdown_header": "# 连接清单报告\n\n生成时间: %s，连接总数: %d\n",
+	"report.none_markdown":   "无\n",
+	"report.html_header":     "<h1>连接清单报告</h1>\n<p>生成时间: %s，连接总数: %d</p>\n",
+	"report.none_html":       "<p>无</p>\n",
+	"report.title":           "清单报告 (M: 复制 Markdown, H: 复制 HTML, ESC: 返回)",
+	"report.copied":          "[green]已复制 %s 报告到剪贴板[-]",
+
+	// 后台服务
+	"daemon.attached":        "[green]已连接后台服务[-] | 恢复 %d 个会话、%d 个隧道",
+	"daemon.stop_failed":     "停止失败: %v\n",
+	"daemon.stopped":         "后台服务已停止",
+	"daemon.unknown_arg":     "未知的 daemon 参数: %s（可用 status、stop）\n",
+	"daemon.start_failed":    "启动失败: %v\n",
+	"daemon.already_running": "后台服务已在运行",
+	"daemon.started":         "后台服务已启动: %s\n",
+	"daemon.exited":          "后台服务已退出",
+	"daemon.tunnel_ready":    "隧道就绪: %s → %s:%d",
+	"daemon.session_managed": "管理会话: %s",
+	"daemon.unknown_request": "未知的请求: %s",
+	"daemon.session_ended":   "会话已结束: %s（持续 %s）",
+	"daemon.tunnel_count":    "隧道 %d 个\n",
+	"daemon.tunnel_idle":     "  %s → 127.0.0.1:%d 空闲 %s\n",
+	"daemon.session_count":   "会话 %d 个\n",
+	"daemon.tunnel_line":     "  %s → 127.0.0.1:%d [gray](后台)[-]%s\n",
+	"daemon.not_running":     "后台服务未运行",
+
+	// 快速添加
+	"quickadd.clipboard_empty":    "剪贴板为空",
+	"quickadd.no_ssh_host":        "ssh 命令中没有目标主机",
+	"quickadd.invalid_uri":        "无效的 URI: %w",
+	"quickadd.unsupported_scheme": "不支持的协议: %s",
+	"quickadd.no_uri_host":        "URI 中没有主机",
+	"quickadd.unrecognized":       "无法识别的连接文本: %s",
+	"quickadd.no_module":          "[red]没有 %s 类型的模块[-]",
+	"quickadd.no_env":             "[red]模块中没有可添加的环境[-]",
+	"quickadd.add_to":             "添加到",
+	"quickadd.duplicate":          "[red]%s 中已有同名连接: %s[-]",
+	"quickadd.staged":             "[green]已暂存新连接 %s，在模块栏按 R 审阅[-]",
+	"quickadd.added":              "[green]已添加连接 %s[-]",
+	"quickadd.title":              "新建连接 - %s",
+
+	// 凭据库
+	"credstore.corrupt":            "凭据库已损坏: %w",
+	"credstore.locked_read":        "凭据库未解锁，无法读取 %s",
+	"credstore.missing":            "凭据库中没有 %s",
+	"credstore.locked":             "凭据库未解锁",
+	"credstore.passphrase":         "主密码",
+	"credstore.confirm_passphrase": "确认主密码",
+	"credstore.created":            "[green]已创建凭据库[-]",
+	"credstore.unlocked":           "[green]凭据库已解锁[-]",
+	"credstore.unlock_title":       "解锁凭据库",
+	"credstore.create_title":       "设置凭据库主密码",
+	"credstore.save_failed":        "[red]保存密码失败: %s[-]",
+	"credstore.audit_password":     "连接密码",
+	"credstore.wrong_passphrase":   "主密码错误",
+
+	// 批量编辑
+	"bulk.no_targets": "[red]请先选择或用 V 标记连接[-]",
+	"bulk.action":     "批量编辑",
+	"bulk.title":      "批量编辑 - %d 个连接",
+	"bulk.nothing":    "没有需要修改的连接",
+	"bulk.set_field":  "设置字段",
+	"bulk.add_tag":    "添加标签",
+	"bulk.remove_tag": "移除标签",
+
+	// 文件浏览器
+	"browser.ssh_only":        "[red]文件浏览器仅支持 SSH 连接[-]",
+	"browser.trashed_hint":    "[green]已将 %s 移入回收站，%s 前按 U 撤销[-] [gray]D: 删除, R: 刷新, ESC: 关闭[-]",
+	"browser.hint":            "[gray]Enter: 打开目录/归档, Backspace: 上级目录, E: 编辑, Ctrl+E: 提权编辑, D: 删除, U: 撤销删除, R: 刷新, ESC: 关闭[-]",
+	"browser.title":           "文件浏览 - %s:%s",
+	"browser.confirm_trash":   "[yellow]将 %s 移入回收站 %s ？[-]\n\n[gray]%s 内可按 U 撤销[-]",
+	"browser.confirm_delete":  "[red]永久删除 %s ？此操作无法撤销[-]",
+	"browser.deleting":        "[yellow]删除中...[-]",
+	"browser.nothing_to_undo": "[red]没有可撤销的删除（已超过撤销时限或为永久删除）[-]",
+	"browser.restoring":       "[yellow]恢复中...[-]",
+	"browser.restore_failed":  "[red]恢复失败: %s[-]",
+
+	// 连接详情
+	"details.header":     "\n[yellow]连接详情[-]\n",
+	"details.id":         "  标识: %s\n",
+	"details.address":    "  地址: %s:%d",
+	"details.user":       "  用户: %s",
+	"details.database":   "  数据库: %s",
+	"details.host_time":  "  主机时间: %s\n",
+	"details.proxy":      "  代理命令: %s\n",
+	"details.jump_error": "  跳板: [red]%s[-]\n",
+	"details.jump":       "  跳板: %s\n",
+	"details.tunnel":     "  SSH隧道: %s\n",
+	"details.addresses":  "  备用地址: %s\n",
+	"details.endpoint":   "  已选端点: %s %s\n",
+	"details.path":       "  连接路径: %s\n",
+	"details.note":       "  [yellow]备注:[-] %s [gray](%s %s)[-]\n",
+	"details.banner":     "  [gray]横幅/MOTD（%s）:[-]\n",
+
+	// 导入状态文件
+	"stateimport.parse_failed":   "解析状态文件失败: %w",
+	"stateimport.flag_project":   "导入到名称匹配的项目（默认各模块的第一个项目）",
+	"stateimport.flag_env":       "导入到匹配的环境（支持 prod/test/dev 别名）",
+	"stateimport.flag_dry_run":   "只显示将要导入的连接",
+	"stateimport.usage":          "用法: import [--project 名称] [--env 环境] [--dry-run] <状态文件|目录|->",
+	"stateimport.empty":          "状态为空",
+	"stateimport.read_failed":    "读取状态失败: %s\n",
+	"stateimport.header":         "连接\t地址\t标签",
+	"stateimport.skipped":        "跳过 %s: 没有可存放的模块、项目或环境\n",
+	"stateimport.total":          "共 %d 个连接\n",
+	"stateimport.save_failed":    "保存失败: %s\n",
+	"stateimport.staged":         "已暂存 %d 个连接，在界面模块栏按 R 审阅\n",
+	"stateimport.imported":       "已导入 %d 个连接\n",
+	"stateimport.prompt_title":   "从 Terraform/Pulumi 状态导入",
+	"stateimport.prompt_label":   "状态文件或目录: ",
+	"stateimport.need_path":      "[red]请输入状态文件或 Terraform/Pulumi 目录[-]",
+	"stateimport.progress_title": "导入基础设施状态",
+	"stateimport.cancelled":      "已取消导入基础设施状态",
+	"stateimport.nothing":        "状态中没有可导入的连接",
+	"stateimport.skipped_count":  "，[yellow]跳过 %d 个没有可存放位置的资源[-]",
+
+	// 晋升连接
+	"promote.no_target":       "[red]没有可晋升的目标环境[-]",
+	"promote.target_env":      "目标环境",
+	"promote.target_user":     "目标用户",
+	"promote.target_identity": "目标密钥文件",
+	"promote.button":          "晋升",
+	"promote.confirm":         "[yellow]将 %s 晋升到 %s ？[-]",
+	"promote.confirm_title":   "确认晋升",
+	"promote.title":           "晋升连接 - %s",
+	"promote.failed":          "[red]晋升失败: %s[-]",
+	"promote.staged":          "[green]已暂存晋升到 %s 的修改，在模块栏按 R 审阅[-]",
+	"promote.done":            "[green]已晋升到 %s[-]",
+
+	// 主机备注
+	"notes.title":          "主机备注 - %s (N: 新增, Enter: 已处理/重新打开, D: 删除, ESC: 返回)",
+	"notes.col_author":     "记录人",
+	"notes.col_text":       "内容",
+	"notes.open":           "未处理",
+	"notes.resolved":       "已处理",
+	"notes.save_failed":    "[red]保存备注失败: %s[-]",
+	"notes.new":            "新增备注",
+	"notes.text_label":     "内容: ",
+	"notes.delete":         "删除备注",
+	"notes.confirm_delete": "确定删除备注“%s”吗？",
+
+	// 依赖关系
+	"deps.cycle":        "存在循环依赖: %s",
+	"deps.cycle_marker": "%s[red](循环依赖)[-]\n",
+	"deps.title":        "依赖关系 - %s (ESC: 返回)",
+	"deps.upstream":     "[blue]上游依赖:[-]\n",
+	"deps.none":         "  (无)\n",
+	"deps.downstream":   "\n[blue]下游依赖者:[-]\n",
+	"deps.cascade":      "\n[red]级联故障: 以下上游连接不可用，可能导致当前连接失败:[-]\n",
+	"deps.checking":     "[gray](检查中)[-]",
+	"deps.progress":     "检查依赖连接",
+	"deps.cancelled":    "[gray](已取消)[-]",
+	"deps.not_found":    "[red](未找到)[-]",
+	"deps.ok":           "[green](正常 %s)[-]",
+	"deps.down":         "[red](不可用)[-]",
+
+	// 事务保护
+	"tx.exec_failed":        "语句执行失败，事务已回滚",
+	"tx.exec_timeout":       "语句执行超时，事务已回滚",
+	"tx.client_gone":        "客户端已退出: %w",
+	"tx.finish_timeout":     "等待客户端结束事务超时，结果未知",
+	"tx.client_failed":      "客户端异常退出: %w",
+	"tx.commit_rolled_back": "提交失败，服务端已回滚事务",
+	"tx.running":            "[yellow]在事务中执行...[-]",
+	"tx.title":              "事务确认",
+	"tx.pending":            "[yellow]语句已在事务中执行（%s），尚未提交:[-]\n%s\n\n",
+	"tx.mysql_ddl":          "[red]警告: MySQL DDL 会隐式提交，回滚无法撤销该语句[-]\n\n",
+	"tx.keys":               "[green]C: COMMIT[-]    [red]R/ESC: ROLLBACK[-]    [gray](%s 后自动回滚)[-]",
+	"tx.finishing":          "[yellow]结束事务中...[-]",
+	"tx.commit_failed":      "[red]事务提交失败: %s[-]",
+	"tx.finish_failed":      "[red]结束事务失败: %s[-]",
+	"tx.committed":          "[green]事务已提交[-]",
+	"tx.rolled_back":        "[yellow]事务已回滚[-]",
+
+	// 表单校验
+	"validate.required":      "不能为空",
+	"validate.port":          "端口必须是 1-65535 之间的整数",
+	"validate.host":          "不是有效的主机名或 IP 地址",
+	"validate.no_file":       "文件不存在",
+	"validate.is_dir":        "不能是目录",
+	"validate.template":      "模板语法错误: ",
+	"validate.redis_db":      "Redis 库编号必须是非负整数",
+	"validate.subnet":        "不是有效的子网（如 192.168.1.0/24）",
+	"validate.regexp":        "正则表达式错误: ",
+	"validate.no_connection": "未找到连接（填写 模块/项目/环境/连接 形式的标识）",
+	"validate.ssh_only":      "必须是 SSH 连接",
+
+	// 保存密钥
+	"secret.reveal":              "Ctrl+R 显示/隐藏",
+	"secret.mismatch":            "两次输入不一致",
+	"secret.no_backend":          "未配置密钥后端（配置项 secrets.backend），无法保存 %s",
+	"secret.unsupported_backend": "不支持的密钥后端: %s",
+	"secret.write_failed":        "写入 %s 失败: %w",
+	"secret.name":                "密钥名称",
+	"secret.confirm":             "确认密码",
+	"secret.saving":              "[yellow]正在保存密钥 %s...[-]",
+	"secret.saved":               "[green]已保存密钥 %s，可在 become 中引用 secret:%s[-]",
+	"secret.title":               "保存密钥 - %s (%s)",
+
+	// 审阅暂存修改
+	"review.staged":          "[green]已暂存 %d 处修改，在模块栏按 R 审阅[-]",
+	"review.changed":         "[green]已修改 %d 处[-]",
+	"review.nothing":         "没有暂存的修改",
+	"review.title":           "审阅暂存修改 (Y: 提交, D: 丢弃, ESC: 返回)",
+	"review.nothing_view":    "[gray]没有暂存的修改[-]",
+	"review.commit_title":    "提交修改",
+	"review.message_label":   "说明: ",
+	"review.default_message": "更新 %d 处连接字段，新增 %d 个连接",
+	"review.commit_failed":   "[red]提交失败: %s[-]",
+	"review.committed":       "[green]暂存修改已提交[-]",
+	"review.discard_title":   "丢弃修改",
+	"review.discard_confirm": "[red]丢弃全部暂存修改？[-]",
+	"review.discarded":       "[green]已丢弃暂存修改[-]",
+
+	// 时间与时长
+	"time.just_now":        "刚刚",
+	"time.relative_future": "%d %s后",
+	"time.relative_past":   "%d %s前",
+	"time.relative_unit.m": "分钟",
+	"time.relative_unit.h": "小时",
+	"time.relative_unit.d": "天",
+	"time.milliseconds":    "%s%d毫秒",
+	"time.seconds":         "%s%.1f秒",
+	"time.unit.d":          "天",
+	"time.unit.h":          "小时",
+	"time.unit.m":          "分",
+	"time.unit.s":          "秒",
+	"time.unit_separator":  "",
+
+	// 传输日志
+	"journal.process_exited":  "进程已退出",
+	"journal.title":           "未完成的传输 (Enter: 恢复, D: 放弃, ESC: 返回)",
+	"journal.col_recipe":      "配方",
+	"journal.col_started":     "开始时间",
+	"journal.col_progress":    "最近进度",
+	"journal.col_reason":      "中断原因",
+	"journal.empty":           "(没有未完成的传输)",
+	"journal.confirm_resume":  "[yellow]恢复传输 %s ？[-]\n\n[gray]%s[-]",
+	"journal.scp_restart":     "\n\n[red]scp 不支持续传，将重新传输[-]",
+	"journal.resume":          "恢复传输",
+	"journal.abandon":         "放弃传输",
+	"journal.confirm_abandon": "[yellow]放弃恢复 %s ？已传输的部分文件不会删除[-]",
+	"journal.pending":         "[yellow]有 %d 个未完成的传输，在连接级别按 R 打开传输配方后按 J 恢复[-]",
+
+	// 导出结果集
+	"export.unsupported": "不支持的导出格式: %s",
+	"export.nothing":     "[red]当前没有可导出的结果[-]",
+	"export.format":      "格式",
+	"export.file":        "文件",
+	"export.clipboard":   "剪贴板",
+	"export.path":        "文件路径",
+	"export.button":      "导出",
+	"export.failed":      "[red]导出失败: %s[-]",
+	"export.copied":      "[green]已复制 %d 行 %s 到剪贴板[-]",
+	"export.written":     "[green]已导出 %d 行到 %s[-]",
+	"export.title":       "导出结果集",
+
+	// 执行计划
+	"explain.write_refused":  "[red]EXPLAIN ANALYZE 会真实执行语句，不允许用于写语句[-]",
+	"explain.loading":        "[yellow]获取执行计划...[-]",
+	"explain.fetch_failed":   "[red]获取执行计划失败: %s[-]",
+	"explain.parse_failed":   "[red]解析执行计划失败: %s[-]",
+	"explain.done":           "[green]执行计划已生成[-] 耗时 %s",
+	"explain.empty":          "执行计划为空",
+	"explain.actual":         "实际耗时=%.3fms 循环=%v",
+	"explain.no_query_block": "执行计划缺少 query_block",
+	"explain.full_scan":      " [yellow]⚠ 全表扫描[-]",
+	"explain.title":          "执行计划 (EXPLAIN)",
+	"explain.title_analyze":  "执行计划 (EXPLAIN ANALYZE)",
+
+	// 归档浏览
+	"archive.title":      "归档 - %s:%s",
+	"archive.hint":       "[gray]Enter/X: 提取到本地, ESC: 返回[-]",
+	"archive.loading":    "[yellow]读取归档目录...[-]",
+	"archive.count":      "%s [gray]共 %d 项[-]",
+	"archive.extract":    "提取到本地",
+	"archive.local_path": "本地路径: ",
+	"archive.extracting": "提取 ",
+	"archive.failed":     "[red]提取失败: %s[-]",
+	"archive.done":       "[green]已提取到 %s[-]",
+
+	// 正则替换
+	"replace.all_fields":     "全部字段",
+	"replace.current_module": "当前模块",
+	"replace.all_modules":    "全部模块",
+	"replace.scope":          "范围",
+	"replace.pattern":        "正则",
+	"replace.replacement":    "替换为",
+	"replace.action":         "正则替换",
+	"replace.dry_run":        "试运行",
+	"replace.title":          "正则查找替换 (替换中可用 $1 引用分组)",
+
+	// 远程编辑
+	"editor.reading":          "[yellow]正在读取 %s...[-]",
+	"editor.read_failed":      "[red]读取失败: %s[-]",
+	"editor.crashed":          "[red]编辑器异常退出: %s[-]",
+	"editor.unchanged":        "[gray]文件未修改[-]",
+	"editor.saving":           "[yellow]正在保存 %s...[-]",
+	"editor.check_failed":     "[red]校验远程文件失败: %s[-]",
+	"editor.conflict":         "文件冲突",
+	"editor.conflict_confirm": "[red]%s 在编辑期间已被修改。[-]\n\n仍要用本地版本覆盖吗？",
+	"editor.write_failed":     "[red]写回失败: %s[-]",
+	"editor.saved":            "[green]已保存 %s (%s)[-]",
+
+	// 查询控制台
+	"console.unsupported":    "[red]%s 模块不支持查询控制台[-]",
+	"console.placeholder":    "输入SQL语句，F5 或 Ctrl+Enter 执行，Ctrl+↑/↓ 调出历史",
+	"console.hint":           "[gray]F5/Ctrl+Enter: 执行, Ctrl+↑/↓: 上一条/下一条, F3: 历史, F6: 导出, F7/F9: EXPLAIN/ANALYZE, F8: 事务保护开关, Tab: 切换输入/结果, /: 搜索结果, ESC: 关闭[-]",
+	"console.results":        "结果",
+	"console.title":          "查询控制台 - %s (%s:%d)",
+	"console.failed":         "[red]执行失败 (%s): %s[-]",
+	"console.succeeded":      "[green]执行成功[-] %d 行, 耗时 %s",
+	"console.guard_required": "[red]受保护环境中无法关闭事务保护[-]",
+	"console.guard_on":       "[green]事务保护已开启：写语句将在事务中执行并等待确认[-]",
+	"console.guard_off":      "[yellow]事务保护已关闭：写语句将直接执行[-]",
+
+	// VPN
+	"vpn.down_marker": " [red]VPN %s 未连接[-]",
+	"vpn.not_found":   "[red]未找到 VPN 配置: %s[-]",
+	"vpn.no_up":       "[red]VPN %s 未连接，且未配置启动命令[-]",
+	"vpn.down_title":  "VPN 未连接",
+	"vpn.confirm_up":  "[yellow]%s 需要 VPN %s，是否启动？[-]\n\n[gray]%s[-]",
+	"vpn.starting":    "启动 VPN %s: %s\n",
+	"vpn.up_failed":   "[red]VPN %s 启动失败: %s[-]",
+	"vpn.waiting":     "[yellow]等待 VPN %s 连通...[-]",
+	"vpn.timeout":     "[red]VPN %s 启动命令已执行，但 %s 内未检测到连接[-]",
+	"vpn.up":          "[green]VPN %s 已连接[-]",
+
+	// 环境对比
+	"envdiff.only_a":       "[red]- %s[-] 仅存在于 A\n",
+	"envdiff.only_b":       "[green]+ %s[-] 仅存在于 B\n",
+	"envdiff.summary":      "\n[gray]共 %d 个连接，%d 个完全一致[-]",
+	"envdiff.too_few":      "[red]当前模块不足两个环境[-]",
+	"envdiff.env_a":        "环境 A",
+	"envdiff.env_b":        "环境 B",
+	"envdiff.compare":      "对比",
+	"envdiff.title":        "环境对比 - %s",
+	"envdiff.result_title": "环境对比 - %s (ESC: 返回)",
+
+	// 认证重试
+	"authretry.agent":          "使用 ssh-agent",
+	"authretry.saved_password": "使用保存的密码",
+	"authretry.key":            "使用密钥 ",
+	"authretry.enter_password": "输入密码...",
+	"authretry.details":        "查看错误详情",
+	"authretry.title":          "%s 认证失败，重试 (Enter: 选择, ESC: 返回)",
+	"authretry.save_password":  "保存密码",
+	"authretry.connect":        "连接",
+	"authretry.password_title": "输入密码 - %s",
+
+	// URL 协议
+	"urlhandler.no_module":         "没有 %s 类型的模块",
+	"urlhandler.staged":            "[yellow]新连接已暂存，在模块栏按 R 审阅提交后可用[-]",
+	"urlhandler.removed":           "已删除 %s\n",
+	"urlhandler.written":           "已写入 %s\n",
+	"urlhandler.registered":        "已注册 %s\n",
+	"urlhandler.registered_scheme": "已注册 %s://\n",
+	"urlhandler.needs_bundle":      "%s 需要应用程序包才能注册 URL 协议，请在终端中执行 %s <URI>",
+	"urlhandler.failed":            "注册失败: %v\n",
+
+	// SSH 会话
+	"session.last_login":  "上次连接: %s 于 %s",
+	"session.note":        "备注: %s（%s，%s）",
+	"session.maintenance": "维护中: ",
+	"session.until":       "（至 ",
+	"session.until_end":   "）",
+	"session.local_time":  "%s 当地时间: %s",
+	"session.recording":   "录制: ",
+	"session.failed":      "[red]SSH 会话异常结束: %s[-]",
+	"session.ended":       "[green]SSH 会话已结束[-] | 时长 %s | %s | %s",
+
+	// 远程图形程序
+	"gui.ssh_only":      "[red]远程图形程序仅支持 SSH 连接[-]",
+	"gui.no_display":    "[red]本地未设置 DISPLAY，无法转发 X11[-]",
+	"gui.title":         "远程图形程序 - %s (Enter: 启动, ESC: 返回)",
+	"gui.none":          "(未配置 gui_apps)",
+	"gui.launch_failed": "[red]启动失败: %s[-]",
+	"gui.launched":      "[green]已在 %s 上启动 %s[-]",
+	"gui.crashed":       "[red]%s 异常退出: %s[-]",
+
+	// 工作区
+	"workspace.load_failed":  "[red]加载工作区失败: %s[-]",
+	"workspace.switched":     "[green]已切换到工作区: %s[-]",
+	"workspace.default":      "默认",
+	"workspace.title":        "工作区 (Enter: 切换, N: 新建, ESC: 返回)",
+	"workspace.current":      " [green](当前)[-]",
+	"workspace.new":          "新建工作区",
+	"workspace.invalid_name": "无效的工作区名称: %s",
+
+	// 修改预览
+	"preview.title":          "%s - 预览 %d 项 (Y: 应用, /: 搜索, ESC: 取消)",
+	"preview.commands":       "将要执行的命令",
+	"preview.confirm":        "[yellow]应用 %s（%d 项）？[-]",
+	"preview.confirm_prefix": "确认",
+	"preview.col_new":        "新增连接",
+
+	// 执行命令
+	"exec.ssh_only":      "[red]执行命令仅支持 SSH 连接[-]",
+	"exec.title":         "执行命令 - %s",
+	"exec.confirm_title": "确认执行",
+	"exec.confirm":       "[red]%s 位于受保护环境[-]\n\n执行: %s",
+	"exec.output_title":  "%s $ %s (/: 搜索, ESC: 返回)",
+	"exec.done":          "\n\n[green]完成[-] 耗时 %s",
+
+	// 钉住连接
+	"watch.save_failed": "[red]保存钉住列表失败: %s[-]",
+	"watch.unpinned":    "[yellow]已取消钉住 %s[-]",
+	"watch.pinned":      "[green]已钉住 %s[-]",
+	"watch.maintenance": " [blue]维护中[-]",
+	"watch.down":        "[red]%s 变为不可达[-]",
+	"watch.up":          "[green]%s 已恢复[-]",
+	"watch.jump_hint":   " | ;: 跳转",
+
+	// 空闲会话
+	"idle.times":           " [gray]建立 %s · 活动 %s[-]",
+	"idle.in_use":          " [green]使用中[-]",
+	"idle.idle":            " [yellow]空闲 %s[-]",
+	"idle.audit_timeout":   "空闲超时",
+	"idle.closed":          "已关闭空闲隧道 %s",
+	"idle.closing":         "隧道 %s 已空闲 %s，将在 %s 后关闭（再次连接可保持）",
+	"idle.session_warning": "\r\n*** 会话已空闲 %s，约 %s 后将自动断开 ***\r\n",
+
+	// 会话回滚
+	"scrollback.none":     "[yellow]%s 本次运行中还没有可用的会话回滚[-]",
+	"scrollback.title":    "会话回滚 - %s %d 行 (/: 搜索, S: 保存到文件, Y: 复制, ESC: 返回)",
+	"scrollback.failed":   "[red]导出回滚失败: %s[-]",
+	"scrollback.exported": "[green]已导出 %d 行回滚到 %s[-]",
+	"scrollback.save":     "保存会话回滚",
+
+	// 查询历史
+	"history.title":     "查询历史 - %s (Enter: 重新执行, ESC: 返回)",
+	"history.col_query": "查询",
+
+	// 数据库客户端
+	"dbclient.template":   "客户端命令模板错误: %w",
+	"dbclient.connecting": "[yellow]正在连接 %s...[-]",
+	"dbclient.local_time": "%s 当地时间: %s\n",
+	"dbclient.failed":     "[red]客户端异常结束: %s[-]",
+	"dbclient.ended":      "[green]%s 会话已结束[-] | 时长 %s",
+
+	// 端口探测
+	"probe.port_error":  "%s 的 %d 端口%w",
+	"probe.timeout":     "%s 的 %d 端口不可达（超时 %s）",
+	"probe.refused":     "%s 的 %d 端口拒绝连接",
+	"probe.unreachable": "%s 的 %d 端口不可达: %w",
+	"probe.probing":     "[yellow]正在探测 %s...[-]",
+	"probe.failed":      "[red]%s[-] | !: 错误详情",
+
+	// 健康检查
+	"health.not_ssh":              "不是 SSH 服务",
+	"health.rejected":             "拒绝了握手",
+	"health.not_postgres":         "不是 PostgreSQL 服务",
+	"health.not_redis":            "不是 Redis 服务",
+	"health.no_handshake_timeout": "没有返回 %s 握手（超时 %s）",
+	"health.no_handshake":         "没有返回 %s 握手: %w",
+
+	// 传输校验
+	"verify.source_failed":     "计算源校验和失败: %w",
+	"verify.target_failed":     "计算目标校验和失败: %w",
+	"verify.missing_in_target": ": 目标中缺失",
+	"verify.mismatch":          "%s: 源 %.12s 目标 %.12s",
+	"verify.missing":           "路径不存在",
+
+	// 可用性
+	"uptime.none": "  [gray]可用性: 暂无健康检查记录[-]\n",
+	"uptime.24h":  "24小时",
+	"uptime.30d":  "30天",
+	"uptime.line": "  可用性 %-6s %s %s\n",
+
+	// 隧道
+	"tunnel.cloudflare_login": "Cloudflare Access 登录失败: %s",
+	"tunnel.start_failed":     "启动隧道失败: %w",
+	"tunnel.exited":           "隧道进程已退出: %s",
+	"tunnel.timeout":          "等待隧道就绪超时（%s）",
+
+	// 跳板
+	"jump.not_found": "未找到跳板连接: %s",
+	"jump.not_ssh":   "跳板必须是 SSH 连接: %s",
+	"jump.cycle":     "跳板形成循环: %s",
+	"jump.too_deep":  "跳板超过 %d 层",
+
+	// 凭据审计
+	"credaudit.unused_keys":  "未被任何连接使用的私钥（~/.ssh）",
+	"credaudit.missing_keys": "连接引用但不存在的私钥",
+	"credaudit.orphaned":     "未匹配任何连接或环境的提权密码",
+	"credaudit.broken":       "无法读取的密码来源",
+
+	// 确认短语
+	"confirm.phrase":   "\n%s\n\n请输入 [yellow::b]%s[-::-] 以确认",
+	"confirm.label":    "确认: ",
+	"confirm.keys":     " (Enter: 确认, ESC: 取消)",
+	"confirm.mismatch": "[red]不匹配，请重新输入:[-] ",
+
+	// 提权
+	"become.env_unset":          "环境变量 %s 未设置",
+	"become.password_failed":    "获取提权密码失败: %w",
+	"become.unsupported_source": "不支持的密码来源: %s",
+	"become.unsupported_method": "不支持的提权方式: %s",
+
+	// 连接路径
+	"sshpath.proxy": "代理命令 ",
+	"sshpath.local": "本机",
+
+	// 会话标签
+	"tabs.no_session": "[yellow]没有编号为 %d 的会话[-]",
+	"tabs.not_found":  "[red]找不到连接: %s[-]",
+	"tabs.none":       "  [gray]无[-]\n",
+
+	// 密钥后端
+	"secrets.no_backend":  "未配置密钥后端（配置项 secrets.backend），无法读取 %s",
+	"secrets.read_failed": "从 %s 读取 %s 失败: %w",
+
+	// 进度
+	"progress.elapsed":    "[gray]已用时 %s[-]",
+	"progress.cancelling": "  [red]正在取消...[-]",
+
+	// Mesh 网络
+	"mesh.parse_failed": "解析 Tailscale 状态失败: %w",
+
+	// 搜索
+	"search.title":    "搜索",
+	"search.no_match": " [/%s 无匹配]",
+
+	// 主机时间
+	"hosttime.invalid_offset": "无效的时区偏移: %s",
+
+	// 未保存的修改
+	"formguard.title": "未保存的修改",
+	"formguard.text":  "\n表单中有未保存的修改，关闭后将丢失\n\n[green]提交 (S)[-]    [red]放弃 (D)[-]    [yellow]继续编辑 (C/ESC)[-]\n",
+
+	// 端点选择
+	"endpoint.all_down": "%s（%d 个候选均不可达，使用主地址）",
+	"endpoint.chosen":   "%s（延迟 %s，%d/%d 个候选可达）",
+
+	// 服务发现
+	"discovery.unsupported": "不支持的服务发现类型: %s",
+	"discovery.failed":      "[red]服务发现失败: %s[-]",
+
+	// 剪贴板
+	"clipboard.no_copy":  "未找到可用的剪贴板命令（pbcopy/wl-copy/xclip/xsel）",
+	"clipboard.no_paste": "未找到可用的剪贴板命令（pbpaste/wl-paste/xclip/xsel）",
+
+	// 启动位置
+	"startup.not_found": "[red]未找到启动位置: %s[-]",
+
+	// 代理命令
+	"proxy.template": "代理命令模板错误: %w",
+
+	// 系统钥匙串
+	"keychain.write_failed": "写入 keychain 失败: %w",
+
+	// 主机时钟
+	"clock.unknown_zone": " [gray]│ 主机时区未知[-]",
+
+	// 带宽限制
+	"bandwidth.invalid": "无效的带宽: %s",
+
+	// 回收站
+	"trash.exists": "原路径已存在",
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+)
+
+// 模块配置：可隐藏、排序，也可以定义同一类型的多个自定义模块
+type moduleConfig struct {
+	Name   string `mapstructure:"name"`   // 显示名称
+	Type   string `mapstructure:"type"`   // 模块类型（SSH/MySQL/PostgreSQL/Redis），默认与名称相同
+	Hidden bool   `mapstructure:"hidden"` // 是否隐藏
+}
+
+// 读取配置的模块列表（配置项 modules），未配置或无有效模块时使用内置默认模块
+func configuredModules() []string {
+	var configs []moduleConfig
+	if err := unmarshalConfig("modules", &configs); err != nil {
+		return defaultModules
+	}
+	seen := make(map[string]bool)
+	var modules []string
+	for _, config := range configs {
+		if config.Hidden || config.Name == "" || seen[config.Name] || normalizeModuleType(config.typeName()) == "" {
+			continue
+		}
+		seen[config.Name] = true
+		modules = append(modules, config.Name)
+	}
+	if len(modules) == 0 {
+		return defaultModules
+	}
+	return modules
+}
+
+// 模块类型名，未指定时使用名称
+func (c moduleConfig) typeName() string {
+	if c.Type == "" {
+		return c.Name
+	}
+	return c.Type
+}
+
+// 将类型名规范为内置模块类型（不区分大小写），未知类型返回空字符串
+func normalizeModuleType(name string) string {
+	for _, kind := range defaultModules {
+		if strings.EqualFold(kind, name) {
+			return kind
+		}
+	}
+	return ""
+}
+
+// 获取模块名对应的模块类型
+func moduleType(module string) string {
+	var configs []moduleConfig
+	if unmarshalConfig("modules", &configs) == nil {
+		for _, config := range configs {
+			if config.Name == module {
+				return normalizeModuleType(config.typeName())
+			}
+		}
+	}
+	return normalizeModuleType(module)
+}
+
+// 模块设置：连接的默认值与客户端选项
+type moduleSettings struct {
+	Port    int    `mapstructure:"port"`     // 默认端口
+	User    string `mapstructure:"user"`     // 默认用户名
+	Client  string `mapstructure:"client"`   // 客户端程序（如 ssh、mysql、psql、redis-cli 的路径）
+	Command string `mapstructure:"command"`  // 交互式客户端命令模板（数据库模块），如 mycli -h {{.Host}} -P {{.Port}}
+	TLSMode string `mapstructure:"tls_mode"` // 默认 TLS 模式（MySQL --ssl-mode / PostgreSQL sslmode；Redis 非空且不为 disable 时启用 --tls）
+	Enter   string `mapstructure:"enter"`    // 在连接上按 Enter 打开的界面（数据库模块）：client（默认，交互式客户端）或 console（查询控制台，Redis 为键浏览器）
+	Probe   string `mapstructure:"probe"`    // 可达性探测方式：tcp（默认，只建立 TCP 连接）或 protocol（协议级探测，见 protocolPing）
+}
+
+// 用非零字段覆盖设置
+func (s moduleSettings) merge(override moduleSettings) moduleSettings {
+	if override.Port > 0 {
+		s.Port = override.Port
+	}
+	if override.User != "" {
+		s.User = override.User
+	}
+	if override.Client != "" {
+		s.Client = override.Client
+	}
+	if override.Command != "" {
+		s.Command = override.Command
+	}
+	if override.TLSMode != "" {
+		s.TLSMode = override.TLSMode
+	}
+	if override.Enter != "" {
+		s.Enter = override.Enter
+	}
+	if override.Probe != "" {
+		s.Probe = override.Probe
+	}
+	return s
+}
+
+// 获取模块设置（配置项 module_settings），模块名上的设置覆盖模块类型上的设置
+func settingsFor(module string) moduleSettings {
+	var all map[string]moduleSettings
+	if err := unmarshalConfig("module_settings", &all); err != nil {
+		return moduleSettings{}
+	}
+	settings := lookupFold(all, moduleType(module))
+	if module != moduleType(module) {
+		settings = settings.merge(lookupFold(all, module))
+	}
+	return settings
+}
+
+// 获取模块的客户端程序，未配置时使用 fallback
+func moduleClient(module, fallback string) string {
+	if client := settingsFor(module).Client; client != "" {
+		return client
+	}
+	return fallback
+}
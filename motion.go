@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// 树视图中单独按下 1-4 后等待多久仍没有后续按键时切换布局（在此之前视为计数前缀）
+const layoutKeyDelay = 500 * time.Millisecond
+
+// 计数前缀的上限
+const maxMotionCount = 9999
+
+// 处理树视图中的数字计数前缀（如 5j 中的 5），已处理时返回 true；
+// 单独的 1-4 在 layoutKeyDelay 内没有后续按键时按原来的方式切换布局
+func (a *App) handleCountKey(event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 {
+		return false
+	}
+	r := event.Rune()
+	if r < '0' || r > '9' || (r == '0' && a.pendingCount == 0) {
+		return false
+	}
+	a.pendingCount = min(a.pendingCount*10+int(r-'0'), maxMotionCount)
+	a.countSeq++
+	a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tr("motion.count", a.pendingCount)))
+
+	if count, seq := a.pendingCount, a.countSeq; count >= 1 && count <= len(layoutPresets) {
+		time.AfterFunc(layoutKeyDelay, func() {
+			a.app.QueueUpdateDraw(func() {
+				if a.countSeq == seq && a.pendingCount == count {
+					a.pendingCount = 0
+					a.switchLayout(count - 1)
+				}
+			})
+		})
+	}
+	return true
+}
+
+// 取出并清除计数前缀，没有计数时为 1
+func (a *App) takeCount() int {
+	count := a.pendingCount
+	a.pendingCount = 0
+	a.countSeq++
+	return max(count, 1)
+}
+
+// 处理树视图中的移动按键（j/k/方向键、[ ]、PgUp/PgDn、Home/End），按计数重复；不是移动按键时返回 false
+func (a *App) handleTreeMotion(event *tcell.EventKey, count int) bool {
+	switch event.Key() {
+	case tcell.KeyUp:
+		a.moveTree(-count)
+	case tcell.KeyDown:
+		a.moveTree(count)
+	case tcell.KeyPgUp:
+		a.moveTree(-count * a.treePageSize())
+	case tcell.KeyPgDn:
+		a.moveTree(count * a.treePageSize())
+	case tcell.KeyHome:
+		a.moveTreeTop()
+	case tcell.KeyEnd:
+		a.moveTreeBottom()
+	case tcell.KeyRune:
+		if event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 {
+			return false
+		}
+		switch event.Rune() {
+		case 'k', 'K':
+			a.moveTree(-count)
+		case 'j', 'J':
+			a.moveTree(count)
+		case '[':
+			a.moveSibling(-count)
+		case ']':
+			a.moveSibling(count)
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// 翻页时移动的节点数：连接树可见区域的高度
+func (a *App) treePageSize() int {
+	_, _, _, height := a.tree.GetInnerRect()
+	return max(height-1, 1)
+}
+
+// 在同一层级的节点间移动（下一个/上一个项目、环境或连接），到达首尾时停止
+func (a *App) moveSibling(offset int) {
+	node := a.selected
+	if node.ID() == "" {
+		return
+	}
+	var siblings []string
+	var name *string
+	switch node.Level {
+	case 0:
+		for _, project := range a.getProjectList() {
+			siblings = append(siblings, project.Name)
+		}
+		name = &node.Project
+	case 1:
+		for _, env := range a.getEnvironmentList(node.Project) {
+			siblings = append(siblings, env.Name)
+		}
+		name = &node.Env
+	case 2:
+		for _, conn := range a.getConnectionList(node.Project, node.Env) {
+			siblings = append(siblings, conn.Name)
+		}
+		name = &node.Conn
+	}
+	if i := slices.Index(siblings, *name); i >= 0 {
+		*name = siblings[clampIndex(i+offset, len(siblings))]
+	}
+	a.setCurrentNode(node)
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 把索引限制在 [0, n) 内
+func clampIndex(index, n int) int {
+	return max(min(index, n-1), 0)
+}
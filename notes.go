@@ -0,0 +1,165 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 主机备注文件名（位于数据目录中），按连接标识保存
+const notesFile = "notes.json"
+
+// 单条主机备注
+type hostNote struct {
+	Text     string    `json:"text"`               // 备注内容
+	Author   string    `json:"author"`             // 记录人
+	Time     time.Time `json:"time"`               // 记录时间
+	Resolved bool      `json:"resolved,omitempty"` // 是否已处理
+}
+
+// 备注读写锁
+var notesMu sync.Mutex
+
+// 读取全部备注
+func loadNotes() map[string][]hostNote {
+	notes := make(map[string][]hostNote)
+	_ = readJSONFile(notesFile, &notes)
+	return notes
+}
+
+// 读取目标的备注
+func targetNotes(target connTarget) []hostNote {
+	notesMu.Lock()
+	defer notesMu.Unlock()
+	return loadNotes()[target.ID()]
+}
+
+// 读取目标未处理的备注
+func openNotes(target connTarget) []hostNote {
+	var result []hostNote
+	for _, note := range targetNotes(target) {
+		if !note.Resolved {
+			result = append(result, note)
+		}
+	}
+	return result
+}
+
+// 修改目标的备注列表并写回
+func updateNotes(target connTarget, change func([]hostNote) []hostNote) error {
+	notesMu.Lock()
+	defer notesMu.Unlock()
+	notes := loadNotes()
+	updated := change(notes[target.ID()])
+	if len(updated) == 0 {
+		delete(notes, target.ID())
+	} else {
+		notes[target.ID()] = updated
+	}
+	return writeJSONFile(notesFile, notes)
+}
+
+// 显示当前连接的备注列表：N 新增，Enter 切换已处理，D 删除
+func (a *App) showNotes() {
+	target, ok := a.currentTarget()
+	if !ok {
+		a.statusBar.SetText(tr("common.select_connection"))
+		return
+	}
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("notes.title", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	var notes []hostNote
+	refresh := func() {
+		notes = targetNotes(target)
+		rows := [][]string{{tr("common.col_status"), tr("common.col_time"), tr("notes.col_author"), tr("notes.col_text")}}
+		for _, note := range notes {
+			status := tr("notes.open")
+			if note.Resolved {
+				status = tr("notes.resolved")
+			}
+			rows = append(rows, []string{status, formatTime(note.Time), note.Author, note.Text})
+		}
+		table.Clear()
+		fillTable(table, rows)
+		if row, _ := table.GetSelection(); row < 1 || row > len(notes) {
+			table.Select(min(1, len(notes)), 0)
+		}
+	}
+	refresh()
+
+	change := func(index int, action string, edit func([]hostNote) []hostNote) {
+		text := notes[index].Text
+		if err := updateNotes(target, func(current []hostNote) []hostNote {
+			if index >= len(current) || current[index].Text != text {
+				return current
+			}
+			return edit(current)
+		}); err != nil {
+			a.statusBar.SetText(tr("notes.save_failed", err))
+			return
+		}
+		recordAudit(auditEvent{Action: action, Target: target.ID(), Detail: text})
+		refresh()
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := table.GetSelection()
+		index := row - 1
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if index < 0 || index >= len(notes) {
+				return nil
+			}
+			action := "note_resolve"
+			if notes[index].Resolved {
+				action = "note_reopen"
+			}
+			change(index, action, func(current []hostNote) []hostNote {
+				current[index].Resolved = !current[index].Resolved
+				return current
+			})
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'n', 'N':
+				a.prompt(tr("notes.new"), tr("notes.text_label"), "", func(text string) {
+					note := hostNote{Text: strings.TrimSpace(text), Author: currentUsername(), Time: time.Now()}
+					if err := updateNotes(target, func(current []hostNote) []hostNote {
+						return append(current, note)
+					}); err != nil {
+						a.statusBar.SetText(tr("notes.save_failed", err))
+						return
+					}
+					recordAudit(auditEvent{Action: "note_add", Target: target.ID(), Detail: note.Text})
+					refresh()
+				})
+				return nil
+			case 'd', 'D':
+				if index < 0 || index >= len(notes) {
+					return nil
+				}
+				a.confirm(tr("notes.delete"), tr("notes.confirm_delete", tview.Escape(notes[index].Text)), func() {
+					change(index, "note_delete", func(current []hostNote) []hostNote {
+						return append(current[:index], current[index+1:]...)
+					})
+				})
+				return nil
+			}
+		}
+		return event
+	})
+}
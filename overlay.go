@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 覆盖层：临时替换根界面的弹出组件，按栈方式管理
+type overlay struct {
+	root    tview.Primitive                             // 覆盖层根组件
+	focus   tview.Primitive                             // 覆盖层打开时的焦点组件
+	handler func(event *tcell.EventKey) *tcell.EventKey // 覆盖层的键盘事件处理器
+}
+
+// 打开一个覆盖层
+func (a *App) pushOverlay(root, focus tview.Primitive, handler func(event *tcell.EventKey) *tcell.EventKey) {
+	a.overlays = append(a.overlays, overlay{root: root, focus: focus, handler: handler})
+	a.zoomed = false
+	a.app.SetRoot(root, true)
+	a.app.SetFocus(focus)
+}
+
+// 关闭最上层的覆盖层，恢复下一层覆盖层或主界面
+func (a *App) popOverlay() {
+	if len(a.overlays) == 0 {
+		return
+	}
+	a.overlays = a.overlays[:len(a.overlays)-1]
+	a.zoomed = false
+	if len(a.overlays) == 0 {
+		a.app.SetRoot(a.grid, true)
+		a.setInitialFocus()
+		return
+	}
+	top := a.overlays[len(a.overlays)-1]
+	a.app.SetRoot(top.root, true)
+	a.app.SetFocus(top.focus)
+}
+
+// 将组件居中放置在指定宽高的区域内
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewGrid().
+		SetRows(0, height, 0).
+		SetColumns(0, width, 0).
+		SetBorders(false).
+		AddItem(p, 1, 1, 1, 1, 0, 0, true)
+}
+
+// 显示Y/N确认对话框，选择Yes后执行回调
+func (a *App) confirm(title, message string, onYes func()) {
+	box := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignCenter).
+		SetWrap(true)
+	box.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	box.SetText(fmt.Sprintf("\n%s\n\n[green]Yes (Y)[-]    [red]No (N)[-]\n", message))
+
+	a.pushOverlay(centered(box, 60, 12), box, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'y', 'Y':
+				a.popOverlay()
+				onYes()
+				return nil
+			case 'n', 'N':
+				a.popOverlay()
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// 显示单行输入框，回车后执行回调
+func (a *App) prompt(title, label, initial string, onDone func(text string)) *tview.InputField {
+	input := tview.NewInputField().
+		SetLabel(label).
+		SetText(initial).
+		SetFieldWidth(0)
+	input.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(input, 70, 3), input, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if input.GetText() == "" {
+				return nil
+			}
+			a.popOverlay()
+			onDone(input.GetText())
+			return nil
+		}
+		return event
+	})
+	return input
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 命令面板最多显示的结果数
+const paletteMaxResults = 200
+
+// 命令面板中的一个节点：模块、项目、环境或连接
+type paletteEntry struct {
+	Path               string // 模块/项目/环境/连接形式的路径
+	Level              int    // -1 模块，0 项目，1 环境，2 连接
+	Module             int    // 模块下标
+	Project, Env, Conn string // 节点名称
+	Status             string // 连接状态（仅连接节点）
+}
+
+// 列出所有模块、项目、环境和连接节点
+func (a *App) paletteEntries() []paletteEntry {
+	var entries []paletteEntry
+	for m, module := range a.modules {
+		entries = append(entries, paletteEntry{Path: module, Level: -1, Module: m})
+		for _, project := range projectList(module) {
+			p := project.Name
+			projectPath := module + "/" + p
+			entries = append(entries, paletteEntry{Path: projectPath, Level: 0, Module: m, Project: p})
+			for _, env := range projectEnvironments(module, p) {
+				e := env.Name
+				envPath := projectPath + "/" + e
+				entries = append(entries, paletteEntry{Path: envPath, Level: 1, Module: m, Project: p, Env: e})
+				for _, conn := range envConnections(module, p, e) {
+					entries = append(entries, paletteEntry{Path: envPath + "/" + conn.Name, Level: 2, Module: m, Project: p, Env: e, Conn: conn.Name, Status: conn.Status})
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// 模糊匹配：关键字中的字符按顺序出现在文本中即匹配（忽略空格和大小写）；
+// 连续匹配、匹配在分段开头或最后一段（节点名称）中时得分更高，返回得分与匹配的字符位置
+func fuzzyMatch(query, text string) (int, []int, bool) {
+	pattern := []rune(strings.ToLower(strings.ReplaceAll(query, " ", "")))
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	lastSegment := strings.LastIndex(text, "/")
+	lastSegment = len([]rune(text[:lastSegment+1]))
+
+	score, positions := 0, make([]int, 0, len(pattern))
+	next := 0
+	for i := 0; i < len(lower) && next < len(pattern); i++ {
+		if lower[i] != pattern[next] {
+			continue
+		}
+		score++
+		if len(positions) > 0 && positions[len(positions)-1] == i-1 {
+			score += 5
+		}
+		if i == 0 || runes[i-1] == '/' || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]) {
+			score += 3
+		}
+		if i >= lastSegment {
+			score += 2
+		}
+		positions = append(positions, i)
+		next++
+	}
+	if next < len(pattern) {
+		return 0, nil, false
+	}
+	// 同分时路径越短越靠前
+	return score*100 - len(runes), positions, true
+}
+
+// 高亮匹配的字符
+func highlightMatches(text string, positions []int) string {
+	var b strings.Builder
+	matched := make(map[int]bool, len(positions))
+	for _, i := range positions {
+		matched[i] = true
+	}
+	for i, r := range []rune(text) {
+		if matched[i] {
+			fmt.Fprintf(&b, "[yellow::b]%s[-::-]", tview.Escape(string(r)))
+		} else {
+			b.WriteString(tview.Escape(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// 在树状视图中定位到指定节点（level 为 -1 时进入模块的树状视图）
+func (a *App) focusNode(entry paletteEntry) {
+	a.recordJump()
+	a.showNode(entry)
+}
+
+// 选中并显示树中的节点，展开其所在的项目和环境（不记录跳转历史）
+func (a *App) showNode(entry paletteEntry) {
+	a.currentModule, a.hoveredModule = entry.Module, entry.Module
+	a.inTreeView = true
+	node := TreeNode{Level: max(entry.Level, 0), Module: a.modules[entry.Module], Project: entry.Project, Env: entry.Env, Conn: entry.Conn}
+	a.setCurrentNode(node)
+	if node.Level == 2 {
+		node = node.parent()
+		a.expandedNodes[node.ID()] = true
+	}
+	if node.Level == 1 {
+		a.expandedNodes[node.parent().ID()] = true
+	}
+	a.updateModuleBar()
+	a.updateMainPanel()
+	a.updateStatusBar()
+}
+
+// 显示命令面板：输入关键字（可预填 query）模糊匹配全部模块、项目、环境和连接，Tab 跳转到节点，Enter 连接（非连接节点为跳转）
+func (a *App) showPalette(query string) {
+	entries := a.paletteEntries()
+	var results []paletteEntry
+
+	input := tview.NewInputField().
+		SetLabel("> ").
+		SetFieldWidth(0)
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetHighlightFullLine(true)
+	layout := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(list, 0, 1, false)
+	layout.SetBorder(true).
+		SetTitle(tr("palette.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	refresh := func(query string) {
+		type match struct {
+			entry     paletteEntry
+			score     int
+			positions []int
+		}
+		var matches []match
+		for _, entry := range entries {
+			if score, positions, ok := fuzzyMatch(query, entry.Path); ok {
+				matches = append(matches, match{entry, score, positions})
+			}
+		}
+		// 没有关键字时保持树中的顺序
+		if strings.TrimSpace(query) != "" {
+			sort.SliceStable(matches, func(i, j int) bool {
+				return matches[i].score > matches[j].score
+			})
+		}
+		if len(matches) > paletteMaxResults {
+			matches = matches[:paletteMaxResults]
+		}
+
+		list.Clear()
+		results = results[:0]
+		for _, m := range matches {
+			text := highlightMatches(m.entry.Path, m.positions)
+			if m.entry.Level == 2 {
+				color, status := connectionStatusStyle(m.entry.Status)
+				text += fmt.Sprintf("  [%s]%s[-]", color, status)
+			}
+			list.AddItem(text, "", 0, nil)
+			results = append(results, m.entry)
+		}
+	}
+	input.SetChangedFunc(refresh)
+	input.SetText(query)
+	refresh(query)
+
+	open := func(connect bool) {
+		index := list.GetCurrentItem()
+		if index < 0 || index >= len(results) {
+			return
+		}
+		entry := results[index]
+		a.popOverlay()
+		a.focusNode(entry)
+		if connect && entry.Level == 2 {
+			a.activateTreeItem()
+		}
+	}
+
+	a.pushOverlay(centered(layout, 90, 24), input, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			open(true)
+			return nil
+		case tcell.KeyTab:
+			open(false)
+			return nil
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			// 方向键移动结果列表，其余按键交给输入框
+			if handler := list.InputHandler(); handler != nil {
+				handler(event, func(p tview.Primitive) {})
+			}
+			return nil
+		}
+		return event
+	})
+}
@@ -0,0 +1,380 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 端口转发类型
+const (
+	forwardLocal   = "local"   // 本地端口转发到远端（ssh -L）
+	forwardRemote  = "remote"  // 远端端口转发到本地（ssh -R）
+	forwardDynamic = "dynamic" // 本地 SOCKS 代理（ssh -D）
+)
+
+// 启动端口转发后等待进程报错的时间，超过后视为已建立
+const forwardStartGrace = 3 * time.Second
+
+// SSH 连接上定义的端口转发（配置项 forwards.<连接标识>）
+type portForward struct {
+	Name   string `mapstructure:"name"`   // 名称，默认由类型和监听地址生成
+	Type   string `mapstructure:"type"`   // local、remote 或 dynamic，默认 local
+	Listen string `mapstructure:"listen"` // 监听地址：端口或 地址:端口（remote 时为远端监听）
+	To     string `mapstructure:"to"`     // 转发目标 主机:端口（dynamic 不需要）
+}
+
+// 转发类型（小写，未配置时为 local）
+func (f portForward) kind() string {
+	if f.Type == "" {
+		return forwardLocal
+	}
+	return strings.ToLower(f.Type)
+}
+
+// 转发名称
+func (f portForward) label() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.kind() + " " + f.Listen
+}
+
+// 转发的 ssh 参数
+func (f portForward) sshFlag() ([]string, error) {
+	if f.Listen == "" {
+		return nil, errors.New(tr("forward.no_listen", f.label()))
+	}
+	switch f.kind() {
+	case forwardLocal, forwardRemote:
+		if f.To == "" {
+			return nil, errors.New(tr("forward.no_to", f.label()))
+		}
+		flag := "-L"
+		if f.kind() == forwardRemote {
+			flag = "-R"
+		}
+		return []string{flag, f.Listen + ":" + f.To}, nil
+	case forwardDynamic:
+		return []string{"-D", f.Listen}, nil
+	}
+	return nil, errors.New(tr("forward.invalid_type", f.Type))
+}
+
+// 转发方向的说明，如“本机 8080 → db.internal:5432”
+func (f portForward) describe() string {
+	switch f.kind() {
+	case forwardRemote:
+		return tr("forward.label_remote", f.Listen, f.To)
+	case forwardDynamic:
+		return tr("forward.label_dynamic", f.Listen)
+	}
+	return tr("forward.label_local", f.Listen, f.To)
+}
+
+// 连接上定义的端口转发（连接标识不区分大小写）
+func forwardsFor(target connTarget) []portForward {
+	var all map[string][]portForward
+	_ = unmarshalConfig("forwards", &all)
+	return lookupFold(all, target.ID())
+}
+
+// 只建立转发、不执行远程命令的 ssh 选项；后台运行时无法输入密码，因此禁止密码提示
+func forwardOptions(target connTarget) []string {
+	options := append([]string{"-N"}, batchModeOptions(target)...)
+	if resolveTransport(target).kind() != transportTeleport {
+		options = append(options, "-o", "ExitOnForwardFailure=yes")
+	}
+	return options
+}
+
+// 数据库连接经由的 SSH 连接（字段“SSH隧道”），未设置时返回 false
+func tunnelBastion(target connTarget) (connTarget, bool, error) {
+	id := target.Conn.SSHTunnel
+	if id == "" || moduleType(target.Module) == "SSH" {
+		return connTarget{}, false, nil
+	}
+	bastion, ok := findTarget(id)
+	if !ok || moduleType(bastion.Module) != "SSH" {
+		return connTarget{}, true, errors.New(tr("forward.tunnel_not_found", id))
+	}
+	return bastion, true, nil
+}
+
+// 经由 SSH 连接把数据库端口转发到本地端口的命令
+func bastionForwardCommand(bastion connTarget, conn Connection, local int) []string {
+	args := sshBaseArgs(bastion, resolveSessionPolicy(bastion))
+	options := append(forwardOptions(bastion), "-L", fmt.Sprintf("127.0.0.1:%d:%s:%d", local, conn.Host, conn.Port))
+	return insertSSHOptions(bastion, args, options...)
+}
+
+// 运行中的端口转发
+type runningForward struct {
+	cmd     *exec.Cmd       // ssh 进程
+	done    chan struct{}   // 进程退出时关闭
+	started time.Time       // 启动时间
+	stderr  strings.Builder // ssh 的错误输出，进程退出后读取
+}
+
+// 运行中的端口转发，按“连接标识#转发名称”登记；进程退出后保留，以便显示失败原因
+var (
+	forwardsMu      sync.Mutex
+	runningForwards = make(map[string]*runningForward)
+)
+
+// 端口转发的登记键
+func forwardKey(target connTarget, f portForward) string {
+	return target.ID() + "#" + f.label()
+}
+
+// 转发是否仍在运行
+func (r *runningForward) alive() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// 启动端口转发，已在运行时返回错误；返回的通道在进程退出或等待 forwardStartGrace 后关闭
+func startForward(target connTarget, f portForward) (<-chan struct{}, error) {
+	flag, err := f.sshFlag()
+	if err != nil {
+		return nil, err
+	}
+	forwardsMu.Lock()
+	defer forwardsMu.Unlock()
+	key := forwardKey(target, f)
+	if r, ok := runningForwards[key]; ok && r.alive() {
+		return nil, errors.New(tr("forward.already_running", f.label()))
+	}
+
+	args := sshBaseArgs(target, resolveSessionPolicy(target))
+	args = insertSSHOptions(target, args, append(forwardOptions(target), flag...)...)
+	r := &runningForward{cmd: exec.Command(args[0], args[1:]...), done: make(chan struct{}), started: time.Now()}
+	r.cmd.Stderr = &r.stderr
+	if err := r.cmd.Start(); err != nil {
+		return nil, fmt.Errorf(tr("forward.start_failed"), err)
+	}
+	go func() {
+		r.cmd.Wait()
+		close(r.done)
+	}()
+	runningForwards[key] = r
+	recordAudit(auditEvent{Action: "forward_start", Target: target.ID(), Detail: f.describe()})
+
+	settled := make(chan struct{})
+	go func() {
+		select {
+		case <-r.done:
+		case <-time.After(forwardStartGrace):
+		}
+		close(settled)
+	}()
+	return settled, nil
+}
+
+// 停止端口转发
+func stopForward(target connTarget, f portForward) bool {
+	forwardsMu.Lock()
+	defer forwardsMu.Unlock()
+	key := forwardKey(target, f)
+	r, ok := runningForwards[key]
+	if !ok {
+		return false
+	}
+	delete(runningForwards, key)
+	if !r.alive() {
+		return false
+	}
+	r.cmd.Process.Kill()
+	recordAudit(auditEvent{Action: "forward_stop", Target: target.ID(), Detail: f.describe(), Duration: time.Since(r.started)})
+	return true
+}
+
+// 端口转发的状态文本
+func forwardStatus(target connTarget, f portForward) string {
+	forwardsMu.Lock()
+	defer forwardsMu.Unlock()
+	r, ok := runningForwards[forwardKey(target, f)]
+	switch {
+	case !ok:
+		return tr("forward.stopped")
+	case r.alive():
+		return tr("forward.running_since", formatShortTime(r.started))
+	}
+	message := strings.TrimSpace(r.stderr.String())
+	if lines := strings.Split(message, "\n"); len(lines) > 1 {
+		message = lines[len(lines)-1]
+	}
+	if message == "" {
+		message = tr("forward.exited")
+	}
+	return fmt.Sprintf("[red]%s[-]", tview.Escape(message))
+}
+
+// 关闭全部端口转发（程序退出时调用）
+func closeForwards() {
+	forwardsMu.Lock()
+	defer forwardsMu.Unlock()
+	for key, r := range runningForwards {
+		if r.alive() {
+			r.cmd.Process.Kill()
+		}
+		delete(runningForwards, key)
+	}
+}
+
+// 隧道面板中的一行：连接上定义的端口转发，或为数据库客户端建立的本地隧道
+type tunnelRow struct {
+	target  connTarget
+	forward *portForward // 为空表示本地隧道
+}
+
+// 显示隧道面板：列出各 SSH 连接定义的端口转发和为数据库客户端建立的本地隧道，
+// Enter/S 启动转发，X 停止转发或关闭隧道，V 打开反向隧道登记，R 刷新状态
+func (a *App) showTunnels() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("forward.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	var rows []tunnelRow
+	render := func() {
+		selection, _ := table.GetSelection()
+		rows = rows[:0]
+		var cells [][]string // 连接、名称、转发、状态（状态带颜色标记）
+		for _, target := range inventoryTargets(configuredModules()) {
+			if moduleType(target.Module) != "SSH" {
+				continue
+			}
+			for _, f := range forwardsFor(target) {
+				rows = append(rows, tunnelRow{target: target, forward: &f})
+				cells = append(cells, []string{target.ID(), f.label(), f.describe(), forwardStatus(target, f)})
+			}
+		}
+
+		now := time.Now()
+		tunnelsMu.Lock()
+		ids := make([]string, 0, len(tunnels))
+		for id, t := range tunnels {
+			if t.alive() {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			t := tunnels[id]
+			via := tr("forward.via_tunnel")
+			if bastion := t.target.Conn.SSHTunnel; bastion != "" {
+				via = tr("forward.via_bastion", bastion)
+			}
+			rows = append(rows, tunnelRow{target: t.target})
+			cells = append(cells, []string{id, tr("forward.client_tunnel"),
+				tr("forward.client_label", t.port, t.target.Conn.Host, t.target.Conn.Port, via),
+				tr("forward.running") + t.activityText(now)})
+		}
+		tunnelsMu.Unlock()
+
+		table.Clear()
+		for c, title := range []string{tr("common.col_connection"), tr("common.col_name"), tr("forward.col_forward"), tr("common.col_status")} {
+			table.SetCell(0, c, tview.NewTableCell(title).SetTextColor(tcell.ColorYellow).SetSelectable(false).SetExpansion(1))
+		}
+		for r, row := range cells {
+			for c, value := range row[:3] {
+				table.SetCell(r+1, c, tview.NewTableCell(tview.Escape(value)).SetExpansion(1))
+			}
+			table.SetCell(r+1, 3, tview.NewTableCell(row[3]).SetExpansion(1))
+		}
+		if len(rows) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell(tr("forward.empty")).SetSelectable(false))
+		}
+		table.Select(max(1, min(selection, len(rows))), 0)
+	}
+	render()
+
+	selected := func() (tunnelRow, bool) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(rows) {
+			return tunnelRow{}, false
+		}
+		return rows[row-1], true
+	}
+	start := func() {
+		row, ok := selected()
+		if !ok || row.forward == nil {
+			return
+		}
+		f := *row.forward
+		settled, err := startForward(row.target, f)
+		if err != nil {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		a.statusBar.SetText(tr("forward.starting", tview.Escape(f.label())))
+		render()
+		go func() {
+			<-settled
+			a.app.QueueUpdateDraw(func() {
+				a.statusBar.SetText(tr("forward.status", tview.Escape(f.label()), forwardStatus(row.target, f)))
+				render()
+			})
+		}()
+	}
+	stop := func() {
+		row, ok := selected()
+		if !ok {
+			return
+		}
+		if row.forward != nil {
+			if stopForward(row.target, *row.forward) {
+				a.statusBar.SetText(tr("forward.stopped_msg", tview.Escape(row.forward.label())))
+			}
+			render()
+			return
+		}
+		id := row.target.ID()
+		closeClientTunnel(id, tr("forward.closed_manually"))
+		a.statusBar.SetText(tr("forward.tunnel_closed", tview.Escape(id)))
+		render()
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			start()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 's', 'S':
+				start()
+				return nil
+			case 'x', 'X':
+				stop()
+				return nil
+			case 'v', 'V':
+				a.showReverseTunnels()
+				return nil
+			case 'r', 'R':
+				render()
+				return nil
+			}
+		}
+		return event
+	})
+}
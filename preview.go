@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 批量或破坏性操作应用前的预览（试运行）
+type changePreview struct {
+	Title    string     // 操作名称
+	Header   []string   // 受影响条目的表头
+	Rows     [][]string // 受影响的条目
+	Commands []string   // 将要执行的命令（已隐去密码）
+	Envs     []string   // 涉及的环境，配置了确认短语时应用前还需输入短语
+	Empty    string     // 没有受影响条目时的说明
+}
+
+// 显示预览：列出受影响的条目和将要执行的命令，按 Y 应用，ESC 取消；没有受影响的条目时不能应用
+func (a *App) showPreview(preview changePreview, apply func()) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("preview.title", preview.Title, len(preview.Rows))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	search := newTableSearch(table)
+
+	rows := append([][]string{preview.Header}, preview.Rows...)
+	if len(preview.Rows) == 0 {
+		empty := make([]string, len(preview.Header))
+		empty[0] = "(" + preview.Empty + ")"
+		rows = append(rows, empty)
+	}
+	fillTable(table, rows)
+	table.Select(1, 0)
+
+	root := tview.Primitive(table)
+	if len(preview.Commands) > 0 {
+		commands := tview.NewTextView().
+			SetScrollable(true).
+			SetWrap(true).
+			SetText(strings.Join(preview.Commands, "\n"))
+		commands.SetBorder(true).
+			SetTitle(tr("preview.commands")).
+			SetTitleAlign(tview.AlignLeft).
+			SetBorderColor(tcell.ColorGray)
+		root = tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(table, 0, 2, true).
+			AddItem(commands, min(len(preview.Commands)+2, 12), 0, false)
+	}
+
+	a.pushOverlay(root, table, func(event *tcell.EventKey) *tcell.EventKey {
+		if search.handleKey(a, event) {
+			return nil
+		}
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'y' || event.Rune() == 'Y'):
+			if len(preview.Rows) == 0 {
+				return nil
+			}
+			a.popOverlay()
+			message := tr("preview.confirm", tview.Escape(preview.Title), len(preview.Rows))
+			a.confirmPhrases(tr("preview.confirm_prefix")+preview.Title, message, envPhrases(preview.Envs), apply)
+			return nil
+		}
+		return event
+	})
+}
+
+// 新增连接的预览条目
+func connectionPreviewRows(entries []inventoryEntry) [][]string {
+	var rows [][]string
+	for _, entry := range entries {
+		rows = append(rows, []string{entry.ID(), fmt.Sprintf("%s:%d", entry.Conn.Host, entry.Conn.Port), entry.Conn.User, strings.Join(entry.Conn.Tags, ",")})
+	}
+	return rows
+}
+
+// 新增连接预览的表头
+func connectionPreviewHeader() []string {
+	return []string{tr("preview.col_new"), tr("common.col_address"), tr("common.col_user"), tr("field.tags")}
+}
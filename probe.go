@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 打开交互式客户端前的可达性探测：建立 TCP 连接，按模块设置进行协议级探测（见 protocolPing）；
+// 经隧道、Teleport、跳板或自定义代理命令连接时由远端解析地址，跳过探测
+func startupProbe(target connTarget, conn Connection) error {
+	timeout := resolveSessionPolicy(target).StartupProbe
+	if timeout <= 0 || conn.ProxyCommand != "" || conn.JumpHost != "" || resolveTransport(target).kind() != transportSSH {
+		return nil
+	}
+	address := net.JoinHostPort(conn.Host, strconv.Itoa(conn.Port))
+	c, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return probeError(conn, timeout, err)
+	}
+	defer c.Close()
+	// 端口可连但服务不响应时客户端同样会长时间挂起
+	if err := protocolPing(target.Module, c, timeout); err != nil {
+		return fmt.Errorf(tr("probe.port_error"), conn.Host, conn.Port, err)
+	}
+	return nil
+}
+
+// 将拨号错误转换为简短说明
+func probeError(conn Connection, timeout time.Duration, err error) error {
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errors.New(tr("probe.timeout", conn.Host, conn.Port, timeout))
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return errors.New(tr("probe.refused", conn.Host, conn.Port))
+	}
+	return fmt.Errorf(tr("probe.unreachable"), conn.Host, conn.Port, err)
+}
+
+// 在后台探测连接，可达时在界面线程中执行 open，不可达时标记连接失败并在状态栏提示
+func (a *App) probeThen(target connTarget, open func()) {
+	a.statusBar.SetText(tr("probe.probing", tview.Escape(target.Conn.Name)))
+	go func() {
+		err := startupProbe(target, target.Conn)
+		a.app.QueueUpdateDraw(func() {
+			if err != nil {
+				setSessionStatus(target, "failed")
+				a.updateMainPanel()
+				recordFailure(failureDetail{Target: target, Summary: err.Error()})
+				a.statusBar.SetText(tr("probe.failed", tview.Escape(err.Error())))
+				return
+			}
+			open()
+		})
+	}()
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 进度弹窗的刷新间隔
+const progressRefresh = 200 * time.Millisecond
+
+// 进度条宽度（字符数）
+const progressBarWidth = 30
+
+// 旋转指示帧（总量未知时显示）
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// 长时间操作的进度弹窗：显示进度条或旋转指示、已用时间，取消时调用操作 context 的 cancel
+type progressModal struct {
+	app    *App
+	root   tview.Primitive
+	view   *tview.TextView
+	cancel context.CancelFunc
+	start  time.Time
+	stop   chan struct{}
+
+	mu        sync.Mutex
+	message   string // 当前进度说明
+	current   int64  // 已完成量
+	total     int64  // 总量，0 表示未知
+	cancelled bool   // 是否已请求取消
+}
+
+// 打开进度弹窗；ESC 或“取消”按钮调用 cancel，操作结束后由工作协程调用 finish
+func (a *App) startProgress(title string, cancel context.CancelFunc) *progressModal {
+	p := &progressModal{
+		app:    a,
+		cancel: cancel,
+		start:  time.Now(),
+		stop:   make(chan struct{}),
+		view:   tview.NewTextView().SetDynamicColors(true).SetTextAlign(tview.AlignCenter),
+	}
+	button := tview.NewButton(tr("common.cancel")).SetSelectedFunc(p.requestCancel)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(p.view, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(button, 10, 0, true).
+			AddItem(nil, 0, 1, false), 1, 0, true)
+	layout.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	p.root = centered(layout, 60, 9)
+	p.render()
+
+	a.pushOverlay(p.root, button, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			p.requestCancel()
+			return nil
+		}
+		return event
+	})
+
+	go func() {
+		ticker := time.NewTicker(progressRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.app.QueueUpdateDraw(p.render)
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// 更新进度（可在任意协程中调用）；total 为 0 时只显示旋转指示
+func (p *progressModal) update(message string, current, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.message, p.current, p.total = message, current, total
+}
+
+// 请求取消操作，弹窗保留到操作实际结束
+func (p *progressModal) requestCancel() {
+	p.mu.Lock()
+	p.cancelled = true
+	p.mu.Unlock()
+	p.cancel()
+	p.render()
+}
+
+// 操作结束：关闭弹窗并在界面协程中执行回调（只能调用一次）
+func (p *progressModal) finish(onDone func()) {
+	close(p.stop)
+	p.app.app.QueueUpdateDraw(func() {
+		if overlays := p.app.overlays; len(overlays) > 0 && overlays[len(overlays)-1].root == p.root {
+			p.app.popOverlay()
+		}
+		if onDone != nil {
+			onDone()
+		}
+	})
+}
+
+// 渲染进度内容
+func (p *progressModal) render() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	elapsed := time.Since(p.start)
+
+	var indicator string
+	if p.total > 0 {
+		ratio := min(float64(p.current)/float64(p.total), 1)
+		filled := int(ratio * progressBarWidth)
+		indicator = fmt.Sprintf("[green]%s[-][gray]%s[-] %3.0f%%",
+			strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled), ratio*100)
+	} else {
+		indicator = "[yellow]" + spinnerFrames[int(elapsed/progressRefresh)%len(spinnerFrames)] + "[-]"
+	}
+
+	content := "\n" + indicator + "\n"
+	if p.message != "" {
+		content += tview.Escape(p.message) + "\n"
+	}
+	content += tr("progress.elapsed", formatDuration(elapsed.Round(time.Second)))
+	if p.cancelled {
+		content += tr("progress.cancelling")
+	}
+	p.view.SetText(content)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 晋升时从源连接复制的非凭据字段
+var promotedFields = []string{"host", "port", "database", "tags"}
+
+// 打开晋升表单，将当前连接定义复制到另一个环境，并填写目标环境的凭据
+func (a *App) showPromoteForm() {
+	source, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	module := source.Module
+	var refs []envRef
+	var labels []string
+	for _, ref := range moduleEnvironments(module) {
+		if ref.Project == source.Project && ref.Env == source.Env {
+			continue
+		}
+		refs = append(refs, ref)
+		labels = append(labels, ref.Label)
+	}
+	if len(refs) == 0 {
+		a.statusBar.SetText(tr("promote.no_target"))
+		return
+	}
+
+	dest, user, identity := 0, source.Conn.User, ""
+	form := tview.NewForm()
+	form.AddDropDown(tr("promote.target_env"), labels, 0, func(option string, index int) {
+		dest = index
+	}).
+		AddInputField(tr("promote.target_user"), user, 30, nil, func(text string) {
+			user = text
+		})
+	if moduleType(module) == "SSH" {
+		form.AddInputField(tr("promote.target_identity"), "", 40, nil, func(text string) {
+			identity = text
+		})
+	}
+	form.AddButton(tr("promote.button"), func() {
+		a.popOverlay()
+		ref := refs[dest]
+		message := tr("promote.confirm", tview.Escape(source.Conn.Name), tview.Escape(ref.Label))
+		target := connTarget{Module: module, Project: ref.Project, Env: ref.Env}
+		if isProtectedEnv(target.Env) {
+			message += tr("common.protected_target")
+		}
+		a.confirmEnvs(tr("promote.confirm_title"), message, []string{target.Env}, func() {
+			a.promoteConnection(source, ref, user, identity)
+		})
+	}).
+		AddButton(tr("common.cancel"), func() {
+			a.popOverlay()
+		})
+	form.SetBorder(true).
+		SetTitle(tr("promote.title", source.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(centered(form, 64, 11), form, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		return event
+	})
+}
+
+// 执行晋升：目标环境已有同名连接时更新字段，否则新增连接；结果记录到审计日志
+func (a *App) promoteConnection(source connTarget, ref envRef, user, identity string) {
+	module := source.Module
+	dest := connTarget{Module: module, Project: ref.Project, Env: ref.Env}
+
+	var existing *Connection
+	for _, conn := range envConnections(module, ref.Project, ref.Env) {
+		if conn.Name == source.Conn.Name {
+			existing = &conn
+			break
+		}
+	}
+
+	var err error
+	if existing != nil {
+		dest.Conn = *existing
+		updated := *existing
+		for _, field := range promotedFields {
+			_ = setConnectionField(&updated, field, connectionField(source.Conn, field))
+		}
+		updated.User, updated.IdentityFile = user, identity
+		var changes []fieldChange
+		for _, field := range connectionFields {
+			if old, value := connectionField(*existing, field), connectionField(updated, field); old != value {
+				changes = append(changes, fieldChange{Target: dest, Field: field, Old: old, New: value})
+			}
+		}
+		err = applyFieldChanges(changes, "promote_field")
+	} else {
+		conn := Connection{Name: source.Conn.Name, Status: "disconnected", User: user, IdentityFile: identity}
+		for _, field := range promotedFields {
+			_ = setConnectionField(&conn, field, connectionField(source.Conn, field))
+		}
+		dest.Conn = conn
+		err = saveNewConnections([]inventoryEntry{{Module: dest.Module, Project: dest.Project, Env: dest.Env, Conn: conn}})
+	}
+
+	event := auditEvent{Action: "promote", Target: dest.ID(), Detail: tr("sshconfig.audit_from") + source.ID()}
+	if err != nil {
+		event.Detail += ": " + err.Error()
+	}
+	recordAudit(event)
+
+	if err != nil {
+		a.statusBar.SetText(tr("promote.failed", tview.Escape(err.Error())))
+		return
+	}
+	a.updateMainPanel()
+	if reviewMode() {
+		a.statusBar.SetText(tr("promote.staged", tview.Escape(dest.ID())))
+		return
+	}
+	a.statusBar.SetText(tr("promote.done", tview.Escape(dest.ID())))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// 代理命令模板中可引用的字段，如 {{.Host}}、{{.Port}}、{{tag "region"}}
+type proxyTemplateData struct {
+	Module  string
+	Project string
+	Env     string
+	Name    string
+	Host    string
+	Port    int
+	User    string
+}
+
+// 解析代理命令模板，tag 函数返回 key=value 形式标签的值
+func parseProxyTemplate(text string, tags []string) (*template.Template, error) {
+	return template.New("proxy").Option("missingkey=error").Funcs(template.FuncMap{
+		"tag": func(key string) string { return tagValue(tags, key) },
+	}).Parse(text)
+}
+
+// 获取 key=value 形式标签的值，没有时返回空字符串
+func tagValue(tags []string, key string) string {
+	for _, tag := range tags {
+		if value, ok := strings.CutPrefix(tag, key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// 代理命令由 sh -c 执行，并由 ssh 展开 % 记号：代入的值按 shell 单引号转义，其中的 % 写作 %%；空值不代入
+func proxyQuote(value string) string {
+	if value == "" {
+		return ""
+	}
+	return shellQuote(strings.ReplaceAll(value, "%", "%%"))
+}
+
+// 渲染连接的 ProxyCommand 模板，代入的字符串值均已转义；模板中仍可使用 OpenSSH 自身的 %h、%p、%r
+func renderProxyCommand(target connTarget) (string, error) {
+	conn := target.Conn
+	tmpl, err := parseProxyTemplate(conn.ProxyCommand, conn.Tags)
+	if err != nil {
+		return "", err
+	}
+	tmpl.Funcs(template.FuncMap{
+		"tag": func(key string) string { return proxyQuote(tagValue(conn.Tags, key)) },
+	})
+	var out strings.Builder
+	err = tmpl.Execute(&out, proxyTemplateData{
+		Module:  proxyQuote(target.Module),
+		Project: proxyQuote(target.Project),
+		Env:     proxyQuote(target.Env),
+		Name:    proxyQuote(conn.Name),
+		Host:    proxyQuote(conn.Host),
+		Port:    conn.Port,
+		User:    proxyQuote(conn.User),
+	})
+	return strings.TrimSpace(out.String()), err
+}
+
+// 连接自定义代理命令（未设置时为跳板）对应的 SSH 选项；仅在直接使用 OpenSSH 时生效，其他传输方式已自带代理。
+// 代理命令模板渲染失败或跳板链无效时代理命令直接失败，ssh 不会绕过代理直连目标；
+// 密码认证时代理命令不继承询问密码的环境，保存的密码只交给连接目标主机的 ssh
+func proxyCommandOptions(target connTarget) []string {
+	if resolveTransport(target).kind() != transportSSH {
+		return nil
+	}
+	render := renderProxyCommand
+	if target.Conn.ProxyCommand == "" {
+		render = jumpProxyCommand
+	}
+	command, err := render(target)
+	if err != nil {
+		command = "sh -c " + shellQuote(`echo "$0" >&2; exit 1`) + " " + proxyQuote(err.Error())
+	} else if command == "" {
+		return nil
+	}
+	if target.Conn.Auth == "password" {
+		command = askpassProxyPrefix + command
+	}
+	return []string{"-o", "ProxyCommand=" + command}
+}
+
+// 连接路径无效（代理命令模板错误、跳板不存在或形成循环等）时返回错误，在会话、远程命令和传输开始前检查
+func proxyCommandError(target connTarget) error {
+	if resolveTransport(target).kind() != transportSSH {
+		return nil
+	}
+	if target.Conn.ProxyCommand != "" {
+		if _, err := renderProxyCommand(target); err != nil {
+			return fmt.Errorf(tr("proxy.template"), err)
+		}
+		return nil
+	}
+	_, err := jumpChain(target)
+	return err
+}
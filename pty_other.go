@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// 当前平台没有伪终端支持，内嵌终端回退为挂起界面运行
+func startPTY(cmd *exec.Cmd) (*os.File, error) {
+	return nil, errPTYUnsupported
+}
+
+// 当前平台没有伪终端支持
+func setPTYSize(master *os.File, rows, cols int) error {
+	return errPTYUnsupported
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// URI 协议对应的连接类型
+var uriSchemeTypes = map[string]string{
+	"ssh":        "SSH",
+	"mysql":      "MySQL",
+	"mariadb":    "MySQL",
+	"postgres":   "PostgreSQL",
+	"postgresql": "PostgreSQL",
+	"redis":      "Redis",
+	"rediss":     "Redis",
+}
+
+// 带参数的 ssh 选项
+const sshOptionsWithArg = "BbcDEeFIiJLlmOopQRSWw"
+
+// 解析粘贴的连接文本：ssh 命令、URI（mysql://user@host:3306/db）或 [user@]host[:port]，返回连接和推断的类型（无法推断时为空）
+func parseConnectionText(text string) (Connection, string, error) {
+	text = strings.TrimSpace(strings.SplitN(strings.TrimSpace(text), "\n", 2)[0])
+	text = strings.TrimPrefix(text, "$ ")
+	if text == "" {
+		return Connection{}, "", errors.New(tr("quickadd.clipboard_empty"))
+	}
+	fields := strings.Fields(text)
+	switch {
+	case fields[0] == "ssh":
+		return parseSSHCommand(fields[1:])
+	case strings.Contains(text, "://"):
+		return parseConnectionURI(text)
+	}
+	return parseHostPort(text, "")
+}
+
+// 解析 ssh 命令行参数
+func parseSSHCommand(args []string) (Connection, string, error) {
+	var conn Connection
+	destination := ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || len(arg) < 2 {
+			destination = arg
+			break
+		}
+		flag := arg[1]
+		if !strings.ContainsRune(sshOptionsWithArg, rune(flag)) {
+			continue
+		}
+		value := arg[2:]
+		if value == "" && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		value = strings.Trim(value, `'"`)
+		switch flag {
+		case 'p':
+			conn.Port, _ = strconv.Atoi(value)
+		case 'l':
+			conn.User = value
+		case 'i':
+			conn.IdentityFile = value
+		case 'J':
+			conn.ProxyCommand = "ssh -W %h:%p " + value
+		case 'o':
+			key, option, _ := strings.Cut(value, "=")
+			switch strings.ToLower(key) {
+			case "port":
+				conn.Port, _ = strconv.Atoi(option)
+			case "user":
+				conn.User = option
+			case "identityfile":
+				conn.IdentityFile = option
+			case "proxycommand":
+				conn.ProxyCommand = option
+			case "proxyjump":
+				conn.ProxyCommand = "ssh -W %h:%p " + option
+			}
+		}
+	}
+	if destination == "" {
+		return Connection{}, "", errors.New(tr("quickadd.no_ssh_host"))
+	}
+	if strings.HasPrefix(destination, "ssh://") {
+		parsed, _, err := parseConnectionURI(destination)
+		if err != nil {
+			return Connection{}, "", err
+		}
+		return mergeParsed(conn, parsed), "SSH", nil
+	}
+	parsed, _, err := parseHostPort(destination, "SSH")
+	if err != nil {
+		return Connection{}, "", err
+	}
+	// ssh 目标中的冒号不表示端口
+	if conn.Port == 0 {
+		conn.Port = 22
+	}
+	return mergeParsed(conn, parsed), "SSH", nil
+}
+
+// 用目标地址中解析出的字段补全 ssh 选项未指定的字段
+func mergeParsed(conn, parsed Connection) Connection {
+	conn.Name, conn.Host = parsed.Name, parsed.Host
+	if conn.User == "" {
+		conn.User = parsed.User
+	}
+	if conn.Port == 0 {
+		conn.Port = parsed.Port
+	}
+	return conn
+}
+
+// 解析连接 URI，密码不会被保存
+func parseConnectionURI(text string) (Connection, string, error) {
+	u, err := url.Parse(text)
+	if err != nil {
+		return Connection{}, "", fmt.Errorf(tr("quickadd.invalid_uri"), err)
+	}
+	kind, ok := uriSchemeTypes[strings.ToLower(u.Scheme)]
+	if !ok {
+		return Connection{}, "", errors.New(tr("quickadd.unsupported_scheme", u.Scheme))
+	}
+	if u.Hostname() == "" {
+		return Connection{}, "", errors.New(tr("quickadd.no_uri_host"))
+	}
+	conn := Connection{Name: u.Hostname(), Host: u.Hostname(), Database: strings.TrimPrefix(u.Path, "/")}
+	if u.User != nil {
+		conn.User = u.User.Username()
+	}
+	if conn.Port, _ = strconv.Atoi(u.Port()); conn.Port == 0 {
+		conn.Port = defaultPortForType(kind)
+	}
+	if strings.EqualFold(u.Scheme, "rediss") {
+		conn.Tags = []string{"tls"}
+	}
+	return conn, kind, nil
+}
+
+// 解析 [user@]host[:port]，未给出类型时按常见端口推断
+func parseHostPort(text, kind string) (Connection, string, error) {
+	var conn Connection
+	if user, rest, ok := strings.Cut(text, "@"); ok {
+		conn.User, text = user, rest
+	}
+	if host, port, err := net.SplitHostPort(text); err == nil {
+		conn.Host = host
+		if conn.Port, err = strconv.Atoi(port); err != nil {
+			return Connection{}, "", errors.New(tr("field.invalid_port", port))
+		}
+	} else {
+		conn.Host = strings.Trim(text, "[]")
+	}
+	if conn.Host == "" || strings.ContainsAny(conn.Host, " /") {
+		return Connection{}, "", errors.New(tr("quickadd.unrecognized", text))
+	}
+	if kind == "" {
+		kind = lanProbePorts[conn.Port]
+	}
+	conn.Name = conn.Host
+	return conn, kind, nil
+}
+
+// 从剪贴板读取连接文本并打开预填的新建连接表单
+func (a *App) pasteToAdd() {
+	text, err := readClipboard()
+	if err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	conn, kind, err := parseConnectionText(text)
+	if err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	a.showNewConnectionForm(conn, kind)
+}
+
+// 显示新建连接表单；kind 与当前模块类型不同时添加到该类型的第一个模块
+func (a *App) showNewConnectionForm(conn Connection, kind string) {
+	module := a.modules[a.currentModule]
+	if kind != "" && kind != moduleType(module) {
+		found := false
+		for _, m := range configuredModules() {
+			if moduleType(m) == kind {
+				module, found = m, true
+				break
+			}
+		}
+		if !found {
+			a.statusBar.SetText(tr("quickadd.no_module", kind))
+			return
+		}
+	}
+	if conn.Port == 0 {
+		conn.Port = defaultPort(module)
+	}
+	if conn.User == "" {
+		conn.User = defaultUser(module)
+	}
+
+	refs := moduleEnvironments(module)
+	if len(refs) == 0 {
+		a.statusBar.SetText(tr("quickadd.no_env"))
+		return
+	}
+	labels := make([]string, len(refs))
+	dest := 0
+	for i, ref := range refs {
+		labels[i] = ref.Label
+		if module == a.selected.Module && ref.Project == a.selected.Project && ref.Env == a.selected.Env {
+			dest = i
+		}
+	}
+
+	schema := fieldSchema(module)
+	values := schemaValues(conn, schema)
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	grid := centered(validator.root(), 64, 0).(*tview.Grid)
+	// 下拉字段取值变化时按字段定义重建表单，只显示当前适用的字段
+	var visible []string
+	var build func(focus string)
+	build = func(focus string) {
+		form.Clear(false)
+		validator.reset()
+		form.AddDropDown(tr("quickadd.add_to"), labels, dest, func(option string, index int) {
+			dest = index
+		})
+		visible = validator.addSchemaFields(module, schema, values, func(field string) {
+			a.app.QueueUpdateDraw(func() { build(field) })
+		})
+		grid.SetRows(0, 3*len(visible)+7, 0)
+		if focus != "" {
+			form.SetFocus(form.GetFormItemIndex(fieldLabel(focus)))
+			a.app.SetFocus(form)
+		}
+	}
+	build("")
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		conn := Connection{Name: values[nameField], Status: "disconnected"}
+		for _, field := range visible[1:] {
+			if field == passwordField {
+				continue
+			}
+			if err := setConnectionField(&conn, field, values[field]); err != nil {
+				a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+				return
+			}
+		}
+		ref := refs[dest]
+		for _, existing := range envConnections(module, ref.Project, ref.Env) {
+			if existing.Name == conn.Name {
+				a.statusBar.SetText(tr("quickadd.duplicate", tview.Escape(ref.Label), tview.Escape(conn.Name)))
+				return
+			}
+		}
+		entry := inventoryEntry{Module: module, Project: ref.Project, Env: ref.Env, Conn: conn}
+		if err := saveFormConnection(entry); err != nil {
+			a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+			return
+		}
+		recordAudit(auditEvent{Action: "add", Target: entry.ID()})
+		a.popOverlay()
+		a.updateMainPanel()
+		if reviewMode() {
+			a.statusBar.SetText(tr("quickadd.staged", tview.Escape(conn.Name)))
+		} else {
+			a.statusBar.SetText(tr("quickadd.added", tview.Escape(conn.Name)))
+		}
+		if slices.Contains(visible, passwordField) {
+			a.saveConnectionPassword(connTarget{Module: entry.Module, Project: entry.Project, Env: entry.Env, Conn: conn}, values[passwordField], nil)
+		}
+	}
+	form.AddButton(tr("common.add"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("quickadd.title", module)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, grid, submit)
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 传输配方：命名的 rsync/scp 传输任务
+type transferRecipe struct {
+	Name        string   `mapstructure:"name"`        // 配方名称
+	Tool        string   `mapstructure:"tool"`        // 传输工具：rsync（默认）或 scp
+	Source      string   `mapstructure:"source"`      // 源：本地路径或 "连接标识:远程路径"
+	Destination string   `mapstructure:"destination"` // 目标：本地路径或 "连接标识:远程路径"
+	Flags       []string `mapstructure:"flags"`       // 额外的命令行参数
+	Bandwidth   string   `mapstructure:"bandwidth"`   // 带宽上限（如 5MB/s、20Mbit），默认使用 transfer.bandwidth
+	Verify      *bool    `mapstructure:"verify"`      // 传输后比较 sha256，默认使用 transfer.verify
+}
+
+// 读取配置中的传输配方（配置项 transfer_recipes）
+func transferRecipes() []transferRecipe {
+	var recipes []transferRecipe
+	if err := unmarshalConfig("transfer_recipes", &recipes); err != nil {
+		return nil
+	}
+	return recipes
+}
+
+// 解析传输端点：形如 "模块/项目/环境/连接:路径" 的为远程端点，否则为本地路径
+func resolveEndpoint(endpoint string) (spec string, target *connTarget, err error) {
+	id, remotePath, found := strings.Cut(endpoint, ":")
+	if !found || strings.Count(id, "/") != 3 {
+		return endpoint, nil, nil
+	}
+	t, ok := findTarget(id)
+	if !ok {
+		return "", nil, errors.New(tr("common.connection_not_found", id))
+	}
+	host := t.Conn.Host
+	if transport := resolveTransport(t); transport.tunneled() {
+		host = transport.tunnelTarget(t.Conn)
+	}
+	if t.Conn.User != "" {
+		host = t.Conn.User + "@" + host
+	}
+	return host + ":" + remotePath, &t, nil
+}
+
+// 构建配方对应的传输命令，返回命令参数和涉及的远程目标
+func recipeCommand(recipe transferRecipe) ([]string, []connTarget, error) {
+	source, sourceTarget, err := resolveEndpoint(recipe.Source)
+	if err != nil {
+		return nil, nil, err
+	}
+	destination, destTarget, err := resolveEndpoint(recipe.Destination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var remotes []connTarget
+	var transport transportConfig
+	var proxyOptions []string
+	port := 22
+	for _, t := range []*connTarget{sourceTarget, destTarget} {
+		if t != nil {
+			if err := proxyCommandError(*t); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", t.ID(), err)
+			}
+			remotes = append(remotes, *t)
+			transport = resolveTransport(*t)
+			proxyOptions = proxyCommandOptions(*t)
+			if t.Conn.Port != 0 {
+				port = t.Conn.Port
+			}
+		}
+	}
+	if len(remotes) == 0 {
+		return nil, nil, errors.New(tr("recipe.no_remote", recipe.Name))
+	}
+	bandwidth, err := recipeBandwidth(recipe)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch recipe.Tool {
+	case "", "rsync":
+		shell := transport.rsyncShell(port)
+		if len(proxyOptions) > 0 {
+			shell += " -o " + shellQuote(proxyOptions[1])
+		}
+		// 保留部分传输的文件，中断后可以续传
+		args := []string{"rsync", "--stats", "--info=progress2", "--partial", "-e", shell}
+		args = append(args, recipe.Flags...)
+		return withBandwidthLimit(append(args, source, destination), bandwidth), remotes, nil
+	case "scp":
+		args := append([]string{"scp", "-P", strconv.Itoa(port)}, proxyOptions...)
+		switch transport.kind() {
+		case transportTeleport:
+			args = append([]string{"tsh", "scp"}, append(transport.client("")[2:], "-P", strconv.Itoa(port))...)
+		case transportSSM, transportCF, transportIAP:
+			args = append(args, "-o", "ProxyCommand="+transport.proxyCommand())
+		case transportReverse:
+			if jump := transport.reverseJump(); jump != "" {
+				args = append(args, "-J", jump)
+			}
+		}
+		args = append(args, recipe.Flags...)
+		return withBandwidthLimit(append(args, source, destination), bandwidth), remotes, nil
+	}
+	return nil, nil, errors.New(tr("recipe.unsupported_tool", recipe.Tool))
+}
+
+// 显示传输配方列表，回车执行选中的配方
+func (a *App) showTransferRecipes() {
+	recipes := transferRecipes()
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("recipe.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	rows := [][]string{{tr("common.col_name"), tr("recipe.col_tool"), tr("recipe.col_source"), tr("recipe.col_dest"), tr("recipe.col_args")}}
+	for _, recipe := range recipes {
+		tool := recipe.Tool
+		if tool == "" {
+			tool = "rsync"
+		}
+		rows = append(rows, []string{recipe.Name, tool, recipe.Source, recipe.Destination, strings.Join(recipe.Flags, " ")})
+	}
+	if len(recipes) == 0 {
+		rows = append(rows, []string{tr("recipe.empty"), "", "", "", ""})
+	}
+	fillTable(table, rows)
+	table.Select(1, 0)
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if row >= 1 && row <= len(recipes) {
+				a.confirmTransferRecipe(recipes[row-1])
+			}
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'j' || event.Rune() == 'J' {
+				a.showInterruptedTransfers()
+				return nil
+			}
+		}
+		return event
+	})
+}
+
+// 预览并执行传输配方，涉及受保护环境时应用前需输入确认短语
+func (a *App) confirmTransferRecipe(recipe transferRecipe) {
+	args, remotes, err := recipeCommand(recipe)
+	if err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+		return
+	}
+	preview := changePreview{
+		Title:    tr("recipe.preview_title", recipe.Name),
+		Header:   []string{tr("recipe.col_source"), tr("recipe.col_dest"), tr("recipe.col_connections"), tr("common.col_protected")},
+		Commands: []string{strings.Join(redactArgs(args), " ")},
+	}
+	var ids, protected []string
+	for _, remote := range remotes {
+		ids = append(ids, remote.ID())
+		if isProtectedEnv(remote.Env) {
+			protected = append(protected, remote.ID())
+		}
+		preview.Envs = append(preview.Envs, remote.Env)
+	}
+	preview.Rows = [][]string{{recipe.Source, recipe.Destination, strings.Join(ids, ", "), strings.Join(protected, ", ")}}
+	a.showPreview(preview, func() {
+		entry := journalEntry{
+			ID:      time.Now().Format("20060102-150405.000"),
+			Name:    recipe.Name,
+			Args:    args,
+			Source:  recipe.Source,
+			Dest:    recipe.Destination,
+			Verify:  recipe.verify(),
+			Started: time.Now(),
+		}
+		for _, remote := range remotes {
+			entry.Remotes = append(entry.Remotes, remote.ID())
+		}
+		a.runTransfer(entry, remotes)
+	})
+}
+
+// rsync --info=progress2 进度行中的完成百分比
+var rsyncPercentPattern = regexp.MustCompile(`(\d+)%`)
+
+// rsync --stats 输出中的收发字节数
+var rsyncStatsPattern = regexp.MustCompile(`sent ([\d,]+) bytes\s+received ([\d,]+) bytes`)
+
+// 按回车或换行切分输出，以便读取 rsync 的进度刷新
+func scanProgressLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// 执行传输命令，实时显示进度并写入传输日志，结束后记录传输统计到审计日志
+func (a *App) runTransfer(entry journalEntry, remotes []connTarget) {
+	name, args := entry.Name, entry.Args
+	// 多个传输同时进行时分摊全局带宽上限
+	args = applySharedBandwidth(args, beginTransfer())
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.SetBorder(true).
+		SetTitle(tr("recipe.transfer_title", name)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	search := newTextSearch(view)
+	ctx, cancel := context.WithCancel(context.Background())
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if search.handleKey(a, event) {
+			return nil
+		}
+		return event
+	})
+
+	header := fmt.Sprintf("[gray]%s[-]\n\n", tview.Escape(strings.Join(args, " ")))
+	view.SetText(header + tr("recipe.starting"))
+	// 进度弹窗显示 rsync 的总体进度，取消时终止传输命令和校验
+	bar := a.startProgress(tr("recipe.transfer_title", name), cancel)
+
+	go func() {
+		defer endTransfer()
+		defer cancel()
+		entry.PID, entry.Status, entry.Error = os.Getpid(), transferRunning, ""
+		saveJournalEntry(entry)
+		journaled := time.Now()
+		start := time.Now()
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		stdout, _ := cmd.StdoutPipe()
+		cmd.Stderr = cmd.Stdout
+		var log []string
+		var progress string
+		var runErr error
+		if err := cmd.Start(); err != nil {
+			runErr = err
+		} else {
+			scanner := bufio.NewScanner(stdout)
+			scanner.Split(scanProgressLines)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if line == "" {
+					continue
+				}
+				if strings.Contains(line, "%") && strings.Contains(line, "/s") {
+					progress = line // 进度行只保留最新一条
+					if match := rsyncPercentPattern.FindStringSubmatch(line); match != nil {
+						percent, _ := strconv.ParseInt(match[1], 10, 64)
+						bar.update(line, percent, 100)
+					}
+					if time.Since(journaled) >= journalInterval {
+						entry.Progress, journaled = progress, time.Now()
+						saveJournalEntry(entry)
+					}
+				} else {
+					log = append(log, line)
+				}
+				text := header + tview.Escape(strings.Join(log, "\n")) + "\n\n[green]" + tview.Escape(progress) + "[-]"
+				a.app.QueueUpdateDraw(func() {
+					view.SetText(text)
+					view.ScrollToEnd()
+				})
+			}
+			runErr = cmd.Wait()
+		}
+		if runErr != nil {
+			entry.Status, entry.Progress, entry.Error = transferInterrupted, progress, runErr.Error()
+			saveJournalEntry(entry)
+		} else {
+			removeJournalEntry(entry.ID)
+		}
+
+		stats := transferStats{Duration: time.Since(start)}
+		if match := rsyncStatsPattern.FindStringSubmatch(strings.Join(log, "\n")); match != nil {
+			stats.Sent, _ = strconv.ParseInt(strings.ReplaceAll(match[1], ",", ""), 10, 64)
+			stats.Received, _ = strconv.ParseInt(strings.ReplaceAll(match[2], ",", ""), 10, 64)
+		}
+		for _, remote := range remotes {
+			event := auditEvent{
+				Action:        "transfer",
+				Target:        remote.ID(),
+				Detail:        name,
+				Duration:      stats.Duration,
+				BytesSent:     stats.Sent,
+				BytesReceived: stats.Received,
+			}
+			if runErr != nil {
+				event.Detail += ": " + runErr.Error()
+			}
+			recordAudit(event)
+		}
+
+		result := tr("recipe.done", formatDuration(stats.Duration), stats)
+		if runErr != nil {
+			result = tr("recipe.failed", tview.Escape(runErr.Error()))
+		}
+		mismatched := false
+		if runErr == nil && entry.Verify {
+			a.app.QueueUpdateDraw(func() {
+				view.SetText(header + tview.Escape(strings.Join(log, "\n")) + result + tr("recipe.verifying"))
+				view.ScrollToEnd()
+			})
+			bar.update(tr("recipe.verify_progress"), 0, 0)
+			verified, err := verifyTransfer(ctx, entry.Source, entry.Dest)
+			detail := tr("recipe.verify_matched", name, verified.Matched)
+			switch {
+			case err != nil:
+				mismatched, detail = true, name+": "+err.Error()
+				result += tr("recipe.verify_failed", tview.Escape(err.Error()))
+			case len(verified.Mismatches) > 0:
+				mismatched, detail = true, tr("recipe.verify_mismatched", name, len(verified.Mismatches))
+				result += tr("recipe.checksum_mismatch", len(verified.Mismatches), tview.Escape(strings.Join(verified.Mismatches, "\n")))
+			default:
+				result += tr("recipe.verify_ok", verified.Matched)
+			}
+			for _, remote := range remotes {
+				recordAudit(auditEvent{Action: "transfer_verify", Target: remote.ID(), Detail: detail})
+			}
+		}
+
+		bar.finish(func() {
+			view.SetText(header + tview.Escape(strings.Join(log, "\n")) + result)
+			view.ScrollToEnd()
+			if mismatched {
+				a.statusBar.SetText(tr("recipe.verify_alert", tview.Escape(name)))
+			}
+		})
+	}()
+}
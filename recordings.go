@@ -0,0 +1,490 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 导出 HTML 回放时合并为一帧的输出间隔
+const recordingFrameGap = 50 * time.Millisecond
+
+// 连续输出时至少每隔这么久生成一帧
+const recordingFrameInterval = 500 * time.Millisecond
+
+// 数据目录中的一个会话录制
+type sessionRecording struct {
+	Path    string    // 录制文件
+	Target  string    // 连接标识（文件名中 / 和空格已替换为 _）
+	Started time.Time // 开始录制的时间
+	Size    int64     // 录制文件大小
+	Timed   bool      // 是否有计时文件（可按原始节奏回放）
+}
+
+// 录制中的一段输出
+type recordingEvent struct {
+	At   time.Duration // 距开始录制的时间
+	Data []byte        // 输出内容
+}
+
+// 可回放的录制：终端大小与按时间排列的输出
+type recordingReplay struct {
+	Cols, Rows int
+	Term       string
+	Started    time.Time
+	Events     []recordingEvent
+}
+
+// 列出数据目录下的全部会话录制，最近的在前
+func listRecordings() ([]sessionRecording, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "recordings", "*.log"))
+	if err != nil {
+		return nil, err
+	}
+	var recordings []sessionRecording
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		recording := sessionRecording{Path: path, Target: strings.TrimSuffix(filepath.Base(path), ".log"), Size: info.Size(), Started: info.ModTime()}
+		// 文件名为 <连接>-20060102-150405.log
+		if name := recording.Target; len(name) > 16 && name[len(name)-16] == '-' {
+			if started, err := time.ParseInLocation("20060102-150405", name[len(name)-15:], time.Local); err == nil {
+				recording.Target, recording.Started = name[:len(name)-16], started
+			}
+		}
+		if _, err := os.Stat(recordingTimingPath(path)); err == nil {
+			recording.Timed = true
+		}
+		recordings = append(recordings, recording)
+	}
+	sort.Slice(recordings, func(i, j int) bool { return recordings[i].Started.After(recordings[j].Started) })
+	return recordings, nil
+}
+
+// 读取录制文件和计时文件；没有计时文件时全部输出作为开头的一段
+func loadRecording(recording sessionRecording) (recordingReplay, error) {
+	data, err := os.ReadFile(recording.Path)
+	if err != nil {
+		return recordingReplay{}, err
+	}
+	// script 在录制文件开头写入一行说明，不计入输出
+	if bytes.HasPrefix(data, []byte("Script started on")) {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	replay := recordingReplay{Cols: 80, Rows: 24, Started: recording.Started}
+	timing, err := os.ReadFile(recordingTimingPath(recording.Path))
+	if err != nil {
+		if i := bytes.LastIndex(data, []byte("\nScript done on")); i >= 0 {
+			data = data[:i+1]
+		}
+		replay.Events = []recordingEvent{{Data: data}}
+		return replay, nil
+	}
+
+	// advanced 格式：H <间隔> <名称> <值> 为头信息，O <间隔> <字节数> 为一段输出；经典格式每行只有 <间隔> <字节数>
+	var at time.Duration
+	for _, line := range strings.Split(string(timing), "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 4)
+		if len(fields) == 2 {
+			fields = append([]string{"O"}, fields...)
+		}
+		switch {
+		case len(fields) == 4 && fields[0] == "H":
+			switch fields[2] {
+			case "COLUMNS":
+				replay.Cols, _ = strconv.Atoi(fields[3])
+			case "LINES":
+				replay.Rows, _ = strconv.Atoi(fields[3])
+			case "TERM":
+				replay.Term = fields[3]
+			}
+		case len(fields) == 3 && fields[0] == "O":
+			delay, err1 := strconv.ParseFloat(fields[1], 64)
+			n, err2 := strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			at += time.Duration(delay * float64(time.Second))
+			n = min(n, len(data))
+			replay.Events = append(replay.Events, recordingEvent{At: at, Data: data[:n]})
+			data = data[n:]
+		}
+	}
+	if replay.Cols <= 0 || replay.Rows <= 0 {
+		replay.Cols, replay.Rows = 80, 24
+	}
+	return replay, nil
+}
+
+// 去掉末尾不完整的 UTF-8 字符后的长度，被截断的字符留到下一段输出
+func completeUTF8(data []byte) int {
+	for i := len(data) - 1; i >= max(0, len(data)-utf8.UTFMax); i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(data)
+}
+
+// 按 asciinema v2 格式（.cast）写出录制：第一行为头信息，之后每行一段输出
+func writeCast(w io.Writer, replay recordingReplay, title string) error {
+	header := struct {
+		Version   int               `json:"version"`
+		Width     int               `json:"width"`
+		Height    int               `json:"height"`
+		Timestamp int64             `json:"timestamp,omitempty"`
+		Title     string            `json:"title,omitempty"`
+		Env       map[string]string `json:"env,omitempty"`
+	}{Version: 2, Width: replay.Cols, Height: replay.Rows, Title: title}
+	if !replay.Started.IsZero() {
+		header.Timestamp = replay.Started.Unix()
+	}
+	if replay.Term != "" {
+		header.Env = map[string]string{"TERM": replay.Term}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+	var pending []byte
+	for _, event := range replay.Events {
+		data := append(pending, event.Data...)
+		cut := completeUTF8(data)
+		pending = append([]byte(nil), data[cut:]...)
+		if cut == 0 {
+			continue
+		}
+		seconds := float64(event.At.Microseconds()) / 1e6
+		if err := encoder.Encode([]any{seconds, "o", string(data[:cut])}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CSS 颜色值，默认颜色返回空
+func cssColor(color tcell.Color) string {
+	if hex := color.Hex(); color != tcell.ColorDefault && hex >= 0 {
+		return fmt.Sprintf("#%06x", hex)
+	}
+	return ""
+}
+
+// 把屏幕各行渲染为带颜色的 HTML（用于 <pre>），相同样式的相邻字符合并为一个 span
+func (s *termScreen) htmlLines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := make([]string, len(s.grid()))
+	for r, line := range s.grid() {
+		var b strings.Builder
+		// 行尾默认样式的空白不输出
+		end := len(line)
+		for end > 0 && (line[end-1].Ch == ' ' || line[end-1].Ch == 0) && line[end-1].Style == tcell.StyleDefault {
+			end--
+		}
+		for c := 0; c < end; {
+			style := line[c].Style
+			var text strings.Builder
+			for ; c < end && line[c].Style == style; c++ {
+				if line[c].Ch != 0 {
+					text.WriteRune(line[c].Ch)
+				}
+			}
+			fg, bg, attrs := style.Decompose()
+			var css []string
+			fgColor, bgColor := cssColor(fg), cssColor(bg)
+			if attrs&tcell.AttrReverse != 0 {
+				fgColor, bgColor = cmp.Or(bgColor, "var(--bg)"), cmp.Or(fgColor, "var(--fg)")
+			}
+			if fgColor != "" {
+				css = append(css, "color:"+fgColor)
+			}
+			if bgColor != "" {
+				css = append(css, "background:"+bgColor)
+			}
+			if attrs&tcell.AttrBold != 0 {
+				css = append(css, "font-weight:bold")
+			}
+			if attrs&tcell.AttrUnderline != 0 {
+				css = append(css, "text-decoration:underline")
+			}
+			if len(css) == 0 {
+				b.WriteString(html.EscapeString(text.String()))
+				continue
+			}
+			fmt.Fprintf(&b, `<span style="%s">%s</span>`, strings.Join(css, ";"), html.EscapeString(text.String()))
+		}
+		lines[r] = b.String()
+	}
+	return lines
+}
+
+// 用内嵌终端的屏幕模型重放录制，生成 HTML 回放的各帧：[秒, {行号: 行 HTML}]。
+// 每帧只记录相对上一帧变化的行，长录制导出时不必为每帧保存整屏内容
+func recordingFrames(replay recordingReplay) [][2]any {
+	screen := newTermScreen(replay.Rows, replay.Cols)
+	var frames [][2]any
+	last := make([]string, replay.Rows)
+	lastAt := time.Duration(-1)
+	for i, event := range replay.Events {
+		screen.Write(event.Data)
+		final := i == len(replay.Events)-1
+		if !final && replay.Events[i+1].At-event.At < recordingFrameGap && event.At-lastAt < recordingFrameInterval {
+			continue
+		}
+		changed := make(map[int]string)
+		for r, line := range screen.htmlLines() {
+			if line != last[r] || len(frames) == 0 {
+				changed[r] = line
+				last[r] = line
+			}
+		}
+		if len(changed) > 0 || final {
+			frames = append(frames, [2]any{float64(event.At.Milliseconds()) / 1000, changed})
+		}
+		lastAt = event.At
+	}
+	return frames
+}
+
+// 独立的 HTML 回放页面：帧数据内嵌在页面中，不依赖外部脚本
+const recordingPlayerHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>__TITLE__</title>
+<style>
+:root { --fg: #d0d0d0; --bg: #1e1e1e; }
+body { background: #111; color: #ccc; font-family: sans-serif; margin: 20px; }
+h1 { font-size: 16px; font-weight: normal; }
+pre { background: var(--bg); color: var(--fg); font: 14px/1.2 monospace; padding: 8px; display: inline-block; margin: 0; white-space: pre; }
+.controls { margin: 8px 0; display: flex; gap: 8px; align-items: center; }
+.controls input[type=range] { flex: 1; max-width: 600px; }
+</style>
+</head>
+<body>
+<h1>__TITLE__</h1>
+<pre id="screen"></pre>
+<div class="controls">
+<button id="play">▶</button>
+<input id="seek" type="range" min="0" value="0">
+<span id="time"></span>
+<select id="speed"><option value="0.5">0.5x</option><option value="1" selected>1x</option><option value="2">2x</option><option value="4">4x</option></select>
+</div>
+<script>
+const frames = __FRAMES__;
+const maxIdle = 2000; // 超过 2 秒的停顿缩短为 2 秒
+const screen = document.getElementById("screen"), seek = document.getElementById("seek");
+const time = document.getElementById("time"), play = document.getElementById("play"), speed = document.getElementById("speed");
+const duration = frames.length ? frames[frames.length - 1][0] : 0;
+let index = -1, lines = [], playing = false, timer = null;
+seek.max = Math.max(frames.length - 1, 0);
+function clock(s) { return Math.floor(s / 60) + ":" + String(Math.floor(s % 60)).padStart(2, "0"); }
+// 每帧只含变化的行：向后跳转时从第一帧重新应用
+function show(i) {
+  if (i < index) { index = -1; lines = []; }
+  for (let j = index + 1; j <= i && j < frames.length; j++) {
+    for (const [row, line] of Object.entries(frames[j][1])) lines[row] = line;
+  }
+  index = i;
+  screen.innerHTML = lines.join("\n");
+  seek.value = i;
+  time.textContent = clock(frames.length ? frames[i][0] : 0) + " / " + clock(duration);
+}
+function schedule() {
+  clearTimeout(timer);
+  if (!playing) return;
+  if (index >= frames.length - 1) { playing = false; play.textContent = "▶"; return; }
+  const wait = Math.min((frames[index + 1][0] - frames[index][0]) * 1000, maxIdle) / Number(speed.value);
+  timer = setTimeout(() => { show(index + 1); schedule(); }, wait);
+}
+function toggle() {
+  playing = !playing;
+  if (playing && index >= frames.length - 1) show(0);
+  play.textContent = playing ? "❚❚" : "▶";
+  schedule();
+}
+play.onclick = toggle;
+seek.oninput = () => { show(Number(seek.value)); schedule(); };
+speed.onchange = schedule;
+document.addEventListener("keydown", e => { if (e.key === " ") { e.preventDefault(); toggle(); } });
+show(0);
+</script>
+</body>
+</html>
+`
+
+// 写出独立的 HTML 回放页面
+func writeHTMLPlayer(w io.Writer, replay recordingReplay, title string) error {
+	frames, err := json.Marshal(recordingFrames(replay))
+	if err != nil {
+		return err
+	}
+	page := strings.NewReplacer("__TITLE__", html.EscapeString(title), "__FRAMES__", string(frames)).Replace(recordingPlayerHTML)
+	_, err = io.WriteString(w, page)
+	return err
+}
+
+// 把录制导出到文件，export 为 writeCast 或 writeHTMLPlayer
+func exportRecording(recording sessionRecording, path string, export func(io.Writer, recordingReplay, string) error) error {
+	replay, err := loadRecording(recording)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	title := fmt.Sprintf("%s %s", recording.Target, formatTime(recording.Started))
+	if err := export(file, replay, title); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// 会话录制列表：Enter 查看文本内容，A 导出为 asciinema（.cast），H 导出为独立的 HTML 回放页面
+func (a *App) showRecordings() {
+	recordings, err := listRecordings()
+	if err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("recordings.load_failed", err))))
+		return
+	}
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("recordings.title", len(recordings))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	rows := [][]string{{tr("recordings.col_time"), tr("recordings.col_target"), tr("recordings.col_size"), tr("recordings.col_timing")}}
+	for _, recording := range recordings {
+		timed := "-"
+		if recording.Timed {
+			timed = tr("recordings.timed")
+		}
+		rows = append(rows, []string{formatTime(recording.Started), recording.Target, formatBytes(recording.Size), timed})
+	}
+	fillTable(table, rows)
+	if len(recordings) == 0 {
+		table.SetCell(1, 0, tview.NewTableCell(tr("recordings.empty")).SetSelectable(false))
+	}
+	table.Select(1, 0)
+
+	selected := func() (sessionRecording, bool) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(recordings) {
+			return sessionRecording{}, false
+		}
+		return recordings[row-1], true
+	}
+	export := func(recording sessionRecording, extension string, write func(io.Writer, recordingReplay, string) error) {
+		name := strings.TrimSuffix(filepath.Base(recording.Path), ".log") + extension
+		a.prompt(tr("recordings.export_title"), tr("recordings.export_label"), name, func(path string) {
+			save := func() {
+				if err := exportRecording(recording, path, write); err != nil {
+					a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("recordings.export_failed", err))))
+					return
+				}
+				recordAudit(auditEvent{Action: "recording_export", Target: recording.Target, Detail: path})
+				message := tr("recordings.exported", path)
+				if !recording.Timed {
+					message += tr("recordings.untimed")
+				}
+				a.statusBar.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(message)))
+			}
+			// 目标文件已存在时确认后再覆盖
+			if _, err := os.Stat(path); err == nil {
+				a.confirm(tr("recordings.overwrite_title"), tr("recordings.overwrite", tview.Escape(path)), save)
+				return
+			}
+			save()
+		})
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		recording, ok := selected()
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if ok {
+				a.showRecordingText(recording)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'q', 'Q':
+				a.popOverlay()
+			case 'a', 'A':
+				if ok {
+					export(recording, ".cast", writeCast)
+				}
+			case 'h', 'H':
+				if ok {
+					export(recording, ".html", writeHTMLPlayer)
+				}
+			default:
+				return event
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+// 以纯文本查看录制内容（去掉控制序列），/ 搜索
+func (a *App) showRecordingText(recording sessionRecording) {
+	data, err := os.ReadFile(recording.Path)
+	if err != nil {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("recordings.load_failed", err))))
+		return
+	}
+	lines := scrollbackText(string(data))
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(tview.Escape(strings.Join(lines, "\n") + "\n"))
+	view.SetBorder(true).
+		SetTitle(tr("recordings.view_title", recording.Target, formatTime(recording.Started), len(lines))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	search := newTextSearch(view)
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if search.handleKey(a, event) {
+			return nil
+		}
+		return event
+	})
+}
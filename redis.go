@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Redis 键浏览器中单条命令的超时时间
+const redisTimeout = 10 * time.Second
+
+// SCAN 最多列出的键数
+const maxRedisKeys = 1000
+
+// 查看集合类型的值时最多显示的元素数
+const maxRedisItems = 100
+
+// 受保护环境中需要确认才能在命令行执行的写入或破坏性 Redis 命令
+var redisDangerousCommands = []string{"DEL", "UNLINK", "FLUSHDB", "FLUSHALL", "RENAME", "RENAMENX", "MOVE", "SWAPDB", "CONFIG", "SHUTDOWN", "DEBUG", "SCRIPT", "EXPIRE", "PERSIST", "SET", "HDEL", "LTRIM", "SREM", "ZREM", "XDEL"}
+
+// 构建对 Redis 目标执行的 redis-cli 命令；需要隧道时先在后台建立
+func redisCommand(ctx context.Context, target connTarget, args ...string) (*exec.Cmd, error) {
+	conn, err := clientEndpoint(target)
+	if err != nil {
+		return nil, err
+	}
+	base := batchClientCommand(target.Module, conn)
+	cmd := exec.CommandContext(ctx, base[0], append(base[1:], args...)...)
+	cmd.Env = clientEnv(target)
+	return cmd, nil
+}
+
+// 执行一条 Redis 命令，返回原始输出（标准输出不是终端时 redis-cli 输出不带类型标注的原始值）
+func runRedis(target connTarget, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	cmd, err := redisCommand(ctx, target, args...)
+	if err != nil {
+		return "", err
+	}
+	output, err := cmd.CombinedOutput()
+	return strings.TrimRight(string(output), "\n"), err
+}
+
+// 执行命令行中输入的一行命令：由 redis-cli 从标准输入读取，按其规则处理引号
+func runRedisLine(target connTarget, line string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	cmd, err := redisCommand(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	cmd.Stdin = strings.NewReader(line + "\n")
+	output, err := cmd.CombinedOutput()
+	return strings.TrimRight(string(output), "\n"), err
+}
+
+// 用 redis-cli --scan 按模式列出键，最多 maxRedisKeys 个；truncated 表示还有更多匹配的键
+func scanRedisKeys(target connTarget, pattern string) (keys []string, truncated bool, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	cmd, err := redisCommand(ctx, target, "--scan", "--pattern", pattern, "--count", "1000")
+	if err != nil {
+		return nil, false, err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, false, err
+	}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if len(keys) == maxRedisKeys {
+			truncated = true
+			cancel()
+			break
+		}
+		keys = append(keys, scanner.Text())
+	}
+	if err := cmd.Wait(); err != nil && !truncated {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return keys, false, fmt.Errorf("%w: %s", err, message)
+		}
+		return keys, false, err
+	}
+	return keys, truncated, nil
+}
+
+// 读取键的类型、TTL、大小和值（集合类型最多 maxRedisItems 个元素），渲染为详情文本
+func describeRedisKey(target connTarget, key string) string {
+	kind, err := runRedis(target, "TYPE", key)
+	if err != nil {
+		return fmt.Sprintf("[red]%s[-]", tview.Escape(firstLine(kind, err)))
+	}
+	ttl, _ := runRedis(target, "TTL", key)
+	switch ttl {
+	case "-1":
+		ttl = tr("redis.ttl_none")
+	case "-2":
+		return fmt.Sprintf("[yellow]%s[-]", tr("redis.key_gone"))
+	default:
+		if seconds, err := time.ParseDuration(ttl + "s"); err == nil {
+			ttl = formatDuration(seconds)
+		}
+	}
+
+	var sizeCmd, valueArgs []string
+	count := fmt.Sprint(maxRedisItems)
+	pairs := false // 值按两行一组显示（字段与值、成员与分数）
+	skip := 0      // 跳过的前导行（SCAN 类命令的游标）
+	switch kind {
+	case "string":
+		sizeCmd, valueArgs = []string{"STRLEN", key}, []string{"GET", key}
+	case "list":
+		sizeCmd, valueArgs = []string{"LLEN", key}, []string{"LRANGE", key, "0", fmt.Sprint(maxRedisItems - 1)}
+	case "set":
+		sizeCmd, valueArgs, skip = []string{"SCARD", key}, []string{"SSCAN", key, "0", "COUNT", count}, 1
+	case "zset":
+		sizeCmd, valueArgs, pairs = []string{"ZCARD", key}, []string{"ZRANGE", key, "0", fmt.Sprint(maxRedisItems - 1), "WITHSCORES"}, true
+	case "hash":
+		sizeCmd, valueArgs, pairs, skip = []string{"HLEN", key}, []string{"HSCAN", key, "0", "COUNT", count}, true, 1
+	case "stream":
+		sizeCmd, valueArgs = []string{"XLEN", key}, []string{"XRANGE", key, "-", "+", "COUNT", count}
+	}
+
+	content := fmt.Sprintf("[yellow]%s[-] %s\n", tr("redis.key"), tview.Escape(key))
+	content += fmt.Sprintf("[yellow]%s[-] %s    [yellow]TTL[-] %s", tr("redis.type"), kind, ttl)
+	if sizeCmd != nil {
+		size, _ := runRedis(target, sizeCmd...)
+		content += fmt.Sprintf("    [yellow]%s[-] %s", tr("redis.size"), size)
+	}
+	content += "\n\n"
+	if valueArgs == nil {
+		return content
+	}
+	value, err := runRedis(target, valueArgs...)
+	if err != nil {
+		return content + fmt.Sprintf("[red]%s[-]", tview.Escape(firstLine(value, err)))
+	}
+	if kind == "string" {
+		return content + tview.Escape(value)
+	}
+	lines := strings.Split(value, "\n")
+	lines = lines[min(skip, len(lines)):]
+	for i := 0; i < len(lines); i++ {
+		if pairs && i+1 < len(lines) {
+			content += fmt.Sprintf("%s  [gray]%s[-]\n", tview.Escape(lines[i]), tview.Escape(lines[i+1]))
+			i++
+			continue
+		}
+		content += tview.Escape(lines[i]) + "\n"
+	}
+	return content
+}
+
+// 打开 Redis 连接的键浏览器：按模式 SCAN 键，查看选中键的类型、TTL 和值，并可在命令行执行任意命令
+func (a *App) showRedisBrowser() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	if moduleType(target.Module) != "Redis" {
+		a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("redis.unsupported", target.Module))))
+		return
+	}
+
+	pattern := tview.NewInputField().
+		SetLabel(tr("redis.pattern_label")).
+		SetText("*")
+	keys := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	keys.SetBorder(true).SetTitle(tr("redis.keys_title", 0)).SetTitleAlign(tview.AlignLeft)
+	detail := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetScrollable(true)
+	detail.SetBorder(true).SetTitle(tr("redis.detail_title")).SetTitleAlign(tview.AlignLeft)
+	command := tview.NewInputField().
+		SetLabel("> ").
+		SetPlaceholder(tr("redis.command_placeholder"))
+	output := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true).
+		SetScrollable(true)
+	output.SetBorder(true).SetTitle(tr("redis.output_title")).SetTitleAlign(tview.AlignLeft)
+	status := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[gray]%s[-]", tr("redis.hint")))
+
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(pattern, 1, 0, true).
+		AddItem(tview.NewFlex().
+			AddItem(keys, 0, 1, false).
+			AddItem(detail, 0, 2, false), 0, 3, false).
+		AddItem(command, 1, 0, false).
+		AddItem(output, 0, 1, false).
+		AddItem(status, 1, 0, false)
+	layout.SetBorder(true).
+		SetTitle(tr("redis.title", target.Conn.Name, target.Conn.Host, target.Conn.Port)).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	// 选中键变化时在后台读取详情，只显示最后一次请求的结果
+	var keyList []string
+	var detailSeq int
+	showDetail := func(row int) {
+		if row < 0 || row >= len(keyList) {
+			detail.SetText("")
+			return
+		}
+		detailSeq++
+		seq, key := detailSeq, keyList[row]
+		detail.SetText(fmt.Sprintf("[gray]%s[-]", tr("redis.loading")))
+		go func() {
+			content := describeRedisKey(target, key)
+			a.app.QueueUpdateDraw(func() {
+				if seq == detailSeq {
+					detail.SetText(content).ScrollToBeginning()
+				}
+			})
+		}()
+	}
+	keys.SetSelectionChangedFunc(func(row, _ int) { showDetail(row) })
+
+	scan := func() {
+		match := strings.TrimSpace(pattern.GetText())
+		if match == "" {
+			match = "*"
+		}
+		status.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("redis.scanning", match))))
+		go func() {
+			start := time.Now()
+			found, truncated, err := scanRedisKeys(target, match)
+			elapsed := time.Since(start)
+			a.app.QueueUpdateDraw(func() {
+				keyList = found
+				keys.Clear()
+				for r, key := range found {
+					keys.SetCell(r, 0, tview.NewTableCell(tview.Escape(key)).SetExpansion(1))
+				}
+				keys.SetTitle(tr("redis.keys_title", len(found)))
+				switch {
+				case err != nil:
+					status.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("redis.scan_failed", err))))
+				case truncated:
+					status.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("redis.scan_truncated", maxRedisKeys, formatDuration(elapsed)))))
+				default:
+					status.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(tr("redis.scan_done", len(found), formatDuration(elapsed)))))
+				}
+				keys.Select(0, 0)
+				keys.ScrollToBeginning()
+				showDetail(0)
+			})
+		}()
+	}
+
+	execute := func(line string) {
+		status.SetText(fmt.Sprintf("[yellow]%s[-]", tr("redis.running")))
+		go func() {
+			start := time.Now()
+			result, err := runRedisLine(target, line)
+			elapsed := time.Since(start)
+			recordQueryHistory(target, line, elapsed, err)
+			a.app.QueueUpdateDraw(func() {
+				entry := fmt.Sprintf("[blue]> %s[-]\n%s\n", tview.Escape(line), tview.Escape(result))
+				output.SetText(output.GetText(false) + entry).ScrollToEnd()
+				if err != nil {
+					status.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("redis.command_failed", firstLine(result, err)))))
+					return
+				}
+				status.SetText(fmt.Sprintf("[green]%s[-]", tr("redis.command_done", formatDuration(elapsed))))
+				// 命令可能修改了选中的键
+				row, _ := keys.GetSelection()
+				showDetail(row)
+			})
+		}()
+	}
+
+	// 命令行历史：该连接执行过的命令，↑/↓ 调出
+	history := recentQueries(target)
+	historyPos := -1
+	pattern.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			scan()
+		}
+	})
+	command.SetDoneFunc(func(key tcell.Key) {
+		line := strings.TrimSpace(command.GetText())
+		if key != tcell.KeyEnter || line == "" {
+			return
+		}
+		if len(history) == 0 || history[0] != line {
+			history = append([]string{line}, history...)
+		}
+		historyPos = -1
+		command.SetText("")
+		if isProtectedEnv(target.Env) && hasLeadingKeyword(line, redisDangerousCommands) {
+			a.confirmEnvs(tr("redis.confirm_title"), tr("redis.confirm_message", tview.Escape(line), tview.Escape(target.ID())), []string{target.Env}, func() {
+				execute(line)
+				a.app.SetFocus(command)
+			})
+			return
+		}
+		execute(line)
+	})
+
+	focusOrder := []tview.Primitive{pattern, keys, detail, command, output}
+	a.pushOverlay(layout, pattern, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			step := 1
+			if event.Key() == tcell.KeyBacktab {
+				step = -1
+			}
+			for i, p := range focusOrder {
+				if p.HasFocus() {
+					a.app.SetFocus(focusOrder[(i+step+len(focusOrder))%len(focusOrder)])
+					break
+				}
+			}
+			return nil
+		case tcell.KeyUp, tcell.KeyDown:
+			if !command.HasFocus() {
+				break
+			}
+			pos := historyPos + 1
+			if event.Key() == tcell.KeyDown {
+				pos = historyPos - 1
+			}
+			if pos >= -1 && pos < len(history) {
+				historyPos = pos
+				if pos == -1 {
+					command.SetText("")
+				} else {
+					command.SetText(history[pos])
+				}
+			}
+			return nil
+		case tcell.KeyRune:
+			if keys.HasFocus() {
+				switch event.Rune() {
+				case 'r', 'R':
+					scan()
+					return nil
+				case '/':
+					a.app.SetFocus(pattern)
+					return nil
+				case ':':
+					a.app.SetFocus(command)
+					return nil
+				}
+			}
+		}
+		return event
+	})
+	scan()
+}
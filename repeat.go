@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+// 可用 . 在当前选中节点上重复的操作
+type repeatAction struct {
+	Label string       // 状态栏与操作菜单中显示的名称
+	Run   func(a *App) // 作用于当前选中的节点
+}
+
+// 记录最近一次可重复的操作
+func (a *App) rememberAction(label string, run func(a *App)) {
+	a.lastAction = &repeatAction{Label: label, Run: run}
+}
+
+// 在当前选中的连接上重复最近一次操作（测试、连接、执行命令）
+func (a *App) repeatLastAction() {
+	if a.lastAction == nil {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tr("repeat.none")))
+		return
+	}
+	if _, ok := a.currentTarget(); !ok {
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("repeat.select_connection", a.lastAction.Label))))
+		return
+	}
+	a.lastAction.Run(a)
+}
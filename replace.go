@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 计算正则替换对清单产生的修改，fields 为参与替换的字段
+func planRegexReplace(targets []connTarget, fields []string, pattern *regexp.Regexp, replacement string) ([]fieldChange, error) {
+	var changes []fieldChange
+	for _, target := range targets {
+		for _, field := range fields {
+			old := connectionField(target.Conn, field)
+			updated := pattern.ReplaceAllString(old, replacement)
+			if updated == old {
+				continue
+			}
+			conn := target.Conn
+			if err := setConnectionField(&conn, field, updated); err != nil {
+				return nil, fmt.Errorf("%s: %w", target.ID(), err)
+			}
+			changes = append(changes, fieldChange{Target: target, Field: field, Old: old, New: connectionField(conn, field)})
+		}
+	}
+	return changes, nil
+}
+
+// 打开正则查找替换表单，预览（试运行）后再应用
+func (a *App) showRegexReplaceForm() {
+	fieldOptions := append([]string{tr("replace.all_fields")}, fieldLabels(connectionFields)...)
+	scopes := []string{tr("replace.current_module"), tr("replace.all_modules")}
+	field, scope := 1, 0
+	pattern, replacement := "", ""
+
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	form.AddDropDown(tr("common.field"), fieldOptions, field, func(option string, index int) {
+		field = index
+	}).
+		AddDropDown(tr("replace.scope"), scopes, scope, func(option string, index int) {
+			scope = index
+		})
+	validator.addInputField(tr("replace.pattern"), "", 40, func(text string) {
+		pattern = text
+	}, fixedRules(ruleRequired, ruleRegexp))
+	form.AddInputField(tr("replace.replacement"), "", 40, nil, func(text string) {
+		replacement = text
+	})
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		re := regexp.MustCompile(pattern)
+		fields := connectionFields
+		if field > 0 {
+			fields = []string{connectionFields[field-1]}
+		}
+		modules := []string{a.modules[a.currentModule]}
+		if scope == 1 {
+			modules = a.modules
+		}
+		changes, err := planRegexReplace(inventoryTargets(modules), fields, re, replacement)
+		if err != nil {
+			a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+			return
+		}
+		a.popOverlay()
+		a.showFieldChangePreview(tr("replace.action"), changes, "regex_replace")
+	}
+	form.AddButton(tr("replace.dry_run"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("replace.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, centered(validator.root(), 64, 14), submit)
+}
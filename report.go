@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 视为长期未使用的天数
+const unusedDays = 90
+
+// 清单统计报告
+type inventoryReport struct {
+	Generated time.Time  // 生成时间
+	Total     int        // 连接总数
+	Counts    [][]string // 各模块/环境的连接数（模块, 环境, 数量）
+	Unused    [][]string // 长期未使用的连接（连接, 最近使用）
+	Failing   [][]string // 最近一次检查失败的连接（连接, 检查时间）
+}
+
+// 从清单、审计日志、查询历史和健康检查历史生成统计报告
+func buildInventoryReport(targets []connTarget, now time.Time) inventoryReport {
+	report := inventoryReport{Generated: now, Total: len(targets)}
+
+	// 最近使用时间：会话、命令、传输等审计事件以及查询历史
+	lastUsed := make(map[string]time.Time)
+	touch := func(id string, at time.Time) {
+		if at.After(lastUsed[id]) {
+			lastUsed[id] = at
+		}
+	}
+	for _, event := range loadAuditEvents() {
+		touch(event.Target, event.Time)
+	}
+	entries, _ := readJSONLines[queryHistoryEntry](historyFile)
+	for _, entry := range entries {
+		touch(entry.Target, entry.Time)
+	}
+
+	// 每个连接最近一次健康检查结果
+	latest := make(map[string]healthSample)
+	samples, _ := readJSONLines[healthSample](healthFile)
+	for _, sample := range samples {
+		if sample.Time.After(latest[sample.Target].Time) {
+			latest[sample.Target] = sample
+		}
+	}
+
+	counts := make(map[[2]string]int)
+	cutoff := now.AddDate(0, 0, -unusedDays)
+	for _, target := range targets {
+		id := target.ID()
+		counts[[2]string{target.Module, target.Env}]++
+		if used, ok := lastUsed[id]; !ok {
+			report.Unused = append(report.Unused, []string{id, tr("report.never_used")})
+		} else if used.Before(cutoff) {
+			report.Unused = append(report.Unused, []string{id, used.Format("2006-01-02")})
+		}
+		if sample, ok := latest[id]; ok && !sample.OK {
+			report.Failing = append(report.Failing, []string{id, sample.Time.Format("2006-01-02 15:04")})
+		}
+	}
+	for key, count := range counts {
+		report.Counts = append(report.Counts, []string{key[0], key[1], fmt.Sprint(count)})
+	}
+	sort.Slice(report.Counts, func(i, j int) bool {
+		if report.Counts[i][0] != report.Counts[j][0] {
+			return report.Counts[i][0] < report.Counts[j][0]
+		}
+		return report.Counts[i][1] < report.Counts[j][1]
+	})
+	return report
+}
+
+// 报告的各个表格段落
+func (r inventoryReport) sections() []struct {
+	title  string
+	header []string
+	rows   [][]string
+} {
+	return []struct {
+		title  string
+		header []string
+		rows   [][]string
+	}{
+		{tr("report.counts"), []string{tr("common.col_module"), tr("common.col_env"), tr("report.col_count")}, r.Counts},
+		{tr("report.unused", unusedDays), []string{tr("common.col_connection"), tr("report.col_last_used")}, r.Unused},
+		{tr("report.failing"), []string{tr("common.col_connection"), tr("report.col_checked")}, r.Failing},
+	}
+}
+
+// 渲染为 Markdown
+func (r inventoryReport) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, tr("report.markdown_header"), r.Generated.Format("2006-01-02 15:04"), r.Total)
+	escape := strings.NewReplacer("|", `\|`).Replace
+	for _, section := range r.sections() {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		if len(section.rows) == 0 {
+			b.WriteString(tr("report.none_markdown"))
+			continue
+		}
+		fmt.Fprintf(&b, "| %s |\n|%s\n", strings.Join(section.header, " | "), strings.Repeat(" --- |", len(section.header)))
+		for _, row := range section.rows {
+			cells := make([]string, len(row))
+			for i, cell := range row {
+				cells[i] = escape(cell)
+			}
+			fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+		}
+	}
+	return b.String()
+}
+
+// 渲染为 HTML 片段
+func (r inventoryReport) html() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, tr("report.html_header"), r.Generated.Format("2006-01-02 15:04"), r.Total)
+	for _, section := range r.sections() {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(section.title))
+		if len(section.rows) == 0 {
+			b.WriteString(tr("report.none_html"))
+			continue
+		}
+		b.WriteString("<table>\n<tr>")
+		for _, cell := range section.header {
+			fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(cell))
+		}
+		b.WriteString("</tr>\n")
+		for _, row := range section.rows {
+			b.WriteString("<tr>")
+			for _, cell := range row {
+				fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(cell))
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</table>\n")
+	}
+	return b.String()
+}
+
+// 显示清单报告，可复制 Markdown 或 HTML 到剪贴板
+func (a *App) showInventoryReport() {
+	report := buildInventoryReport(inventoryTargets(a.modules), time.Now())
+	markdown := report.markdown()
+	view := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetText(markdown)
+	view.SetBorder(true).
+		SetTitle(tr("report.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+		var text, format string
+		switch event.Rune() {
+		case 'm', 'M':
+			text, format = markdown, "Markdown"
+		case 'h', 'H':
+			text, format = report.html(), "HTML"
+		default:
+			return event
+		}
+		if err := copyToClipboard(text); err != nil {
+			a.statusBar.SetText(tr("common.copy_failed", tview.Escape(err.Error())))
+		} else {
+			a.statusBar.SetText(tr("report.copied", format))
+		}
+		return nil
+	})
+}
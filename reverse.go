@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 反向隧道登记文件名（位于数据目录中）
+const reverseTunnelsFile = "reverse_tunnels.json"
+
+// 反向隧道默认起始端口（配置项 reverse.base_port 可覆盖）
+const defaultReverseBasePort = 20022
+
+// 远程设备主动拨入本机的反向隧道登记
+type reverseTunnel struct {
+	Name string `json:"name"` // 设备名称
+	Port int    `json:"port"` // 本机上为该设备分配的端口
+	User string `json:"user"` // 登录设备使用的用户
+	Note string `json:"note"` // 备注
+}
+
+// 读取反向隧道登记
+func loadReverseTunnels() []reverseTunnel {
+	var tunnels []reverseTunnel
+	_ = readJSONFile(reverseTunnelsFile, &tunnels)
+	return tunnels
+}
+
+// 按名称查找反向隧道
+func findReverseTunnel(name string) (reverseTunnel, bool) {
+	for _, t := range loadReverseTunnels() {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return reverseTunnel{}, false
+}
+
+// 分配下一个未被登记和占用的端口
+func nextReversePort(tunnels []reverseTunnel) int {
+	port := configInt("reverse.base_port")
+	if port <= 0 {
+		port = defaultReverseBasePort
+	}
+	used := make(map[int]bool)
+	for _, t := range tunnels {
+		used[t.Port] = true
+	}
+	for used[port] || reverseDialedIn(port) {
+		port++
+	}
+	return port
+}
+
+// 远程设备是否已拨入（本机端口有 sshd 转发的监听）
+func reverseDialedIn(port int) bool {
+	c, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), 300*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	c.Close()
+	return true
+}
+
+// ProxyJump 跳板地址
+func (t reverseTunnel) jump() string {
+	return fmt.Sprintf("%s@127.0.0.1:%d", t.User, t.Port)
+}
+
+// 通过反向隧道连接设备本身的目标
+func (t reverseTunnel) target() connTarget {
+	module := "SSH"
+	for _, m := range configuredModules() {
+		if moduleType(m) == "SSH" {
+			module = m
+			break
+		}
+	}
+	return connTarget{Module: module, Project: "反向隧道", Env: "本机", Conn: Connection{
+		Name: t.Name, Status: "connected", Host: "127.0.0.1", Port: t.Port, User: t.User, Tags: []string{"reverse"},
+	}}
+}
+
+// 在远程设备上执行的拨入命令（本机地址可由配置项 reverse.workstation 指定）
+func (t reverseTunnel) setupCommand() string {
+	workstation := configString("reverse.workstation")
+	if workstation == "" {
+		host, _ := os.Hostname()
+		workstation = host
+		if current, err := user.Current(); err == nil {
+			workstation = current.Username + "@" + host
+		}
+	}
+	return fmt.Sprintf("autossh -M 0 -f -N -o ServerAliveInterval=30 -o ExitOnForwardFailure=yes -R %d:localhost:22 %s", t.Port, workstation)
+}
+
+// 显示反向隧道登记：Enter 连接已拨入的设备，N 登记，D 删除，C 复制设备端拨入命令，R 刷新状态
+func (a *App) showReverseTunnels() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("reverse.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	var tunnels []reverseTunnel
+	render := func() {
+		tunnels = loadReverseTunnels()
+		table.Clear()
+		for c, title := range []string{tr("common.col_name"), tr("common.col_port"), tr("common.col_user"), tr("common.col_status"), tr("reverse.col_note")} {
+			table.SetCell(0, c, tview.NewTableCell(title).SetTextColor(tcell.ColorYellow).SetSelectable(false).SetExpansion(1))
+		}
+		for r, t := range tunnels {
+			status := tr("reverse.offline")
+			if reverseDialedIn(t.Port) {
+				status = tr("reverse.online")
+			}
+			table.SetCell(r+1, 0, tview.NewTableCell(tview.Escape(t.Name)).SetExpansion(1))
+			table.SetCell(r+1, 1, tview.NewTableCell(strconv.Itoa(t.Port)).SetExpansion(1))
+			table.SetCell(r+1, 2, tview.NewTableCell(tview.Escape(t.User)).SetExpansion(1))
+			table.SetCell(r+1, 3, tview.NewTableCell(status).SetExpansion(1))
+			table.SetCell(r+1, 4, tview.NewTableCell(tview.Escape(t.Note)).SetExpansion(1))
+		}
+		if len(tunnels) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell(tr("reverse.empty")).SetSelectable(false))
+		}
+		table.Select(1, 0)
+	}
+	render()
+
+	selected := func() (reverseTunnel, bool) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(tunnels) {
+			return reverseTunnel{}, false
+		}
+		return tunnels[row-1], true
+	}
+	save := func(updated []reverseTunnel) {
+		if err := writeJSONFile(reverseTunnelsFile, updated); err != nil {
+			a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+		}
+		render()
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if t, ok := selected(); ok {
+				if !reverseDialedIn(t.Port) {
+					a.statusBar.SetText(tr("reverse.not_dialed", tview.Escape(t.Name)))
+					return nil
+				}
+				a.openSSHSession(t.target())
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'n', 'N':
+				a.prompt(tr("reverse.register"), tr("common.name_label"), "", func(name string) {
+					if name == "" {
+						return
+					}
+					if _, exists := findReverseTunnel(name); exists {
+						a.statusBar.SetText(tr("reverse.exists", tview.Escape(name)))
+						return
+					}
+					a.prompt(tr("reverse.register"), tr("reverse.login_user"), "root", func(loginUser string) {
+						all := loadReverseTunnels()
+						t := reverseTunnel{Name: name, Port: nextReversePort(all), User: loginUser}
+						save(append(all, t))
+						recordAudit(auditEvent{Action: "reverse_add", Target: t.Name, Detail: tr("reverse.port_detail", t.Port)})
+						a.statusBar.SetText(tr("reverse.registered", tview.Escape(t.Name), t.Port))
+					})
+				})
+				return nil
+			case 'd', 'D':
+				if t, ok := selected(); ok {
+					a.confirm(tr("reverse.delete_title"), tr("reverse.delete_confirm", tview.Escape(t.Name)), func() {
+						var kept []reverseTunnel
+						for _, other := range loadReverseTunnels() {
+							if other.Name != t.Name {
+								kept = append(kept, other)
+							}
+						}
+						save(kept)
+						recordAudit(auditEvent{Action: "reverse_remove", Target: t.Name})
+					})
+				}
+				return nil
+			case 'c', 'C':
+				if t, ok := selected(); ok {
+					if err := copyToClipboard(t.setupCommand()); err != nil {
+						a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+						return nil
+					}
+					a.statusBar.SetText(tr("reverse.copied", tview.Escape(t.Name)))
+				}
+				return nil
+			case 'r', 'R':
+				render()
+				return nil
+			}
+		}
+		return event
+	})
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 暂存修改文件名（位于数据目录中）
+const stagedFile = "staged.json"
+
+// 暂存的字段修改
+type stagedField struct {
+	Target string `json:"target"` // 目标连接标识
+	Field  string `json:"field"`  // 字段
+	Old    string `json:"old"`    // 原值
+	New    string `json:"new"`    // 新值
+	Action string `json:"action"` // 产生修改的操作
+}
+
+// 待审阅的暂存修改
+type stagedChanges struct {
+	Fields []stagedField    `json:"fields"` // 字段修改
+	Added  []inventoryEntry `json:"added"`  // 新增连接
+}
+
+// 是否启用审阅模式（配置项 shared.review）：修改先暂存，审阅后再写入并同步
+func reviewMode() bool {
+	return configBool("shared.review")
+}
+
+// 字段修改保存后的提示信息
+func changeSavedMessage(count int) string {
+	if reviewMode() {
+		return tr("review.staged", count)
+	}
+	return tr("review.changed", count)
+}
+
+// 读取暂存修改（旧版本保存的中文字段名换成字段标识）
+func loadStaged() stagedChanges {
+	var staged stagedChanges
+	_ = readJSONFile(stagedFile, &staged)
+	for i := range staged.Fields {
+		staged.Fields[i].Field = fieldID(staged.Fields[i].Field)
+	}
+	return staged
+}
+
+// 暂存字段修改，同一连接同一字段的修改合并为一条（保留最初的原值）
+func stageFieldChanges(changes []fieldChange, action string) error {
+	staged := loadStaged()
+	for _, change := range changes {
+		id := change.Target.ID()
+		merged := false
+		for i := range staged.Fields {
+			if staged.Fields[i].Target == id && staged.Fields[i].Field == change.Field {
+				staged.Fields[i].New = change.New
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			staged.Fields = append(staged.Fields, stagedField{Target: id, Field: change.Field, Old: change.Old, New: change.New, Action: action})
+		}
+	}
+	return writeJSONFile(stagedFile, staged)
+}
+
+// 保存新增连接；启用审阅模式时只暂存
+func saveNewConnections(entries []inventoryEntry) error {
+	if !reviewMode() {
+		return addConnections(entries)
+	}
+	staged := loadStaged()
+	staged.Added = append(staged.Added, entries...)
+	return writeJSONFile(stagedFile, staged)
+}
+
+// 将暂存修改渲染为差异文本
+func (s stagedChanges) diff() string {
+	var b strings.Builder
+	for _, entry := range s.Added {
+		fmt.Fprintf(&b, "[green]+ %s[-]\n", tview.Escape(entry.ID()))
+		for _, field := range connectionFields {
+			if value := connectionField(entry.Conn, field); value != "" && value != "0" {
+				fmt.Fprintf(&b, "[green]+     %s: %s[-]\n", fieldLabel(field), tview.Escape(value))
+			}
+		}
+	}
+	current := ""
+	for _, field := range s.Fields {
+		if field.Target != current {
+			current = field.Target
+			fmt.Fprintf(&b, "[yellow]~ %s[-]\n", tview.Escape(field.Target))
+		}
+		fmt.Fprintf(&b, "[red]-     %s: %s[-]\n", fieldLabel(field.Field), tview.Escape(field.Old))
+		fmt.Fprintf(&b, "[green]+     %s: %s[-]\n", fieldLabel(field.Field), tview.Escape(field.New))
+	}
+	return b.String()
+}
+
+// 提交暂存修改：写入清单、记录审计，并在启用 shared.git 时通过 git 提交和推送
+func commitStaged(message string) error {
+	staged := loadStaged()
+	if len(staged.Fields) == 0 && len(staged.Added) == 0 {
+		return errors.New(tr("review.nothing"))
+	}
+	if len(staged.Added) > 0 {
+		if err := addConnections(staged.Added); err != nil {
+			return err
+		}
+	}
+	updates := make(map[string]map[string]string)
+	for _, field := range staged.Fields {
+		if updates[field.Target] == nil {
+			updates[field.Target] = make(map[string]string)
+		}
+		updates[field.Target][field.Field] = field.New
+	}
+	if err := saveConnectionChanges(updates); err != nil {
+		return err
+	}
+	for _, entry := range staged.Added {
+		recordAudit(auditEvent{Action: "add", Target: entry.ID(), Detail: message})
+	}
+	for _, field := range staged.Fields {
+		recordAudit(auditEvent{Action: field.Action, Target: field.Target, Detail: fmt.Sprintf("%s: %s -> %s", field.Field, field.Old, field.New)})
+	}
+	if err := discardStaged(); err != nil {
+		return err
+	}
+	if configBool("shared.git") {
+		return gitSync(message)
+	}
+	return nil
+}
+
+// 丢弃暂存修改
+func discardStaged() error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, stagedFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// 在数据目录的 git 仓库中提交清单文件（含位于其中的配置文件），存在远程仓库时推送
+func gitSync(message string) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(output)))
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+	// 清单中连接的修改写回配置文件，配置文件位于工作区目录中时一并提交
+	files := []string{overridesFile, connectionsFile}
+	if used := configFileUsed(); used != "" {
+		if rel, err := filepath.Rel(dir, used); err == nil && filepath.IsLocal(rel) {
+			files = append(files, rel)
+		}
+	}
+	if _, err := run(append([]string{"add", "--"}, files...)...); err != nil {
+		return err
+	}
+	if _, err := run(append([]string{"commit", "-m", message, "--"}, files...)...); err != nil {
+		return err
+	}
+	if remotes, _ := run("remote"); remotes != "" {
+		_, err = run("push")
+	}
+	return err
+}
+
+// 显示暂存修改的审阅视图
+func (a *App) showStagedReview() {
+	staged := loadStaged()
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true)
+	view.SetBorder(true).
+		SetTitle(tr("review.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	if text := staged.diff(); text != "" {
+		view.SetText(text)
+	} else {
+		view.SetText(tr("review.nothing_view"))
+	}
+
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+		switch event.Rune() {
+		case 'y', 'Y':
+			a.popOverlay()
+			a.prompt(tr("review.commit_title"), tr("review.message_label"), tr("review.default_message", len(staged.Fields), len(staged.Added)), func(message string) {
+				if err := commitStaged(message); err != nil {
+					a.statusBar.SetText(tr("review.commit_failed", tview.Escape(err.Error())))
+					return
+				}
+				a.updateMainPanel()
+				a.statusBar.SetText(tr("review.committed"))
+			})
+			return nil
+		case 'd', 'D':
+			a.popOverlay()
+			a.confirm(tr("review.discard_title"), tr("review.discard_confirm"), func() {
+				if err := discardStaged(); err != nil {
+					a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+					return
+				}
+				a.statusBar.SetText(tr("review.discarded"))
+			})
+			return nil
+		}
+		return event
+	})
+}
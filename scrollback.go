@@ -0,0 +1,147 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 默认保留的会话回滚行数
+const defaultScrollbackLines = 5000
+
+// 终端控制序列（CSI、OSC、字符集切换等），导出回滚时去除
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[()][0-9A-Za-z]|\x1b[=>78]`)
+
+// 每个连接最近一次 SSH 会话的回滚内容
+var (
+	scrollbackMu sync.Mutex
+	scrollbacks  = make(map[string][]string)
+)
+
+// 保留的回滚行数（配置项 scrollback.lines），0 表示不捕获会话输出
+func scrollbackLines() int {
+	if !configIsSet("scrollback.lines") {
+		return defaultScrollbackLines
+	}
+	return max(configInt("scrollback.lines"), 0)
+}
+
+// 用 script 包装会话命令以捕获输出，返回包装后的命令和捕获文件（不捕获时为空）
+func scrollbackCommand(args []string) ([]string, string) {
+	if scrollbackLines() == 0 {
+		return args, ""
+	}
+	if _, err := exec.LookPath("script"); err != nil {
+		return args, ""
+	}
+	file, err := os.CreateTemp("", "connectionmanager-scrollback-*.log")
+	if err != nil {
+		return args, ""
+	}
+	file.Close()
+	return recordedCommand(args, file.Name()), file.Name()
+}
+
+// 将终端输出整理为纯文本行：去掉控制序列，回车覆盖的内容只保留最后一段
+func scrollbackText(raw string) []string {
+	raw = ansiPattern.ReplaceAllString(raw, "")
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n") {
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		if strings.HasPrefix(line, "Script started on") || strings.HasPrefix(line, "Script done on") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \b\x07"))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// 读取捕获文件并保存为目标的回滚内容，只保留最后 scrollback.lines 行
+func storeScrollback(target connTarget, file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	lines := scrollbackText(string(data))
+	if limit := scrollbackLines(); limit > 0 && len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	scrollbackMu.Lock()
+	defer scrollbackMu.Unlock()
+	scrollbacks[target.ID()] = lines
+}
+
+// 读取目标最近一次会话的回滚内容
+func sessionScrollback(target connTarget) []string {
+	scrollbackMu.Lock()
+	defer scrollbackMu.Unlock()
+	return scrollbacks[target.ID()]
+}
+
+// 显示当前连接最近一次会话的回滚内容：/ 搜索，S 保存到文件，Y 复制到剪贴板
+func (a *App) showScrollback() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	lines := sessionScrollback(target)
+	if len(lines) == 0 {
+		a.statusBar.SetText(tr("scrollback.none", target.Conn.Name))
+		return
+	}
+	text := strings.Join(lines, "\n") + "\n"
+
+	view := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetText(tview.Escape(text))
+	view.SetBorder(true).
+		SetTitle(tr("scrollback.title", target.Conn.Name, len(lines))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	view.ScrollToEnd()
+
+	search := newTextSearch(view)
+	exported := func(destination string, err error) {
+		if err != nil {
+			a.statusBar.SetText(tr("scrollback.failed", tview.Escape(err.Error())))
+			return
+		}
+		recordAudit(auditEvent{Action: "scrollback_export", Target: target.ID(), Detail: destination})
+		a.statusBar.SetText(tr("scrollback.exported", len(lines), tview.Escape(destination)))
+	}
+	a.pushOverlay(view, view, func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			a.popOverlay()
+			return nil
+		}
+		if search.handleKey(a, event) {
+			return nil
+		}
+		if event.Key() != tcell.KeyRune {
+			return event
+		}
+		switch event.Rune() {
+		case 's', 'S':
+			name := strings.NewReplacer("/", "_", " ", "_").Replace(target.Conn.Name) + "-scrollback.txt"
+			a.prompt(tr("scrollback.save"), tr("common.path_label"), name, func(path string) {
+				exported(path, os.WriteFile(path, []byte(text), 0o600))
+			})
+			return nil
+		case 'y', 'Y':
+			exported(tr("export.clipboard"), copyToClipboard(text))
+			return nil
+		}
+		return event
+	})
+}
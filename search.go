@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 面板内搜索：'/' 输入关键字（不区分大小写），n/N 跳到下一个/上一个匹配
+type paneSearch struct {
+	query   string // 当前关键字
+	current int    // 当前匹配序号
+	count   int    // 匹配总数
+	title   string // 面板原标题
+
+	// 文本面板
+	view   *tview.TextView // 搜索的文本面板
+	plain  string          // 去掉样式标记后的文本
+	marked string          // 加入匹配标记后的文本，用于判断面板内容是否已被更新
+
+	// 表格面板
+	table   *tview.Table       // 搜索的表格
+	matches [][2]int           // 匹配的单元格（行、列）
+	colored []*tview.TableCell // 已标记背景色的单元格
+}
+
+// 为文本面板（命令输出、传输日志等）创建搜索
+func newTextSearch(view *tview.TextView) *paneSearch {
+	return &paneSearch{view: view, title: view.GetTitle()}
+}
+
+// 为结果表格创建搜索
+func newTableSearch(table *tview.Table) *paneSearch {
+	return &paneSearch{table: table, title: table.GetTitle()}
+}
+
+// 处理搜索按键，已处理时返回 true
+func (s *paneSearch) handleKey(a *App, event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyRune {
+		return false
+	}
+	switch event.Rune() {
+	case '/':
+		a.prompt(tr("search.title"), "/", s.query, func(query string) {
+			s.query = query
+			s.current = 0
+			s.find()
+		})
+	case 'n':
+		s.step(1)
+	case 'N':
+		s.step(-1)
+	default:
+		return false
+	}
+	return true
+}
+
+// 按当前关键字重新查找匹配（面板内容更新后也会重新计算）
+func (s *paneSearch) find() {
+	if s.query == "" {
+		return
+	}
+	pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(s.query))
+	if s.view != nil {
+		s.findText(pattern)
+	} else {
+		s.findCells(pattern)
+	}
+	if s.current >= s.count {
+		s.current = 0
+	}
+	s.show()
+}
+
+// 在文本面板中查找，并用区域标记高亮全部匹配
+func (s *paneSearch) findText(pattern *regexp.Regexp) {
+	if text := s.view.GetText(false); text != s.marked {
+		s.plain = s.view.GetText(true)
+	}
+	matches := pattern.FindAllStringIndex(s.plain, -1)
+	var b strings.Builder
+	last := 0
+	for i, match := range matches {
+		b.WriteString(tview.Escape(s.plain[last:match[0]]))
+		fmt.Fprintf(&b, `["s%d"][black:yellow]%s[-:-][""]`, i, tview.Escape(s.plain[match[0]:match[1]]))
+		last = match[1]
+	}
+	b.WriteString(tview.Escape(s.plain[last:]))
+	s.count = len(matches)
+	s.marked = b.String()
+	s.view.SetRegions(true).SetText(s.marked)
+	s.marked = s.view.GetText(false)
+}
+
+// 在表格中查找，并为匹配的单元格标记背景色
+func (s *paneSearch) findCells(pattern *regexp.Regexp) {
+	for _, cell := range s.colored {
+		cell.SetBackgroundColor(tcell.ColorDefault)
+	}
+	s.colored, s.matches = nil, nil
+	for row := 1; row < s.table.GetRowCount(); row++ {
+		for col := 0; col < s.table.GetColumnCount(); col++ {
+			cell := s.table.GetCell(row, col)
+			if cell == nil || !pattern.MatchString(cell.Text) {
+				continue
+			}
+			cell.SetBackgroundColor(tcell.ColorOlive)
+			s.colored = append(s.colored, cell)
+			s.matches = append(s.matches, [2]int{row, col})
+		}
+	}
+	s.count = len(s.matches)
+}
+
+// 跳到下一个（delta 为 1）或上一个（delta 为 -1）匹配
+func (s *paneSearch) step(delta int) {
+	if s.query == "" {
+		return
+	}
+	s.find()
+	if s.count == 0 {
+		return
+	}
+	s.current = (s.current + delta + s.count) % s.count
+	s.show()
+}
+
+// 定位到当前匹配，并在标题中显示匹配序号
+func (s *paneSearch) show() {
+	status := fmt.Sprintf(" [/%s %d/%d]", s.query, s.current+1, s.count)
+	if s.count == 0 {
+		status = tr("search.no_match", s.query)
+	}
+	status = tview.Escape(status)
+	if s.view != nil {
+		if s.count > 0 {
+			s.view.Highlight(fmt.Sprintf("s%d", s.current)).ScrollToHighlight()
+		}
+		s.view.SetTitle(s.title + status)
+		return
+	}
+	if s.count > 0 {
+		match := s.matches[s.current]
+		s.table.Select(match[0], match[1])
+	}
+	s.table.SetTitle(s.title + status)
+}
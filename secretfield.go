@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 密码输入的遮盖字符
+const secretMask = '•'
+
+// 添加密码输入框：输入内容遮盖显示，Ctrl+R 切换明文，支持粘贴；内容不会写入状态栏或日志
+func (v *formValidator) addSecretField(label string, width int, changed func(text string), rules func() []fieldRule) {
+	v.addInputField(label, "", width, changed, rules)
+	input := v.form.GetFormItem(v.form.GetFormItemCount() - 1).(*tview.InputField)
+	revealed := false
+	input.SetMaskCharacter(secretMask).
+		SetPlaceholder(tr("secret.reveal")).
+		SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+			if event.Key() != tcell.KeyCtrlR {
+				return event
+			}
+			revealed = !revealed
+			if revealed {
+				input.SetMaskCharacter(0)
+			} else {
+				input.SetMaskCharacter(secretMask)
+			}
+			return nil
+		})
+}
+
+// 校验两次输入的密码一致
+func ruleSameAs(other func() string) fieldRule {
+	return func(value string) string {
+		if value != other() {
+			return tr("secret.mismatch")
+		}
+		return ""
+	}
+}
+
+// 将密钥写入目标对应的密钥后端；内容只通过标准输入传递，不出现在命令行参数中
+func storeSecret(target connTarget, name, secret string) error {
+	backend := resolveSecretBackend(target)
+	var args []string
+	input := secret + "\n"
+	switch backend {
+	case "vault":
+		secretPath, field, found := strings.Cut(name, "#")
+		if !found {
+			field = "password"
+		}
+		mount := configString("secrets.vault.mount")
+		if mount == "" {
+			mount = "secret"
+		}
+		// field=- 表示从标准输入读取值
+		args = []string{"vault", "kv", "put", "-mount=" + mount, path.Join(configString("secrets.vault.prefix"), secretPath), field + "=-"}
+		input = secret
+	case "keychain":
+		return keychainStore(name, secret)
+	case "pass":
+		args = []string{"pass", "insert", "--multiline", "--force", name}
+	case "local":
+		return setCredentialSecret(name, secret)
+	case "":
+		return errors.New(tr("secret.no_backend", name))
+	default:
+		return errors.New(tr("secret.unsupported_backend", backend))
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(input)
+	// 不返回命令输出，避免后端回显的内容进入状态栏
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(tr("secret.write_failed"), backend, err)
+	}
+	return nil
+}
+
+// 将提权密码等密钥保存到当前连接对应的密钥后端，保存后可在 become 中以 secret:<名称> 引用
+func (a *App) showSecretForm() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	name, secret := target.Conn.Name, ""
+
+	form := tview.NewForm()
+	validator := newFormValidator(form)
+	validator.addInputField(tr("secret.name"), name, 40, func(text string) {
+		name = strings.TrimSpace(text)
+	}, fixedRules(ruleRequired))
+	validator.addSecretField(tr("field.password"), 40, func(text string) {
+		secret = text
+	}, fixedRules(ruleRequired))
+	validator.addSecretField(tr("secret.confirm"), 40, nil, func() []fieldRule {
+		return []fieldRule{ruleSameAs(func() string { return secret })}
+	})
+	submit := func() {
+		if !validator.validate() {
+			return
+		}
+		a.popOverlay()
+		save := func(name, secret string) {
+			a.statusBar.SetText(tr("secret.saving", tview.Escape(name)))
+			go func() {
+				err := storeSecret(target, name, secret)
+				a.app.QueueUpdateDraw(func() {
+					if err != nil {
+						a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+						return
+					}
+					recordAudit(auditEvent{Action: "secret_store", Target: target.ID(), Detail: name})
+					a.statusBar.SetText(tr("secret.saved", tview.Escape(name), tview.Escape(name)))
+				})
+			}()
+		}
+		// 内置凭据库未解锁时先输入主密码
+		if resolveSecretBackend(target) == "local" && !credentialsUnlocked() {
+			a.unlockCredentialForm(func() { save(name, secret) })
+			return
+		}
+		save(name, secret)
+	}
+	form.AddButton(tr("common.save"), submit).
+		AddButton(tr("common.cancel"), func() {
+			a.closeForm(validator, submit)
+		})
+	validator.root().SetBorder(true).
+		SetTitle(tr("secret.title", target.Conn.Name, resolveSecretBackend(target))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	a.pushForm(validator, centered(validator.root(), 64, 15), submit)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+// 解析目标使用的密钥后端，优先级：连接 > 环境 > 工作区默认（配置项 secrets）
+func resolveSecretBackend(target connTarget) string {
+	backend := configString("secrets.backend")
+	if byEnv := configStringMapString("secrets.environments"); len(byEnv) > 0 {
+		if value := lookupFold(byEnv, target.Env); value != "" {
+			backend = value
+		}
+	}
+	if byConn := configStringMapString("secrets.connections"); len(byConn) > 0 {
+		if value := lookupFold(byConn, target.ID()); value != "" {
+			backend = value
+		}
+	}
+	return backend
+}
+
+// 通过目标对应的密钥后端读取密钥
+func lookupSecret(target connTarget, name string) (string, error) {
+	backend := resolveSecretBackend(target)
+	var args []string
+	switch backend {
+	case "vault":
+		// 名称格式为 路径#字段，字段默认为 password
+		secretPath, field, found := strings.Cut(name, "#")
+		if !found {
+			field = "password"
+		}
+		mount := configString("secrets.vault.mount")
+		if mount == "" {
+			mount = "secret"
+		}
+		args = []string{"vault", "kv", "get", "-mount=" + mount, "-field=" + field, path.Join(configString("secrets.vault.prefix"), secretPath)}
+	case "keychain":
+		args = keychainLookupCommand(name)
+	case "pass":
+		args = []string{"pass", "show", name}
+	case "local":
+		return credentialSecret(name)
+	case "":
+		return "", errors.New(tr("secrets.no_backend", name))
+	default:
+		return "", errors.New(tr("secret.unsupported_backend", backend))
+	}
+
+	output, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf(tr("secrets.read_failed"), backend, name, err)
+	}
+	// pass 的第一行为密码，其余为附加信息
+	secret, _, _ := strings.Cut(string(output), "\n")
+	return strings.TrimRight(secret, "\r"), nil
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 会话策略：保活与空闲超时设置
+type sessionPolicy struct {
+	KeepaliveInterval time.Duration `mapstructure:"keepalive_interval"`  // 保活探测间隔（对应 ServerAliveInterval）
+	KeepaliveCountMax int           `mapstructure:"keepalive_count_max"` // 保活探测最大失败次数（对应 ServerAliveCountMax）
+	IdleTimeout       time.Duration `mapstructure:"idle_timeout"`        // 空闲自动断开时间，0 表示不断开
+	IdleWarning       time.Duration `mapstructure:"idle_warning"`        // 空闲自动断开前提前提示的时间，0 表示不提示
+	Reattach          string        `mapstructure:"reattach"`            // 登录后附加的远程终端复用器：tmux 或 screen
+	ReattachSession   string        `mapstructure:"reattach_session"`    // 复用器会话名，默认 cm-<连接名>
+	X11               bool          `mapstructure:"x11"`                 // 是否启用 X11 转发
+	X11Trusted        bool          `mapstructure:"x11_trusted"`         // 是否使用受信任的 X11 转发（-Y）
+	SuppressBanner    bool          `mapstructure:"suppress_banner"`     // 交互式会话中不显示横幅和 MOTD（仍会记录）
+	ConnectSummary    bool          `mapstructure:"connect_summary"`     // 连接前显示上次连接、未处理备注和维护窗口摘要
+	StartupProbe      time.Duration `mapstructure:"startup_probe"`       // 打开客户端前探测可达性的超时时间，负数表示不探测
+}
+
+// 内置默认会话策略
+var defaultSessionPolicy = sessionPolicy{
+	KeepaliveInterval: 30 * time.Second,
+	KeepaliveCountMax: 3,
+	StartupProbe:      3 * time.Second,
+}
+
+// 用非零字段覆盖策略
+func (p sessionPolicy) merge(override sessionPolicy) sessionPolicy {
+	if override.KeepaliveInterval > 0 {
+		p.KeepaliveInterval = override.KeepaliveInterval
+	}
+	if override.KeepaliveCountMax > 0 {
+		p.KeepaliveCountMax = override.KeepaliveCountMax
+	}
+	if override.IdleTimeout > 0 {
+		p.IdleTimeout = override.IdleTimeout
+	}
+	if override.IdleWarning > 0 {
+		p.IdleWarning = override.IdleWarning
+	}
+	if override.Reattach != "" {
+		p.Reattach = override.Reattach
+	}
+	if override.ReattachSession != "" {
+		p.ReattachSession = override.ReattachSession
+	}
+	if override.X11 {
+		p.X11 = true
+	}
+	if override.X11Trusted {
+		p.X11Trusted = true
+	}
+	if override.SuppressBanner {
+		p.SuppressBanner = true
+	}
+	if override.ConnectSummary {
+		p.ConnectSummary = true
+	}
+	if override.StartupProbe != 0 {
+		p.StartupProbe = override.StartupProbe
+	}
+	return p
+}
+
+// 解析目标的会话策略，优先级：连接 > 环境 > 全局默认（配置项 session_policies）
+func resolveSessionPolicy(target connTarget) sessionPolicy {
+	policy := defaultSessionPolicy
+
+	var global sessionPolicy
+	if unmarshalConfig("session_policies.default", &global) == nil {
+		policy = policy.merge(global)
+	}
+	var byEnv map[string]sessionPolicy
+	if unmarshalConfig("session_policies.environments", &byEnv) == nil {
+		policy = policy.merge(lookupFold(byEnv, target.Env))
+	}
+	var byConn map[string]sessionPolicy
+	if unmarshalConfig("session_policies.connections", &byConn) == nil {
+		policy = policy.merge(lookupFold(byConn, target.ID()))
+	}
+	return policy
+}
+
+// 在 viper 读取的（键已小写化的）映射中按不区分大小写的方式查找
+func lookupFold[T any](values map[string]T, key string) T {
+	for k, v := range values {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
+
+// 构建SSH基础参数（选项与目标地址），应用保活策略
+func sshBaseArgs(target connTarget, policy sessionPolicy) []string {
+	conn := target.Conn
+	transport := resolveTransport(target)
+	teleport := transport.kind() == transportTeleport
+	args := transport.client(target.Module)
+	if conn.Port != 0 && conn.Port != 22 {
+		args = append(args, "-p", strconv.Itoa(conn.Port))
+	}
+	// tsh 的 -i 表示 Teleport 身份文件，且不支持 ServerAlive 选项
+	if !teleport {
+		args = append(args, sshAuthArgs(conn)...)
+	}
+	args = append(args, proxyCommandOptions(target)...)
+	if policy.KeepaliveInterval > 0 && !teleport {
+		args = append(args,
+			"-o", fmt.Sprintf("ServerAliveInterval=%d", int(policy.KeepaliveInterval.Seconds())),
+			"-o", fmt.Sprintf("ServerAliveCountMax=%d", policy.KeepaliveCountMax))
+	}
+
+	destination := conn.Host
+	if transport.tunneled() {
+		destination = transport.tunnelTarget(conn)
+	}
+	if conn.User != "" {
+		destination = conn.User + "@" + destination
+	}
+	return append(args, destination)
+}
+
+// 构建交互式SSH会话命令，应用保活与空闲超时策略
+func sshCommand(target connTarget) []string {
+	policy := resolveSessionPolicy(target)
+	args := sshBaseArgs(target, policy)
+	if flag := x11Flag(policy); flag != "" {
+		args = insertSSHOptions(target, args, flag)
+	}
+
+	// 附加到（或创建）远程复用器会话，断线重连后恢复原有工作现场；此时不再应用空闲超时
+	command := reattachCommand(target, policy)
+	// 登录后自动切换用户，需要密码时由用户在终端中输入
+	if become := resolveBecome(target); become.enabled() && become.OnLogin {
+		command = become.loginCommand(command)
+	}
+	switch {
+	case command != "":
+	case policy.IdleTimeout > 0:
+		// 空闲超时通过远端 shell 的 TMOUT 实现（bash/zsh/ksh 支持），到期后自动登出
+		command = fmt.Sprintf("TMOUT=%d exec ${SHELL:-/bin/sh} -l", int(policy.IdleTimeout.Seconds()))
+	case policy.SuppressBanner:
+		// 显式指定远程命令时 sshd 不再输出 MOTD
+		command = "exec ${SHELL:-/bin/sh} -l"
+	}
+	if command != "" {
+		args = insertSSHOptions(target, args, "-t")
+		args = append(args, command)
+	}
+	return args
+}
+
+// 获取策略对应的 X11 转发参数，未启用时返回空字符串
+func x11Flag(policy sessionPolicy) string {
+	switch {
+	case policy.X11Trusted:
+		return "-Y"
+	case policy.X11:
+		return "-X"
+	}
+	return ""
+}
+
+// 构建附加远程复用器会话的命令，未配置时返回空字符串
+func reattachCommand(target connTarget, policy sessionPolicy) string {
+	name := policy.ReattachSession
+	if name == "" {
+		name = "cm-" + target.Conn.Name
+	}
+	switch strings.ToLower(policy.Reattach) {
+	case "tmux":
+		return "tmux new-session -A -s " + shellQuote(name)
+	case "screen":
+		return "screen -D -R -S " + shellQuote(name)
+	}
+	return ""
+}
+
+// 构建在远程主机上执行单条命令的非交互SSH命令
+func sshExecCommand(target connTarget, remoteCommand string) []string {
+	args := sshBaseArgs(target, resolveSessionPolicy(target))
+	args = insertSSHOptions(target, args, batchModeOptions(target)...)
+	return append(args, remoteCommand)
+}
+
+// 在远程主机上执行命令并返回标准输出，失败时错误中包含标准错误输出
+func runRemote(ctx context.Context, target connTarget, remoteCommand string) (string, error) {
+	return runRemoteInput(ctx, target, remoteCommand, nil)
+}
+
+// 在远程主机上执行命令，并将 input 作为远程命令的标准输入
+func runRemoteInput(ctx context.Context, target connTarget, remoteCommand string, input io.Reader) (string, error) {
+	if err := proxyCommandError(target); err != nil {
+		return "", err
+	}
+	args := sshExecCommand(target, remoteCommand)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = input
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return string(output), fmt.Errorf("%w: %s", err, message)
+		}
+	}
+	return string(output), err
+}
+
+// 连接前摘要：最近一次连接的用户与时间（来自审计日志）、未处理的备注和当前维护窗口
+func connectSummary(target connTarget, now time.Time) []string {
+	var lines []string
+	events := loadAuditEvents()
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Action == "session" && events[i].Target == target.ID() {
+			lines = append(lines, tr("session.last_login", events[i].User, formatTime(events[i].Time)))
+			break
+		}
+	}
+	for _, note := range openNotes(target) {
+		lines = append(lines, tr("session.note", note.Text, note.Author, formatTime(note.Time)))
+	}
+	if window, ok := inMaintenance(target, now); ok {
+		end := window.To
+		if window.End != "" {
+			end = window.End
+		}
+		line := tr("session.maintenance") + window.Name
+		if end != "" {
+			line += tr("session.until") + end + tr("session.until_end")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// 对远程命令参数进行 shell 单引号转义
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// 挂起界面并运行交互式SSH会话，会话结束后恢复界面并记录传输统计
+func (a *App) openSSHSession(target connTarget) {
+	if err := proxyCommandError(target); err != nil {
+		setSessionStatus(target, "failed")
+		a.updateMainPanel()
+		recordFailure(failureDetail{Target: target, Summary: err.Error()})
+		a.statusBar.SetText(tr("common.connect_failed", tview.Escape(err.Error())))
+		return
+	}
+	args := sshCommand(target)
+	path := plannedPath(target, args)
+	args, managed := managedSessionArgs(target, args)
+
+	// 会话结束后删除的临时文件（内嵌终端中的会话在进程退出后才结束）
+	var temporary []string
+
+	// 将 ssh 日志写入临时文件，会话结束后从中解析收发字节数和实际连接地址（tsh 不支持）
+	var logFile *os.File
+	if resolveTransport(target).kind() != transportTeleport {
+		if file, err := os.CreateTemp("", "connectionmanager-ssh-*.log"); err == nil {
+			logFile = file
+			logFile.Close()
+			temporary = append(temporary, logFile.Name())
+			args = append([]string{args[0], "-E", logFile.Name(), "-o", "LogLevel=VERBOSE"}, args[1:]...)
+		}
+	}
+	args, recording := applyConnectRules(target, args)
+
+	// 捕获会话输出作为回滚内容，已录制的会话直接使用录制文件
+	scrollback := recording
+	if scrollback == "" {
+		var capture string
+		if args, capture = scrollbackCommand(args); capture != "" {
+			scrollback = capture
+			temporary = append(temporary, capture)
+		}
+	}
+
+	// 横幅由 ssh 输出到标准错误，MOTD 在后台单独读取
+	var stderr bytes.Buffer
+	suppressBanner := resolveSessionPolicy(target).SuppressBanner
+	motd := fetchMOTD(target)
+
+	// 会话开头显示主机当地时间，便于跨时区安排维护
+	var intro []string
+	if zone, ok := cachedHostTimezone(target); ok {
+		intro = append(intro, tr("session.local_time", target.Conn.Name, zone.localTime(time.Now())))
+	}
+	if resolveSessionPolicy(target).ConnectSummary {
+		intro = append(intro, connectSummary(target, time.Now())...)
+	}
+
+	start := time.Now()
+	setSessionStatus(target, "connected")
+	cmd := exec.Command(args[0], args[1:]...)
+	env, stopAskpass := sshPasswordEnv(target)
+	cmd.Env = env
+
+	// 接近空闲登出时在会话所在的终端中提示
+	stopIdle := func() {}
+
+	// 会话进程结束后的处理：记录横幅、回滚内容、收发字节数和审计日志，出错时显示详情
+	finish := func(runErr error) {
+		stopIdle()
+		stopAskpass()
+		defer func() {
+			for _, name := range temporary {
+				os.Remove(name)
+			}
+		}()
+		captureBanner(target, stderr.String(), <-motd, runErr)
+		if scrollback != "" {
+			storeScrollback(target, scrollback)
+		}
+
+		var stats transferStats
+		if logFile != nil {
+			stats, _ = parseSSHTransferLog(logFile.Name())
+			path = realizedPath(path, logFile.Name())
+		}
+		stats.Duration = time.Since(start)
+		addSessionUsage(target, stats)
+
+		event := auditEvent{
+			Action:        "session",
+			Target:        target.ID(),
+			Duration:      stats.Duration,
+			BytesSent:     stats.Sent,
+			BytesReceived: stats.Received,
+			Path:          path,
+		}
+		if recording != "" {
+			event.Detail = tr("session.recording") + recording
+		}
+		if runErr != nil {
+			event.Detail = strings.TrimPrefix(event.Detail+"; "+runErr.Error(), "; ")
+		}
+		recordAudit(event)
+
+		// ssh 自身出错（无法连接、认证失败）时标记为失败，远程命令的退出码不影响连接状态
+		if sshConnectionFailed(runErr) {
+			setSessionStatus(target, "failed")
+		} else if managed {
+			// 主连接由后台服务保持，再次连接时直接复用
+			setSessionStatus(target, "detached")
+		} else {
+			setSessionStatus(target, "disconnected")
+		}
+		a.updateMainPanel()
+		if runErr != nil {
+			a.statusBar.SetText(tr("session.failed", runErr))
+			// ssh 的错误信息在 -E 日志中，使用 script 捕获时也会出现在回滚内容里
+			outputs := []string{stderr.String()}
+			if logFile != nil {
+				outputs = append(outputs, fileTail(logFile.Name()))
+			}
+			if scrollback != "" {
+				outputs = append(outputs, fileTail(scrollback))
+			}
+			recordFailure(failureDetail{Target: target, Summary: runErr.Error(), Command: args, Output: strings.Join(outputs, "\n")})
+			if authFailed(target.Module, outputs...) {
+				a.offerAuthRetry(target, a.openSSHSession)
+				return
+			}
+			a.showLastFailure()
+			return
+		}
+		a.statusBar.SetText(tr("session.ended", formatDuration(stats.Duration), stats, tview.Escape(strings.Join(path, pathSeparator))))
+	}
+
+	// 内嵌终端中的会话在后台运行，进程退出后再处理；不支持时回退为挂起界面
+	if embeddedTerminal() {
+		pane, err := a.openTerminalPane(target, cmd, intro, &stderr, !suppressBanner, finish)
+		if err == nil {
+			stopIdle = watchSessionIdle(target, resolveSessionPolicy(target), scrollback, pane.screen)
+			return
+		}
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("pane.fallback", err))))
+		cmd = exec.Command(args[0], args[1:]...)
+		cmd.Env = env
+	}
+
+	stopIdle = watchSessionIdle(target, resolveSessionPolicy(target), scrollback, os.Stderr)
+	var runErr error
+	a.suspend(func() {
+		for _, line := range intro {
+			fmt.Println(line)
+		}
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+		if suppressBanner {
+			cmd.Stderr = &stderr
+		}
+		runErr = cmd.Run()
+	})
+	finish(runErr)
+}
+
+// 本次运行中 SSH 会话的连接状态（连接标识 -> 状态），覆盖清单中的状态
+var (
+	sessionStatusMu sync.Mutex
+	sessionStatus   = make(map[string]string)
+)
+
+// 记录连接的会话状态：connecting、connected、detached、disconnected 或 failed
+func setSessionStatus(target connTarget, status string) {
+	sessionStatusMu.Lock()
+	defer sessionStatusMu.Unlock()
+	previous := sessionStatus[target.ID()]
+	sessionStatus[target.ID()] = status
+	if status == "connected" || status == "detached" {
+		addSessionTab(target)
+		if previous != "connected" && previous != "detached" {
+			noteSessionConnected(target, time.Now())
+		}
+	}
+}
+
+// 用会话状态覆盖环境中连接的状态，没有会话状态时使用后台健康检查的结果
+func applySessionStatus(module, project, env string, conns []Connection) []Connection {
+	sessionStatusMu.Lock()
+	defer sessionStatusMu.Unlock()
+	for i := range conns {
+		target := connTarget{Module: module, Project: project, Env: env, Conn: conns[i]}
+		if status, ok := sessionStatus[target.ID()]; ok {
+			conns[i].Status = status
+		} else if healthChecksEnabled() {
+			conns[i].Status = healthStatus(target)
+		}
+	}
+	return conns
+}
+
+// ssh 自身是否出错（退出码 255），而不是远程命令返回非零退出码
+func sshConnectionFailed(err error) bool {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode() == 255
+	}
+	return err != nil
+}
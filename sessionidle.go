@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 无活动超过该时间的会话在会话面板中标记为空闲
+const idleBadgeAfter = 5 * time.Minute
+
+// 空闲检查间隔
+const idleCheckInterval = 15 * time.Second
+
+// 开始在客户端会话中使用目标的本地隧道，返回结束使用时调用的函数（没有隧道时为空操作）
+func useTunnel(target connTarget) func() {
+	tunnelsMu.Lock()
+	t, ok := tunnels[target.ID()]
+	if !ok {
+		tunnelsMu.Unlock()
+		// 隧道由后台服务持有时通知服务记录使用状态
+		if _, err := daemonCall(daemonRequest{Op: "use", Target: target}, daemonCallTimeout); inDaemon || err != nil {
+			return func() {}
+		}
+		return func() { daemonCall(daemonRequest{Op: "release", Target: target}, daemonCallTimeout) }
+	}
+	defer tunnelsMu.Unlock()
+	t.inUse++
+	t.active, t.warned = time.Now(), false
+	return func() {
+		tunnelsMu.Lock()
+		defer tunnelsMu.Unlock()
+		t.inUse--
+		t.active, t.warned = time.Now(), false
+	}
+}
+
+// 隧道的活动时间与空闲标记，调用方需持有 tunnelsMu
+func (t *localTunnel) activityText(now time.Time) string {
+	text := tr("idle.times", formatShortTime(t.started), formatShortTime(t.active))
+	switch idle := now.Sub(t.active); {
+	case t.inUse > 0:
+		text += tr("idle.in_use")
+	case idle >= idleBadgeAfter:
+		text += tr("idle.idle", formatDuration(idle.Round(time.Minute)))
+	}
+	return text
+}
+
+// 定期检查本地隧道并在状态栏提示，同时刷新后台服务持有的隧道
+func (a *App) startTunnelReaper() {
+	go func() {
+		ticker := time.NewTicker(idleCheckInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			messages := reapTunnels(time.Now())
+			refreshDaemonState()
+			a.app.QueueUpdateDraw(func() {
+				for _, message := range messages {
+					a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(message)))
+				}
+				a.updateSidePanels()
+			})
+		}
+	}()
+}
+
+// 关闭空闲超过会话策略 idle_timeout 的本地隧道，关闭前按 idle_warning 提前提示；返回需要提示的消息
+func reapTunnels(now time.Time) []string {
+	var messages []string
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	for id, t := range tunnels {
+		if !t.alive() || t.inUse > 0 {
+			continue
+		}
+		policy := resolveSessionPolicy(t.target)
+		if policy.IdleTimeout <= 0 {
+			continue
+		}
+		idle := now.Sub(t.active)
+		switch {
+		case idle >= policy.IdleTimeout:
+			t.cmd.Process.Kill()
+			delete(tunnels, id)
+			recordAudit(auditEvent{Action: "tunnel_close", Target: id, Detail: tr("idle.audit_timeout"), Duration: now.Sub(t.started)})
+			messages = append(messages, tr("idle.closed", id))
+		case policy.IdleWarning > 0 && !t.warned && idle >= policy.IdleTimeout-policy.IdleWarning:
+			t.warned = true
+			messages = append(messages, tr("idle.closing",
+				id, formatDuration(idle.Round(time.Second)), formatDuration((policy.IdleTimeout-idle).Round(time.Second))))
+		}
+	}
+	return messages
+}
+
+// 会话是否通过远端 TMOUT 空闲自动登出（附加复用器或登录后切换用户时不生效）
+func idleLogoutApplies(target connTarget, policy sessionPolicy) bool {
+	if policy.IdleTimeout <= 0 || reattachCommand(target, policy) != "" {
+		return false
+	}
+	become := resolveBecome(target)
+	return !become.enabled() || !become.OnLogin
+}
+
+// 在 SSH 会话期间监视输出捕获文件判断活动，接近空闲登出时向会话所在的终端 out 输出提示；返回停止监视的函数
+func watchSessionIdle(target connTarget, policy sessionPolicy, capture string, out io.Writer) func() {
+	if capture == "" || policy.IdleWarning <= 0 || !idleLogoutApplies(target, policy) {
+		return func() {}
+	}
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		var size int64
+		active, warned := time.Now(), false
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			// 终端回显也会写入捕获文件，输入和输出都算作活动
+			if info, err := os.Stat(capture); err == nil && info.Size() != size {
+				size, active, warned = info.Size(), time.Now(), false
+				continue
+			}
+			if idle := time.Since(active); !warned && idle >= policy.IdleTimeout-policy.IdleWarning {
+				warned = true
+				fmt.Fprintf(out, tr("idle.session_warning"),
+					formatDuration(idle.Round(time.Second)), formatDuration((policy.IdleTimeout - idle).Round(time.Second)))
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 本次运行中某个连接的会话统计
+type sessionUsage struct {
+	Connected time.Time     // 最近一次建立连接的时间
+	Sessions  int           // 已结束的会话次数
+	Sent      int64         // 累计发送字节数（仅 SSH）
+	Received  int64         // 累计接收字节数（仅 SSH）
+	Total     time.Duration // 累计会话时长
+}
+
+// 连接标识 -> 会话统计
+var (
+	sessionUsageMu sync.Mutex
+	sessionUsages  = make(map[string]*sessionUsage)
+)
+
+// 记录连接建立的时间（从未连接状态变为已连接时调用）
+func noteSessionConnected(target connTarget, now time.Time) {
+	sessionUsageMu.Lock()
+	defer sessionUsageMu.Unlock()
+	usage, ok := sessionUsages[target.ID()]
+	if !ok {
+		usage = &sessionUsage{}
+		sessionUsages[target.ID()] = usage
+	}
+	usage.Connected = now
+}
+
+// 累加一次已结束会话的时长和收发字节数
+func addSessionUsage(target connTarget, stats transferStats) {
+	sessionUsageMu.Lock()
+	defer sessionUsageMu.Unlock()
+	usage, ok := sessionUsages[target.ID()]
+	if !ok {
+		usage = &sessionUsage{}
+		sessionUsages[target.ID()] = usage
+	}
+	usage.Sessions++
+	usage.Sent += stats.Sent
+	usage.Received += stats.Received
+	usage.Total += stats.Duration
+}
+
+// 会话当前是否保持着连接：SSH 主连接由后台服务保持，或数据库客户端的本地隧道仍在运行
+func sessionHeld(target connTarget) (status string, tunnelPort int) {
+	sessionStatusMu.Lock()
+	status = sessionStatus[target.ID()]
+	sessionStatusMu.Unlock()
+	tunnelsMu.Lock()
+	if t, ok := tunnels[target.ID()]; ok && t.alive() {
+		tunnelPort = t.port
+	}
+	tunnelsMu.Unlock()
+	return status, tunnelPort
+}
+
+// 会话管理面板：列出本次运行中打开过的全部会话，可切换、断开或重新连接
+func (a *App) showSessionManager() {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("sessions.manager_title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	var targets []connTarget
+	render := func() {
+		selection, _ := table.GetSelection()
+		sessionTabsMu.Lock()
+		targets = slices.Clone(sessionTabs)
+		sessionTabsMu.Unlock()
+
+		table.Clear()
+		headers := []string{"#", "sessions.col_conn", "sessions.col_status", "sessions.col_uptime", "sessions.col_count", "sessions.col_total", "sessions.col_sent", "sessions.col_received", "sessions.col_tunnel"}
+		for c, header := range headers {
+			if c > 0 {
+				header = tr(header)
+			}
+			table.SetCell(0, c, tview.NewTableCell(header).SetTextColor(tcell.ColorYellow).SetSelectable(false).SetExpansion(1))
+		}
+		now := time.Now()
+		for r, target := range targets {
+			status, port := sessionHeld(target)
+			color, label := connectionStatusStyle(status)
+			var usage sessionUsage
+			sessionUsageMu.Lock()
+			if u, ok := sessionUsages[target.ID()]; ok {
+				usage = *u
+			}
+			sessionUsageMu.Unlock()
+
+			uptime, tunnel := "-", "-"
+			if (status == "connected" || status == "detached" || port != 0) && !usage.Connected.IsZero() {
+				uptime = formatDuration(now.Sub(usage.Connected).Round(time.Second))
+			}
+			if port != 0 {
+				tunnel = fmt.Sprintf("127.0.0.1:%d", port)
+			}
+			sent, received := "-", "-"
+			if usage.Sent > 0 || usage.Received > 0 {
+				sent, received = formatBytes(usage.Sent), formatBytes(usage.Received)
+			}
+			cells := []string{strconv.Itoa(r + 1), target.ID(), "", uptime, strconv.Itoa(usage.Sessions), formatDuration(usage.Total.Round(time.Second)), sent, received, tunnel}
+			for c, value := range cells {
+				table.SetCell(r+1, c, tview.NewTableCell(tview.Escape(value)).SetExpansion(1))
+			}
+			table.SetCell(r+1, 2, tview.NewTableCell(fmt.Sprintf("[%s]%s[-]", color, label)).SetExpansion(1))
+		}
+		if len(targets) == 0 {
+			table.SetCell(1, 0, tview.NewTableCell(tr("sessions.manager_empty")).SetSelectable(false))
+		}
+		table.Select(max(1, min(selection, len(targets))), 0)
+	}
+	render()
+
+	selected := func() (int, connTarget, bool) {
+		row, _ := table.GetSelection()
+		if row < 1 || row > len(targets) {
+			return 0, connTarget{}, false
+		}
+		return row, targets[row-1], true
+	}
+	// 断开保持中的连接（后台服务的 SSH 主连接、数据库客户端的本地隧道），完成后执行 then
+	disconnect := func(target connTarget, then func()) {
+		// 内嵌终端中运行的会话：结束 ssh 进程，会话处理完成后再继续
+		if pane := a.paneFor(target); pane != nil {
+			a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("sessions.disconnecting", target.ID()))))
+			pane.cmd.Process.Kill()
+			go func() {
+				<-pane.done
+				a.app.QueueUpdateDraw(func() {
+					render()
+					then()
+				})
+			}()
+			return
+		}
+		status, port := sessionHeld(target)
+		if status != "detached" && port == 0 {
+			a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("sessions.not_held", target.ID()))))
+			then()
+			return
+		}
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("sessions.disconnecting", target.ID()))))
+		go func() {
+			var err error
+			if status == "detached" {
+				err = controlMaster(target, "exit")
+			}
+			closeClientTunnel(target.ID(), tr("sessions.closed_manually"))
+			recordAudit(auditEvent{Action: "session_close", Target: target.ID(), Detail: tr("sessions.closed_manually")})
+			a.app.QueueUpdateDraw(func() {
+				if err != nil {
+					a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("sessions.disconnect_failed", target.ID(), err))))
+				} else {
+					setSessionStatus(target, "disconnected")
+					a.statusBar.SetText(fmt.Sprintf("[green]%s[-]", tview.Escape(tr("sessions.disconnected", target.ID()))))
+				}
+				render()
+				a.updateMainPanel()
+				a.updateSidePanels()
+				then()
+			})
+		}()
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		row, target, ok := selected()
+		switch event.Key() {
+		case tcell.KeyEsc, tcell.KeyF2:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			if ok {
+				a.popOverlay()
+				a.switchToSession(row)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 's', 'S':
+				if ok {
+					a.popOverlay()
+					a.switchToSession(row)
+				}
+				return nil
+			case 'x', 'X':
+				if ok {
+					disconnect(target, func() {})
+				}
+				return nil
+			case 'c', 'C':
+				if ok {
+					disconnect(target, func() {
+						if top := len(a.overlays) - 1; top >= 0 && a.overlays[top].root == table {
+							a.popOverlay()
+						}
+						a.switchToSession(row)
+					})
+				}
+				return nil
+			case 'r', 'R':
+				render()
+				return nil
+			}
+		}
+		return event
+	})
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 会话标签数量上限（对应数字键 1-9）
+const maxSessionTabs = 9
+
+// 本次运行中打开过会话的连接，按首次打开的顺序编号
+var (
+	sessionTabsMu sync.Mutex
+	sessionTabs   []connTarget
+)
+
+// 为打开会话的连接分配标签编号，已有编号的保持不变；超过上限时移除最早的标签
+func addSessionTab(target connTarget) {
+	sessionTabsMu.Lock()
+	defer sessionTabsMu.Unlock()
+	if slices.ContainsFunc(sessionTabs, func(t connTarget) bool { return t.ID() == target.ID() }) {
+		return
+	}
+	if len(sessionTabs) == maxSessionTabs {
+		sessionTabs = sessionTabs[1:]
+	}
+	sessionTabs = append(sessionTabs, target)
+}
+
+// 连接的会话标签编号，没有标签时为 0
+func sessionTabNumber(target connTarget) int {
+	sessionTabsMu.Lock()
+	defer sessionTabsMu.Unlock()
+	for i, t := range sessionTabs {
+		if t.ID() == target.ID() {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// 切换会话使用的修饰键（配置项 ui.session_switch：alt、ctrl 或 off，默认 alt）
+func sessionSwitchModifier() (tcell.ModMask, bool) {
+	switch configString("ui.session_switch") {
+	case "", "alt":
+		return tcell.ModAlt, true
+	case "ctrl":
+		return tcell.ModCtrl, true
+	}
+	return 0, false
+}
+
+// 处理切换会话的按键：修饰键+1..9 切换到对应标签的会话，修饰键+0 回到树视图
+func (a *App) handleSessionSwitch(event *tcell.EventKey) bool {
+	modifier, ok := sessionSwitchModifier()
+	if !ok || event.Key() != tcell.KeyRune || event.Modifiers()&modifier == 0 || event.Rune() < '0' || event.Rune() > '9' {
+		return false
+	}
+	if event.Rune() == '0' {
+		if !a.inTreeView {
+			a.currentModule = a.hoveredModule
+			a.inTreeView = true
+			a.updateModuleBar()
+			a.updateMainPanel()
+			a.updateStatusBar()
+		}
+		return true
+	}
+	a.switchToSession(int(event.Rune() - '0'))
+	return true
+}
+
+// 切换到指定编号的会话：在树中定位连接并重新连接（配置了 reattach 时恢复远程复用器会话）
+func (a *App) switchToSession(number int) {
+	sessionTabsMu.Lock()
+	var target connTarget
+	ok := number >= 1 && number <= len(sessionTabs)
+	if ok {
+		target = sessionTabs[number-1]
+	}
+	sessionTabsMu.Unlock()
+	if !ok {
+		a.statusBar.SetText(tr("tabs.no_session", number))
+		return
+	}
+	if !a.focusTarget(target) {
+		a.statusBar.SetText(tr("tabs.not_found", tview.Escape(target.ID())))
+		return
+	}
+	a.activateTreeItem()
+}
+
+// 渲染会话面板中的会话标签列表
+func renderSessionTabs() string {
+	sessionTabsMu.Lock()
+	tabs := slices.Clone(sessionTabs)
+	sessionTabsMu.Unlock()
+	if len(tabs) == 0 {
+		return tr("tabs.none")
+	}
+	content := ""
+	for i, target := range tabs {
+		sessionStatusMu.Lock()
+		color, status := connectionStatusStyle(sessionStatus[target.ID()])
+		sessionStatusMu.Unlock()
+		content += fmt.Sprintf("  [blue]%d[-] %s [%s]%s[-]\n", i+1, tview.Escape(target.ID()), color, status)
+	}
+	return content
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// SQL文件执行超时时间
+const sqlFileTimeout = 5 * time.Minute
+
+// 打开SQL文件选择输入框，对选中的数据库连接执行
+func (a *App) runSQLFile() {
+	module := a.modules[a.currentModule]
+	if kind := moduleType(module); kind != "MySQL" && kind != "PostgreSQL" {
+		a.statusBar.SetText(tr("sqlfile.unsupported", module))
+		return
+	}
+	targets := a.selectedTargets()
+	if len(targets) == 0 {
+		return
+	}
+
+	input := a.prompt(tr("sqlfile.prompt_title", len(targets)), tr("sqlfile.prompt_label"), "", func(path string) {
+		a.confirmSQLFile(path, targets)
+	})
+	// 按输入前缀补全本地文件路径
+	input.SetAutocompleteFunc(func(text string) []string {
+		matches, _ := filepath.Glob(text + "*")
+		var entries []string
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && (info.IsDir() || strings.HasSuffix(match, ".sql")) {
+				entries = append(entries, match)
+			}
+		}
+		return entries
+	})
+}
+
+// 校验SQL文件并预览各目标将要执行的命令，受保护环境中的目标需要输入确认短语
+func (a *App) confirmSQLFile(path string, targets []connTarget) {
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		a.statusBar.SetText(tr("sqlfile.unreadable", path))
+		return
+	}
+
+	preview := changePreview{Title: tr("sqlfile.preview_title", filepath.Base(path)), Header: []string{tr("common.col_target"), tr("common.col_env"), tr("common.col_protected")}, Empty: tr("sqlfile.no_targets")}
+	for _, target := range targets {
+		protected := ""
+		if isProtectedEnv(target.Env) {
+			protected = tr("common.yes")
+		}
+		preview.Rows = append(preview.Rows, []string{target.ID(), target.Env, protected})
+		preview.Envs = append(preview.Envs, target.Env)
+		// 经隧道连接时实际地址为本地转发端口
+		command := redactArgs(batchClientCommand(target.Module, target.Conn))
+		preview.Commands = append(preview.Commands, strings.Join(command, " ")+" < "+path)
+	}
+	a.showPreview(preview, func() {
+		a.showSQLFileResults(path, targets)
+	})
+}
+
+// 显示SQL文件执行结果表格，并在后台依次执行；关闭表格时停止执行，某个目标失败后暂停，由用户选择是否继续剩余目标
+func (a *App) showSQLFileResults(path string, targets []connTarget) {
+	title := tr("sqlfile.results_title", filepath.Base(path))
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(title).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	search := newTableSearch(table)
+
+	rows := [][]string{{tr("common.col_target"), tr("common.col_env"), tr("common.col_result"), tr("common.col_duration"), tr("common.col_output")}}
+	for _, target := range targets {
+		rows = append(rows, []string{target.Conn.Name, target.Env, tr("sqlfile.waiting"), "", ""})
+	}
+	fillTable(table, rows)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	progressTitle := tr("sqlfile.progress", filepath.Base(path))
+	resume := make(chan *progressModal, 1) // 继续执行时传入新的进度弹窗
+	paused := false                        // 只在界面协程中读写
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		if search.handleKey(a, event) {
+			return nil
+		}
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cancel()
+			a.popOverlay()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'q', 'Q':
+				cancel()
+				a.popOverlay()
+				return nil
+			case 'c', 'C':
+				if paused {
+					paused = false
+					table.SetTitle(title).SetBorderColor(tcell.ColorYellow)
+					resume <- a.startProgress(progressTitle, cancel)
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	// 执行期间显示进度弹窗，暂停时关闭弹窗以便查看结果，继续时重新打开
+	progress := a.startProgress(progressTitle, cancel)
+	go func() {
+		defer cancel()
+		defer func() {
+			if progress != nil {
+				progress.finish(nil)
+			}
+		}()
+		for i, target := range targets {
+			if ctx.Err() != nil {
+				return
+			}
+			progress.update(tr("sqlfile.progress_target", target.Conn.Name, i+1, len(targets)), int64(i), int64(len(targets)))
+			start := time.Now()
+			output, err := execSQLFile(ctx, path, target)
+			elapsed := formatDuration(time.Since(start))
+			if ctx.Err() != nil {
+				a.app.QueueUpdateDraw(func() {
+					table.SetTitle(tr("sqlfile.cancelled", filepath.Base(path)))
+				})
+				return
+			}
+			result := tr("common.succeeded")
+			if err != nil {
+				result = tr("common.failed")
+			}
+			summary := firstLine(output, err)
+			row := i + 1
+			remaining := len(targets) - row
+			a.app.QueueUpdateDraw(func() {
+				table.SetCell(row, 2, tview.NewTableCell(result).SetExpansion(1))
+				table.SetCell(row, 3, tview.NewTableCell(elapsed).SetExpansion(1))
+				table.SetCell(row, 4, tview.NewTableCell(tview.Escape(summary)).SetExpansion(1))
+				if err != nil && remaining > 0 {
+					paused = true
+					table.SetTitle(tr("sqlfile.paused", target.Conn.Name, remaining)).
+						SetBorderColor(tcell.ColorRed)
+				}
+			})
+			if err != nil && remaining > 0 {
+				progress.finish(nil)
+				progress = nil
+				select {
+				case progress = <-resume:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+}
+
+// 通过客户端的标准输入对单个目标执行SQL文件，ctx 取消时终止客户端
+func execSQLFile(ctx context.Context, path string, target connTarget) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	conn, err := clientEndpoint(target)
+	if err != nil {
+		return "", err
+	}
+	base := batchClientCommand(target.Module, conn)
+	args := base[1:]
+	if moduleType(target.Module) == "PostgreSQL" {
+		args = append(args, "-v", "ON_ERROR_STOP=1")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sqlFileTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, base[0], args...)
+	cmd.Env = clientEnv(target)
+	cmd.Stdin = file
+	output, err := cmd.CombinedOutput()
+	return string(output), err
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// Include 嵌套的最大深度
+const sshConfigMaxDepth = 8
+
+// ~/.ssh/config 中的一个主机
+type sshConfigHost struct {
+	Conn  Connection // 解析出的连接（名称为 Host 别名）
+	Known bool       // 清单中是否已有同名 SSH 连接
+}
+
+// ssh 配置文件路径（配置项 ssh_config.path，默认 ~/.ssh/config）
+func sshConfigPath() string {
+	if path := configString("ssh_config.path"); path != "" {
+		return expandHome(path)
+	}
+	return expandHome("~/.ssh/config")
+}
+
+// 解析 ssh 配置中的主机：每个不含通配符的 Host 别名生成一个连接，同一选项以第一次出现的值为准（与 ssh 一致）
+func parseSSHConfig(ctx context.Context, path string) ([]Connection, error) {
+	var conns []Connection
+	index := make(map[string]int)
+	var current []string // 当前 Host 块中的别名
+	err := readSSHConfig(ctx, path, 0, func(key, value string) {
+		if key == "host" {
+			current = nil
+			for _, alias := range strings.Fields(value) {
+				if strings.ContainsAny(alias, "*?!") {
+					continue
+				}
+				if _, ok := index[alias]; !ok {
+					index[alias] = len(conns)
+					conns = append(conns, Connection{Name: alias, Status: "disconnected"})
+				}
+				current = append(current, alias)
+			}
+			return
+		}
+		if key == "match" {
+			current = nil // Match 块的条件无法静态判断，忽略
+			return
+		}
+		for _, alias := range current {
+			applySSHOption(&conns[index[alias]], key, value)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range conns {
+		if conns[i].Host == "" {
+			conns[i].Host = conns[i].Name
+		}
+		if conns[i].Port == 0 {
+			conns[i].Port = 22
+		}
+	}
+	return conns, nil
+}
+
+// 逐行读取 ssh 配置（展开 Include），对每个选项调用 visit，键名转为小写；ctx 取消后不再读取后续文件
+func readSSHConfig(ctx context.Context, path string, depth int, visit func(key, value string)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if depth > sshConfigMaxDepth {
+		return errors.New(tr("sshconfig.include_depth", path))
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		// 选项与值之间可以是空白或等号
+		key, value, found := strings.Cut(line, "=")
+		if i := strings.IndexAny(line, " \t"); i >= 0 && (!found || i < len(key)) {
+			key, value = line[:i], line[i+1:]
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "=")), `"`)
+		if key != "include" {
+			visit(key, value)
+			continue
+		}
+		// 相对路径相对于 ~/.ssh
+		for _, pattern := range strings.Fields(value) {
+			pattern = expandHome(pattern)
+			if !filepath.IsAbs(pattern) {
+				pattern = expandHome("~/.ssh/" + pattern)
+			}
+			matches, _ := filepath.Glob(pattern)
+			for _, match := range matches {
+				if err := readSSHConfig(ctx, match, depth+1, visit); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// 将 ssh 选项设置到连接上，已设置的字段不覆盖
+func applySSHOption(conn *Connection, key, value string) {
+	switch key {
+	case "hostname":
+		if conn.Host == "" {
+			conn.Host = value
+		}
+	case "user":
+		if conn.User == "" {
+			conn.User = value
+		}
+	case "port":
+		if conn.Port == 0 {
+			conn.Port, _ = strconv.Atoi(value)
+		}
+	case "identityfile":
+		if conn.IdentityFile == "" {
+			conn.IdentityFile = value
+		}
+	case "proxyjump":
+		if conn.ProxyCommand == "" && !strings.EqualFold(value, "none") {
+			conn.ProxyCommand = proxyJumpCommand(value)
+		}
+	case "proxycommand":
+		if conn.ProxyCommand == "" && !strings.EqualFold(value, "none") {
+			conn.ProxyCommand = value
+		}
+	}
+}
+
+// 将 ProxyJump（可能有多个逗号分隔的跳板）转换为等价的 ProxyCommand
+func proxyJumpCommand(value string) string {
+	hops := strings.Split(value, ",")
+	last := hops[len(hops)-1]
+	if len(hops) == 1 {
+		return "ssh -W %h:%p " + last
+	}
+	return "ssh -J " + strings.Join(hops[:len(hops)-1], ",") + " -W %h:%p " + last
+}
+
+// 读取 ssh 配置中的主机，并标记清单中已有的同名 SSH 连接
+func loadSSHConfigHosts(ctx context.Context) ([]sshConfigHost, error) {
+	conns, err := parseSSHConfig(ctx, sshConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool)
+	for _, target := range inventoryTargets(configuredModules()) {
+		if moduleType(target.Module) == "SSH" {
+			known[target.Conn.Name] = true
+		}
+	}
+	hosts := make([]sshConfigHost, len(conns))
+	for i, conn := range conns {
+		hosts[i] = sshConfigHost{Conn: conn, Known: known[conn.Name]}
+	}
+	return hosts, nil
+}
+
+// 计算导入后新增的连接：放入配置的项目和环境（配置项 ssh_config.project、ssh_config.env，默认第一个 SSH 模块的第一个环境），已存在的主机不导入
+func planSSHConfigImport(hosts []sshConfigHost) ([]inventoryEntry, error) {
+	place, ok := placeConnection("SSH", configString("ssh_config.project"), configString("ssh_config.env"))
+	if !ok {
+		return nil, errors.New(tr("sshconfig.no_env"))
+	}
+	var entries []inventoryEntry
+	for _, host := range hosts {
+		if host.Known {
+			continue
+		}
+		entry := place
+		entry.Conn = host.Conn
+		entry.Conn.Tags = []string{"ssh_config"}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// 将主机导入清单并写入审计日志
+func importSSHConfigHosts(hosts []sshConfigHost) ([]inventoryEntry, error) {
+	entries, err := planSSHConfigImport(hosts)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	if err := saveNewConnections(entries); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		recordAudit(auditEvent{Action: "import", Target: entry.ID(), Detail: tr("sshconfig.audit_from") + sshConfigPath()})
+	}
+	return entries, nil
+}
+
+// 启动时导入 ssh 配置中的新主机（配置项 ssh_config.import_on_start）
+func (a *App) importSSHConfigOnStart() {
+	if !configBool("ssh_config.import_on_start") {
+		return
+	}
+	hosts, err := loadSSHConfigHosts(context.Background())
+	if err == nil {
+		var entries []inventoryEntry
+		if entries, err = importSSHConfigHosts(hosts); err == nil && len(entries) > 0 {
+			a.statusBar.SetText(importedMessage(len(entries)))
+		}
+	}
+	if err != nil && !os.IsNotExist(err) {
+		a.statusBar.SetText(tr("sshconfig.import_failed", tview.Escape(err.Error())))
+	}
+}
+
+// 导入结果提示
+func importedMessage(count int) string {
+	if reviewMode() {
+		return tr("sshconfig.staged", count)
+	}
+	return tr("sshconfig.imported", count)
+}
+
+// 在进度弹窗中读取 ssh 配置（Include 的文件可能位于网络文件系统上），完成后显示其中的主机
+func (a *App) showSSHConfigImport() {
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := a.startProgress(tr("sshconfig.preview_title"), cancel)
+	progress.update(sshConfigPath(), 0, 0)
+	go func() {
+		defer cancel()
+		hosts, err := loadSSHConfigHosts(ctx)
+		progress.finish(func() {
+			switch {
+			case ctx.Err() != nil:
+				a.statusBar.SetText(tr("sshconfig.cancelled"))
+			case err != nil:
+				a.statusBar.SetText(tr("sshconfig.read_failed", tview.Escape(err.Error())))
+			case len(hosts) == 0:
+				a.statusBar.SetText(tr("sshconfig.no_hosts", tview.Escape(sshConfigPath())))
+			default:
+				a.showSSHConfigHosts(hosts)
+			}
+		})
+	}()
+}
+
+// 显示 ssh 配置中的主机：Space 标记，Enter 预览导入标记的（或当前）主机，A 预览导入全部新主机
+func (a *App) showSSHConfigHosts(hosts []sshConfigHost) {
+
+	table := tview.NewTable().
+		SetBorders(false).
+		SetFixed(1, 0).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("sshconfig.title", sshConfigPath(), len(hosts))).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+
+	marked := make(map[int]bool)
+	render := func() {
+		rows := [][]string{{"", tr("common.col_name"), tr("common.col_address"), tr("common.col_user"), tr("field.identity_file"), tr("field.proxy_command")}}
+		for i, host := range hosts {
+			mark := " "
+			switch {
+			case host.Known:
+				mark = "="
+			case marked[i]:
+				mark = "*"
+			}
+			conn := host.Conn
+			rows = append(rows, []string{mark, conn.Name, fmt.Sprintf("%s:%d", conn.Host, conn.Port), conn.User, conn.IdentityFile, conn.ProxyCommand})
+		}
+		row, _ := table.GetSelection()
+		fillTable(table, rows)
+		table.Select(max(row, 1), 0)
+	}
+	render()
+
+	add := func(indexes []int) {
+		var selected []sshConfigHost
+		for _, i := range indexes {
+			selected = append(selected, hosts[i])
+		}
+		planned, err := planSSHConfigImport(selected)
+		if err != nil {
+			a.statusBar.SetText(tr("common.import_failed", tview.Escape(err.Error())))
+			return
+		}
+		preview := changePreview{Title: tr("sshconfig.preview_title"), Header: connectionPreviewHeader(), Rows: connectionPreviewRows(planned), Empty: tr("sshconfig.nothing_new")}
+		a.showPreview(preview, func() {
+			entries, err := importSSHConfigHosts(selected)
+			if err != nil {
+				a.statusBar.SetText(tr("common.import_failed", tview.Escape(err.Error())))
+				return
+			}
+			for _, i := range indexes {
+				hosts[i].Known = true
+				delete(marked, i)
+			}
+			render()
+			a.updateMainPanel()
+			a.statusBar.SetText(importedMessage(len(entries)))
+		})
+	}
+
+	a.pushOverlay(table, table, func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := table.GetSelection()
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case event.Key() == tcell.KeyEnter:
+			var indexes []int
+			for i := range hosts {
+				if marked[i] {
+					indexes = append(indexes, i)
+				}
+			}
+			if len(indexes) == 0 && row >= 1 {
+				indexes = []int{row - 1}
+			}
+			add(indexes)
+			return nil
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			if row >= 1 && !hosts[row-1].Known {
+				marked[row-1] = !marked[row-1]
+				render()
+			}
+			return nil
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'a' || event.Rune() == 'A'):
+			indexes := make([]int, len(hosts))
+			for i := range hosts {
+				indexes[i] = i
+			}
+			add(indexes)
+			return nil
+		}
+		return event
+	})
+}
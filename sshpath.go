@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 解析 ssh -G 的超时时间
+const sshConfigTimeout = 3 * time.Second
+
+// 展开跳板机自身跳板的最大层数，防止配置循环
+const maxJumpDepth = 5
+
+// 路径分隔符
+const pathSeparator = " → "
+
+// ssh 日志中认证成功的记录，经 ProxyCommand 连接时地址为 via proxy
+var sshAuthenticatedPattern = regexp.MustCompile(`Authenticated to (\S+) \((?:\[([^\]]+)\]:(\d+)|via proxy)\)`)
+
+// 执行 ssh -G 获取生效的配置（键为小写）
+func sshEffectiveConfig(client string, args []string) map[string]string {
+	ctx, cancel := context.WithTimeout(context.Background(), sshConfigTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, client, append([]string{"-G"}, args...)...).Output()
+	if err != nil {
+		return nil
+	}
+	config := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		if key, value, ok := strings.Cut(line, " "); ok {
+			config[key] = value
+		}
+	}
+	return config
+}
+
+// 按 ssh 配置格式化主机：用户@主机:端口（默认端口省略）
+func formatHop(config map[string]string, fallback string) string {
+	host := config["hostname"]
+	if host == "" {
+		return fallback
+	}
+	if port := config["port"]; port != "" && port != "22" {
+		host += ":" + port
+	}
+	if user := config["user"]; user != "" {
+		host = user + "@" + host
+	}
+	return host
+}
+
+// 展开跳板机：先展开该跳板机自身配置的跳板，再追加它本身
+func expandJump(client, jump string, depth int) []string {
+	config := sshEffectiveConfig(client, []string{jump})
+	var hops []string
+	if upstream := config["proxyjump"]; upstream != "" && upstream != "none" && depth < maxJumpDepth {
+		for _, hop := range strings.Split(upstream, ",") {
+			hops = append(hops, expandJump(client, hop, depth+1)...)
+		}
+	} else if command := config["proxycommand"]; command != "" && command != "none" {
+		hops = append(hops, tr("sshpath.proxy")+strings.Fields(command)[0])
+	}
+	return append(hops, formatHop(config, jump))
+}
+
+// 本机名称
+func workstationName() string {
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return tr("sshpath.local")
+}
+
+// 根据传输方式与 ssh 生效配置推算会话的连接路径（本机 → 跳板/代理 → 目标）
+func plannedPath(target connTarget, args []string) []string {
+	hops := []string{workstationName()}
+	transport := resolveTransport(target)
+	switch transport.kind() {
+	case transportTeleport:
+		proxy := "Teleport"
+		if transport.Proxy != "" {
+			proxy += " " + transport.Proxy
+		}
+		if transport.Cluster != "" {
+			proxy += " (" + transport.Cluster + ")"
+		}
+		return append(hops, proxy, target.Conn.Host)
+	case transportSSM:
+		hops = append(hops, "AWS SSM")
+	case transportCF:
+		hops = append(hops, "Cloudflare Access")
+	case transportIAP:
+		hops = append(hops, "GCP IAP")
+	}
+
+	config := sshEffectiveConfig(args[0], args[1:])
+	if !transport.tunneled() {
+		if chain, err := jumpChain(target); err == nil && len(chain) > 0 && target.Conn.ProxyCommand == "" {
+			for _, hop := range chain {
+				hops = append(hops, jumpHopLabel(hop))
+			}
+		} else if jumps := config["proxyjump"]; jumps != "" && jumps != "none" {
+			for _, jump := range strings.Split(jumps, ",") {
+				hops = append(hops, expandJump(args[0], jump, 1)...)
+			}
+		} else if command := config["proxycommand"]; command != "" && command != "none" {
+			hops = append(hops, tr("sshpath.proxy")+strings.Fields(command)[0])
+		}
+	}
+	return append(hops, formatHop(config, target.Conn.Host))
+}
+
+// 用 ssh 日志中认证成功的实际地址补全路径的最后一跳，没有认证记录时返回原路径
+func realizedPath(planned []string, logPath string) []string {
+	file, err := os.Open(logPath)
+	if err != nil {
+		return planned
+	}
+	defer file.Close()
+	var match []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if m := sshAuthenticatedPattern.FindStringSubmatch(scanner.Text()); m != nil {
+			match = m
+		}
+	}
+	if match == nil || match[2] == "" || len(planned) == 0 {
+		return planned
+	}
+	path := append([]string(nil), planned...)
+	if last := path[len(path)-1]; !strings.Contains(last, match[2]) {
+		path[len(path)-1] = fmt.Sprintf("%s [%s:%s]", last, match[2], match[3])
+	}
+	return path
+}
+
+// 获取目标最近一次会话记录的连接路径
+func latestSessionPath(target connTarget) []string {
+	events := loadAuditEvents()
+	id := target.ID()
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Action == "session" && events[i].Target == id && len(events[i].Path) > 0 {
+			return events[i].Path
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// 从命令行参数中取出 --start 选项，未指定时使用环境变量 CM_START
+func parseStartFlag(args []string) (string, []string) {
+	start := os.Getenv("CM_START")
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--start" && i+1 < len(args):
+			start = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--start="):
+			start = strings.TrimPrefix(arg, "--start=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return start, rest
+}
+
+// 拆分启动位置中的工作区前缀：“工作区:路径”，只写“工作区:”时进入该工作区的模块栏
+func splitStartWorkspace(start string) (workspace string, path string, ok bool) {
+	workspace, path, ok = strings.Cut(start, ":")
+	if !ok || strings.HasPrefix(start, "?") {
+		return "", start, false
+	}
+	return workspace, path, true
+}
+
+// 解析启动路径：模块/项目/环境/连接，模块之后的各段按顺序匹配节点路径中的某一段（不区分大小写的子串，环境支持 prod 等英文别名），
+// 最后一段必须匹配节点自身的名称；有多个节点匹配时取层级最浅、在树中最靠前的
+func resolveStartPath(entries []paletteEntry, path string) (paletteEntry, bool) {
+	query := strings.Split(strings.Trim(path, "/"), "/")
+	var best paletteEntry
+	found := false
+	for _, entry := range entries {
+		segments := strings.Split(entry.Path, "/")
+		if !matchModule(segments[0], query[0]) || !matchSegments(segments[1:], query[1:]) {
+			continue
+		}
+		if !found || entry.Level < best.Level {
+			best, found = entry, true
+		}
+	}
+	return best, found
+}
+
+// 查询的各段是否按顺序匹配节点路径的各段，且最后一段匹配节点自身
+func matchSegments(segments, query []string) bool {
+	if len(query) == 0 {
+		return len(segments) == 0
+	}
+	if len(segments) == 0 || !matchEnv(segments[len(segments)-1], query[len(query)-1]) {
+		return false
+	}
+	next := 0
+	for _, segment := range segments[:len(segments)-1] {
+		if next < len(query)-1 && matchEnv(segment, query[next]) {
+			next++
+		}
+	}
+	return next == len(query)-1
+}
+
+// 配置项 start 中的启动位置；带有其他工作区的前缀时切换到该工作区
+func configuredStart() (string, error) {
+	workspace, path, ok := splitStartWorkspace(configString("start"))
+	if ok && workspace != activeWorkspace {
+		return path, loadConfig(workspace)
+	}
+	return path, nil
+}
+
+// 定位到启动位置（命令行 --start 或配置项 start）：模块、树中的节点，或以 ? 开头时打开预填关键字的跳转面板
+func (a *App) applyStartTarget(start string) {
+	if query, ok := strings.CutPrefix(start, "?"); ok {
+		a.showPalette(query)
+		return
+	}
+	if start == "" {
+		return
+	}
+	entry, ok := resolveStartPath(a.paletteEntries(), start)
+	if !ok {
+		a.statusBar.SetText(tr("startup.not_found", tview.Escape(start)))
+		return
+	}
+	a.focusNode(entry)
+}
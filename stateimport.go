@@ -0,0 +1,460 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rivo/tview"
+)
+
+// 基础设施状态中的单个资源实例
+type stateResource struct {
+	Type  string         // 资源类型（Terraform 命名，如 aws_instance）
+	Name  string         // 资源名称
+	Attrs map[string]any // 资源属性
+}
+
+// terraform show -json 输出中的模块
+type showModule struct {
+	Resources []struct {
+		Mode   string         `json:"mode"`
+		Type   string         `json:"type"`
+		Name   string         `json:"name"`
+		Index  any            `json:"index"`
+		Values map[string]any `json:"values"`
+	} `json:"resources"`
+	ChildModules []showModule `json:"child_modules"`
+}
+
+// 兼容 Terraform 状态文件、terraform show -json 与 pulumi stack export 三种格式
+type stateDocument struct {
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   any            `json:"index_key"`
+			Attributes map[string]any `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+	Values *struct {
+		RootModule showModule `json:"root_module"`
+	} `json:"values"`
+	Deployment *struct {
+		Resources []struct {
+			URN     string         `json:"urn"`
+			Type    string         `json:"type"`
+			Outputs map[string]any `json:"outputs"`
+		} `json:"resources"`
+	} `json:"deployment"`
+}
+
+// Pulumi 资源类型到 Terraform 资源类型的对应关系
+var pulumiTypes = map[string]string{
+	"aws:ec2/instance:Instance":                             "aws_instance",
+	"aws:rds/instance:Instance":                             "aws_db_instance",
+	"aws:rds/cluster:Cluster":                               "aws_rds_cluster",
+	"aws:elasticache/cluster:Cluster":                       "aws_elasticache_cluster",
+	"aws:elasticache/replicationGroup:ReplicationGroup":     "aws_elasticache_replication_group",
+	"aws:lb/loadBalancer:LoadBalancer":                      "aws_lb",
+	"aws:alb/loadBalancer:LoadBalancer":                     "aws_lb",
+	"aws:elb/loadBalancer:LoadBalancer":                     "aws_elb",
+	"gcp:compute/instance:Instance":                         "google_compute_instance",
+	"gcp:sql/databaseInstance:DatabaseInstance":             "google_sql_database_instance",
+	"gcp:compute/forwardingRule:ForwardingRule":             "google_compute_forwarding_rule",
+	"azure-native:compute:VirtualMachine":                   "azurerm_linux_virtual_machine",
+	"digitalocean:index/droplet:Droplet":                    "digitalocean_droplet",
+	"hcloud:index/server:Server":                            "hcloud_server",
+	"azure:postgresql/flexibleServer:FlexibleServer":        "azurerm_postgresql_flexible_server",
+	"azure:mysql/flexibleServer:FlexibleServer":             "azurerm_mysql_flexible_server",
+	"azure:compute/linuxVirtualMachine:LinuxVirtualMachine": "azurerm_linux_virtual_machine",
+}
+
+// 解析状态文件中的资源实例（只保留托管资源）
+func parseStateResources(data []byte) ([]stateResource, error) {
+	var doc stateDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf(tr("stateimport.parse_failed"), err)
+	}
+	var resources []stateResource
+	switch {
+	case doc.Deployment != nil:
+		for _, r := range doc.Deployment.Resources {
+			if tfType, ok := pulumiTypes[r.Type]; ok {
+				name := r.URN[strings.LastIndex(r.URN, "::")+2:]
+				resources = append(resources, stateResource{Type: tfType, Name: name, Attrs: r.Outputs})
+			}
+		}
+	case doc.Values != nil:
+		var walk func(m showModule)
+		walk = func(m showModule) {
+			for _, r := range m.Resources {
+				if r.Mode == "managed" {
+					resources = append(resources, stateResource{Type: r.Type, Name: indexedName(r.Name, r.Index), Attrs: r.Values})
+				}
+			}
+			for _, child := range m.ChildModules {
+				walk(child)
+			}
+		}
+		walk(doc.Values.RootModule)
+	default:
+		for _, r := range doc.Resources {
+			if r.Mode != "managed" {
+				continue
+			}
+			for _, instance := range r.Instances {
+				resources = append(resources, stateResource{Type: r.Type, Name: indexedName(r.Name, instance.IndexKey), Attrs: instance.Attributes})
+			}
+		}
+	}
+	return resources, nil
+}
+
+// 为 count/for_each 创建的实例名称附加索引
+func indexedName(name string, index any) string {
+	switch index := index.(type) {
+	case nil:
+		return name
+	case float64:
+		return fmt.Sprintf("%s-%d", name, int(index))
+	default:
+		return fmt.Sprintf("%s-%v", name, index)
+	}
+}
+
+// 将下划线命名转换为 Pulumi 使用的驼峰命名
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// 按点分路径读取属性（数字段表示列表下标），同时尝试驼峰命名
+func attrValue(attrs map[string]any, path string) any {
+	var current any = attrs
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			value, ok := node[segment]
+			if !ok {
+				value = node[camelCase(segment)]
+			}
+			current = value
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index >= len(node) {
+				return nil
+			}
+			current = node[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// 返回第一个非空的字符串属性
+func attrString(attrs map[string]any, paths ...string) string {
+	for _, path := range paths {
+		switch value := attrValue(attrs, path).(type) {
+		case string:
+			if value != "" {
+				return value
+			}
+		case float64:
+			if value != 0 {
+				return strconv.FormatFloat(value, 'f', -1, 64)
+			}
+		}
+	}
+	return ""
+}
+
+// 根据数据库引擎名称推断模块类型
+func engineType(engine string) string {
+	engine = strings.ToLower(engine)
+	switch {
+	case strings.Contains(engine, "postgres"):
+		return "PostgreSQL"
+	case strings.Contains(engine, "mysql"), strings.Contains(engine, "mariadb"), engine == "aurora":
+		return "MySQL"
+	case strings.Contains(engine, "redis"), strings.Contains(engine, "valkey"):
+		return "Redis"
+	}
+	return ""
+}
+
+// 将资源转换为连接，返回对应的模块类型；不是可连接资源时返回空类型
+func stateConnection(r stateResource) (string, Connection) {
+	a := r.Attrs
+	var kind, host, port string
+	conn := Connection{Status: "disconnected"}
+	switch r.Type {
+	case "aws_instance", "aws_spot_instance_request":
+		kind, host = "SSH", attrString(a, "public_ip", "private_ip", "public_dns", "private_dns")
+	case "google_compute_instance":
+		kind, host = "SSH", attrString(a, "network_interface.0.access_config.0.nat_ip", "network_interface.0.network_ip")
+	case "azurerm_linux_virtual_machine", "azurerm_virtual_machine":
+		kind, host = "SSH", attrString(a, "public_ip_address", "private_ip_address")
+		conn.User = attrString(a, "admin_username")
+	case "digitalocean_droplet", "hcloud_server":
+		kind, host = "SSH", attrString(a, "ipv4_address", "ipv4_address_private")
+	case "aws_lb", "aws_alb", "aws_elb":
+		kind, host = "SSH", attrString(a, "dns_name")
+	case "google_compute_forwarding_rule":
+		kind, host = "SSH", attrString(a, "ip_address")
+	case "aws_db_instance", "aws_rds_cluster":
+		kind, host, port = engineType(attrString(a, "engine")), attrString(a, "address", "endpoint"), attrString(a, "port")
+		conn.User = attrString(a, "username", "master_username")
+		conn.Database = attrString(a, "db_name", "database_name", "name")
+	case "google_sql_database_instance":
+		kind, host = engineType(attrString(a, "database_version")), attrString(a, "public_ip_address", "private_ip_address", "ip_address.0.ip_address")
+	case "azurerm_postgresql_flexible_server", "azurerm_postgresql_server":
+		kind, host = "PostgreSQL", attrString(a, "fqdn")
+		conn.User = attrString(a, "administrator_login")
+	case "azurerm_mysql_flexible_server", "azurerm_mysql_server":
+		kind, host = "MySQL", attrString(a, "fqdn")
+		conn.User = attrString(a, "administrator_login")
+	case "aws_elasticache_cluster":
+		kind, host, port = engineType(attrString(a, "engine")), attrString(a, "cache_nodes.0.address", "cluster_address"), attrString(a, "port", "cache_nodes.0.port")
+	case "aws_elasticache_replication_group":
+		kind, host, port = "Redis", attrString(a, "primary_endpoint_address", "configuration_endpoint_address"), attrString(a, "port")
+	case "google_redis_instance":
+		kind, host, port = "Redis", attrString(a, "host"), attrString(a, "port")
+	}
+	if kind == "" || host == "" {
+		return "", conn
+	}
+	conn.Host = host
+	conn.Port, _ = strconv.Atoi(port)
+	conn.Name = attrString(a, "tags.Name", "labels.name", "identifier", "cluster_identifier", "replication_group_id", "cluster_id", "name")
+	if conn.Name == "" {
+		conn.Name = r.Name
+	}
+	conn.Tags = stateTags(r)
+	return kind, conn
+}
+
+// 由资源类型和 tags/labels 属性生成连接标签
+func stateTags(r stateResource) []string {
+	tags := []string{"terraform", r.Type}
+	var pairs []string
+	for _, key := range []string{"tags", "labels"} {
+		values, _ := attrValue(r.Attrs, key).(map[string]any)
+		for k, v := range values {
+			if s, ok := v.(string); ok && k != "Name" {
+				pairs = append(pairs, k+"="+s)
+			}
+		}
+	}
+	sort.Strings(pairs)
+	return splitTags(strings.Join(append(tags, pairs...), ","))
+}
+
+// 读取状态数据：- 表示标准输入；目录中有 Pulumi.yaml 时执行 pulumi stack export，否则执行 terraform show -json，
+// ctx 取消时终止命令，命令失败时错误中带有其错误输出
+func readStateSource(ctx context.Context, source string) ([]byte, error) {
+	if source == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return os.ReadFile(source)
+	}
+	cmd := exec.CommandContext(ctx, "terraform", "show", "-json")
+	if _, err := os.Stat(filepath.Join(source, "Pulumi.yaml")); err == nil {
+		cmd = exec.CommandContext(ctx, "pulumi", "stack", "export")
+	}
+	cmd.Dir = source
+	data, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		err = fmt.Errorf("%w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+	}
+	return data, err
+}
+
+// 读取并解析状态中的资源
+func loadStateResources(ctx context.Context, source string) ([]stateResource, error) {
+	data, err := readStateSource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New(tr("stateimport.empty"))
+	}
+	return parseStateResources(data)
+}
+
+// 将资源分配到同类型的第一个模块，以及其中匹配的项目和环境
+func planStateImport(resources []stateResource, projectFilter, envFilter string) ([]inventoryEntry, []string) {
+	var entries []inventoryEntry
+	var skipped []string
+	for _, r := range resources {
+		kind, conn := stateConnection(r)
+		if kind == "" {
+			continue
+		}
+		entry, ok := placeConnection(kind, projectFilter, envFilter)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s.%s (%s)", r.Type, r.Name, kind))
+			continue
+		}
+		if conn.Port == 0 {
+			conn.Port = defaultPort(entry.Module)
+		}
+		if conn.User == "" {
+			conn.User = defaultUser(entry.Module)
+		}
+		entry.Conn = conn
+		entries = append(entries, entry)
+	}
+	return entries, skipped
+}
+
+// 查找用于存放指定类型连接的模块、项目和环境
+func placeConnection(kind, projectFilter, envFilter string) (inventoryEntry, bool) {
+	for _, module := range configuredModules() {
+		if moduleType(module) != kind {
+			continue
+		}
+		if entry, ok := placeInModule(module, projectFilter, envFilter); ok {
+			return entry, true
+		}
+	}
+	return inventoryEntry{}, false
+}
+
+// 在模块中查找第一个匹配的项目和环境
+func placeInModule(module, projectFilter, envFilter string) (inventoryEntry, bool) {
+	for _, project := range projectList(module) {
+		if projectFilter != "" && !strings.Contains(strings.ToLower(project.Name), strings.ToLower(projectFilter)) {
+			continue
+		}
+		for _, env := range projectEnvironments(module, project.Name) {
+			if matchEnv(env.Name, envFilter) {
+				return inventoryEntry{Module: module, Project: project.Name, Env: env.Name}, true
+			}
+		}
+	}
+	return inventoryEntry{}, false
+}
+
+// import 子命令：从 Terraform/Pulumi 状态导入连接
+func runImportCommand(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	project := flags.String("project", "", tr("stateimport.flag_project"))
+	env := flags.String("env", "", tr("stateimport.flag_env"))
+	dryRun := flags.Bool("dry-run", false, tr("stateimport.flag_dry_run"))
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, tr("stateimport.usage"))
+		return exitUsage
+	}
+	source := flags.Arg(0)
+
+	resources, err := loadStateResources(context.Background(), source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("stateimport.read_failed"), err)
+		return exitFailure
+	}
+
+	entries, skipped := planStateImport(resources, *project, *env)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, tr("stateimport.header"))
+	for _, entry := range entries {
+		fmt.Fprintf(w, "%s\t%s:%d\t%s\n", entry.ID(), entry.Conn.Host, entry.Conn.Port, strings.Join(entry.Conn.Tags, ","))
+	}
+	w.Flush()
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, tr("stateimport.skipped"), s)
+	}
+	if *dryRun || len(entries) == 0 {
+		fmt.Fprintf(out, tr("stateimport.total"), len(entries))
+		return exitOK
+	}
+
+	if err := saveNewConnections(entries); err != nil {
+		fmt.Fprintf(os.Stderr, tr("stateimport.save_failed"), err)
+		return exitFailure
+	}
+	for _, entry := range entries {
+		recordAudit(auditEvent{Action: "import", Target: entry.ID(), Detail: tr("sshconfig.audit_from") + source})
+	}
+	if reviewMode() {
+		fmt.Fprintf(out, tr("stateimport.staged"), len(entries))
+	} else {
+		fmt.Fprintf(out, tr("stateimport.imported"), len(entries))
+	}
+	return exitOK
+}
+
+// 从状态文件或 Terraform/Pulumi 目录导入连接：在进度弹窗中读取状态（可取消），预览后保存到各模块的第一个匹配项目
+func (a *App) showStateImport() {
+	a.prompt(tr("stateimport.prompt_title"), tr("stateimport.prompt_label"), "", func(source string) {
+		source = expandHome(strings.TrimSpace(source))
+		if source == "" || source == "-" {
+			a.statusBar.SetText(tr("stateimport.need_path"))
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		progress := a.startProgress(tr("stateimport.progress_title"), cancel)
+		progress.update(source, 0, 0)
+		go func() {
+			defer cancel()
+			resources, err := loadStateResources(ctx, source)
+			progress.finish(func() {
+				switch {
+				case ctx.Err() != nil:
+					a.statusBar.SetText(tr("stateimport.cancelled"))
+					return
+				case err != nil:
+					a.statusBar.SetText(tr("common.import_failed", tview.Escape(err.Error())))
+					return
+				}
+				entries, skipped := planStateImport(resources, "", "")
+				preview := changePreview{Title: tr("stateimport.progress_title"), Header: connectionPreviewHeader(), Rows: connectionPreviewRows(entries), Empty: tr("stateimport.nothing")}
+				a.showPreview(preview, func() {
+					if err := saveNewConnections(entries); err != nil {
+						a.statusBar.SetText(tr("common.save_failed", tview.Escape(err.Error())))
+						return
+					}
+					for _, entry := range entries {
+						recordAudit(auditEvent{Action: "import", Target: entry.ID(), Detail: tr("sshconfig.audit_from") + source})
+					}
+					a.updateMainPanel()
+					message := tr("common.added_connections", len(entries))
+					if reviewMode() {
+						message = tr("common.staged_connections", len(entries))
+					}
+					if len(skipped) > 0 {
+						message += tr("stateimport.skipped_count", len(skipped))
+					}
+					a.statusBar.SetText(message)
+				})
+			})
+		}()
+	})
+}
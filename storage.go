@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// 获取当前工作区的数据目录（默认工作区为 $HOME/.connectionmanager），不存在时自动创建
+func dataDir() (string, error) {
+	configMu.RLock()
+	workspace := activeWorkspace
+	configMu.RUnlock()
+	dir, err := workspaceDir(workspace)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// 向数据目录下的JSON Lines文件追加一条记录
+func appendJSONLine(name string, v any) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// 读取数据目录下JSON Lines文件中的所有记录，文件不存在时返回空列表
+func readJSONLines[T any](name string) ([]T, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []T
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // 跳过损坏的行
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// 读取数据目录下的JSON文件，文件不存在时保持 v 不变
+func readJSONFile(name string, v any) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// 将 v 写入数据目录下的JSON文件（先写临时文件再重命名，避免写入中断导致文件损坏）
+func writeJSONFile(name string, v any) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
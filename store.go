@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// 连接数据模型：示例数据、配置清单（inventory）、新增连接（connections.json）、删除记录（removed）和字段覆盖（overrides.json）
+// 合并后的项目 -> 环境 -> 连接。每个模块在首次使用时构建一次，任一来源变化时作废，下次使用时重建；
+// Mesh 节点、服务发现的连接和会话状态随时变化，在读取连接列表时再加入
+type storeModule struct {
+	Projects []storeProject
+	Removed  []string // 已删除的节点路径，同样作用于服务发现的连接
+}
+
+// 数据模型中的项目
+type storeProject struct {
+	Name string
+	Demo bool // 示例项目
+	Envs []storeEnv
+}
+
+// 数据模型中的环境；连接名称在环境中唯一，树节点和连接标识都按名称定位
+type storeEnv struct {
+	Name  string
+	Demo  bool         // 示例环境
+	Conns []Connection // 已应用字段覆盖，读取方不能修改
+}
+
+var (
+	storeMu      sync.Mutex
+	storeCache   map[string]*storeModule // 模块 -> 已构建的数据模型
+	storeBuiltAt uint64                  // 构建 storeCache 时的版本
+	storeVersion atomic.Uint64           // 数据来源每变化一次加一
+)
+
+// 作废已构建的数据模型（清单、新增连接、字段覆盖或配置变化时调用）；只修改版本号，持有其他锁时也可以调用
+func invalidateStore() {
+	storeVersion.Add(1)
+}
+
+// 模块的数据模型，数据来源变化后重新构建
+func loadStore(module string) *storeModule {
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	if version := storeVersion.Load(); storeCache == nil || storeBuiltAt != version {
+		storeCache = make(map[string]*storeModule)
+		storeBuiltAt = version
+	}
+	m, ok := storeCache[module]
+	if !ok {
+		m = buildStore(module)
+		storeCache[module] = m
+	}
+	return m
+}
+
+// 合并模块的各个数据来源：示例项目在前，随后是清单中的项目和只出现在新增连接中的项目；
+// 同名连接只保留一个：真实连接取代同名的示例连接，清单中的连接优先于新增连接
+func buildStore(module string) *storeModule {
+	inventory := lookupFold(inventoryConfig(), module)
+	m := &storeModule{Removed: slices.Clone(inventory.Removed)}
+	if demoDataEnabled() {
+		for _, project := range builtinProjects(module) {
+			if inventory.isRemoved(project.Name) {
+				continue
+			}
+			p := m.project(project.Name)
+			p.Demo = true
+			for _, env := range builtinEnvironments(module, project.Name) {
+				if !inventory.isRemoved(project.Name + "/" + env.Name) {
+					p.env(env.Name).Demo = true
+				}
+			}
+		}
+	}
+	for _, project := range inventory.Projects {
+		if inventory.isRemoved(project.Name) {
+			continue
+		}
+		p := m.project(project.Name)
+		for _, env := range project.Environments {
+			if inventory.isRemoved(project.Name + "/" + env.Name) {
+				continue
+			}
+			e := p.env(env.Name)
+			for _, conn := range env.Connections {
+				if conn.Name != "" {
+					e.add(conn.connection(module))
+				}
+			}
+		}
+	}
+	addedMu.Lock()
+	for _, entry := range loadAddedConnections() {
+		if entry.Module == module && !inventory.isRemoved(entry.Project) && !inventory.isRemoved(entry.Project+"/"+entry.Env) {
+			m.project(entry.Project).env(entry.Env).add(entry.Conn)
+		}
+	}
+	addedMu.Unlock()
+
+	for i := range m.Projects {
+		p := &m.Projects[i]
+		for j := range p.Envs {
+			e := &p.Envs[j]
+			if e.Demo {
+				// 示例连接排在前面，被同名的真实连接取代
+				demo := slices.DeleteFunc(demoConnections(module), e.has)
+				e.Conns = append(demo, e.Conns...)
+			}
+			e.Conns = m.filterRemoved(p.Name, e.Name, e.Conns)
+			e.Conns = applyOverrides(module, p.Name, e.Name, e.Conns)
+		}
+	}
+	return m
+}
+
+// 获取项目，不存在时追加
+func (m *storeModule) project(name string) *storeProject {
+	for i := range m.Projects {
+		if m.Projects[i].Name == name {
+			return &m.Projects[i]
+		}
+	}
+	m.Projects = append(m.Projects, storeProject{Name: name})
+	return &m.Projects[len(m.Projects)-1]
+}
+
+// 获取环境，不存在时追加
+func (p *storeProject) env(name string) *storeEnv {
+	for i := range p.Envs {
+		if p.Envs[i].Name == name {
+			return &p.Envs[i]
+		}
+	}
+	p.Envs = append(p.Envs, storeEnv{Name: name})
+	return &p.Envs[len(p.Envs)-1]
+}
+
+// 环境中是否已有同名连接
+func (e *storeEnv) has(conn Connection) bool {
+	return slices.ContainsFunc(e.Conns, func(c Connection) bool { return c.Name == conn.Name })
+}
+
+// 加入连接，已有同名连接时忽略
+func (e *storeEnv) add(conn Connection) {
+	if !e.has(conn) {
+		e.Conns = append(e.Conns, conn)
+	}
+}
+
+// 查找项目中的环境，不存在时返回 nil
+func (m *storeModule) findEnv(project, env string) *storeEnv {
+	for i := range m.Projects {
+		if m.Projects[i].Name != project {
+			continue
+		}
+		for j := range m.Projects[i].Envs {
+			if m.Projects[i].Envs[j].Name == env {
+				return &m.Projects[i].Envs[j]
+			}
+		}
+	}
+	return nil
+}
+
+// 去掉已删除的连接
+func (m *storeModule) filterRemoved(project, env string, conns []Connection) []Connection {
+	if len(m.Removed) == 0 {
+		return conns
+	}
+	return slices.DeleteFunc(conns, func(conn Connection) bool {
+		return slices.Contains(m.Removed, project+"/"+env+"/"+conn.Name)
+	})
+}
+
+// 是否显示内置的示例项目、环境和连接：只在交互界面中显示；配置了 demo_data 时按配置，
+// 否则在还没有真实清单（配置清单中的项目或新增连接）时显示，写入第一个项目或连接后自动隐藏
+func demoDataEnabled() bool {
+	if !interactiveUI {
+		return false
+	}
+	if configIsSet("demo_data") {
+		return configBool("demo_data")
+	}
+	return !hasRealInventory()
+}
+
+// 是否已有真实的连接清单：任一模块的配置清单中有项目，或数据目录中有新增连接（只有删除记录不算）
+func hasRealInventory() bool {
+	for _, inventory := range inventoryConfig() {
+		if len(inventory.Projects) > 0 {
+			return true
+		}
+	}
+	addedMu.Lock()
+	defer addedMu.Unlock()
+	return len(loadAddedConnections()) > 0
+}
+
+// 交互界面已启动；命令行子命令（check、report 等）只处理真实的连接，不包含示例数据
+var interactiveUI bool
+
+// 示例环境中的连接，状态由会话和健康检查决定
+func demoConnections(module string) []Connection {
+	port, user := defaultPort(module), defaultUser(module)
+	return []Connection{
+		{Name: fmt.Sprintf("%s-01", module), Status: "disconnected", Host: "127.0.0.1", Port: port, User: user},
+		{Name: fmt.Sprintf("%s-02", module), Status: "disconnected", Host: "127.0.0.1", Port: port, User: user},
+		{Name: fmt.Sprintf("%s-03", module), Status: "disconnected", Host: "127.0.0.1", Port: port, User: user},
+	}
+}
+
+// 环境中的连接：数据模型中的连接（Mesh 项目为对等节点）加上服务发现的连接，并应用会话状态；返回副本
+func envConnections(module, project, env string) []Connection {
+	m := loadStore(module)
+	var conns []Connection
+	if project == meshProjectName {
+		conns = applyOverrides(module, project, env, meshConnections(module))
+		return applySessionStatus(module, project, env, conns)
+	}
+	if e := m.findEnv(project, env); e != nil {
+		conns = slices.Clone(e.Conns)
+	}
+	if discovered := discoveredConnections(module, project, env); len(discovered) > 0 {
+		// 服务发现的连接与已有连接同名时忽略，保持连接名称在环境中唯一
+		existing := storeEnv{Conns: conns}
+		discovered = slices.DeleteFunc(m.filterRemoved(project, env, discovered), existing.has)
+		conns = append(conns, applyOverrides(module, project, env, discovered)...)
+	}
+	return applySessionStatus(module, project, env, conns)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"path"
+)
+
+// 操作目标：带有所属模块、项目和环境信息的连接
+type connTarget struct {
+	Module  string     // 所属模块
+	Project string     // 所属项目名称
+	Env     string     // 所属环境名称
+	Conn    Connection // 连接信息
+}
+
+// 获取指定模块中的全部连接目标
+func inventoryTargets(modules []string) []connTarget {
+	var targets []connTarget
+	for _, module := range modules {
+		for _, project := range projectList(module) {
+			for _, env := range projectEnvironments(module, project.Name) {
+				for _, conn := range envConnections(module, project.Name, env.Name) {
+					targets = append(targets, connTarget{Module: module, Project: project.Name, Env: env.Name, Conn: conn})
+				}
+			}
+		}
+	}
+	return targets
+}
+
+// 判断目标标识是否匹配模式（模块/项目/环境/连接，支持通配符，空模式匹配全部）
+func matchTargetPattern(pattern string, target connTarget) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, err := path.Match(pattern, target.ID())
+	return err == nil && matched
+}
+
+// 判断环境是否为受保护环境（配置项 protected_environments，默认仅生产环境）
+func isProtectedEnv(name string) bool {
+	protected := configStringSlice("protected_environments")
+	if len(protected) == 0 {
+		protected = []string{"生产环境"}
+	}
+	for _, env := range protected {
+		if env == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 切换当前连接的标记状态，用于多选操作
+func (a *App) toggleMark() {
+	if a.selected.Level != 2 {
+		return
+	}
+	key := a.selected.ID()
+	if a.markedConns[key] {
+		delete(a.markedConns, key)
+	} else {
+		a.markedConns[key] = true
+	}
+	a.updateMainPanel()
+}
+
+// 获取当前模块中的操作目标：优先使用已标记的连接，否则使用当前选中的连接
+func (a *App) selectedTargets() []connTarget {
+	module := a.modules[a.currentModule]
+	var targets []connTarget
+	for _, project := range a.getProjectList() {
+		for _, env := range a.getEnvironmentList(project.Name) {
+			for _, conn := range a.getConnectionList(project.Name, env.Name) {
+				target := connTarget{Module: module, Project: project.Name, Env: env.Name, Conn: conn}
+				if a.markedConns[target.ID()] {
+					targets = append(targets, target)
+				}
+			}
+		}
+	}
+	if len(targets) > 0 {
+		return targets
+	}
+
+	target, ok := a.currentTarget()
+	if !ok {
+		return nil
+	}
+	return []connTarget{target}
+}
+
+// 获取当前选中连接对应的操作目标
+func (a *App) currentTarget() (connTarget, bool) {
+	conn, ok := a.currentConnection()
+	if !ok {
+		return connTarget{}, false
+	}
+	return connTarget{
+		Module:  a.selected.Module,
+		Project: a.selected.Project,
+		Env:     a.selected.Env,
+		Conn:    conn,
+	}, true
+}
+
+// 目标的唯一标识，格式为 模块/项目/环境/连接
+func (t connTarget) ID() string {
+	return fmt.Sprintf("%s/%s/%s/%s", t.Module, t.Project, t.Env, t.Conn.Name)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 当前平台不支持伪终端时返回的错误
+var errPTYUnsupported error = messageError("termpane.unsupported")
+
+// 内嵌终端时连接树侧栏的宽度
+const paneSidebarWidth = 36
+
+// 内嵌终端中运行的程序看到的终端类型
+const paneTermType = "xterm-256color"
+
+// SSH 会话是否在主面板的内嵌终端中运行（配置项 ui.terminal 为 pane），默认挂起界面在整个终端中运行
+func embeddedTerminal() bool {
+	return configString("ui.terminal") == "pane"
+}
+
+// 主面板中运行 SSH 会话的内嵌终端
+type terminalPane struct {
+	*tview.Box
+	target  connTarget    // 会话对应的连接
+	screen  *termScreen   // 屏幕内容
+	master  *os.File      // 伪终端主设备
+	cmd     *exec.Cmd     // 会话进程
+	done    chan struct{} // 会话结束、处理完成后关闭
+	dirty   atomic.Bool   // 是否已请求重绘
+	focused bool          // 按键是否发送给该终端（此时显示光标）
+}
+
+// 绘制终端内容，区域大小变化时同步调整伪终端的窗口大小
+func (p *terminalPane) Draw(screen tcell.Screen) {
+	p.DrawForSubclass(screen, p)
+	x, y, width, height := p.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	p.screen.mu.Lock()
+	changed := p.screen.rows != height || p.screen.cols != width
+	p.screen.mu.Unlock()
+	if changed {
+		p.screen.resize(height, width)
+		setPTYSize(p.master, height, width)
+	}
+	cx, cy, visible := p.screen.draw(screen, x, y)
+	if visible && p.focused {
+		screen.ShowCursor(cx, cy)
+	}
+}
+
+// 把按键转换为发给终端程序的字节，Ctrl+] 留给界面切换焦点
+func (p *terminalPane) sendKey(event *tcell.EventKey) {
+	p.screen.mu.Lock()
+	appCursor := p.screen.appCursor
+	p.screen.mu.Unlock()
+	if data := terminalKeyBytes(event, appCursor); len(data) > 0 {
+		p.master.Write(data)
+	}
+}
+
+// 粘贴的内容整体发给终端程序：换行按回车发送，程序开启了括号粘贴模式时包上起止标记
+// （内容中的结束标记被去掉，避免粘贴内容提前结束粘贴模式后被当作按键执行）
+func (p *terminalPane) PasteHandler() func(pastedText string, setFocus func(p tview.Primitive)) {
+	return p.WrapPasteHandler(func(text string, _ func(tview.Primitive)) {
+		p.screen.mu.Lock()
+		bracketed := p.screen.bracketedPaste
+		p.screen.mu.Unlock()
+		p.master.Write(pasteBytes(text, bracketed))
+	})
+}
+
+// 粘贴内容对应的终端输入字节
+func pasteBytes(text string, bracketed bool) []byte {
+	text = strings.NewReplacer("\r\n", "\r", "\n", "\r").Replace(text)
+	if !bracketed {
+		return []byte(text)
+	}
+	return []byte("\x1b[200~" + strings.ReplaceAll(text, "\x1b[201~", "") + "\x1b[201~")
+}
+
+// 方向键等功能键对应的 xterm 转义序列
+var terminalKeySequences = map[tcell.Key]string{
+	tcell.KeyEnter:      "\r",
+	tcell.KeyTab:        "\t",
+	tcell.KeyBacktab:    "\x1b[Z",
+	tcell.KeyBackspace:  "\x7f",
+	tcell.KeyBackspace2: "\x7f", // 多数终端的退格键发送 DEL
+	tcell.KeyEsc:        "\x1b",
+	tcell.KeyHome:       "\x1b[H",
+	tcell.KeyEnd:        "\x1b[F",
+	tcell.KeyInsert:     "\x1b[2~",
+	tcell.KeyDelete:     "\x1b[3~",
+	tcell.KeyPgUp:       "\x1b[5~",
+	tcell.KeyPgDn:       "\x1b[6~",
+	tcell.KeyF1:         "\x1bOP",
+	tcell.KeyF2:         "\x1bOQ",
+	tcell.KeyF3:         "\x1bOR",
+	tcell.KeyF4:         "\x1bOS",
+	tcell.KeyF5:         "\x1b[15~",
+	tcell.KeyF6:         "\x1b[17~",
+	tcell.KeyF7:         "\x1b[18~",
+	tcell.KeyF8:         "\x1b[19~",
+	tcell.KeyF9:         "\x1b[20~",
+	tcell.KeyF10:        "\x1b[21~",
+	tcell.KeyF11:        "\x1b[23~",
+	tcell.KeyF12:        "\x1b[24~",
+}
+
+// 按键对应的终端输入字节；appCursor 为应用光标键模式时方向键使用 ESC O 前缀
+func terminalKeyBytes(event *tcell.EventKey, appCursor bool) []byte {
+	var prefix []byte
+	if event.Modifiers()&tcell.ModAlt != 0 {
+		prefix = []byte{0x1b}
+	}
+	switch key := event.Key(); {
+	case key == tcell.KeyRune:
+		return append(prefix, string(event.Rune())...)
+	case key == tcell.KeyUp || key == tcell.KeyDown || key == tcell.KeyRight || key == tcell.KeyLeft:
+		final := map[tcell.Key]byte{tcell.KeyUp: 'A', tcell.KeyDown: 'B', tcell.KeyRight: 'C', tcell.KeyLeft: 'D'}[key]
+		if appCursor {
+			return append(prefix, 0x1b, 'O', final)
+		}
+		return append(prefix, 0x1b, '[', final)
+	case terminalKeySequences[key] != "":
+		return append(prefix, terminalKeySequences[key]...)
+	case key >= tcell.KeyCtrlSpace && key <= tcell.KeyCtrlUnderscore:
+		// Ctrl+字母等控制字符，tcell 的键值即为对应的 ASCII 码
+		return append(prefix, byte(key))
+	}
+	return nil
+}
+
+// 程序写往标准错误的内容（不经过伪终端）在显示前补上回车
+type crlfWriter struct{ w io.Writer }
+
+func (c crlfWriter) Write(p []byte) (int, error) {
+	if _, err := c.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// 在内嵌终端中启动 SSH 会话：intro 中的行先显示在终端中，echoStderr 为 true 时标准错误同时显示在终端中；
+// 进程退出后在界面线程中关闭终端并调用 onExit。平台不支持伪终端或启动失败时返回错误
+func (a *App) openTerminalPane(target connTarget, cmd *exec.Cmd, intro []string, stderr *bytes.Buffer, echoStderr bool, onExit func(error)) (*terminalPane, error) {
+	pane := &terminalPane{
+		Box:    tview.NewBox(),
+		target: target,
+		screen: newTermScreen(24, 80),
+		cmd:    cmd,
+		done:   make(chan struct{}),
+	}
+	pane.SetBorder(true).
+		SetTitle(tr("pane.title", target.Conn.Name)).
+		SetTitleAlign(tview.AlignLeft)
+	for _, line := range intro {
+		pane.screen.Write([]byte(line + "\r\n"))
+	}
+
+	cmd.Env = append(slices.Clone(cmd.Environ()), "TERM="+paneTermType)
+	cmd.Stderr = stderr
+	if echoStderr {
+		cmd.Stderr = io.MultiWriter(crlfWriter{pane.screen}, stderr)
+	}
+	master, err := startPTY(cmd)
+	if err != nil {
+		return nil, err
+	}
+	pane.master = master
+	pane.screen.reply = func(data []byte) { master.Write(data) }
+	setPTYSize(master, 24, 80)
+
+	a.panes = append(a.panes, pane)
+	a.showPane(pane, true)
+
+	// 读取终端输出，合并重绘请求
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := master.Read(buf)
+			if n > 0 {
+				pane.screen.Write(buf[:n])
+				if pane.dirty.CompareAndSwap(false, true) {
+					a.app.QueueUpdateDraw(func() { pane.dirty.Store(false) })
+				}
+			}
+			if err != nil {
+				break
+			}
+		}
+	}()
+	go func() {
+		err := cmd.Wait()
+		master.Close()
+		a.app.QueueUpdateDraw(func() {
+			a.closePane(pane)
+			onExit(err)
+			close(pane.done)
+		})
+	}()
+	return pane, nil
+}
+
+// 连接正在内嵌终端中运行的会话
+func (a *App) paneFor(target connTarget) *terminalPane {
+	for _, pane := range a.panes {
+		if pane.target.ID() == target.ID() {
+			return pane
+		}
+	}
+	return nil
+}
+
+// 在主面板中显示内嵌终端，focus 为 true 时按键直接发送给终端
+func (a *App) showPane(pane *terminalPane, focus bool) {
+	if a.activePane != nil {
+		a.activePane.focused = false
+		a.activePane.SetBorderColor(tcell.ColorWhite)
+	}
+	a.activePane = pane
+	if !a.inTreeView {
+		a.currentModule = a.hoveredModule
+		a.inTreeView = true
+		a.updateModuleBar()
+	}
+	a.setPaneFocus(focus)
+	a.updateMainPanel()
+}
+
+// 切换按键发送给内嵌终端还是连接树
+func (a *App) setPaneFocus(focus bool) {
+	a.paneFocused = focus && a.activePane != nil
+	// 粘贴事件交给获得焦点的组件，内嵌终端获得焦点时由它接收粘贴内容
+	if len(a.overlays) == 0 {
+		if a.paneFocused {
+			a.app.SetFocus(a.activePane)
+		} else {
+			a.app.SetFocus(a.moduleBar)
+		}
+	}
+	if a.activePane == nil {
+		return
+	}
+	a.activePane.focused = a.paneFocused
+	if a.paneFocused {
+		a.activePane.SetBorderColor(tcell.ColorYellow)
+		a.statusBar.SetText(fmt.Sprintf("[yellow]%s[-]", tview.Escape(tr("pane.focused", a.activePane.target.Conn.Name))))
+		return
+	}
+	a.activePane.SetBorderColor(tcell.ColorWhite)
+	a.updateStatusBar()
+}
+
+// 会话结束后移除内嵌终端，主面板改为显示其余终端中最近打开的一个
+func (a *App) closePane(pane *terminalPane) {
+	a.panes = slices.DeleteFunc(a.panes, func(other *terminalPane) bool { return other == pane })
+	if a.activePane != pane {
+		return
+	}
+	a.activePane = nil
+	if len(a.panes) > 0 {
+		a.activePane = a.panes[len(a.panes)-1]
+	}
+	a.setPaneFocus(false)
+	a.updateMainPanel()
+}
+
+// 程序退出时结束全部内嵌终端中的会话
+func (a *App) closePanes() {
+	for _, pane := range a.panes {
+		if pane.cmd.Process != nil {
+			pane.cmd.Process.Kill()
+		}
+	}
+}
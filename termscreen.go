@@ -0,0 +1,589 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// 内嵌终端的一个字符单元，宽字符的后半格 Ch 为 0
+type termCell struct {
+	Ch    rune
+	Style tcell.Style
+}
+
+// 转义序列解析状态
+type termParseState int
+
+const (
+	termGround  termParseState = iota // 普通字符
+	termEscape                        // 收到 ESC
+	termCSI                           // ESC [ 控制序列
+	termOSC                           // ESC ] 操作系统命令（忽略内容）
+	termOSCEsc                        // OSC 中收到 ESC，等待 \ 结束
+	termCharset                       // ESC ( 等字符集选择，忽略下一个字节
+)
+
+// 内嵌终端的屏幕模型：解析 VT100/xterm 常用控制序列并维护字符网格，
+// 支持光标移动、擦除、滚动区域、插入删除行列、SGR 颜色属性与备用屏幕
+type termScreen struct {
+	mu sync.Mutex
+
+	rows, cols int
+	primary    [][]termCell
+	alternate  [][]termCell
+	altActive  bool // 是否在备用屏幕（全屏程序使用）
+
+	cx, cy         int         // 光标位置
+	savedX, savedY int         // ESC 7 / CSI s 保存的光标位置
+	style          tcell.Style // 当前字符样式
+	top, bottom    int         // 滚动区域（含两端）
+	wrapNext       bool        // 光标位于行尾，下一个字符先换行
+	cursorHidden   bool        // 程序隐藏了光标
+	appCursor      bool        // 方向键使用应用模式（ESC O A）
+	bracketedPaste bool        // 程序开启了括号粘贴模式，粘贴内容需包在 ESC [200~ 与 ESC [201~ 之间
+
+	state  termParseState
+	params []byte // CSI 参数
+	utf8   []byte // 未完整的 UTF-8 字节
+
+	reply func([]byte) // 回应终端查询（如光标位置报告）
+}
+
+// 创建指定大小的屏幕
+func newTermScreen(rows, cols int) *termScreen {
+	s := &termScreen{rows: rows, cols: cols, style: tcell.StyleDefault}
+	s.primary = s.blankGrid(rows, cols)
+	s.alternate = s.blankGrid(rows, cols)
+	s.top, s.bottom = 0, rows-1
+	return s
+}
+
+// 空白单元（使用当前背景色）
+func (s *termScreen) blank() termCell {
+	_, bg, _ := s.style.Decompose()
+	return termCell{Ch: ' ', Style: tcell.StyleDefault.Background(bg)}
+}
+
+// 生成空白网格
+func (s *termScreen) blankGrid(rows, cols int) [][]termCell {
+	grid := make([][]termCell, rows)
+	for r := range grid {
+		grid[r] = s.blankLine(cols)
+	}
+	return grid
+}
+
+// 生成空白行
+func (s *termScreen) blankLine(cols int) []termCell {
+	line := make([]termCell, cols)
+	cell := s.blank()
+	for c := range line {
+		line[c] = cell
+	}
+	return line
+}
+
+// 当前显示的网格
+func (s *termScreen) grid() [][]termCell {
+	if s.altActive {
+		return s.alternate
+	}
+	return s.primary
+}
+
+// 调整屏幕大小：保留左上角的内容，光标超出新高度时内容上移使光标保持可见
+func (s *termScreen) resize(rows, cols int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rows < 1 || cols < 1 || (rows == s.rows && cols == s.cols) {
+		return
+	}
+	shift := max(s.cy-rows+1, 0)
+	resizeGrid := func(old [][]termCell, shift int) [][]termCell {
+		grid := s.blankGrid(rows, cols)
+		for r := range grid {
+			if r+shift < len(old) {
+				copy(grid[r], old[r+shift])
+			}
+		}
+		return grid
+	}
+	s.primary = resizeGrid(s.primary, shift)
+	s.alternate = resizeGrid(s.alternate, shift)
+	s.rows, s.cols = rows, cols
+	s.cy -= shift
+	s.moveCursor(s.cx, s.cy)
+	s.top, s.bottom = 0, rows-1
+	s.wrapNext = false
+}
+
+// 写入程序输出
+func (s *termScreen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data := p
+	if len(s.utf8) > 0 {
+		data = append(s.utf8, p...)
+		s.utf8 = nil
+	}
+	for len(data) > 0 {
+		b := data[0]
+		if b < utf8.RuneSelf || s.state != termGround {
+			s.handleByte(b)
+			data = data[1:]
+			continue
+		}
+		if !utf8.FullRune(data) {
+			s.utf8 = append([]byte(nil), data...)
+			break
+		}
+		r, size := utf8.DecodeRune(data)
+		s.put(r)
+		data = data[size:]
+	}
+	return len(p), nil
+}
+
+// 处理单个字节
+func (s *termScreen) handleByte(b byte) {
+	switch s.state {
+	case termEscape:
+		s.handleEscape(b)
+		return
+	case termCSI:
+		switch {
+		case b >= 0x30 && b <= 0x3f:
+			s.params = append(s.params, b)
+		case b >= 0x40 && b <= 0x7e:
+			s.state = termGround
+			s.handleCSI(b)
+		case b == 0x1b:
+			s.state = termEscape
+		}
+		return
+	case termOSC:
+		switch b {
+		case 0x07:
+			s.state = termGround
+		case 0x1b:
+			s.state = termOSCEsc
+		}
+		return
+	case termOSCEsc:
+		s.state = termGround
+		if b != '\\' {
+			s.handleByte(b)
+		}
+		return
+	case termCharset:
+		s.state = termGround
+		return
+	}
+
+	switch b {
+	case 0x1b:
+		s.state = termEscape
+	case '\r':
+		s.cx, s.wrapNext = 0, false
+	case '\n', 0x0b, 0x0c:
+		s.lineFeed()
+	case '\b':
+		s.cx, s.wrapNext = max(s.cx-1, 0), false
+	case '\t':
+		s.cx, s.wrapNext = min((s.cx/8+1)*8, s.cols-1), false
+	default:
+		if b >= 0x20 && b != 0x7f {
+			s.put(rune(b))
+		}
+	}
+}
+
+// 处理 ESC 之后的字节
+func (s *termScreen) handleEscape(b byte) {
+	s.state = termGround
+	switch b {
+	case '[':
+		s.state, s.params = termCSI, s.params[:0]
+	case ']':
+		s.state = termOSC
+	case '(', ')', '*', '+':
+		s.state = termCharset
+	case '7':
+		s.savedX, s.savedY = s.cx, s.cy
+	case '8':
+		s.moveCursor(s.savedX, s.savedY)
+		s.wrapNext = false
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.cx = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		s.reset()
+	}
+}
+
+// 终端复位（ESC c）：清屏并恢复默认模式，保留大小
+func (s *termScreen) reset() {
+	s.style = tcell.StyleDefault
+	s.primary = s.blankGrid(s.rows, s.cols)
+	s.alternate = s.blankGrid(s.rows, s.cols)
+	s.altActive, s.cursorHidden, s.appCursor, s.bracketedPaste, s.wrapNext = false, false, false, false, false
+	s.cx, s.cy, s.savedX, s.savedY = 0, 0, 0, 0
+	s.top, s.bottom = 0, s.rows-1
+}
+
+// 在光标处写入字符，到达行尾时自动换行
+func (s *termScreen) put(r rune) {
+	width := runewidth.RuneWidth(r)
+	if width == 0 {
+		return
+	}
+	if s.wrapNext || s.cx+width > s.cols {
+		s.cx, s.wrapNext = 0, false
+		s.lineFeed()
+	}
+	line := s.grid()[s.cy]
+	line[s.cx] = termCell{Ch: r, Style: s.style}
+	if width == 2 && s.cx+1 < s.cols {
+		line[s.cx+1] = termCell{Ch: 0, Style: s.style}
+	}
+	s.cx += width
+	if s.cx >= s.cols {
+		s.cx, s.wrapNext = s.cols-1, true
+	}
+}
+
+// 换行：光标在滚动区域底部时区域内容上移
+func (s *termScreen) lineFeed() {
+	s.wrapNext = false
+	if s.cy == s.bottom {
+		s.scrollUp(1)
+	} else if s.cy < s.rows-1 {
+		s.cy++
+	}
+}
+
+// 反向换行：光标在滚动区域顶部时区域内容下移
+func (s *termScreen) reverseIndex() {
+	s.wrapNext = false
+	if s.cy == s.top {
+		s.scrollDown(1)
+	} else if s.cy > 0 {
+		s.cy--
+	}
+}
+
+// 滚动区域内容上移 n 行
+func (s *termScreen) scrollUp(n int) {
+	s.deleteLinesAt(s.top, n)
+}
+
+// 滚动区域内容下移 n 行
+func (s *termScreen) scrollDown(n int) {
+	s.insertLinesAt(s.top, n)
+}
+
+// 在第 row 行插入 n 个空行，滚动区域底部的行被移出
+func (s *termScreen) insertLinesAt(row, n int) {
+	grid := s.grid()
+	n = min(n, s.bottom-row+1)
+	for r := s.bottom; r >= row+n; r-- {
+		grid[r] = grid[r-n]
+	}
+	for r := row; r < row+n; r++ {
+		grid[r] = s.blankLine(s.cols)
+	}
+}
+
+// 删除第 row 行起的 n 行，滚动区域底部补充空行
+func (s *termScreen) deleteLinesAt(row, n int) {
+	grid := s.grid()
+	n = min(n, s.bottom-row+1)
+	for r := row; r <= s.bottom-n; r++ {
+		grid[r] = grid[r+n]
+	}
+	for r := s.bottom - n + 1; r <= s.bottom; r++ {
+		grid[r] = s.blankLine(s.cols)
+	}
+}
+
+// 擦除一行中 [from, to) 的单元
+func (s *termScreen) eraseCells(row, from, to int) {
+	line := s.grid()[row]
+	cell := s.blank()
+	for c := max(from, 0); c < min(to, s.cols); c++ {
+		line[c] = cell
+	}
+}
+
+// CSI 数字参数的上限，防止远端发送超大参数时光标计算溢出
+const maxCSIParam = 9999
+
+// 解析 CSI 数字参数，缺省或为 0 时使用 def，超过 maxCSIParam 时截断
+func csiParams(raw string, count, def int) []int {
+	fields := strings.Split(raw, ";")
+	values := make([]int, max(count, len(fields)))
+	for i := range values {
+		values[i] = def
+		if i < len(fields) {
+			if n, err := strconv.Atoi(fields[i]); err == nil && n > 0 {
+				values[i] = min(n, maxCSIParam)
+			}
+		}
+	}
+	return values
+}
+
+// 移动光标到 (x, y)，超出屏幕的坐标截断到边界
+func (s *termScreen) moveCursor(x, y int) {
+	s.cx = min(max(x, 0), s.cols-1)
+	s.cy = min(max(y, 0), s.rows-1)
+}
+
+// 执行 CSI 控制序列
+func (s *termScreen) handleCSI(final byte) {
+	raw := string(s.params)
+	private := strings.HasPrefix(raw, "?")
+	raw = strings.TrimLeft(raw, "?>=")
+	p := csiParams(raw, 2, 1)
+	s.wrapNext = false
+
+	switch final {
+	case 'A':
+		s.moveCursor(s.cx, s.cy-p[0])
+	case 'B':
+		s.moveCursor(s.cx, s.cy+p[0])
+	case 'C':
+		s.moveCursor(s.cx+p[0], s.cy)
+	case 'D':
+		s.moveCursor(s.cx-p[0], s.cy)
+	case 'E':
+		s.moveCursor(0, s.cy+p[0])
+	case 'F':
+		s.moveCursor(0, s.cy-p[0])
+	case 'G', '`':
+		s.moveCursor(p[0]-1, s.cy)
+	case 'd':
+		s.moveCursor(s.cx, p[0]-1)
+	case 'H', 'f':
+		s.moveCursor(p[1]-1, p[0]-1)
+	case 'J':
+		s.eraseDisplay(csiParams(raw, 1, 0)[0])
+	case 'K':
+		switch csiParams(raw, 1, 0)[0] {
+		case 0:
+			s.eraseCells(s.cy, s.cx, s.cols)
+		case 1:
+			s.eraseCells(s.cy, 0, s.cx+1)
+		case 2:
+			s.eraseCells(s.cy, 0, s.cols)
+		}
+	case 'L':
+		if s.cy >= s.top && s.cy <= s.bottom {
+			s.insertLinesAt(s.cy, p[0])
+		}
+	case 'M':
+		if s.cy >= s.top && s.cy <= s.bottom {
+			s.deleteLinesAt(s.cy, p[0])
+		}
+	case 'P':
+		line := s.grid()[s.cy]
+		n := min(p[0], s.cols-s.cx)
+		copy(line[s.cx:], line[s.cx+n:])
+		s.eraseCells(s.cy, s.cols-n, s.cols)
+	case '@':
+		line := s.grid()[s.cy]
+		n := min(p[0], s.cols-s.cx)
+		copy(line[s.cx+n:], line[s.cx:s.cols-n])
+		s.eraseCells(s.cy, s.cx, s.cx+n)
+	case 'X':
+		s.eraseCells(s.cy, s.cx, s.cx+p[0])
+	case 'S':
+		s.scrollUp(p[0])
+	case 'T':
+		s.scrollDown(p[0])
+	case 'r':
+		top, bottom := p[0]-1, s.rows-1
+		if len(p) > 1 && strings.Contains(raw, ";") {
+			bottom = min(p[1]-1, s.rows-1)
+		}
+		if top < bottom {
+			s.top, s.bottom = top, bottom
+			s.moveCursor(0, 0)
+		}
+	case 's':
+		s.savedX, s.savedY = s.cx, s.cy
+	case 'u':
+		s.moveCursor(s.savedX, s.savedY)
+	case 'm':
+		s.applySGR(raw)
+	case 'h', 'l':
+		if private {
+			s.setPrivateModes(raw, final == 'h')
+		}
+	case 'n':
+		if raw == "6" && s.reply != nil {
+			s.reply(fmt.Appendf(nil, "\x1b[%d;%dR", s.cy+1, s.cx+1))
+		}
+	case 'c':
+		if !private && s.reply != nil && !strings.HasPrefix(string(s.params), ">") {
+			s.reply([]byte("\x1b[?1;2c"))
+		}
+	}
+}
+
+// 擦除屏幕：0 光标到末尾，1 开头到光标，2/3 全部
+func (s *termScreen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseCells(s.cy, s.cx, s.cols)
+		for r := s.cy + 1; r < s.rows; r++ {
+			s.eraseCells(r, 0, s.cols)
+		}
+	case 1:
+		for r := 0; r < s.cy; r++ {
+			s.eraseCells(r, 0, s.cols)
+		}
+		s.eraseCells(s.cy, 0, s.cx+1)
+	case 2, 3:
+		for r := 0; r < s.rows; r++ {
+			s.eraseCells(r, 0, s.cols)
+		}
+	}
+}
+
+// 设置私有模式：?1 应用光标键，?25 显示光标，?47/?1047/?1049 备用屏幕，?2004 括号粘贴
+func (s *termScreen) setPrivateModes(raw string, on bool) {
+	for _, field := range strings.Split(raw, ";") {
+		switch field {
+		case "1":
+			s.appCursor = on
+		case "25":
+			s.cursorHidden = !on
+		case "2004":
+			s.bracketedPaste = on
+		case "47", "1047", "1049":
+			if on == s.altActive {
+				continue
+			}
+			if on {
+				s.savedX, s.savedY = s.cx, s.cy
+				s.alternate = s.blankGrid(s.rows, s.cols)
+			}
+			s.altActive = on
+			if !on && field == "1049" {
+				s.moveCursor(s.savedX, s.savedY)
+			}
+			s.top, s.bottom = 0, s.rows-1
+		}
+	}
+}
+
+// 应用 SGR 字符属性与颜色
+func (s *termScreen) applySGR(raw string) {
+	fields := strings.Split(raw, ";")
+	codes := make([]int, len(fields))
+	for i, field := range fields {
+		codes[i], _ = strconv.Atoi(field)
+	}
+	for i := 0; i < len(codes); i++ {
+		switch code := codes[i]; {
+		case code == 0:
+			s.style = tcell.StyleDefault
+		case code == 1:
+			s.style = s.style.Bold(true)
+		case code == 2:
+			s.style = s.style.Dim(true)
+		case code == 3:
+			s.style = s.style.Italic(true)
+		case code == 4:
+			s.style = s.style.Underline(true)
+		case code == 5:
+			s.style = s.style.Blink(true)
+		case code == 7:
+			s.style = s.style.Reverse(true)
+		case code == 22:
+			s.style = s.style.Bold(false).Dim(false)
+		case code == 23:
+			s.style = s.style.Italic(false)
+		case code == 24:
+			s.style = s.style.Underline(false)
+		case code == 25:
+			s.style = s.style.Blink(false)
+		case code == 27:
+			s.style = s.style.Reverse(false)
+		case code >= 30 && code <= 37:
+			s.style = s.style.Foreground(tcell.PaletteColor(code - 30))
+		case code == 39:
+			s.style = s.style.Foreground(tcell.ColorDefault)
+		case code >= 40 && code <= 47:
+			s.style = s.style.Background(tcell.PaletteColor(code - 40))
+		case code == 49:
+			s.style = s.style.Background(tcell.ColorDefault)
+		case code >= 90 && code <= 97:
+			s.style = s.style.Foreground(tcell.PaletteColor(code - 90 + 8))
+		case code >= 100 && code <= 107:
+			s.style = s.style.Background(tcell.PaletteColor(code - 100 + 8))
+		case code == 38 || code == 48:
+			color, used := sgrExtendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				s.style = s.style.Foreground(color)
+			} else {
+				s.style = s.style.Background(color)
+			}
+		}
+	}
+}
+
+// 解析 38/48 之后的扩展颜色：5;n 为 256 色，2;r;g;b 为真彩色；返回颜色和用掉的参数个数
+func sgrExtendedColor(codes []int) (tcell.Color, int) {
+	switch {
+	case len(codes) >= 2 && codes[0] == 5:
+		return tcell.PaletteColor(codes[1]), 2
+	case len(codes) >= 4 && codes[0] == 2:
+		return tcell.NewRGBColor(int32(codes[1]), int32(codes[2]), int32(codes[3])), 4
+	}
+	return tcell.ColorDefault, len(codes)
+}
+
+// 把屏幕内容绘制到 tcell 屏幕的指定区域，返回光标位置及是否显示光标
+func (s *termScreen) draw(screen tcell.Screen, x, y int) (cx, cy int, visible bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for r, line := range s.grid() {
+		for c, cell := range line {
+			if cell.Ch == 0 {
+				continue
+			}
+			screen.SetContent(x+c, y+r, cell.Ch, nil, cell.Style)
+		}
+	}
+	return x + s.cx, y + s.cy, !s.cursorHidden
+}
+
+// 屏幕的纯文本内容（去掉行尾空白），用于调试和复制
+func (s *termScreen) text() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lines := make([]string, len(s.grid()))
+	for r, line := range s.grid() {
+		var b strings.Builder
+		for _, cell := range line {
+			if cell.Ch != 0 {
+				b.WriteRune(cell.Ch)
+			}
+		}
+		lines[r] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
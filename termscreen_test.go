@@ -0,0 +1,229 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestTermScreenText(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   int
+		cols   int
+		chunks []string
+		want   string
+		cx, cy int
+	}{
+		{
+			name:   "plain text and CRLF",
+			rows:   3,
+			cols:   10,
+			chunks: []string{"ab\r\ncd"},
+			want:   "ab\ncd",
+			cx:     2, cy: 1,
+		},
+		{
+			name:   "cursor position and erase line",
+			rows:   3,
+			cols:   10,
+			chunks: []string{"hello\x1b[1;3H\x1b[K", "X"},
+			want:   "heX",
+			cx:     3, cy: 0,
+		},
+		{
+			name:   "cursor movement",
+			rows:   3,
+			cols:   10,
+			chunks: []string{"\x1b[3;5Ha\x1b[2A\x1b[2Db"},
+			want:   "   b\n\n    a",
+			cx:     4, cy: 0,
+		},
+		{
+			name:   "erase display",
+			rows:   3,
+			cols:   10,
+			chunks: []string{"one\r\ntwo\r\nthree\x1b[2J\x1b[H"},
+			want:   "",
+			cx:     0, cy: 0,
+		},
+		{
+			name:   "insert and delete characters",
+			rows:   1,
+			cols:   10,
+			chunks: []string{"abcdef\x1b[1;2H\x1b[2P\x1b[1@Z"},
+			want:   "aZdef",
+			cx:     2, cy: 0,
+		},
+		{
+			name:   "wrap at line end",
+			rows:   3,
+			cols:   4,
+			chunks: []string{"abcdef"},
+			want:   "abcd\nef",
+			cx:     2, cy: 1,
+		},
+		{
+			name:   "scroll at bottom",
+			rows:   2,
+			cols:   10,
+			chunks: []string{"1\r\n2\r\n3"},
+			want:   "2\n3",
+			cx:     1, cy: 1,
+		},
+		{
+			name:   "scroll region keeps lines outside",
+			rows:   4,
+			cols:   10,
+			chunks: []string{"head\r\na\r\nb\r\nfoot", "\x1b[2;3r\x1b[3;1H\nc"},
+			want:   "head\nb\nc\nfoot",
+			cx:     1, cy: 2,
+		},
+		{
+			name:   "reverse index in scroll region",
+			rows:   4,
+			cols:   10,
+			chunks: []string{"head\r\na\r\nb\r\nfoot", "\x1b[2;3r\x1b[2;1H\x1bMz"},
+			want:   "head\nz\na\nfoot",
+			cx:     1, cy: 1,
+		},
+		{
+			name:   "insert lines in scroll region",
+			rows:   4,
+			cols:   10,
+			chunks: []string{"head\r\na\r\nb\r\nfoot", "\x1b[2;3r\x1b[2;1H\x1b[L"},
+			want:   "head\n\na\nfoot",
+			cx:     0, cy: 1,
+		},
+		{
+			name:   "UTF-8 split across writes",
+			rows:   1,
+			cols:   10,
+			chunks: []string{"a\xe4\xb8", "\xad\xe6", "\x96\x87b"},
+			want:   "a中文b",
+			cx:     6, cy: 0,
+		},
+		{
+			name:   "CSI split across writes",
+			rows:   1,
+			cols:   10,
+			chunks: []string{"abc\x1b[", "1;2", "H", "X"},
+			want:   "aXc",
+			cx:     2, cy: 0,
+		},
+		{
+			name:   "OSC title ignored",
+			rows:   1,
+			cols:   10,
+			chunks: []string{"\x1b]0;title\x07ok\x1b]2;x\x1b\\!"},
+			want:   "ok!",
+			cx:     3, cy: 0,
+		},
+		{
+			name:   "huge cursor parameters are clamped",
+			rows:   3,
+			cols:   5,
+			chunks: []string{"\x1b[3;3H\x1b[9223372036854775807B", "a\x1b[1;1H\x1b[9223372036854775807C", "b\x1b[1;1H\x1b[9223372036854775807E", "c"},
+			want:   "    b\n\nc a",
+			cx:     1, cy: 2,
+		},
+		{
+			name:   "alternate screen restores primary",
+			rows:   2,
+			cols:   10,
+			chunks: []string{"main\x1b[?1049h", "full", "\x1b[?1049l"},
+			want:   "main",
+			cx:     4, cy: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTermScreen(tt.rows, tt.cols)
+			for _, chunk := range tt.chunks {
+				s.Write([]byte(chunk))
+			}
+			if got := s.text(); got != tt.want {
+				t.Errorf("text = %q, want %q", got, tt.want)
+			}
+			if s.cx != tt.cx || s.cy != tt.cy {
+				t.Errorf("cursor = (%d, %d), want (%d, %d)", s.cx, s.cy, tt.cx, tt.cy)
+			}
+		})
+	}
+}
+
+func TestTermScreenSGR(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  tcell.Style
+	}{
+		{"default", "x", tcell.StyleDefault},
+		{"bold red", "\x1b[1;31mx", tcell.StyleDefault.Bold(true).Foreground(tcell.PaletteColor(1))},
+		{"bright background", "\x1b[102mx", tcell.StyleDefault.Background(tcell.PaletteColor(10))},
+		{"256 colors", "\x1b[38;5;208;48;5;17mx", tcell.StyleDefault.Foreground(tcell.PaletteColor(208)).Background(tcell.PaletteColor(17))},
+		{"true color", "\x1b[38;2;10;20;30mx", tcell.StyleDefault.Foreground(tcell.NewRGBColor(10, 20, 30))},
+		{"reset", "\x1b[1;4;31m\x1b[0mx", tcell.StyleDefault},
+		{"empty params reset", "\x1b[7m\x1b[mx", tcell.StyleDefault},
+		{"partial reset", "\x1b[1;4;7m\x1b[22;27mx", tcell.StyleDefault.Underline(true)},
+		{"default foreground", "\x1b[32;39mx", tcell.StyleDefault.Foreground(tcell.ColorDefault)},
+		{"split sequence", "\x1b[3\x1b[0m\x1b[3" + "3mx", tcell.StyleDefault.Foreground(tcell.PaletteColor(3))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTermScreen(1, 10)
+			s.Write([]byte(tt.input))
+			if got := s.primary[0][0].Style; got != tt.want {
+				t.Errorf("style = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTermScreenModes(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		appCursor  bool
+		bracketed  bool
+		cursorHide bool
+	}{
+		{"defaults", "", false, false, false},
+		{"application cursor", "\x1b[?1h", true, false, false},
+		{"bracketed paste", "\x1b[?2004h", false, true, false},
+		{"combined", "\x1b[?1;2004h\x1b[?25l", true, true, true},
+		{"turned off", "\x1b[?2004h\x1b[?2004l", false, false, false},
+		{"reset", "\x1b[?1;2004h\x1bc", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTermScreen(2, 10)
+			s.Write([]byte(tt.input))
+			if s.appCursor != tt.appCursor || s.bracketedPaste != tt.bracketed || s.cursorHidden != tt.cursorHide {
+				t.Errorf("modes = (appCursor %v, bracketed %v, hidden %v), want (%v, %v, %v)",
+					s.appCursor, s.bracketedPaste, s.cursorHidden, tt.appCursor, tt.bracketed, tt.cursorHide)
+			}
+		})
+	}
+}
+
+func TestPasteBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		bracketed bool
+		want      string
+	}{
+		{"plain", "ls -l\n", false, "ls -l\r"},
+		{"CRLF", "a\r\nb", false, "a\rb"},
+		{"bracketed", "echo hi\n", true, "\x1b[200~echo hi\r\x1b[201~"},
+		{"end marker stripped", "x\x1b[201~rm -rf /\n", true, "\x1b[200~xrm -rf /\r\x1b[201~"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(pasteBytes(tt.text, tt.bracketed)); got != tt.want {
+				t.Errorf("pasteBytes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
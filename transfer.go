@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// 传输统计：会话、隧道或文件传输的收发字节数
+type transferStats struct {
+	Sent     int64         // 发送字节数
+	Received int64         // 接收字节数
+	Duration time.Duration // 持续时间
+}
+
+// 平均发送速率（字节/秒）
+func (s transferStats) SendRate() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Sent) / s.Duration.Seconds()
+}
+
+// 平均接收速率（字节/秒）
+func (s transferStats) ReceiveRate() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+	return float64(s.Received) / s.Duration.Seconds()
+}
+
+// 格式化为可读文本
+func (s transferStats) String() string {
+	return fmt.Sprintf("↑ %s (%s/s) ↓ %s (%s/s)",
+		formatBytes(s.Sent), formatBytes(int64(s.SendRate())),
+		formatBytes(s.Received), formatBytes(int64(s.ReceiveRate())))
+}
+
+// OpenSSH 在 LogLevel=VERBOSE 时会话结束输出的统计行
+var sshTransferPattern = regexp.MustCompile(`Transferred: sent (\d+), received (\d+) bytes`)
+
+// 从 ssh -E 写出的日志文件中解析传输统计
+func parseSSHTransferLog(path string) (transferStats, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return transferStats{}, false
+	}
+	defer file.Close()
+
+	var stats transferStats
+	found := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if match := sshTransferPattern.FindStringSubmatch(scanner.Text()); match != nil {
+			stats.Sent, _ = strconv.ParseInt(match[1], 10, 64)
+			stats.Received, _ = strconv.ParseInt(match[2], 10, 64)
+			found = true
+		}
+	}
+	return stats, found
+}
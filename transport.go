@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 连接传输方式
+const (
+	transportSSH      = "ssh"        // 直接使用 OpenSSH
+	transportTeleport = "teleport"   // 通过 tsh ssh 连接
+	transportSSM      = "ssm"        // 通过 AWS Session Manager 隧道承载 OpenSSH
+	transportCF       = "cloudflare" // 通过 Cloudflare Access（cloudflared）隧道承载
+	transportIAP      = "iap"        // 通过 GCP IAP TCP 转发隧道承载
+	transportReverse  = "reverse"    // 经由已拨入本机的反向隧道设备跳转
+)
+
+// 传输配置
+type transportConfig struct {
+	Type    string `mapstructure:"type"`    // ssh、teleport、ssm、cloudflare、iap 或 reverse，默认 ssh
+	Proxy   string `mapstructure:"proxy"`   // Teleport 代理地址
+	Cluster string `mapstructure:"cluster"` // Teleport 集群
+	Target  string `mapstructure:"target"`  // 隧道目标：SSM 实例 ID、Access 主机名、IAP 实例名或反向隧道设备名，默认取 instance= 标签或主机名
+	Region  string `mapstructure:"region"`  // AWS 区域
+	Profile string `mapstructure:"profile"` // AWS 配置文件
+	Zone    string `mapstructure:"zone"`    // GCP 可用区
+	Project string `mapstructure:"project"` // GCP 项目
+}
+
+// 用非空字段覆盖配置
+func (c transportConfig) merge(override transportConfig) transportConfig {
+	if override.Type != "" {
+		c.Type = override.Type
+	}
+	if override.Proxy != "" {
+		c.Proxy = override.Proxy
+	}
+	if override.Cluster != "" {
+		c.Cluster = override.Cluster
+	}
+	if override.Target != "" {
+		c.Target = override.Target
+	}
+	if override.Region != "" {
+		c.Region = override.Region
+	}
+	if override.Profile != "" {
+		c.Profile = override.Profile
+	}
+	if override.Zone != "" {
+		c.Zone = override.Zone
+	}
+	if override.Project != "" {
+		c.Project = override.Project
+	}
+	return c
+}
+
+// 传输方式（小写，未配置时为 ssh）
+func (c transportConfig) kind() string {
+	if c.Type == "" {
+		return transportSSH
+	}
+	return strings.ToLower(c.Type)
+}
+
+// 解析目标的传输配置，优先级：连接 > 环境 > 全局默认（配置项 transport）
+func resolveTransport(target connTarget) transportConfig {
+	var config transportConfig
+	var global transportConfig
+	if unmarshalConfig("transport.default", &global) == nil {
+		config = config.merge(global)
+	}
+	var byEnv map[string]transportConfig
+	if unmarshalConfig("transport.environments", &byEnv) == nil {
+		config = config.merge(lookupFold(byEnv, target.Env))
+	}
+	var byConn map[string]transportConfig
+	if unmarshalConfig("transport.connections", &byConn) == nil {
+		config = config.merge(lookupFold(byConn, target.ID()))
+	}
+	return config
+}
+
+// 是否通过 ProxyCommand 隧道承载 OpenSSH
+func (c transportConfig) tunneled() bool {
+	switch c.kind() {
+	case transportSSM, transportCF, transportIAP:
+		return true
+	}
+	return false
+}
+
+// 隧道的目标（SSM 实例 ID、Access 主机名或 IAP 实例名）
+func (c transportConfig) tunnelTarget(conn Connection) string {
+	if c.Target != "" {
+		return c.Target
+	}
+	for _, tag := range conn.Tags {
+		if value, ok := strings.CutPrefix(tag, "instance="); ok {
+			return value
+		}
+	}
+	return conn.Host
+}
+
+// AWS CLI 的区域和配置文件参数
+func (c transportConfig) awsFlags() []string {
+	var flags []string
+	if c.Region != "" {
+		flags = append(flags, "--region", c.Region)
+	}
+	if c.Profile != "" {
+		flags = append(flags, "--profile", c.Profile)
+	}
+	return flags
+}
+
+// gcloud 的可用区和项目参数
+func (c transportConfig) gcloudFlags() []string {
+	var flags []string
+	if c.Zone != "" {
+		flags = append(flags, "--zone="+c.Zone)
+	}
+	if c.Project != "" {
+		flags = append(flags, "--project="+c.Project)
+	}
+	return flags
+}
+
+// 将参数转义后拼接为 shell 命令片段
+func quoteArgs(args []string) string {
+	var quoted string
+	for _, arg := range args {
+		quoted += " " + shellQuote(arg)
+	}
+	return quoted
+}
+
+// 隧道承载 SSH 的 ProxyCommand（%h 为隧道目标，%p 为端口）
+func (c transportConfig) proxyCommand() string {
+	switch c.kind() {
+	case transportSSM:
+		return "aws ssm start-session --target %h --document-name AWS-StartSSHSession --parameters portNumber=%p" + quoteArgs(c.awsFlags())
+	case transportCF:
+		return "cloudflared access ssh --hostname %h"
+	case transportIAP:
+		return "gcloud compute start-iap-tunnel %h %p --listen-on-stdin --verbosity=warning" + quoteArgs(c.gcloudFlags())
+	}
+	return ""
+}
+
+// 客户端命令前缀，之后可追加 SSH 选项
+func (c transportConfig) client(module string) []string {
+	switch c.kind() {
+	case transportTeleport:
+		args := []string{"tsh", "ssh"}
+		if c.Proxy != "" {
+			args = append(args, "--proxy="+c.Proxy)
+		}
+		if c.Cluster != "" {
+			args = append(args, "--cluster="+c.Cluster)
+		}
+		return args
+	case transportSSM, transportCF, transportIAP:
+		return []string{moduleClient(module, "ssh"), "-o", "ProxyCommand=" + c.proxyCommand()}
+	case transportReverse:
+		// 经反向隧道跳转时不用 -J：-J 启动的跳板 ssh 会继承询问密码的环境，抢先取走目标主机的密码
+		if t, ok := findReverseTunnel(c.Target); ok {
+			ssh := moduleClient(module, "ssh")
+			proxy := fmt.Sprintf("%s%s -W %%h:%%p -p %d %s", askpassProxyPrefix, shellQuote(ssh), t.Port, shellQuote(t.User+"@127.0.0.1"))
+			return []string{ssh, "-o", "ProxyCommand=" + proxy}
+		}
+	}
+	return []string{moduleClient(module, "ssh")}
+}
+
+// 反向隧道设备的跳板地址，设备未登记时为空
+func (c transportConfig) reverseJump() string {
+	if t, ok := findReverseTunnel(c.Target); ok {
+		return t.jump()
+	}
+	return ""
+}
+
+// 在客户端命令之后插入 SSH 选项（tsh ssh 的选项位于子命令之后）
+func insertSSHOptions(target connTarget, args []string, options ...string) []string {
+	at := 1
+	if resolveTransport(target).kind() == transportTeleport {
+		at = 2
+	}
+	result := make([]string, 0, len(args)+len(options))
+	result = append(result, args[:at]...)
+	result = append(result, options...)
+	return append(result, args[at:]...)
+}
+
+// 非交互执行时禁止密码提示的选项（tsh 不支持 BatchMode）
+func batchModeOptions(target connTarget) []string {
+	if resolveTransport(target).kind() == transportTeleport {
+		return nil
+	}
+	return []string{"-o", "BatchMode=yes"}
+}
+
+// rsync -e 使用的远程 shell 命令
+func (c transportConfig) rsyncShell(port int) string {
+	switch c.kind() {
+	case transportTeleport:
+		return fmt.Sprintf("%s -p %d", strings.Join(c.client(""), " "), port)
+	case transportSSM, transportCF, transportIAP:
+		return fmt.Sprintf("ssh -p %d -o %s", port, shellQuote("ProxyCommand="+c.proxyCommand()))
+	case transportReverse:
+		if jump := c.reverseJump(); jump != "" {
+			return fmt.Sprintf("ssh -p %d -J %s", port, shellQuote(jump))
+		}
+	}
+	return fmt.Sprintf("ssh -p %d", port)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// 默认的撤销时限（配置项 trash.undo_window 可覆盖）
+const defaultUndoWindow = time.Minute
+
+// 移入回收站的远程文件
+type trashedFile struct {
+	target   connTarget // 所在连接
+	original string     // 原路径
+	trashed  string     // 回收站中的路径
+	at       time.Time  // 删除时间
+}
+
+// 解析目标的远程回收站目录，优先级：连接 > 环境 > 全局默认（配置项 trash.default、trash.environments、trash.connections）；为空或 none 时直接删除
+func resolveTrashDir(target connTarget) string {
+	dir := configString("trash.default")
+	if byEnv := configStringMapString("trash.environments"); len(byEnv) > 0 {
+		if value := lookupFold(byEnv, target.Env); value != "" {
+			dir = value
+		}
+	}
+	if byConn := configStringMapString("trash.connections"); len(byConn) > 0 {
+		if value := lookupFold(byConn, target.ID()); value != "" {
+			dir = value
+		}
+	}
+	if strings.EqualFold(dir, "none") {
+		return ""
+	}
+	return dir
+}
+
+// 撤销时限
+func undoWindow() time.Duration {
+	if window := configDuration("trash.undo_window"); window > 0 {
+		return window
+	}
+	return defaultUndoWindow
+}
+
+// 删除远程文件或目录：配置了回收站时移入回收站（名称加时间戳前缀），否则 rm -rf
+func removeRemote(ctx context.Context, target connTarget, remotePath string) (*trashedFile, error) {
+	dir := resolveTrashDir(target)
+	if dir == "" {
+		_, err := runRemote(ctx, target, "rm -rf -- "+shellQuote(remotePath))
+		return nil, err
+	}
+	now := time.Now()
+	trashed := path.Join(dir, now.Format("20060102-150405")+"-"+path.Base(remotePath))
+	command := fmt.Sprintf("mkdir -p %s && mv -- %s %s", remotePathQuote(dir), shellQuote(remotePath), remotePathQuote(trashed))
+	if _, err := runRemote(ctx, target, command); err != nil {
+		return nil, err
+	}
+	return &trashedFile{target: target, original: remotePath, trashed: trashed, at: now}, nil
+}
+
+// 是否仍在撤销时限内
+func (t *trashedFile) undoable() bool {
+	return t != nil && time.Since(t.at) < undoWindow()
+}
+
+// 从回收站恢复到原路径，原路径已存在同名文件时失败
+func (t *trashedFile) restore(ctx context.Context) error {
+	command := fmt.Sprintf("if [ -e %[1]s ]; then echo %[3]s >&2; exit 1; fi; mv -- %[2]s %[1]s", shellQuote(t.original), remotePathQuote(t.trashed), shellQuote(tr("trash.exists")))
+	_, err := runRemote(ctx, t.target, command)
+	return err
+}
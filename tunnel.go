@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 等待本地隧道监听就绪的最长时间（首次使用时可能需要在浏览器中完成登录）
+const tunnelStartTimeout = 2 * time.Minute
+
+// 为数据库客户端建立的本地隧道
+type localTunnel struct {
+	cmd     *exec.Cmd     // 隧道进程
+	port    int           // 本地监听端口
+	done    chan struct{} // 进程退出时关闭
+	target  connTarget    // 隧道对应的连接
+	started time.Time     // 建立时间
+	active  time.Time     // 最近活动时间（客户端开始或结束使用隧道）
+	inUse   int           // 正在使用隧道的客户端会话数
+	warned  bool          // 是否已提示即将因空闲关闭
+}
+
+// 已建立的本地隧道，按连接标识复用
+var (
+	tunnelsMu sync.Mutex
+	tunnels   = make(map[string]*localTunnel)
+)
+
+// 隧道是否仍在运行
+func (t *localTunnel) alive() bool {
+	select {
+	case <-t.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// 分配一个空闲的本地端口
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// 构建把远程端口转发到本地端口的隧道命令
+func (c transportConfig) forwardCommand(conn Connection, local int) []string {
+	target := c.tunnelTarget(conn)
+	listen := "127.0.0.1:" + strconv.Itoa(local)
+	switch c.kind() {
+	case transportCF:
+		return []string{"cloudflared", "access", "tcp", "--hostname", target, "--url", listen}
+	case transportIAP:
+		args := []string{"gcloud", "compute", "start-iap-tunnel", target, strconv.Itoa(conn.Port), "--local-host-port=" + listen}
+		return append(args, c.gcloudFlags()...)
+	case transportSSM:
+		args := []string{"aws", "ssm", "start-session", "--target", target,
+			"--document-name", "AWS-StartPortForwardingSessionToRemoteHost",
+			"--parameters", fmt.Sprintf("host=%s,portNumber=%d,localPortNumber=%d", conn.Host, conn.Port, local)}
+		return append(args, c.awsFlags()...)
+	}
+	return nil
+}
+
+// 确保 Cloudflare Access 令牌有效，没有缓存令牌时执行登录（会打开浏览器）
+func ensureAccessToken(ctx context.Context, hostname string) error {
+	app := "https://" + hostname
+	if exec.CommandContext(ctx, "cloudflared", "access", "token", "-app="+app).Run() == nil {
+		return nil
+	}
+	if output, err := exec.CommandContext(ctx, "cloudflared", "access", "login", app).CombinedOutput(); err != nil {
+		return errors.New(tr("tunnel.cloudflare_login", strings.TrimSpace(string(output))))
+	}
+	return nil
+}
+
+// 获取客户端实际连接的地址：需要隧道（传输方式隧道或经由 SSH 连接转发）时建立（或复用）本地监听并返回指向它的连接，
+// 有备用地址时选择延迟最低的端点
+func clientEndpoint(target connTarget) (Connection, error) {
+	transport := resolveTransport(target)
+	bastion, viaSSH, err := tunnelBastion(target)
+	if err != nil {
+		return Connection{}, err
+	}
+	if !viaSSH && (!transport.tunneled() || moduleType(target.Module) == "SSH") {
+		return selectEndpoint(target).Conn, nil
+	}
+	// 后台服务运行时由它建立并持有隧道，界面退出后隧道仍然保留
+	if conn, ok, err := daemonEndpoint(target); ok {
+		return conn, err
+	}
+
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	id := target.ID()
+	if t, ok := tunnels[id]; ok && t.alive() {
+		t.active, t.warned = time.Now(), false
+		return localConnection(target.Conn, t.port), nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tunnelStartTimeout)
+	defer cancel()
+	if !viaSSH && transport.kind() == transportCF {
+		if err := ensureAccessToken(ctx, transport.tunnelTarget(target.Conn)); err != nil {
+			return Connection{}, err
+		}
+	}
+	port, err := freeLocalPort()
+	if err != nil {
+		return Connection{}, err
+	}
+	args := transport.forwardCommand(target.Conn, port)
+	if viaSSH {
+		args = bastionForwardCommand(bastion, target.Conn, port)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return Connection{}, fmt.Errorf(tr("tunnel.start_failed"), err)
+	}
+	now := time.Now()
+	t := &localTunnel{cmd: cmd, port: port, done: make(chan struct{}), target: target, started: now, active: now}
+	go func() {
+		cmd.Wait()
+		close(t.done)
+	}()
+
+	// 等待本地端口开始监听
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	for {
+		if c, err := net.DialTimeout("tcp", address, time.Second); err == nil {
+			c.Close()
+			break
+		}
+		select {
+		case <-t.done:
+			return Connection{}, errors.New(tr("tunnel.exited", strings.TrimSpace(stderr.String())))
+		case <-ctx.Done():
+			cmd.Process.Kill()
+			return Connection{}, errors.New(tr("tunnel.timeout", tunnelStartTimeout))
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	tunnels[id] = t
+	return localConnection(target.Conn, port), nil
+}
+
+// 将连接地址改写为本地隧道端口
+func localConnection(conn Connection, port int) Connection {
+	conn.Host, conn.Port = "127.0.0.1", port
+	return conn
+}
+
+// 关闭连接的本地隧道并记录审计日志，没有隧道时返回 false
+func closeClientTunnel(id, reason string) bool {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	t, ok := tunnels[id]
+	if !ok {
+		return false
+	}
+	if t.alive() {
+		t.cmd.Process.Kill()
+	}
+	delete(tunnels, id)
+	recordAudit(auditEvent{Action: "tunnel_close", Target: id, Detail: reason, Duration: time.Since(t.started)})
+	return true
+}
+
+// 关闭全部本地隧道（程序退出时调用）
+func closeTunnels() {
+	tunnelsMu.Lock()
+	defer tunnelsMu.Unlock()
+	for id, t := range tunnels {
+		if t.alive() {
+			t.cmd.Process.Kill()
+		}
+		delete(tunnels, id)
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// 事务执行完成后的标记输出，用于判断语句已执行完毕
+const txMarker = "__CM_TX_MARK__"
+
+// 受事务保护的写语句关键字
+var writeKeywords = []string{"INSERT", "UPDATE", "DELETE", "REPLACE", "MERGE", "ALTER", "DROP", "TRUNCATE", "CREATE", "GRANT", "REVOKE", "RENAME"}
+
+// MySQL中会隐式提交、无法回滚的DDL关键字
+var mysqlImplicitCommitKeywords = []string{"ALTER", "DROP", "TRUNCATE", "CREATE", "GRANT", "REVOKE", "RENAME"}
+
+// 受保护事务：保持客户端进程存活，等待用户选择提交或回滚
+type guardedTx struct {
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	stdin  io.WriteCloser
+	lines  chan string   // 客户端输出行
+	exited chan struct{} // 客户端进程退出后关闭
+	err    error         // 客户端进程的退出状态，exited 关闭后才能读取
+}
+
+// 获取事务等待确认的超时时间（配置项 console.transaction_timeout，默认60秒）
+func transactionTimeout() time.Duration {
+	if timeout := configDuration("console.transaction_timeout"); timeout > 0 {
+		return timeout
+	}
+	return 60 * time.Second
+}
+
+// 获取语句的第一个关键字（大写）
+func leadingKeyword(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimRight(fields[0], ";"))
+}
+
+// 判断语句是否以指定关键字之一开头
+func hasLeadingKeyword(query string, keywords []string) bool {
+	keyword := leadingKeyword(query)
+	for _, candidate := range keywords {
+		if keyword == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// 启动客户端进程，在事务中执行语句并等待执行完毕，返回执行期间的输出
+func beginGuardedTx(target connTarget, query string) (*guardedTx, string, error) {
+	conn, err := clientEndpoint(target)
+	if err != nil {
+		return nil, "", err
+	}
+	base := batchClientCommand(target.Module, conn)
+	args := append([]string{}, base[1:]...)
+	if moduleType(target.Module) == "PostgreSQL" {
+		args = append(args, "-v", "ON_ERROR_STOP=1")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, base[0], args...)
+	cmd.Env = clientEnv(target)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		cancel()
+		return nil, "", err
+	}
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, "", err
+	}
+
+	tx := &guardedTx{cmd: cmd, cancel: cancel, stdin: stdin, lines: make(chan string, 256), exited: make(chan struct{})}
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			tx.lines <- scanner.Text()
+		}
+		close(tx.lines)
+	}()
+	go func() {
+		tx.err = cmd.Wait()
+		writer.Close()
+		close(tx.exited)
+	}()
+
+	statement := strings.TrimRight(strings.TrimSpace(query), ";")
+	script := "BEGIN;\n" + statement + ";\n"
+	if moduleType(target.Module) == "MySQL" {
+		script += "SELECT ROW_COUNT() AS affected_rows;\n"
+	}
+	script += fmt.Sprintf("SELECT '%s';\n", txMarker)
+	if _, err := io.WriteString(stdin, script); err != nil {
+		tx.abort()
+		return nil, "", err
+	}
+
+	// 读取输出直到出现标记，客户端提前退出说明语句执行失败
+	var output []string
+	timeout := time.After(queryTimeout)
+	for {
+		select {
+		case line, ok := <-tx.lines:
+			if !ok {
+				tx.abort()
+				return nil, strings.Join(output, "\n"), errors.New(tr("tx.exec_failed"))
+			}
+			if strings.Contains(line, txMarker) {
+				return tx, strings.Join(output, "\n"), nil
+			}
+			if line != "?column?" && line != txMarker {
+				output = append(output, line)
+			}
+		case <-timeout:
+			tx.abort()
+			return nil, strings.Join(output, "\n"), errors.New(tr("tx.exec_timeout"))
+		}
+	}
+}
+
+// 结束事务：提交或回滚后关闭客户端，客户端输出错误或异常退出时返回错误（提交失败时服务端已回滚或结果未知）
+func (tx *guardedTx) finish(commit bool) error {
+	statement := "ROLLBACK;\n"
+	if commit {
+		statement = "COMMIT;\n"
+	}
+	defer tx.cancel()
+	if _, err := io.WriteString(tx.stdin, statement); err != nil {
+		tx.abort()
+		return fmt.Errorf(tr("tx.client_gone"), err)
+	}
+	tx.stdin.Close()
+
+	// 收集结束语句的输出，等待客户端处理完毕并退出
+	var output []string
+	timeout := time.After(queryTimeout)
+	for done := false; !done; {
+		select {
+		case line, ok := <-tx.lines:
+			if !ok {
+				done = true
+				break
+			}
+			output = append(output, line)
+		case <-timeout:
+			tx.abort()
+			return errors.New(tr("tx.finish_timeout"))
+		}
+	}
+	<-tx.exited
+	if line, ok := txErrorLine(output, commit); ok {
+		return errors.New(line)
+	}
+	if tx.err != nil {
+		return fmt.Errorf(tr("tx.client_failed"), tx.err)
+	}
+	return nil
+}
+
+// 在结束事务的输出中查找错误：MySQL 以 ERROR 开头，psql 输出 ERROR:/FATAL:，
+// PostgreSQL 提交已失败的事务时不报错而输出 ROLLBACK
+func txErrorLine(output []string, commit bool) (string, bool) {
+	for _, line := range output {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "ERROR"), strings.Contains(trimmed, "ERROR:"), strings.Contains(trimmed, "FATAL:"):
+			return trimmed, true
+		case commit && trimmed == "ROLLBACK":
+			return tr("tx.commit_rolled_back"), true
+		}
+	}
+	return "", false
+}
+
+// 中止事务：直接终止客户端进程，未提交的事务由服务端回滚
+func (tx *guardedTx) abort() {
+	tx.stdin.Close()
+	tx.cancel()
+}
+
+// 在事务中执行写语句，并弹出提交/回滚确认框
+func (a *App) executeGuardedWrite(query string) {
+	console := a.console
+	console.running = true
+	console.status.SetText(tr("tx.running"))
+
+	go func() {
+		start := time.Now()
+		tx, output, err := beginGuardedTx(console.target, query)
+		elapsed := time.Since(start)
+		a.app.QueueUpdateDraw(func() {
+			console.running = false
+			if err != nil {
+				recordQueryHistory(console.target, query, elapsed, err)
+				console.status.SetText(fmt.Sprintf("[red]%s: %s[-]", err, tview.Escape(firstLine(output, nil))))
+				return
+			}
+			a.showTransactionPrompt(tx, query, output, elapsed)
+		})
+	}()
+}
+
+// 显示提交/回滚确认框，超时后自动回滚
+func (a *App) showTransactionPrompt(tx *guardedTx, query, output string, elapsed time.Duration) {
+	console := a.console
+	box := tview.NewTextView().
+		SetDynamicColors(true).
+		SetWrap(true)
+	box.SetBorder(true).
+		SetTitle(tr("tx.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorRed)
+
+	deadline := time.Now().Add(transactionTimeout())
+	done := make(chan struct{})
+	render := func() {
+		content := tr("tx.pending", formatDuration(elapsed), tview.Escape(query))
+		content += fmt.Sprintf("[gray]%s[-]\n\n", tview.Escape(output))
+		if moduleType(console.target.Module) == "MySQL" && hasLeadingKeyword(query, mysqlImplicitCommitKeywords) {
+			content += tr("tx.mysql_ddl")
+		}
+		remaining := time.Until(deadline).Round(time.Second)
+		content += tr("tx.keys", formatDuration(remaining))
+		box.SetText(content)
+	}
+	render()
+
+	finish := func(commit bool) {
+		close(done)
+		a.popOverlay()
+		console.running = true
+		console.status.SetText(tr("tx.finishing"))
+		go func() {
+			err := tx.finish(commit)
+			recordQueryHistory(console.target, query, elapsed, err)
+			a.app.QueueUpdateDraw(func() {
+				console.running = false
+				switch {
+				case err != nil && commit:
+					console.status.SetText(tr("tx.commit_failed", tview.Escape(err.Error())))
+				case err != nil:
+					console.status.SetText(tr("tx.finish_failed", tview.Escape(err.Error())))
+				case commit:
+					console.status.SetText(tr("tx.committed"))
+				default:
+					console.status.SetText(tr("tx.rolled_back"))
+				}
+			})
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				a.app.QueueUpdateDraw(func() {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					if time.Now().After(deadline) {
+						finish(false)
+						return
+					}
+					render()
+				})
+			}
+		}
+	}()
+
+	a.pushOverlay(centered(box, 80, 16), box, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			finish(false)
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'c', 'C':
+				finish(true)
+				return nil
+			case 'r', 'R':
+				finish(false)
+				return nil
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 当前版本，发布构建时通过 -ldflags "-X main.version=v1.2.3" 写入
+var version = "dev"
+
+// 发布签名公钥（base64 编码的 ed25519 公钥），只能在发布构建时通过 -ldflags 写入；为空时拒绝自动更新
+var updatePublicKey = ""
+
+// 发布所在的 GitHub 仓库（不允许由配置覆盖，避免从当前目录的配置文件指向其他仓库）
+const updateRepository = "TermWar/ConnectionManager"
+
+// 版本检查结果缓存文件名（位于数据目录中）及有效期
+const (
+	updateCheckFile = "update_check.json"
+	updateCheckTTL  = 24 * time.Hour
+)
+
+// 发布文件中的校验和与签名文件名
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = "checksums.txt.sig"
+)
+
+// GitHub 发布信息
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// 缓存的版本检查结果
+type updateCheck struct {
+	Checked time.Time `json:"checked"`
+	Latest  string    `json:"latest"`
+}
+
+// 获取发布文件的下载地址
+func (r githubRelease) assetURL(name string) (string, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset.URL, true
+		}
+	}
+	return "", false
+}
+
+// 当前平台对应的发布文件名，如 connectionmanager_linux_amd64
+func binaryAssetName() string {
+	name := fmt.Sprintf("connectionmanager_%s_%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// 比较两个形如 v1.2.3 的版本号，返回 -1、0 或 1（预发布后缀不参与比较）
+func compareVersions(a, b string) int {
+	parse := func(v string) []int {
+		v, _, _ = strings.Cut(strings.TrimPrefix(v, "v"), "-")
+		var parts []int
+		for _, part := range strings.Split(v, ".") {
+			n, _ := strconv.Atoi(part)
+			parts = append(parts, n)
+		}
+		return parts
+	}
+	left, right := parse(a), parse(b)
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var x, y int
+		if i < len(left) {
+			x = left[i]
+		}
+		if i < len(right) {
+			y = right[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// 以 GET 请求下载内容
+func httpGet(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "connectionmanager/"+version)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: HTTP %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// 查询 GitHub 上的最新发布
+func latestRelease(ctx context.Context) (githubRelease, error) {
+	data, err := httpGet(ctx, fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", updateRepository))
+	if err != nil {
+		return githubRelease{}, err
+	}
+	var release githubRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return githubRelease{}, fmt.Errorf(tr("update.parse_release"), err)
+	}
+	return release, nil
+}
+
+// 检查是否有新版本（结果缓存一天），没有新版本或无法检查时返回空字符串
+func cachedNewVersion() string {
+	if version == "dev" || configIsSet("update.check") && !configBool("update.check") {
+		return ""
+	}
+	var cached updateCheck
+	_ = readJSONFile(updateCheckFile, &cached)
+	if time.Since(cached.Checked) > updateCheckTTL {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		release, err := latestRelease(ctx)
+		if err != nil {
+			return ""
+		}
+		cached = updateCheck{Checked: time.Now(), Latest: release.TagName}
+		_ = writeJSONFile(updateCheckFile, cached)
+	}
+	if cached.Latest != "" && compareVersions(cached.Latest, version) > 0 {
+		return cached.Latest
+	}
+	return ""
+}
+
+// 在后台检查新版本，有新版本时在状态栏提示
+func (a *App) startUpdateCheck() {
+	go func() {
+		latest := cachedNewVersion()
+		if latest == "" {
+			return
+		}
+		a.app.QueueUpdateDraw(func() {
+			if len(a.overlays) == 0 {
+				a.statusBar.SetText(tr("update.available", tview.Escape(latest), version))
+			}
+		})
+	}()
+}
+
+// 在 sha256sum 格式的校验和文件中查找文件的校验和
+func lookupChecksum(checksums []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(string(checksums)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// 用 ed25519 公钥验证校验和文件的签名（签名文件为原始字节或 base64 文本）
+func verifySignature(checksums, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New(tr("update.bad_key"))
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		signature = decoded
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), checksums, signature) {
+		return errors.New(tr("update.bad_signature"))
+	}
+	return nil
+}
+
+// 下载最新版本，验证签名与校验和后替换当前可执行文件
+func selfUpdate(ctx context.Context, release githubRelease, out io.Writer) error {
+	// 只信任构建时内置的公钥；没有公钥时仅凭同一发布中的校验和无法证明文件来源
+	if updatePublicKey == "" {
+		return errors.New(tr("update.no_key"))
+	}
+	asset := binaryAssetName()
+	binaryURL, ok := release.assetURL(asset)
+	if !ok {
+		return errors.New(tr("update.no_asset", release.TagName, asset))
+	}
+	checksumsURL, ok := release.assetURL(checksumsAsset)
+	if !ok {
+		return errors.New(tr("update.no_checksums", release.TagName, checksumsAsset))
+	}
+	checksums, err := httpGet(ctx, checksumsURL)
+	if err != nil {
+		return err
+	}
+
+	signatureURL, ok := release.assetURL(signatureAsset)
+	if !ok {
+		return errors.New(tr("update.no_signature", release.TagName, signatureAsset))
+	}
+	signature, err := httpGet(ctx, signatureURL)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(checksums, signature, updatePublicKey); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, tr("update.signature_ok"))
+
+	expected, ok := lookupChecksum(checksums, asset)
+	if !ok {
+		return errors.New(tr("update.no_checksum", checksumsAsset, asset))
+	}
+	fmt.Fprintf(out, tr("update.downloading"), binaryURL)
+	binary, err := httpGet(ctx, binaryURL)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(binary)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return errors.New(tr("update.checksum_mismatch", expected, actual))
+	}
+	fmt.Fprintln(out, tr("update.checksum_ok"))
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if executable, err = filepath.EvalSymlinks(executable); err != nil {
+		return err
+	}
+	// 先写入同目录的临时文件再重命名，保证替换是原子的
+	tmp := executable + ".new"
+	if err := os.WriteFile(tmp, binary, 0o755); err != nil {
+		return err
+	}
+	if runtime.GOOS == "windows" {
+		// Windows 不能覆盖正在运行的程序，先将其改名
+		old := executable + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := os.Rename(tmp, executable); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	recordAudit(auditEvent{Action: "self_update", Target: executable, Detail: version + " -> " + release.TagName})
+	return nil
+}
+
+// 执行 self-update 子命令，--check 时只检查不更新
+func runSelfUpdateCommand(args []string, out io.Writer) int {
+	checkOnly := len(args) > 0 && args[0] == "--check"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	release, err := latestRelease(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, tr("update.check_failed"), err)
+		return exitFailure
+	}
+	if compareVersions(release.TagName, version) <= 0 && version != "dev" {
+		fmt.Fprintf(out, tr("update.latest"), version)
+		return exitOK
+	}
+	fmt.Fprintf(out, tr("update.newer"), version, release.TagName, release.HTMLURL)
+	if checkOnly {
+		return exitOK
+	}
+	if err := selfUpdate(ctx, release, out); err != nil {
+		fmt.Fprintf(os.Stderr, tr("update.failed"), err)
+		return exitFailure
+	}
+	fmt.Fprintf(out, tr("update.updated"), release.TagName)
+	return exitOK
+}
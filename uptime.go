@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// 健康检查历史文件名（位于数据目录中）
+const healthFile = "health.jsonl"
+
+// 一次健康检查的历史记录
+type healthSample struct {
+	Time      time.Time `json:"time"`       // 检查时间
+	Target    string    `json:"target"`     // 目标连接标识
+	OK        bool      `json:"ok"`         // 是否可达
+	LatencyMS int64     `json:"latency_ms"` // 建立连接耗时
+}
+
+// 记录一次健康检查结果
+func recordHealth(target connTarget, result healthResult) {
+	sample := healthSample{Time: result.Checked, Target: target.ID(), OK: result.OK, LatencyMS: result.Latency.Milliseconds()}
+	_ = appendJSONLine(healthFile, sample)
+}
+
+// 读取目标在指定时间之后的健康检查记录
+func loadHealthHistory(target connTarget, since time.Time) []healthSample {
+	samples, _ := readJSONLines[healthSample](healthFile)
+	id := target.ID()
+	var matched []healthSample
+	for _, sample := range samples {
+		if sample.Target == id && !sample.Time.Before(since) {
+			matched = append(matched, sample)
+		}
+	}
+	return matched
+}
+
+// 按时间段统计的可用性
+type uptimeBucket struct {
+	Total  int // 检查次数
+	Failed int // 失败次数
+}
+
+// 将健康检查记录按固定时长分桶，最后一个桶截止到 now
+func bucketHealth(samples []healthSample, now time.Time, size time.Duration, count int) []uptimeBucket {
+	buckets := make([]uptimeBucket, count)
+	start := now.Add(-size * time.Duration(count))
+	for _, sample := range samples {
+		index := int(sample.Time.Sub(start) / size)
+		if index < 0 || index >= count {
+			continue
+		}
+		buckets[index].Total++
+		if !sample.OK {
+			buckets[index].Failed++
+		}
+	}
+	return buckets
+}
+
+// 渲染可用性色条：绿色全部正常，黄色部分失败，红色全部失败，灰色无数据
+func renderUptimeStrip(buckets []uptimeBucket) string {
+	strip := ""
+	for _, bucket := range buckets {
+		switch {
+		case bucket.Total == 0:
+			strip += "[gray]·[-]"
+		case bucket.Failed == 0:
+			strip += "[green]█[-]"
+		case bucket.Failed == bucket.Total:
+			strip += "[red]█[-]"
+		default:
+			strip += "[yellow]█[-]"
+		}
+	}
+	return strip
+}
+
+// 计算分桶的整体可用率
+func uptimePercent(buckets []uptimeBucket) (float64, bool) {
+	total, failed := 0, 0
+	for _, bucket := range buckets {
+		total += bucket.Total
+		failed += bucket.Failed
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(total-failed) / float64(total) * 100, true
+}
+
+// 渲染目标最近 24 小时（按小时）和 30 天（按天）的可用性历史
+func renderUptimeHistory(target connTarget, now time.Time) string {
+	samples := loadHealthHistory(target, now.AddDate(0, 0, -30))
+	if len(samples) == 0 {
+		return tr("uptime.none")
+	}
+	content := ""
+	for _, period := range []struct {
+		label string
+		size  time.Duration
+		count int
+	}{
+		{tr("uptime.24h"), time.Hour, 24},
+		{tr("uptime.30d"), 24 * time.Hour, 30},
+	} {
+		buckets := bucketHealth(samples, now, period.size, period.count)
+		percent := "-"
+		if value, ok := uptimePercent(buckets); ok {
+			percent = fmt.Sprintf("%.2f%%", value)
+		}
+		content += tr("uptime.line", period.label, renderUptimeStrip(buckets), percent)
+	}
+	return content
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// 注册的 URL 协议
+var handledSchemes = []string{"ssh", "mysql", "postgres", "postgresql", "redis"}
+
+// Linux 桌面入口文件名
+const desktopEntryName = "connectionmanager-url.desktop"
+
+// 在清单中查找与 URI 匹配的连接：主机和端口相同，优先选择用户也相同的连接
+func matchURLTarget(conn Connection, kind string) (connTarget, bool) {
+	var fallback *connTarget
+	for _, target := range inventoryTargets(configuredModules()) {
+		if moduleType(target.Module) != kind || !strings.EqualFold(target.Conn.Host, conn.Host) || target.Conn.Port != conn.Port {
+			continue
+		}
+		if conn.User == "" || target.Conn.User == conn.User {
+			return target, true
+		}
+		if fallback == nil {
+			t := target
+			fallback = &t
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return connTarget{}, false
+}
+
+// 解析 URI 得到要打开的目标；清单中没有匹配的连接时返回尚未保存的新连接（created 为 true），确认后才保存
+func resolveURLTarget(uri string) (target connTarget, created bool, err error) {
+	conn, kind, err := parseConnectionURI(uri)
+	if err != nil {
+		return connTarget{}, false, err
+	}
+	if target, ok := matchURLTarget(conn, kind); ok {
+		return target, false, nil
+	}
+	entry, ok := placeConnection(kind, "", "")
+	if !ok {
+		return connTarget{}, false, errors.New(tr("urlhandler.no_module", kind))
+	}
+	conn.Status = "disconnected"
+	if conn.User == "" {
+		conn.User = defaultUser(entry.Module)
+	}
+	return connTarget{Module: entry.Module, Project: entry.Project, Env: entry.Env, Conn: conn}, true, nil
+}
+
+// 在树状视图中定位到目标连接，目标不在清单中时返回 false
+func (a *App) focusTarget(target connTarget) bool {
+	for m, module := range a.modules {
+		if module != target.Module {
+			continue
+		}
+		for _, conn := range envConnections(module, target.Project, target.Env) {
+			if conn.Name == target.Conn.Name {
+				a.focusNode(paletteEntry{Level: 2, Module: m, Project: target.Project, Env: target.Env, Conn: conn.Name})
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 启动后确认并打开通过 URL 指定的连接：显示主机、用户和端口，确认后才保存新连接并连接
+func (a *App) confirmURLTarget(target connTarget, created bool) {
+	conn := target.Conn
+	message := tr("url.confirm", moduleType(target.Module), tview.Escape(conn.Host), conn.Port, tview.Escape(cmp.Or(conn.User, "-")))
+	if created {
+		message += "\n\n" + tr("url.confirm_new", tview.Escape(target.ID()))
+	} else {
+		message += "\n\n" + tr("url.confirm_existing", tview.Escape(target.ID()))
+	}
+	if isProtectedEnv(target.Env) {
+		message += "\n\n[red]" + tr("url.protected") + "[-]"
+	}
+	a.confirm(tr("url.confirm_title"), message, func() {
+		if created {
+			entry := inventoryEntry{Module: target.Module, Project: target.Project, Env: target.Env, Conn: conn}
+			if err := saveNewConnections([]inventoryEntry{entry}); err != nil {
+				a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(tr("url.save_failed", err))))
+				return
+			}
+			recordAudit(auditEvent{Action: "url_add", Target: entry.ID(), Detail: conn.Host})
+		}
+		a.openURLTarget(target)
+	})
+}
+
+// 打开通过 URL 指定的连接：SSH 打开会话，MySQL/PostgreSQL 打开查询控制台
+func (a *App) openURLTarget(target connTarget) {
+	focused := a.focusTarget(target)
+	switch kind := moduleType(target.Module); {
+	case kind == "SSH" && focused:
+		a.activateTreeItem()
+	case kind == "SSH":
+		// 审阅模式下新建的连接尚未提交，直接连接
+		a.requireVPN(target, func() {
+			a.openSSHSession(target)
+		})
+	case !focused:
+		a.statusBar.SetText(tr("urlhandler.staged"))
+	case kind == "MySQL" || kind == "PostgreSQL":
+		a.showQueryConsole()
+	}
+}
+
+// 注册 URL 协议处理程序（Linux 使用 xdg-mime，Windows 写入当前用户注册表）
+func registerURLHandlers(out io.Writer, unregister bool) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	command := `"` + executable + `"`
+	if activeWorkspace != "" {
+		command += ` --workspace "` + activeWorkspace + `"`
+	}
+	command += " open"
+
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		dir := filepath.Join(os.Getenv("HOME"), ".local", "share", "applications")
+		if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "applications")
+		}
+		path := filepath.Join(dir, desktopEntryName)
+		if unregister {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+			fmt.Fprintf(out, tr("urlhandler.removed"), path)
+			return nil
+		}
+		var mimeTypes []string
+		for _, scheme := range handledSchemes {
+			mimeTypes = append(mimeTypes, "x-scheme-handler/"+scheme)
+		}
+		entry := fmt.Sprintf("[Desktop Entry]\nType=Application\nName=ConnectionManager\nExec=%s %%u\nTerminal=true\nNoDisplay=true\nMimeType=%s;\n",
+			command, strings.Join(mimeTypes, ";"))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(entry), 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, tr("urlhandler.written"), path)
+		for _, mimeType := range mimeTypes {
+			if output, err := exec.Command("xdg-mime", "default", desktopEntryName, mimeType).CombinedOutput(); err != nil {
+				return fmt.Errorf("xdg-mime default %s: %s", mimeType, strings.TrimSpace(string(output)+" "+err.Error()))
+			}
+			fmt.Fprintf(out, tr("urlhandler.registered"), mimeType)
+		}
+		return nil
+	case "windows":
+		for _, scheme := range handledSchemes {
+			key := `HKCU\Software\Classes\` + scheme
+			if unregister {
+				_ = exec.Command("reg", "delete", key, "/f").Run()
+				fmt.Fprintf(out, tr("urlhandler.removed"), key)
+				continue
+			}
+			for _, args := range [][]string{
+				{"add", key, "/ve", "/d", "URL:" + scheme, "/f"},
+				{"add", key, "/v", "URL Protocol", "/d", "", "/f"},
+				{"add", key + `\shell\open\command`, "/ve", "/d", command + ` "%1"`, "/f"},
+			} {
+				if output, err := exec.Command("reg", args...).CombinedOutput(); err != nil {
+					return fmt.Errorf("reg %s: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+				}
+			}
+			fmt.Fprintf(out, tr("urlhandler.registered_scheme"), scheme)
+		}
+		return nil
+	}
+	return errors.New(tr("urlhandler.needs_bundle", runtime.GOOS, command))
+}
+
+// 执行 register-handlers 子命令
+func runRegisterHandlersCommand(args []string, out io.Writer) int {
+	unregister := len(args) > 0 && args[0] == "--unregister"
+	if err := registerURLHandlers(out, unregister); err != nil {
+		fmt.Fprintf(os.Stderr, tr("urlhandler.failed"), err)
+		return exitFailure
+	}
+	return exitOK
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// 字段校验规则：返回错误说明，空字符串表示通过
+type fieldRule func(value string) string
+
+// 主机名语法（RFC 1123，允许下划线以兼容内部命名）
+var hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]{0,62})(\.[A-Za-z0-9_]([A-Za-z0-9_-]{0,62}))*\.?$`)
+
+// 必填
+func ruleRequired(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return tr("validate.required")
+	}
+	return ""
+}
+
+// 端口范围 1-65535
+func rulePort(value string) string {
+	if value == "" {
+		return ""
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return tr("validate.port")
+	}
+	return ""
+}
+
+// 主机名或 IP 地址
+func ruleHost(value string) string {
+	if value == "" || net.ParseIP(strings.Trim(value, "[]")) != nil {
+		return ""
+	}
+	if len(value) > 253 || !hostnamePattern.MatchString(value) {
+		return tr("validate.host")
+	}
+	return ""
+}
+
+// 本地文件存在（支持 ~/ 开头）
+func ruleFileExists(value string) string {
+	if value == "" {
+		return ""
+	}
+	info, err := os.Stat(expandHome(value))
+	switch {
+	case err != nil:
+		return tr("validate.no_file")
+	case info.IsDir():
+		return tr("validate.is_dir")
+	}
+	return ""
+}
+
+// 逗号分隔的 host 或 host:port 列表
+func ruleAddresses(value string) string {
+	for _, address := range splitAddresses(value) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			host, port = address, ""
+		}
+		if message := ruleHost(host); message != "" {
+			return fmt.Sprintf("%s: %s", address, message)
+		}
+		if message := rulePort(port); message != "" {
+			return fmt.Sprintf("%s: %s", address, message)
+		}
+	}
+	return ""
+}
+
+// ProxyCommand 模板语法
+func ruleProxyTemplate(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, err := parseProxyTemplate(value, nil); err != nil {
+		return tr("validate.template") + err.Error()
+	}
+	return ""
+}
+
+// Redis 库编号
+func ruleRedisDatabase(value string) string {
+	if value == "" {
+		return ""
+	}
+	if n, err := strconv.Atoi(value); err != nil || n < 0 {
+		return tr("validate.redis_db")
+	}
+	return ""
+}
+
+// CIDR 子网
+func ruleCIDR(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return tr("validate.subnet")
+	}
+	return ""
+}
+
+// 正则表达式语法
+func ruleRegexp(value string) string {
+	if _, err := regexp.Compile(value); err != nil {
+		return tr("validate.regexp") + err.Error()
+	}
+	return ""
+}
+
+// 连接字段的校验规则，所有模块共用，按模块类型补充特有规则
+func connectionFieldRules(module, field string) []fieldRule {
+	switch field {
+	case nameField:
+		return []fieldRule{ruleRequired}
+	case "host":
+		return []fieldRule{ruleRequired, ruleHost}
+	case "port":
+		return []fieldRule{ruleRequired, rulePort}
+	case "identity_file", "certificate", "tls_ca", "tls_cert", "tls_key":
+		return []fieldRule{ruleFileExists}
+	case "proxy_command":
+		return []fieldRule{ruleProxyTemplate}
+	case "addresses":
+		return []fieldRule{ruleAddresses}
+	case "ssh_tunnel":
+		return []fieldRule{ruleSSHTunnel}
+	case "jump_host":
+		return []fieldRule{ruleJumpHost}
+	case "database":
+		if moduleType(module) == "Redis" {
+			return []fieldRule{ruleRedisDatabase}
+		}
+	}
+	return nil
+}
+
+// SSH 隧道：清单中已有的 SSH 连接标识
+func ruleSSHTunnel(value string) string {
+	if value == "" {
+		return ""
+	}
+	target, ok := findTarget(value)
+	if !ok {
+		return tr("validate.no_connection")
+	}
+	if moduleType(target.Module) != "SSH" {
+		return tr("validate.ssh_only")
+	}
+	return ""
+}
+
+// 跳板：清单中已有的 SSH 连接标识
+func ruleJumpHost(value string) string {
+	if value == "" {
+		return ""
+	}
+	target, ok := findTarget(value)
+	if !ok {
+		return tr("validate.no_connection")
+	}
+	if moduleType(target.Module) != "SSH" {
+		return tr("validate.ssh_only")
+	}
+	if _, err := jumpChain(target); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// 依次执行规则，返回第一个错误
+func checkRules(value string, rules []fieldRule) string {
+	for _, rule := range rules {
+		if message := rule(value); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// 表单校验：输入时即时校验，错误字段的标签标红并在表单下方列出红色错误信息，存在错误时阻止提交
+type formValidator struct {
+	form   *tview.Form
+	errors *tview.TextView
+	layout *tview.Flex
+	fields []validatedField
+	saved  []string // 打开表单时的内容，用于检测未保存的修改
+}
+
+// 带校验规则的输入框
+type validatedField struct {
+	label string
+	input *tview.InputField
+	rules func() []fieldRule // 规则可随表单其他选项变化
+}
+
+// 为表单创建校验器
+func newFormValidator(form *tview.Form) *formValidator {
+	v := &formValidator{
+		form:   form,
+		errors: tview.NewTextView().SetDynamicColors(true).SetWrap(true),
+	}
+	v.layout = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(form, 0, 1, true).
+		AddItem(v.errors, 0, 0, false)
+	return v
+}
+
+// 添加带校验的输入框，rules 返回当前适用的规则
+func (v *formValidator) addInputField(label, value string, width int, changed func(text string), rules func() []fieldRule) {
+	v.form.AddInputField(label, value, width, nil, func(text string) {
+		if changed != nil {
+			changed(text)
+		}
+		v.validate()
+	})
+	input := v.form.GetFormItem(v.form.GetFormItemCount() - 1).(*tview.InputField)
+	v.fields = append(v.fields, validatedField{label: label, input: input, rules: rules})
+}
+
+// 清空已登记的字段（表单重建前调用）
+func (v *formValidator) reset() {
+	v.fields = nil
+	v.errors.Clear()
+	v.layout.ResizeItem(v.errors, 0, 0)
+}
+
+// 固定规则的简写
+func fixedRules(rules ...fieldRule) func() []fieldRule {
+	return func() []fieldRule { return rules }
+}
+
+// 校验全部字段并刷新错误显示，全部通过时返回 true
+func (v *formValidator) validate() bool {
+	var messages []string
+	for _, field := range v.fields {
+		message := checkRules(strings.TrimSpace(field.input.GetText()), field.rules())
+		if message == "" {
+			field.input.SetLabel(field.label)
+			continue
+		}
+		field.input.SetLabel(fmt.Sprintf("[red]%s[-]", tview.Escape(field.label)))
+		messages = append(messages, fmt.Sprintf("[red]✗ %s: %s[-]", tview.Escape(field.label), tview.Escape(message)))
+	}
+	v.errors.SetText(strings.Join(messages, "\n"))
+	v.layout.ResizeItem(v.errors, len(messages), 0)
+	return len(messages) == 0
+}
+
+// 表单与错误信息的整体布局，作为覆盖层的根组件
+func (v *formValidator) root() *tview.Flex {
+	return v.layout
+}
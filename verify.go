@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 计算远程校验和的超时时间（大文件需要较长时间）
+const verifyTimeout = 30 * time.Minute
+
+// 端点不存在
+var errEndpointMissing error = messageError("verify.missing")
+
+// 端点下全部文件的校验和：相对路径 -> sha256，端点是单个文件时相对路径为空
+type checksumSet map[string]string
+
+// 远程计算校验和的脚本：先输出类型（dir/file），再输出 sha256sum 格式的结果，路径不存在时退出码为 3
+const remoteChecksumScript = `h=sha256sum; command -v sha256sum >/dev/null 2>&1 || h="shasum -a 256"
+if [ -d %[1]s ]; then echo dir; cd %[1]s && find . -type f -exec $h {} +
+elif [ -f %[1]s ]; then echo file; $h %[1]s
+else exit 3; fi`
+
+// 传输配方是否需要校验（配方的 verify 优先，否则使用 transfer.verify）
+func (r transferRecipe) verify() bool {
+	if r.Verify != nil {
+		return *r.Verify
+	}
+	return configBool("transfer.verify")
+}
+
+// 计算单个本地文件的 sha256
+func sha256File(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// 计算本地文件或目录的校验和
+func localChecksums(root string) (checksumSet, error) {
+	root = expandHome(root)
+	info, err := os.Stat(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errEndpointMissing
+	}
+	if err != nil {
+		return nil, err
+	}
+	sums := make(checksumSet)
+	if !info.IsDir() {
+		sum, err := sha256File(root)
+		sums[""] = sum
+		return sums, err
+	}
+	err = filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, _ := filepath.Rel(root, name)
+		sum, err := sha256File(name)
+		sums[filepath.ToSlash(rel)] = sum
+		return err
+	})
+	return sums, err
+}
+
+// 转义远程路径，保留开头的 ~/ 以便由远程 shell 展开
+func remotePathQuote(p string) string {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		return `"$HOME"/` + shellQuote(rest)
+	}
+	return shellQuote(p)
+}
+
+// 计算远程文件或目录的校验和
+func remoteChecksums(ctx context.Context, target connTarget, root string) (checksumSet, error) {
+	output, err := runRemote(ctx, target, fmt.Sprintf(remoteChecksumScript, remotePathQuote(root)))
+	if err != nil {
+		if strings.Contains(err.Error(), "exit status 3") {
+			return nil, errEndpointMissing
+		}
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	isDir := len(lines) > 0 && lines[0] == "dir"
+	sums := make(checksumSet)
+	for _, line := range lines[1:] {
+		sum, name, ok := strings.Cut(line, "  ")
+		if !ok {
+			continue
+		}
+		if isDir {
+			sums[strings.TrimPrefix(name, "./")] = sum
+		} else {
+			sums[""] = sum
+		}
+	}
+	return sums, nil
+}
+
+// 计算传输端点（本地路径或 "连接标识:远程路径"）的校验和
+func endpointChecksums(ctx context.Context, endpoint string) (checksumSet, error) {
+	_, target, err := resolveEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return localChecksums(endpoint)
+	}
+	_, remotePath, _ := strings.Cut(endpoint, ":")
+	return remoteChecksums(ctx, *target, remotePath)
+}
+
+// 传输后的目标端点候选：源以 / 结尾时内容直接写入目标，否则优先查找目标下的同名文件或目录
+func destinationCandidates(source, destination string) []string {
+	if strings.HasSuffix(source, "/") {
+		return []string{destination}
+	}
+	sourcePath := source
+	if _, remotePath, found := strings.Cut(source, ":"); found && strings.Count(strings.SplitN(source, ":", 2)[0], "/") == 3 {
+		sourcePath = remotePath
+	}
+	return []string{strings.TrimSuffix(destination, "/") + "/" + path.Base(sourcePath), destination}
+}
+
+// 校验结果
+type verifyResult struct {
+	Matched    int      // 一致的文件数
+	Mismatches []string // 不一致或缺失的文件
+}
+
+// 比较源和目标的 sha256，返回不一致或缺失的文件；ctx 取消时中止校验
+func verifyTransfer(ctx context.Context, source, destination string) (verifyResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+	sourceSums, err := endpointChecksums(ctx, source)
+	if err != nil {
+		return verifyResult{}, fmt.Errorf(tr("verify.source_failed"), err)
+	}
+	var destSums checksumSet
+	for _, candidate := range destinationCandidates(source, destination) {
+		if destSums, err = endpointChecksums(ctx, candidate); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return verifyResult{}, fmt.Errorf(tr("verify.target_failed"), err)
+	}
+
+	var result verifyResult
+	for name, sum := range sourceSums {
+		label := name
+		if label == "" {
+			label = path.Base(source)
+		}
+		actual, ok := destSums[name]
+		if !ok && len(sourceSums) == 1 && len(destSums) == 1 {
+			// 单个文件改名传输时按唯一的文件比较
+			for _, only := range destSums {
+				actual, ok = only, true
+			}
+		}
+		switch {
+		case !ok:
+			result.Mismatches = append(result.Mismatches, label+tr("verify.missing_in_target"))
+		case actual != sum:
+			result.Mismatches = append(result.Mismatches, tr("verify.mismatch", label, sum, actual))
+		default:
+			result.Matched++
+		}
+	}
+	sort.Strings(result.Mismatches)
+	return result, nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// VPN 状态缓存时间，避免每次重绘都读取网络接口与路由表
+const vpnStatusTTL = 5 * time.Second
+
+// VPN 启动后等待连通的最长时间
+const vpnUpTimeout = 30 * time.Second
+
+// VPN 前置配置（配置项 vpn.profiles）
+type vpnProfile struct {
+	Interface string `mapstructure:"interface"` // 网络接口名（如 tun0、wg0），存在且已启用视为已连接
+	Route     string `mapstructure:"route"`     // 目标网段（如 10.20.0.0/16），存在对应路由视为已连接
+	Up        string `mapstructure:"up"`        // 启动 VPN 的命令
+	AutoUp    bool   `mapstructure:"auto_up"`   // 连接前自动执行启动命令，不再询问
+}
+
+// 解析目标要求的 VPN 配置名，优先级：连接 > 环境（配置项 vpn.environments、vpn.connections）
+func resolveVPN(target connTarget) string {
+	name := ""
+	if byEnv := configStringMapString("vpn.environments"); len(byEnv) > 0 {
+		name = lookupFold(byEnv, target.Env)
+	}
+	if byConn := configStringMapString("vpn.connections"); len(byConn) > 0 {
+		if value := lookupFold(byConn, target.ID()); value != "" {
+			name = value
+		}
+	}
+	if strings.EqualFold(name, "none") {
+		return ""
+	}
+	return name
+}
+
+// 获取 VPN 配置
+func vpnProfileByName(name string) (vpnProfile, bool) {
+	var profiles map[string]vpnProfile
+	if err := unmarshalConfig("vpn.profiles", &profiles); err != nil {
+		return vpnProfile{}, false
+	}
+	for key, profile := range profiles {
+		if strings.EqualFold(key, name) {
+			return profile, true
+		}
+	}
+	return vpnProfile{}, false
+}
+
+// 网络接口是否存在且已启用
+func interfaceUp(name string) bool {
+	iface, err := net.InterfaceByName(name)
+	return err == nil && iface.Flags&net.FlagUp != 0
+}
+
+// 路由表中是否存在覆盖目标网段的非默认路由
+func routePresent(cidr string) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	file, err := os.Open("/proc/net/route")
+	if err != nil {
+		// 非 Linux 系统通过 route -n get 查询，命中默认路由视为不存在
+		output, err := exec.Command("route", "-n", "get", network.IP.String()).Output()
+		if err != nil {
+			return false
+		}
+		for _, line := range strings.Split(string(output), "\n") {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(line), "destination:"); ok {
+				return strings.TrimSpace(value) != "default"
+			}
+		}
+		return false
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // 跳过表头
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		destination, err1 := hex.DecodeString(fields[1])
+		mask, err2 := hex.DecodeString(fields[7])
+		if err1 != nil || err2 != nil || len(destination) != 4 || len(mask) != 4 {
+			continue
+		}
+		// /proc/net/route 中的地址为小端序
+		route := net.IPNet{
+			IP:   net.IPv4(destination[3], destination[2], destination[1], destination[0]),
+			Mask: net.IPv4Mask(mask[3], mask[2], mask[1], mask[0]),
+		}
+		if binary.BigEndian.Uint32(route.Mask) == 0 {
+			continue
+		}
+		if route.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// 检查 VPN 是否已连接（接口与路由都配置时需同时满足）
+func (p vpnProfile) connected() bool {
+	if p.Interface != "" && !interfaceUp(p.Interface) {
+		return false
+	}
+	if p.Route != "" && !routePresent(p.Route) {
+		return false
+	}
+	return p.Interface != "" || p.Route != ""
+}
+
+// VPN 状态缓存
+var (
+	vpnMu     sync.Mutex
+	vpnStatus = make(map[string]vpnState)
+)
+
+// 缓存的 VPN 状态
+type vpnState struct {
+	up      bool
+	checked time.Time
+}
+
+// 获取 VPN 是否已连接（带缓存），force 为 true 时重新检查
+func vpnConnected(name string, force bool) bool {
+	profile, ok := vpnProfileByName(name)
+	if !ok {
+		return false
+	}
+	vpnMu.Lock()
+	defer vpnMu.Unlock()
+	if state, ok := vpnStatus[name]; ok && !force && time.Since(state.checked) < vpnStatusTTL {
+		return state.up
+	}
+	up := profile.connected()
+	vpnStatus[name] = vpnState{up: up, checked: time.Now()}
+	return up
+}
+
+// 树状视图中显示的 VPN 状态提示，目标不需要 VPN 或 VPN 已连接时为空
+func vpnStatusText(target connTarget) string {
+	name := resolveVPN(target)
+	if name == "" || vpnConnected(name, false) {
+		return ""
+	}
+	return tr("vpn.down_marker", name)
+}
+
+// 连接前确保所需 VPN 已连接：未连接时按配置自动或经确认后执行启动命令，成功后调用 proceed
+func (a *App) requireVPN(target connTarget, proceed func()) {
+	name := resolveVPN(target)
+	if name == "" || vpnConnected(name, true) {
+		proceed()
+		return
+	}
+	profile, ok := vpnProfileByName(name)
+	if !ok {
+		a.statusBar.SetText(tr("vpn.not_found", name))
+		return
+	}
+	if profile.Up == "" {
+		a.statusBar.SetText(tr("vpn.no_up", name))
+		return
+	}
+	bringUp := func() {
+		a.bringUpVPN(name, profile, proceed)
+	}
+	if profile.AutoUp {
+		bringUp()
+		return
+	}
+	a.confirm(tr("vpn.down_title"), tr("vpn.confirm_up", target.Conn.Name, name, profile.Up), bringUp)
+}
+
+// 挂起界面执行 VPN 启动命令（可能需要输入密码），在后台等待 VPN 连通后调用 onReady
+func (a *App) bringUpVPN(name string, profile vpnProfile, onReady func()) {
+	var runErr error
+	a.suspend(func() {
+		fmt.Printf(tr("vpn.starting"), name, profile.Up)
+		cmd := exec.Command("sh", "-c", profile.Up)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		runErr = cmd.Run()
+	})
+	recordAudit(auditEvent{Action: "vpn_up", Target: name, Detail: profile.Up})
+	if runErr != nil {
+		a.statusBar.SetText(tr("vpn.up_failed", name, tview.Escape(runErr.Error())))
+		return
+	}
+
+	a.statusBar.SetText(tr("vpn.waiting", name))
+	go func() {
+		deadline := time.Now().Add(vpnUpTimeout)
+		for !vpnConnected(name, true) {
+			if time.Now().After(deadline) {
+				a.app.QueueUpdateDraw(func() {
+					a.statusBar.SetText(tr("vpn.timeout", name, vpnUpTimeout))
+					a.updateMainPanel()
+				})
+				return
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		a.app.QueueUpdateDraw(func() {
+			a.updateMainPanel()
+			a.statusBar.SetText(tr("vpn.up", name))
+			onReady()
+		})
+	}()
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// 钉住连接文件名（位于数据目录中），保存连接标识列表
+const pinsFile = "pins.json"
+
+// 监视栏默认检查间隔
+const defaultWatchInterval = 15 * time.Second
+
+// 钉住连接最近一次检查结果，以及触发立即刷新的信号
+var (
+	watchMu      sync.Mutex
+	watchResults = make(map[string]healthResult)
+	watchRefresh = make(chan struct{}, 1)
+)
+
+// 可达状态发生变化、等待在界面线程中提示的连接
+type watchChange struct {
+	Target connTarget
+	Result healthResult
+	Alert  bool // 开启了变化提醒
+	Rang   bool // 界面挂起时已直接响铃
+}
+
+// 待处理的状态变化（受 watchMu 保护），以及是否已请求刷新（已排队，或界面挂起中、恢复后刷新）
+var (
+	watchPending []watchChange
+	watchDirty   atomic.Bool
+)
+
+// 读取钉住的连接标识
+func loadPins() []string {
+	var pins []string
+	_ = readJSONFile(pinsFile, &pins)
+	return pins
+}
+
+// 钉住或取消钉住当前连接
+func (a *App) togglePin() {
+	target, ok := a.currentTarget()
+	if !ok {
+		return
+	}
+	id := target.ID()
+	var pins []string
+	pinned := false
+	for _, pin := range loadPins() {
+		if pin == id {
+			pinned = true
+			continue
+		}
+		pins = append(pins, pin)
+	}
+	if !pinned {
+		pins = append(pins, id)
+	}
+	if err := writeJSONFile(pinsFile, pins); err != nil {
+		a.statusBar.SetText(tr("watch.save_failed", err))
+		return
+	}
+
+	if pinned {
+		a.statusBar.SetText(tr("watch.unpinned", target.Conn.Name))
+	} else {
+		a.statusBar.SetText(tr("watch.pinned", target.Conn.Name))
+	}
+	a.arrangeGrid()
+	a.updateWatchBar()
+	select {
+	case watchRefresh <- struct{}{}:
+	default:
+	}
+}
+
+// 按是否有钉住的连接排列主界面：模块栏、监视栏（可选）、主面板、状态栏
+func (a *App) arrangeGrid() {
+	a.grid.Clear()
+	row := 0
+	if len(loadPins()) > 0 {
+		a.grid.SetRows(3, 3, 0, 3)
+		a.grid.AddItem(a.watchBar, 1, 0, 1, 1, 0, 0, false)
+		row = 1
+	} else {
+		a.grid.SetRows(3, 0, 3)
+	}
+	a.grid.AddItem(a.moduleBar, 0, 0, 1, 1, 0, 0, true).
+		AddItem(a.body, row+1, 0, 1, 1, 0, 0, false).
+		AddItem(a.statusRow, row+2, 0, 1, 1, 0, 0, false)
+}
+
+// 渲染监视栏：连接名、状态与延迟
+func (a *App) updateWatchBar() {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+	var items []string
+	for _, id := range loadPins() {
+		target, ok := findTarget(id)
+		if !ok {
+			items = append(items, fmt.Sprintf("[gray]%s ?[-]", tview.Escape(id)))
+			continue
+		}
+		item := tview.Escape(target.Conn.Name)
+		result, checked := watchResults[id]
+		switch {
+		case !healthCheckable(target):
+			item += fmt.Sprintf(" [gray]%s[-]", tr("conn.unchecked"))
+		case !checked:
+			item += " [gray]…[-]"
+		case result.OK:
+			item += fmt.Sprintf(" [green]●[-] %s", formatDuration(result.Latency))
+		default:
+			item += " [red]✗[-]"
+		}
+		if _, ok := inMaintenance(target, time.Now()); ok {
+			item += tr("watch.maintenance")
+		}
+		items = append(items, item)
+	}
+	content := " " + strings.Join(items, " │ ")
+	a.watchBar.SetText(content)
+}
+
+// 在后台定期检查钉住的连接和开启了变化提醒的连接（间隔由 watch.interval 配置），钉住的连接结果显示在监视栏
+func (a *App) startWatchStrip() {
+	interval := configDuration("watch.interval")
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			// 钉住的连接和开启了变化提醒的连接；经隧道、跳板或代理命令连接的主机无法在本机探测，不检查
+			var targets []connTarget
+			alerts := loadAlerts()
+			for _, id := range append(loadPins(), alerts...) {
+				if target, ok := findTarget(id); ok && healthCheckable(target) && !slices.ContainsFunc(targets, func(other connTarget) bool { return other.ID() == id }) {
+					targets = append(targets, target)
+				}
+			}
+			results := make([]healthResult, len(targets))
+			var wg sync.WaitGroup
+			for i, target := range targets {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results[i] = checkTCP(target.Conn, defaultHealthTimeout)
+				}()
+			}
+			wg.Wait()
+
+			// 可达状态翻转时提示并闪烁对应节点；开启提醒的连接在界面挂起时先直接响铃
+			var changes []watchChange
+			watchMu.Lock()
+			for i, target := range targets {
+				if previous, ok := watchResults[target.ID()]; ok && previous.OK != results[i].OK {
+					changes = append(changes, watchChange{Target: target, Result: results[i], Alert: slices.Contains(alerts, target.ID())})
+				}
+				watchResults[target.ID()] = results[i]
+			}
+			watchMu.Unlock()
+			for i := range changes {
+				if changes[i].Alert {
+					changes[i].Rang = ringSuspendedBell()
+				}
+				a.noteStatusChange(changes[i].Target)
+			}
+			watchMu.Lock()
+			watchPending = append(watchPending, changes...)
+			watchMu.Unlock()
+			for i, target := range targets {
+				recordHealth(target, results[i])
+			}
+			a.requestWatchDraw()
+
+			select {
+			case <-ticker.C:
+			case <-watchRefresh:
+			}
+		}
+	}()
+}
+
+// 请求在界面线程中刷新监视栏并提示状态变化：已有刷新在排队时合并；界面挂起时不排队，恢复后由 suspend 刷新
+func (a *App) requestWatchDraw() {
+	if watchDirty.Swap(true) || uiSuspended.Load() {
+		return
+	}
+	a.app.QueueUpdateDraw(a.flushWatch)
+}
+
+// 刷新监视栏，在状态栏提示待处理的状态变化并为开启提醒的连接弹出提示框（在界面线程中调用）
+func (a *App) flushWatch() {
+	watchDirty.Store(false)
+	watchMu.Lock()
+	changes := watchPending
+	watchPending = nil
+	watchMu.Unlock()
+	for _, change := range changes {
+		name := tview.Escape(change.Target.Conn.Name)
+		text := tr("watch.down", name)
+		if change.Result.OK {
+			text = tr("watch.up", name)
+		}
+		a.statusBar.SetText(text + tr("watch.jump_hint"))
+		if change.Alert {
+			a.raiseAlert(change.Target, change.Result, change.Rang)
+		}
+	}
+	a.updateWatchBar()
+}
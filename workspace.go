@@ -0,0 +1,189 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/spf13/viper"
+)
+
+// 当前工作区名称，为空表示默认工作区；与配置一起在 loadConfig 中切换，后台 goroutine 通过 dataDir 读取时由 configMu 保护
+var activeWorkspace string
+
+// 获取应用根目录（$HOME/.connectionmanager）
+func baseDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".connectionmanager"), nil
+}
+
+// 获取工作区目录，每个工作区拥有独立的配置与状态数据
+func workspaceDir(name string) (string, error) {
+	base, err := baseDir()
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return base, nil
+	}
+	return filepath.Join(base, "workspaces", name), nil
+}
+
+// 列出已存在的工作区
+func listWorkspaces() []string {
+	base, err := baseDir()
+	if err != nil {
+		return nil
+	}
+	entries, err := os.ReadDir(filepath.Join(base, "workspaces"))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 加载工作区配置；默认工作区依次查找当前目录和根目录，命名工作区只读取自己的目录
+func loadConfig(workspace string) error {
+	dir, err := workspaceDir(workspace)
+	if err != nil {
+		return err
+	}
+	configMu.Lock()
+	viper.Reset()
+	viper.SetConfigName("config")
+	viper.SetConfigType("yaml")
+	if workspace == "" {
+		viper.AddConfigPath(".")
+	}
+	viper.AddConfigPath(dir)
+	viper.AutomaticEnv()
+
+	err = viper.ReadInConfig()
+	if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+		err = nil
+	}
+	if err == nil {
+		activeWorkspace = workspace
+	}
+	configMu.Unlock()
+	if err != nil {
+		return err
+	}
+	resetInventory()
+	resetOverrides()
+	resetAddedConnections()
+	resetDiscovery()
+	resetMesh()
+	lockCredentials()
+	return nil
+}
+
+// 从命令行参数中取出 --workspace/-w 选项，未指定时使用环境变量 CM_WORKSPACE
+func parseWorkspaceFlag(args []string) (string, []string) {
+	workspace := os.Getenv("CM_WORKSPACE")
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case (arg == "--workspace" || arg == "-w") && i+1 < len(args):
+			workspace = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--workspace="):
+			workspace = strings.TrimPrefix(arg, "--workspace=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return workspace, rest
+}
+
+// 切换到指定工作区并重置界面状态
+func (a *App) switchWorkspace(name string) {
+	if err := loadConfig(name); err != nil {
+		a.statusBar.SetText(tr("workspace.load_failed", tview.Escape(err.Error())))
+		return
+	}
+	a.modules = configuredModules()
+	a.currentModule, a.hoveredModule = 0, 0
+	a.inTreeView = false
+	a.selected, a.treeShape = TreeNode{}, ""
+	a.expandedNodes = make(map[string]bool)
+	a.markedConns = make(map[string]bool)
+	a.updateModuleBar()
+	a.updateMainPanel()
+	a.statusBar.SetText(tr("workspace.switched", workspaceLabel(name)))
+}
+
+// 工作区显示名称
+func workspaceLabel(name string) string {
+	if name == "" {
+		return tr("workspace.default")
+	}
+	return name
+}
+
+// 显示工作区切换器，N 新建工作区
+func (a *App) showWorkspaceSwitcher() {
+	names := append([]string{""}, listWorkspaces()...)
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false)
+	table.SetBorder(true).
+		SetTitle(tr("workspace.title")).
+		SetTitleAlign(tview.AlignLeft).
+		SetBorderColor(tcell.ColorYellow)
+	for i, name := range names {
+		label := workspaceLabel(name)
+		if name == activeWorkspace {
+			label += tr("workspace.current")
+		}
+		table.SetCell(i, 0, tview.NewTableCell(label))
+	}
+
+	a.pushOverlay(centered(table, 50, 12), table, func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			a.popOverlay()
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			a.popOverlay()
+			a.switchWorkspace(names[row])
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'n' || event.Rune() == 'N' {
+				a.popOverlay()
+				a.prompt(tr("workspace.new"), tr("common.name_label"), "", func(name string) {
+					dir, err := workspaceDir(name)
+					if err == nil && (strings.ContainsAny(name, `/\`) || name == "." || name == "..") {
+						err = errors.New(tr("workspace.invalid_name", name))
+					}
+					if err == nil {
+						err = os.MkdirAll(dir, 0o700)
+					}
+					if err != nil {
+						a.statusBar.SetText(fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error())))
+						return
+					}
+					a.switchWorkspace(name)
+				})
+				return nil
+			}
+		}
+		return event
+	})
+}
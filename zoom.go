@@ -0,0 +1,32 @@
+package main
+
+// 放大或还原当前焦点所在的面板（类似 tmux 的 prefix z）：主界面放大连接树，覆盖层中放大获得焦点的组件
+func (a *App) toggleZoom() {
+	if a.zoomed {
+		a.restoreZoom()
+		return
+	}
+	pane := a.app.GetFocus()
+	if len(a.overlays) == 0 {
+		pane = a.mainPanel
+	}
+	if pane == nil {
+		return
+	}
+	focus := a.app.GetFocus()
+	a.zoomed = true
+	a.app.SetRoot(pane, true)
+	a.app.SetFocus(focus)
+}
+
+// 还原放大前的布局，保持当前焦点
+func (a *App) restoreZoom() {
+	a.zoomed = false
+	focus := a.app.GetFocus()
+	if len(a.overlays) == 0 {
+		a.app.SetRoot(a.grid, true)
+	} else {
+		a.app.SetRoot(a.overlays[len(a.overlays)-1].root, true)
+	}
+	a.app.SetFocus(focus)
+}